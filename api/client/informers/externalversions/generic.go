@@ -62,6 +62,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1().VLSingles().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("vmanomalies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1().VMAnomalies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vmanomalymodels"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1().VMAnomalyModels().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vmanomalyqueries"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1().VMAnomalyQueries().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("vmanomalyschedulers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1().VMAnomalySchedulers().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("vtclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1().VTClusters().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("vtsingles"):
@@ -80,14 +86,20 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlerts().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmalertmanagers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagers().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmalertmanagerclusterreceivers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagerClusterReceivers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmalertmanagerconfigs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagerConfigs().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmalertmanagerreceivers"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAlertmanagerReceivers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmauths"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMAuths().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmclusters"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMClusters().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmnodescrapes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMNodeScrapes().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmoperatorconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMOperatorConfigs().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmpodscrapes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMPodScrapes().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmprobes"):
@@ -98,6 +110,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMScrapeConfigs().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmservicescrapes"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMServiceScrapes().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("vmsilences"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMSilences().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmsingles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Operator().V1beta1().VMSingles().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("vmstaticscrapes"):
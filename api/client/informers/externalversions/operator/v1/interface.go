@@ -31,6 +31,12 @@ type Interface interface {
 	VLSingles() VLSingleInformer
 	// VMAnomalies returns a VMAnomalyInformer.
 	VMAnomalies() VMAnomalyInformer
+	// VMAnomalyModels returns a VMAnomalyModelInformer.
+	VMAnomalyModels() VMAnomalyModelInformer
+	// VMAnomalyQueries returns a VMAnomalyQueryInformer.
+	VMAnomalyQueries() VMAnomalyQueryInformer
+	// VMAnomalySchedulers returns a VMAnomalySchedulerInformer.
+	VMAnomalySchedulers() VMAnomalySchedulerInformer
 	// VTClusters returns a VTClusterInformer.
 	VTClusters() VTClusterInformer
 	// VTSingles returns a VTSingleInformer.
@@ -68,6 +74,21 @@ func (v *version) VMAnomalies() VMAnomalyInformer {
 	return &vMAnomalyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMAnomalyModels returns a VMAnomalyModelInformer.
+func (v *version) VMAnomalyModels() VMAnomalyModelInformer {
+	return &vMAnomalyModelInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VMAnomalyQueries returns a VMAnomalyQueryInformer.
+func (v *version) VMAnomalyQueries() VMAnomalyQueryInformer {
+	return &vMAnomalyQueryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// VMAnomalySchedulers returns a VMAnomalySchedulerInformer.
+func (v *version) VMAnomalySchedulers() VMAnomalySchedulerInformer {
+	return &vMAnomalySchedulerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VTClusters returns a VTClusterInformer.
 func (v *version) VTClusters() VTClusterInformer {
 	return &vTClusterInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -31,14 +31,20 @@ type Interface interface {
 	VMAlerts() VMAlertInformer
 	// VMAlertmanagers returns a VMAlertmanagerInformer.
 	VMAlertmanagers() VMAlertmanagerInformer
+	// VMAlertmanagerClusterReceivers returns a VMAlertmanagerClusterReceiverInformer.
+	VMAlertmanagerClusterReceivers() VMAlertmanagerClusterReceiverInformer
 	// VMAlertmanagerConfigs returns a VMAlertmanagerConfigInformer.
 	VMAlertmanagerConfigs() VMAlertmanagerConfigInformer
+	// VMAlertmanagerReceivers returns a VMAlertmanagerReceiverInformer.
+	VMAlertmanagerReceivers() VMAlertmanagerReceiverInformer
 	// VMAuths returns a VMAuthInformer.
 	VMAuths() VMAuthInformer
 	// VMClusters returns a VMClusterInformer.
 	VMClusters() VMClusterInformer
 	// VMNodeScrapes returns a VMNodeScrapeInformer.
 	VMNodeScrapes() VMNodeScrapeInformer
+	// VMOperatorConfigs returns a VMOperatorConfigInformer.
+	VMOperatorConfigs() VMOperatorConfigInformer
 	// VMPodScrapes returns a VMPodScrapeInformer.
 	VMPodScrapes() VMPodScrapeInformer
 	// VMProbes returns a VMProbeInformer.
@@ -49,6 +55,8 @@ type Interface interface {
 	VMScrapeConfigs() VMScrapeConfigInformer
 	// VMServiceScrapes returns a VMServiceScrapeInformer.
 	VMServiceScrapes() VMServiceScrapeInformer
+	// VMSilences returns a VMSilenceInformer.
+	VMSilences() VMSilenceInformer
 	// VMSingles returns a VMSingleInformer.
 	VMSingles() VMSingleInformer
 	// VMStaticScrapes returns a VMStaticScrapeInformer.
@@ -88,11 +96,21 @@ func (v *version) VMAlertmanagers() VMAlertmanagerInformer {
 	return &vMAlertmanagerInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMAlertmanagerClusterReceivers returns a VMAlertmanagerClusterReceiverInformer.
+func (v *version) VMAlertmanagerClusterReceivers() VMAlertmanagerClusterReceiverInformer {
+	return &vMAlertmanagerClusterReceiverInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // VMAlertmanagerConfigs returns a VMAlertmanagerConfigInformer.
 func (v *version) VMAlertmanagerConfigs() VMAlertmanagerConfigInformer {
 	return &vMAlertmanagerConfigInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMAlertmanagerReceivers returns a VMAlertmanagerReceiverInformer.
+func (v *version) VMAlertmanagerReceivers() VMAlertmanagerReceiverInformer {
+	return &vMAlertmanagerReceiverInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMAuths returns a VMAuthInformer.
 func (v *version) VMAuths() VMAuthInformer {
 	return &vMAuthInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -108,6 +126,11 @@ func (v *version) VMNodeScrapes() VMNodeScrapeInformer {
 	return &vMNodeScrapeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMOperatorConfigs returns a VMOperatorConfigInformer.
+func (v *version) VMOperatorConfigs() VMOperatorConfigInformer {
+	return &vMOperatorConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // VMPodScrapes returns a VMPodScrapeInformer.
 func (v *version) VMPodScrapes() VMPodScrapeInformer {
 	return &vMPodScrapeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -133,6 +156,11 @@ func (v *version) VMServiceScrapes() VMServiceScrapeInformer {
 	return &vMServiceScrapeInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// VMSilences returns a VMSilenceInformer.
+func (v *version) VMSilences() VMSilenceInformer {
+	return &vMSilenceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // VMSingles returns a VMSingleInformer.
 func (v *version) VMSingles() VMSingleInformer {
 	return &vMSingleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
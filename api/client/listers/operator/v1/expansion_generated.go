@@ -49,6 +49,30 @@ type VMAnomalyListerExpansion interface{}
 // VMAnomalyNamespaceLister.
 type VMAnomalyNamespaceListerExpansion interface{}
 
+// VMAnomalyModelListerExpansion allows custom methods to be added to
+// VMAnomalyModelLister.
+type VMAnomalyModelListerExpansion interface{}
+
+// VMAnomalyModelNamespaceListerExpansion allows custom methods to be added to
+// VMAnomalyModelNamespaceLister.
+type VMAnomalyModelNamespaceListerExpansion interface{}
+
+// VMAnomalyQueryListerExpansion allows custom methods to be added to
+// VMAnomalyQueryLister.
+type VMAnomalyQueryListerExpansion interface{}
+
+// VMAnomalyQueryNamespaceListerExpansion allows custom methods to be added to
+// VMAnomalyQueryNamespaceLister.
+type VMAnomalyQueryNamespaceListerExpansion interface{}
+
+// VMAnomalySchedulerListerExpansion allows custom methods to be added to
+// VMAnomalySchedulerLister.
+type VMAnomalySchedulerListerExpansion interface{}
+
+// VMAnomalySchedulerNamespaceListerExpansion allows custom methods to be added to
+// VMAnomalySchedulerNamespaceLister.
+type VMAnomalySchedulerNamespaceListerExpansion interface{}
+
 // VTClusterListerExpansion allows custom methods to be added to
 // VTClusterLister.
 type VTClusterListerExpansion interface{}
@@ -49,6 +49,10 @@ type VMAlertmanagerListerExpansion interface{}
 // VMAlertmanagerNamespaceLister.
 type VMAlertmanagerNamespaceListerExpansion interface{}
 
+// VMAlertmanagerClusterReceiverListerExpansion allows custom methods to be added to
+// VMAlertmanagerClusterReceiverLister.
+type VMAlertmanagerClusterReceiverListerExpansion interface{}
+
 // VMAlertmanagerConfigListerExpansion allows custom methods to be added to
 // VMAlertmanagerConfigLister.
 type VMAlertmanagerConfigListerExpansion interface{}
@@ -57,6 +61,14 @@ type VMAlertmanagerConfigListerExpansion interface{}
 // VMAlertmanagerConfigNamespaceLister.
 type VMAlertmanagerConfigNamespaceListerExpansion interface{}
 
+// VMAlertmanagerReceiverListerExpansion allows custom methods to be added to
+// VMAlertmanagerReceiverLister.
+type VMAlertmanagerReceiverListerExpansion interface{}
+
+// VMAlertmanagerReceiverNamespaceListerExpansion allows custom methods to be added to
+// VMAlertmanagerReceiverNamespaceLister.
+type VMAlertmanagerReceiverNamespaceListerExpansion interface{}
+
 // VMAuthListerExpansion allows custom methods to be added to
 // VMAuthLister.
 type VMAuthListerExpansion interface{}
@@ -81,6 +93,10 @@ type VMNodeScrapeListerExpansion interface{}
 // VMNodeScrapeNamespaceLister.
 type VMNodeScrapeNamespaceListerExpansion interface{}
 
+// VMOperatorConfigListerExpansion allows custom methods to be added to
+// VMOperatorConfigLister.
+type VMOperatorConfigListerExpansion interface{}
+
 // VMPodScrapeListerExpansion allows custom methods to be added to
 // VMPodScrapeLister.
 type VMPodScrapeListerExpansion interface{}
@@ -121,6 +137,14 @@ type VMServiceScrapeListerExpansion interface{}
 // VMServiceScrapeNamespaceLister.
 type VMServiceScrapeNamespaceListerExpansion interface{}
 
+// VMSilenceListerExpansion allows custom methods to be added to
+// VMSilenceLister.
+type VMSilenceListerExpansion interface{}
+
+// VMSilenceNamespaceListerExpansion allows custom methods to be added to
+// VMSilenceNamespaceLister.
+type VMSilenceNamespaceListerExpansion interface{}
+
 // VMSingleListerExpansion allows custom methods to be added to
 // VMSingleLister.
 type VMSingleListerExpansion interface{}
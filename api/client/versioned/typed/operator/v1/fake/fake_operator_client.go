@@ -43,6 +43,18 @@ func (c *FakeOperatorV1) VMAnomalies(namespace string) v1.VMAnomalyInterface {
 	return newFakeVMAnomalies(c, namespace)
 }
 
+func (c *FakeOperatorV1) VMAnomalyModels(namespace string) v1.VMAnomalyModelInterface {
+	return newFakeVMAnomalyModels(c, namespace)
+}
+
+func (c *FakeOperatorV1) VMAnomalyQueries(namespace string) v1.VMAnomalyQueryInterface {
+	return newFakeVMAnomalyQueries(c, namespace)
+}
+
+func (c *FakeOperatorV1) VMAnomalySchedulers(namespace string) v1.VMAnomalySchedulerInterface {
+	return newFakeVMAnomalySchedulers(c, namespace)
+}
+
 func (c *FakeOperatorV1) VTClusters(namespace string) v1.VTClusterInterface {
 	return newFakeVTClusters(c, namespace)
 }
@@ -25,6 +25,12 @@ type VLSingleExpansion interface{}
 
 type VMAnomalyExpansion interface{}
 
+type VMAnomalyModelExpansion interface{}
+
+type VMAnomalyQueryExpansion interface{}
+
+type VMAnomalySchedulerExpansion interface{}
+
 type VTClusterExpansion interface{}
 
 type VTSingleExpansion interface{}
@@ -31,6 +31,9 @@ type OperatorV1Interface interface {
 	VLClustersGetter
 	VLSinglesGetter
 	VMAnomaliesGetter
+	VMAnomalyModelsGetter
+	VMAnomalyQueriesGetter
+	VMAnomalySchedulersGetter
 	VTClustersGetter
 	VTSinglesGetter
 }
@@ -56,6 +59,18 @@ func (c *OperatorV1Client) VMAnomalies(namespace string) VMAnomalyInterface {
 	return newVMAnomalies(c, namespace)
 }
 
+func (c *OperatorV1Client) VMAnomalyModels(namespace string) VMAnomalyModelInterface {
+	return newVMAnomalyModels(c, namespace)
+}
+
+func (c *OperatorV1Client) VMAnomalyQueries(namespace string) VMAnomalyQueryInterface {
+	return newVMAnomalyQueries(c, namespace)
+}
+
+func (c *OperatorV1Client) VMAnomalySchedulers(namespace string) VMAnomalySchedulerInterface {
+	return newVMAnomalySchedulers(c, namespace)
+}
+
 func (c *OperatorV1Client) VTClusters(namespace string) VTClusterInterface {
 	return newVTClusters(c, namespace)
 }
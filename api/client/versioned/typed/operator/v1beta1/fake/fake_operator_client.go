@@ -43,10 +43,18 @@ func (c *FakeOperatorV1beta1) VMAlertmanagers(namespace string) v1beta1.VMAlertm
 	return newFakeVMAlertmanagers(c, namespace)
 }
 
+func (c *FakeOperatorV1beta1) VMAlertmanagerClusterReceivers() v1beta1.VMAlertmanagerClusterReceiverInterface {
+	return newFakeVMAlertmanagerClusterReceivers(c)
+}
+
 func (c *FakeOperatorV1beta1) VMAlertmanagerConfigs(namespace string) v1beta1.VMAlertmanagerConfigInterface {
 	return newFakeVMAlertmanagerConfigs(c, namespace)
 }
 
+func (c *FakeOperatorV1beta1) VMAlertmanagerReceivers(namespace string) v1beta1.VMAlertmanagerReceiverInterface {
+	return newFakeVMAlertmanagerReceivers(c, namespace)
+}
+
 func (c *FakeOperatorV1beta1) VMAuths(namespace string) v1beta1.VMAuthInterface {
 	return newFakeVMAuths(c, namespace)
 }
@@ -59,6 +67,10 @@ func (c *FakeOperatorV1beta1) VMNodeScrapes(namespace string) v1beta1.VMNodeScra
 	return newFakeVMNodeScrapes(c, namespace)
 }
 
+func (c *FakeOperatorV1beta1) VMOperatorConfigs() v1beta1.VMOperatorConfigInterface {
+	return newFakeVMOperatorConfigs(c)
+}
+
 func (c *FakeOperatorV1beta1) VMPodScrapes(namespace string) v1beta1.VMPodScrapeInterface {
 	return newFakeVMPodScrapes(c, namespace)
 }
@@ -79,6 +91,10 @@ func (c *FakeOperatorV1beta1) VMServiceScrapes(namespace string) v1beta1.VMServi
 	return newFakeVMServiceScrapes(c, namespace)
 }
 
+func (c *FakeOperatorV1beta1) VMSilences(namespace string) v1beta1.VMSilenceInterface {
+	return newFakeVMSilences(c, namespace)
+}
+
 func (c *FakeOperatorV1beta1) VMSingles(namespace string) v1beta1.VMSingleInterface {
 	return newFakeVMSingles(c, namespace)
 }
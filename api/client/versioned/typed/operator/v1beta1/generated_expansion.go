@@ -25,14 +25,20 @@ type VMAlertExpansion interface{}
 
 type VMAlertmanagerExpansion interface{}
 
+type VMAlertmanagerClusterReceiverExpansion interface{}
+
 type VMAlertmanagerConfigExpansion interface{}
 
+type VMAlertmanagerReceiverExpansion interface{}
+
 type VMAuthExpansion interface{}
 
 type VMClusterExpansion interface{}
 
 type VMNodeScrapeExpansion interface{}
 
+type VMOperatorConfigExpansion interface{}
+
 type VMPodScrapeExpansion interface{}
 
 type VMProbeExpansion interface{}
@@ -43,6 +49,8 @@ type VMScrapeConfigExpansion interface{}
 
 type VMServiceScrapeExpansion interface{}
 
+type VMSilenceExpansion interface{}
+
 type VMSingleExpansion interface{}
 
 type VMStaticScrapeExpansion interface{}
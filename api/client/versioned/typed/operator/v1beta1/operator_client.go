@@ -31,15 +31,19 @@ type OperatorV1beta1Interface interface {
 	VMAgentsGetter
 	VMAlertsGetter
 	VMAlertmanagersGetter
+	VMAlertmanagerClusterReceiversGetter
 	VMAlertmanagerConfigsGetter
+	VMAlertmanagerReceiversGetter
 	VMAuthsGetter
 	VMClustersGetter
 	VMNodeScrapesGetter
+	VMOperatorConfigsGetter
 	VMPodScrapesGetter
 	VMProbesGetter
 	VMRulesGetter
 	VMScrapeConfigsGetter
 	VMServiceScrapesGetter
+	VMSilencesGetter
 	VMSinglesGetter
 	VMStaticScrapesGetter
 	VMUsersGetter
@@ -66,10 +70,18 @@ func (c *OperatorV1beta1Client) VMAlertmanagers(namespace string) VMAlertmanager
 	return newVMAlertmanagers(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMAlertmanagerClusterReceivers() VMAlertmanagerClusterReceiverInterface {
+	return newVMAlertmanagerClusterReceivers(c)
+}
+
 func (c *OperatorV1beta1Client) VMAlertmanagerConfigs(namespace string) VMAlertmanagerConfigInterface {
 	return newVMAlertmanagerConfigs(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMAlertmanagerReceivers(namespace string) VMAlertmanagerReceiverInterface {
+	return newVMAlertmanagerReceivers(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMAuths(namespace string) VMAuthInterface {
 	return newVMAuths(c, namespace)
 }
@@ -82,6 +94,10 @@ func (c *OperatorV1beta1Client) VMNodeScrapes(namespace string) VMNodeScrapeInte
 	return newVMNodeScrapes(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMOperatorConfigs() VMOperatorConfigInterface {
+	return newVMOperatorConfigs(c)
+}
+
 func (c *OperatorV1beta1Client) VMPodScrapes(namespace string) VMPodScrapeInterface {
 	return newVMPodScrapes(c, namespace)
 }
@@ -102,6 +118,10 @@ func (c *OperatorV1beta1Client) VMServiceScrapes(namespace string) VMServiceScra
 	return newVMServiceScrapes(c, namespace)
 }
 
+func (c *OperatorV1beta1Client) VMSilences(namespace string) VMSilenceInterface {
+	return newVMSilences(c, namespace)
+}
+
 func (c *OperatorV1beta1Client) VMSingles(namespace string) VMSingleInterface {
 	return newVMSingles(c, namespace)
 }
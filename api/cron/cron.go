@@ -0,0 +1,154 @@
+// Package cron implements parsing and matching of standard 5-field cron schedules.
+//
+// It follows vixie cron semantics: fields are minute, hour, day of month, month and day of week,
+// each field accepts lists, ranges and steps, 7 is an alias for Sunday,
+// and day of month and day of week are OR'ed only if both of them are restricted,
+// e.g. don't start with "*".
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxLookahead limits search of the next matching time,
+// since schedule with impossible date, like 30 of February, never matches
+const maxLookahead = 5
+
+// bits holds allowed values of cron field
+type bits uint64
+
+func (b bits) has(v int) bool {
+	return b&(1<<uint(v)) != 0
+}
+
+// Schedule is a parsed cron expression with 5 fields
+type Schedule struct {
+	minute, hour, dom, month, dow bits
+	domRestricted, dowRestricted  bool
+}
+
+// Parse parses cron expression with minute, hour, day of month, month and day of week fields
+func Parse(s string) (*Schedule, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields: minute, hour, day of month, month and day of week, got %d", len(fields))
+	}
+	var cs Schedule
+	var err error
+	if cs.minute, err = parseField(fields[0], 0, 59); err != nil {
+		return nil, fmt.Errorf("minute: %w", err)
+	}
+	if cs.hour, err = parseField(fields[1], 0, 23); err != nil {
+		return nil, fmt.Errorf("hour: %w", err)
+	}
+	if cs.dom, err = parseField(fields[2], 1, 31); err != nil {
+		return nil, fmt.Errorf("day of month: %w", err)
+	}
+	if cs.month, err = parseField(fields[3], 1, 12); err != nil {
+		return nil, fmt.Errorf("month: %w", err)
+	}
+	if cs.dow, err = parseField(fields[4], 0, 7); err != nil {
+		return nil, fmt.Errorf("day of week: %w", err)
+	}
+	// 7 is an alias for Sunday
+	if cs.dow.has(7) {
+		cs.dow |= 1
+	}
+	// vixie cron treats field as unrestricted if it starts with star, including steps like */2
+	cs.domRestricted = !strings.HasPrefix(fields[2], "*")
+	cs.dowRestricted = !strings.HasPrefix(fields[4], "*")
+	return &cs, nil
+}
+
+// Matches checks if the given time matches schedule with minute precision
+func (cs *Schedule) Matches(t time.Time) bool {
+	return cs.minute.has(t.Minute()) && cs.hour.has(t.Hour()) && cs.month.has(int(t.Month())) && cs.matchesDay(t)
+}
+
+// Next returns the nearest time after t, which matches schedule.
+// Schedule is evaluated at location of t
+func (cs *Schedule) Next(t time.Time) (time.Time, error) {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxLookahead, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case !cs.month.has(int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !cs.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !cs.hour.has(t.Hour()):
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case !cs.minute.has(t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("schedule doesn't match any time within %d years", maxLookahead)
+}
+
+func (cs *Schedule) matchesDay(t time.Time) bool {
+	domMatch := cs.dom.has(t.Day())
+	dowMatch := cs.dow.has(int(t.Weekday()))
+	if cs.domRestricted && cs.dowRestricted {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
+
+// parseField parses comma separated list of values, ranges and steps, e.g. 1,5-10,*/15
+func parseField(s string, minValue, maxValue int) (bits, error) {
+	var result bits
+	for part := range strings.SplitSeq(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			v, err := strconv.Atoi(stepPart)
+			if err != nil || v <= 0 {
+				return 0, fmt.Errorf("invalid step=%q", stepPart)
+			}
+			step = v
+		}
+		start, end := minValue, maxValue
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseValue(from, minValue, maxValue); err != nil {
+				return 0, err
+			}
+			end = start
+			if isRange {
+				if end, err = parseValue(to, minValue, maxValue); err != nil {
+					return 0, err
+				}
+				if end < start {
+					return 0, fmt.Errorf("invalid range=%q, start must not exceed end", rangePart)
+				}
+			} else if hasStep {
+				end = maxValue
+			}
+		}
+		for v := start; v <= end; v += step {
+			result |= 1 << uint(v)
+		}
+	}
+	if result == 0 {
+		return 0, fmt.Errorf("no values matched by %q", s)
+	}
+	return result, nil
+}
+
+func parseValue(s string, minValue, maxValue int) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value=%q", s)
+	}
+	if v < minValue || v > maxValue {
+		return 0, fmt.Errorf("value=%d is out of range [%d, %d]", v, minValue, maxValue)
+	}
+	return v, nil
+}
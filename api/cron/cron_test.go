@@ -0,0 +1,161 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	f := func(s string, wantErr bool) {
+		t.Helper()
+		_, err := Parse(s)
+		if wantErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	// valid expressions
+	f("* * * * *", false)
+	f("0 2 * * 6", false)
+	f("*/15 1-3,22 1,15 */2 1-5", false)
+	f("5-50/5 0 1-31/10 1-12 0-7", false)
+	f("  0   2 * * 6 ", false)
+
+	// sunday as 7
+	f("0 0 * * 7", false)
+
+	// wrong number of fields
+	f("", true)
+	f("0 2 * *", true)
+	f("0 2 * * * *", true)
+
+	// values out of range
+	f("60 * * * *", true)
+	f("* 24 * * *", true)
+	f("* * 0 * *", true)
+	f("* * 32 * *", true)
+	f("* * * 0 *", true)
+	f("* * * 13 *", true)
+	f("* * * * 8", true)
+
+	// malformed values
+	f("a * * * *", true)
+	f("1- * * * *", true)
+	f("1,,2 * * * *", true)
+	f("-1 * * * *", true)
+
+	// reversed range
+	f("0 5-2 * * *", true)
+
+	// invalid step
+	f("*/0 * * * *", true)
+	f("*/x * * * *", true)
+	f("1/-1 * * * *", true)
+}
+
+func TestScheduleNext(t *testing.T) {
+	f := func(s, from string, want ...string) {
+		t.Helper()
+		cs, err := Parse(s)
+		assert.NoError(t, err)
+		ts, err := time.Parse(time.RFC3339, from)
+		assert.NoError(t, err)
+		var got []string
+		for range want {
+			ts, err = cs.Next(ts)
+			assert.NoError(t, err)
+			assert.True(t, cs.Matches(ts))
+			got = append(got, ts.Format(time.RFC3339))
+		}
+		assert.Equal(t, want, got)
+	}
+	// 2026-10-19 is Monday
+	const from = "2026-10-19T10:00:00Z"
+
+	// every minute
+	f("* * * * *", from, "2026-10-19T10:01:00Z", "2026-10-19T10:02:00Z", "2026-10-19T10:03:00Z")
+
+	// step
+	f("*/15 * * * *", from, "2026-10-19T10:15:00Z", "2026-10-19T10:30:00Z", "2026-10-19T10:45:00Z")
+
+	// range with step
+	f("5-10/2 * * * *", from, "2026-10-19T10:05:00Z", "2026-10-19T10:07:00Z", "2026-10-19T10:09:00Z", "2026-10-19T11:05:00Z")
+
+	// value with step starts at value
+	f("0 20/2 * * *", from, "2026-10-19T20:00:00Z", "2026-10-19T22:00:00Z", "2026-10-20T20:00:00Z")
+
+	// lists and ranges
+	f("0,30 9-10 * * 1-5", from, "2026-10-19T10:30:00Z", "2026-10-20T09:00:00Z", "2026-10-20T09:30:00Z")
+
+	// sunday as 0 and 7
+	f("0 0 * * 0", from, "2026-10-25T00:00:00Z", "2026-11-01T00:00:00Z", "2026-11-08T00:00:00Z")
+	f("0 0 * * 7", from, "2026-10-25T00:00:00Z", "2026-11-01T00:00:00Z", "2026-11-08T00:00:00Z")
+
+	// day of month
+	f("0 0 1,15 * *", from, "2026-11-01T00:00:00Z", "2026-11-15T00:00:00Z", "2026-12-01T00:00:00Z")
+
+	// month
+	f("0 12 * 1-3 *", from, "2027-01-01T12:00:00Z", "2027-01-02T12:00:00Z", "2027-01-03T12:00:00Z")
+
+	// restricted day of month and day of week match any of them
+	f("0 0 1 * 1", from, "2026-10-26T00:00:00Z", "2026-11-01T00:00:00Z", "2026-11-02T00:00:00Z")
+	f("0 0 1-3 * 1", from, "2026-10-26T00:00:00Z", "2026-11-01T00:00:00Z", "2026-11-02T00:00:00Z", "2026-11-03T00:00:00Z", "2026-11-09T00:00:00Z")
+
+	// day of month starting with star isn't restricted, both fields must match
+	f("0 0 */2 * 1", from, "2026-11-09T00:00:00Z", "2026-11-23T00:00:00Z", "2026-12-07T00:00:00Z")
+
+	// day of week starting with star isn't restricted, both fields must match
+	f("0 0 13 * */2", from, "2026-12-13T00:00:00Z", "2027-02-13T00:00:00Z", "2027-03-13T00:00:00Z")
+
+	// leap day
+	f("30 2 29 2 *", from, "2028-02-29T02:30:00Z", "2032-02-29T02:30:00Z")
+
+	// seconds are truncated
+	f("* * * * *", "2026-10-19T10:00:59Z", "2026-10-19T10:01:00Z")
+}
+
+func TestScheduleNextLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Kolkata")
+	assert.NoError(t, err)
+	cs, err := Parse("0 2 * * *")
+	assert.NoError(t, err)
+	got, err := cs.Next(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC).In(loc))
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-10-19T20:30:00Z", got.UTC().Format(time.RFC3339))
+}
+
+func TestScheduleNextImpossibleDate(t *testing.T) {
+	cs, err := Parse("0 0 30 2 *")
+	assert.NoError(t, err)
+	_, err = cs.Next(time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC))
+	assert.Error(t, err)
+}
+
+func TestScheduleMatches(t *testing.T) {
+	f := func(s, ts string, want bool) {
+		t.Helper()
+		cs, err := Parse(s)
+		assert.NoError(t, err)
+		tt, err := time.Parse(time.RFC3339, ts)
+		assert.NoError(t, err)
+		assert.Equal(t, want, cs.Matches(tt))
+	}
+
+	// seconds are ignored
+	f("0 2 * * *", "2026-10-19T02:00:30Z", true)
+	f("0 2 * * *", "2026-10-19T02:01:00Z", false)
+
+	// restricted day of month and day of week
+	f("0 0 1 * 1", "2026-10-19T00:00:00Z", true)
+	f("0 0 1 * 1", "2026-10-01T00:00:00Z", true)
+	f("0 0 1 * 1", "2026-10-20T00:00:00Z", false)
+
+	// unrestricted day of month with step
+	f("0 0 */2 * 1", "2026-10-19T00:00:00Z", true)
+	f("0 0 */2 * 1", "2026-10-26T00:00:00Z", false)
+	f("0 0 */2 * 1", "2026-10-21T00:00:00Z", false)
+}
@@ -7,6 +7,7 @@ go 1.26.0
 require (
 	github.com/VictoriaMetrics/VictoriaMetrics v1.136.0
 	github.com/prometheus/alertmanager v0.31.1
+	github.com/prometheus/common v0.67.5
 	github.com/stretchr/testify v1.11.1
 	gopkg.in/yaml.v2 v2.4.0
 	gopkg.in/yaml.v3 v3.0.1
@@ -77,7 +78,6 @@ require (
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
 	github.com/prometheus/client_golang v1.23.2 // indirect
 	github.com/prometheus/client_model v0.6.2 // indirect
-	github.com/prometheus/common v0.67.5 // indirect
 	github.com/prometheus/procfs v0.19.2 // indirect
 	github.com/prometheus/sigv4 v0.4.1 // indirect
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
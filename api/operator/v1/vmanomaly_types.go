@@ -74,6 +74,36 @@ type VMAnomalySpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret with anomaly config",xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
 	ConfigSecret *corev1.SecretKeySelector `json:"configSecret,omitempty"`
+	// ModelSelector defines VMAnomalyModel objects to be selected for anomaly detection.
+	// Works in combination with ModelNamespaceSelector.
+	// If both nil - models are not selected.
+	// ModelNamespaceSelector nil - only objects at VMAnomaly namespace.
+	// +optional
+	ModelSelector *metav1.LabelSelector `json:"modelSelector,omitempty"`
+	// ModelNamespaceSelector defines namespaces to be selected for VMAnomalyModel discovery.
+	// Works in combination with ModelSelector.
+	// +optional
+	ModelNamespaceSelector *metav1.LabelSelector `json:"modelNamespaceSelector,omitempty"`
+	// SchedulerSelector defines VMAnomalyScheduler objects to be selected for anomaly detection.
+	// Works in combination with SchedulerNamespaceSelector.
+	// If both nil - schedulers are not selected.
+	// SchedulerNamespaceSelector nil - only objects at VMAnomaly namespace.
+	// +optional
+	SchedulerSelector *metav1.LabelSelector `json:"schedulerSelector,omitempty"`
+	// SchedulerNamespaceSelector defines namespaces to be selected for VMAnomalyScheduler discovery.
+	// Works in combination with SchedulerSelector.
+	// +optional
+	SchedulerNamespaceSelector *metav1.LabelSelector `json:"schedulerNamespaceSelector,omitempty"`
+	// QuerySelector defines VMAnomalyQuery objects to be selected for anomaly detection.
+	// Works in combination with QueryNamespaceSelector.
+	// If both nil - queries are not selected.
+	// QueryNamespaceSelector nil - only objects at VMAnomaly namespace.
+	// +optional
+	QuerySelector *metav1.LabelSelector `json:"querySelector,omitempty"`
+	// QueryNamespaceSelector defines namespaces to be selected for VMAnomalyQuery discovery.
+	// Works in combination with QuerySelector.
+	// +optional
+	QueryNamespaceSelector *metav1.LabelSelector `json:"queryNamespaceSelector,omitempty"`
 	// Metrics source for VMAnomaly
 	// See https://docs.victoriametrics.com/anomaly-detection/components/reader/
 	Reader *VMAnomalyReadersSpec `json:"reader"`
@@ -98,6 +128,10 @@ type VMAnomalySpec struct {
 	// Server configures HTTP server for VMAnomaly
 	// +optional
 	Server *VMAnomalyServerSpec `json:"server,omitempty"`
+	// Alerting configures alerts on anomaly scores.
+	// If defined, operator generates VMRule per each model of the configuration
+	// +optional
+	Alerting *VMAnomalyAlertingSpec `json:"alerting,omitempty"`
 	// License allows to configure license key to be used for enterprise features.
 	// Using license key is supported starting from VictoriaMetrics v1.94.0.
 	// See [here](https://docs.victoriametrics.com/victoriametrics/enterprise/)
@@ -115,7 +149,12 @@ type VMAnomalyWritersSpec struct {
 	// DatasourceURL defines remote write url for write requests
 	// provided endpoint must serve /api/v1/import path
 	// vmanomaly joins datasourceURL + "/api/v1/import"
-	DatasourceURL string `json:"datasourceURL" yaml:"datasource_url,omitempty"`
+	// +optional
+	DatasourceURL string `json:"datasourceURL,omitempty" yaml:"datasource_url,omitempty"`
+	// Ref defines VMSingle, VMCluster or VMAuth, which is used as a destination instead of datasourceURL.
+	// For VMCluster tenantID is added to vminsert URL path
+	// +optional
+	Ref *vmv1beta1.DatasourceRef `json:"ref,omitempty" yaml:"-"`
 	// Metrics to save the output (in metric names or labels)
 	// +optional
 	MetricFormat VMAnomalyVMWriterMetricFormatSpec `json:"metricFormat,omitempty" yaml:"metric_format,omitempty"`
@@ -154,7 +193,12 @@ type VMAnomalyHTTPClientSpec struct {
 type VMAnomalyReadersSpec struct {
 	// DatasourceURL address
 	// datasource must serve /api/v1/query and /api/v1/query_range APIs
-	DatasourceURL string `json:"datasourceURL" yaml:"datasource_url,omitempty"`
+	// +optional
+	DatasourceURL string `json:"datasourceURL,omitempty" yaml:"datasource_url,omitempty"`
+	// Ref defines VMSingle, VMCluster or VMAuth, which is used as a datasource instead of datasourceURL.
+	// For VMCluster tenantID is added to vmselect URL path
+	// +optional
+	Ref *vmv1beta1.DatasourceRef `json:"ref,omitempty" yaml:"-"`
 	// Frequency of the points returned
 	SamplingPeriod string `json:"samplingPeriod" yaml:"sampling_period,omitempty"`
 	// Performs PromQL/MetricsQL range query
@@ -262,6 +306,31 @@ type VMAnomalyServerSpec struct {
 	UIDefaultState string `json:"uiDefaultState,omitempty" yaml:"ui_default_state,omitempty"`
 }
 
+// VMAnomalyAlertingSpec defines alerting rules, which are generated by operator for anomaly scores
+type VMAnomalyAlertingSpec struct {
+	// Threshold defines anomaly score value, above which alert is triggered
+	// Defaults to 1.0
+	// +optional
+	// +kubebuilder:validation:Pattern:="^[0-9]+(\\.[0-9]+)?$"
+	Threshold string `json:"threshold,omitempty"`
+	// For defines duration of anomaly score exceeding threshold before alert is triggered
+	// +optional
+	For string `json:"for,omitempty"`
+	// Interval defines how often generated rules are evaluated
+	// +optional
+	Interval string `json:"interval,omitempty"`
+	// Labels defines labels added to each alert, for example severity
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations defines annotations added to each alert
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// RuleLabels defines labels added to generated VMRule objects.
+	// Must match ruleSelector of VMAlert, which should evaluate generated rules
+	// +optional
+	RuleLabels map[string]string `json:"ruleLabels,omitempty"`
+}
+
 // AsOwner returns owner references with current object as owner
 func (cr *VMAnomaly) AsOwner() metav1.OwnerReference {
 	return metav1.OwnerReference{
@@ -434,6 +503,38 @@ func (cr *VMAnomaly) Validate() error {
 	if !cr.Spec.License.IsProvided() {
 		return fmt.Errorf("no license is provided!. Either spec.license.key or spec.license.keyRef is required")
 	}
+	if r := cr.Spec.Reader; r != nil {
+		if err := validateAnomalyDatasource(r.DatasourceURL, r.Ref, &r.VMAnomalyHTTPClientSpec); err != nil {
+			return fmt.Errorf("incorrect spec.reader: %w", err)
+		}
+	}
+	if w := cr.Spec.Writer; w != nil {
+		if err := validateAnomalyDatasource(w.DatasourceURL, w.Ref, &w.VMAnomalyHTTPClientSpec); err != nil {
+			return fmt.Errorf("incorrect spec.writer: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateAnomalyDatasource(url string, ref *vmv1beta1.DatasourceRef, cfg *VMAnomalyHTTPClientSpec) error {
+	if ref == nil {
+		if url == "" {
+			return fmt.Errorf("either datasourceURL or ref must be defined")
+		}
+		return nil
+	}
+	if url != "" {
+		return fmt.Errorf("datasourceURL and ref cannot be defined at the same time")
+	}
+	if err := ref.Validate("VMSingle", "VMCluster", "VMAuth"); err != nil {
+		return fmt.Errorf("incorrect ref: %w", err)
+	}
+	if ref.Kind == "VMSingle" && cfg.TenantID != "" {
+		return fmt.Errorf("tenantID cannot be used with VMSingle ref")
+	}
+	if ref.User != "" && (cfg.BasicAuth != nil || cfg.BearerAuth != nil) {
+		return fmt.Errorf("ref.user cannot be used with basicAuth or bearer")
+	}
 	return nil
 }
 
@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"fmt"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// reservedAnomalyParams defines model and scheduler params, which are managed by operator
+var reservedAnomalyParams = []string{"class", "queries", "schedulers"}
+
+// VMAnomalyModelSpec defines anomaly detection model, which is added to the configuration of selected VMAnomaly
+// See https://docs.victoriametrics.com/anomaly-detection/components/models/
+type VMAnomalyModelSpec struct {
+	// Class defines model class, for example zscore, prophet or model.zscore.ZscoreModel
+	Class string `json:"class"`
+	// Queries defines names of VMAnomalyQuery objects from the model namespace,
+	// which are used as an input for the model
+	// +optional
+	Queries []string `json:"queries,omitempty"`
+	// Schedulers defines names of VMAnomalyScheduler objects from the model namespace,
+	// which are used to fit and infer the model
+	// +optional
+	Schedulers []string `json:"schedulers,omitempty"`
+	// Params defines class specific model parameters, for example z_threshold or detection_direction
+	// +optional
+	Params map[string]apiextensionsv1.JSON `json:"params,omitempty"`
+}
+
+// VMAnomalyModelStatus defines the observed state of VMAnomalyModel
+type VMAnomalyModelStatus struct {
+	vmv1beta1.StatusMetadata `json:",inline"`
+}
+
+// VMAnomalyModel is the Schema for the vmanomalymodels API.
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMAnomaly Model"
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmanomalymodels,scope=Namespaced
+// +kubebuilder:printcolumn:name="Class",type="string",JSONPath=".spec.class"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+// +k8s:openapi-gen=true
+type VMAnomalyModel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMAnomalyModelSpec `json:"spec"`
+	// +optional
+	Status VMAnomalyModelStatus `json:"status,omitempty"`
+}
+
+// GetStatusMetadata implements reconcile.objectWithStatus interface
+func (cr *VMAnomalyModel) GetStatusMetadata() *vmv1beta1.StatusMetadata {
+	return &cr.Status.StatusMetadata
+}
+
+// AsKey returns unique key for object
+func (cr *VMAnomalyModel) AsKey(_ bool) string {
+	return fmt.Sprintf("%s-%s", cr.Namespace, cr.Name)
+}
+
+// Validate performs syntax validation
+func (cr *VMAnomalyModel) Validate() error {
+	if vmv1beta1.MustSkipCRValidation(cr) {
+		return nil
+	}
+	if cr.Spec.Class == "" {
+		return fmt.Errorf("class cannot be empty")
+	}
+	if len(cr.Spec.Queries) == 0 {
+		return fmt.Errorf("at least one query is required")
+	}
+	if err := validateAnomalyRefs("queries", cr.Spec.Queries); err != nil {
+		return err
+	}
+	if err := validateAnomalyRefs("schedulers", cr.Spec.Schedulers); err != nil {
+		return err
+	}
+	return validateAnomalyParams(cr.Spec.Params)
+}
+
+func validateAnomalyRefs(field string, refs []string) error {
+	for i, ref := range refs {
+		if ref == "" {
+			return fmt.Errorf("%s[%d] cannot be empty", field, i)
+		}
+		if slices.Contains(refs[:i], ref) {
+			return fmt.Errorf("%s[%d]=%q is duplicated", field, i, ref)
+		}
+	}
+	return nil
+}
+
+func validateAnomalyParams(params map[string]apiextensionsv1.JSON) error {
+	for _, name := range reservedAnomalyParams {
+		if _, ok := params[name]; ok {
+			return fmt.Errorf("params.%s is not allowed, use spec.%s instead", name, name)
+		}
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+
+// VMAnomalyModelList contains a list of VMAnomalyModel
+type VMAnomalyModelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMAnomalyModel `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMAnomalyModel{}, &VMAnomalyModelList{})
+}
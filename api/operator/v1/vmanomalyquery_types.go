@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMAnomalyQuerySpec defines reader query, which could be referenced by VMAnomalyModel
+// See https://docs.victoriametrics.com/anomaly-detection/components/reader/#per-query-parameters
+type VMAnomalyQuerySpec struct {
+	// Expr defines MetricsQL or LogsQL expression
+	Expr string `json:"expr" yaml:"expr"`
+	// Step defines query resolution, overrides reader samplingPeriod
+	// +optional
+	Step string `json:"step,omitempty" yaml:"step,omitempty"`
+	// DataRange defines valid data range for the query results
+	// +optional
+	DataRange []string `json:"dataRange,omitempty" yaml:"data_range,omitempty"`
+	// MaxPointsPerQuery overrides reader maxPointsPerQuery for the query
+	// +optional
+	MaxPointsPerQuery int `json:"maxPointsPerQuery,omitempty" yaml:"max_points_per_query,omitempty"`
+	// Timezone defines IANA timezone for the query, overrides reader tz
+	// +optional
+	Timezone string `json:"tz,omitempty" yaml:"tz,omitempty"`
+	// TenantID defines tenant for VictoriaMetrics cluster version, overrides reader tenantID
+	// +optional
+	TenantID string `json:"tenantID,omitempty" yaml:"tenant_id,omitempty"`
+}
+
+// VMAnomalyQueryStatus defines the observed state of VMAnomalyQuery
+type VMAnomalyQueryStatus struct {
+	vmv1beta1.StatusMetadata `json:",inline"`
+}
+
+// VMAnomalyQuery is the Schema for the vmanomalyqueries API.
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMAnomaly Query"
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmanomalyqueries,scope=Namespaced
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+// +k8s:openapi-gen=true
+type VMAnomalyQuery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMAnomalyQuerySpec `json:"spec"`
+	// +optional
+	Status VMAnomalyQueryStatus `json:"status,omitempty"`
+}
+
+// GetStatusMetadata implements reconcile.objectWithStatus interface
+func (cr *VMAnomalyQuery) GetStatusMetadata() *vmv1beta1.StatusMetadata {
+	return &cr.Status.StatusMetadata
+}
+
+// AsKey returns unique key for object
+func (cr *VMAnomalyQuery) AsKey(_ bool) string {
+	return fmt.Sprintf("%s-%s", cr.Namespace, cr.Name)
+}
+
+// Validate performs syntax validation
+func (cr *VMAnomalyQuery) Validate() error {
+	if vmv1beta1.MustSkipCRValidation(cr) {
+		return nil
+	}
+	if cr.Spec.Expr == "" {
+		return fmt.Errorf("expr cannot be empty")
+	}
+	if len(cr.Spec.DataRange) > 0 && len(cr.Spec.DataRange) != 2 {
+		return fmt.Errorf("only two values are expected in dataRange, got %d", len(cr.Spec.DataRange))
+	}
+	return nil
+}
+
+// +kubebuilder:object:root=true
+
+// VMAnomalyQueryList contains a list of VMAnomalyQuery
+type VMAnomalyQueryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMAnomalyQuery `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMAnomalyQuery{}, &VMAnomalyQueryList{})
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMAnomalySchedulerSpec defines anomaly detection scheduler, which could be referenced by VMAnomalyModel
+// See https://docs.victoriametrics.com/anomaly-detection/components/scheduler/
+type VMAnomalySchedulerSpec struct {
+	// Class defines scheduler class, for example periodic, oneoff or scheduler.periodic.PeriodicScheduler
+	Class string `json:"class"`
+	// Params defines class specific scheduler parameters, for example fit_every or infer_every
+	// +optional
+	Params map[string]apiextensionsv1.JSON `json:"params,omitempty"`
+}
+
+// VMAnomalySchedulerStatus defines the observed state of VMAnomalyScheduler
+type VMAnomalySchedulerStatus struct {
+	vmv1beta1.StatusMetadata `json:",inline"`
+}
+
+// VMAnomalyScheduler is the Schema for the vmanomalyschedulers API.
+// +operator-sdk:gen-csv:customresourcedefinitions.displayName="VMAnomaly Scheduler"
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=vmanomalyschedulers,scope=Namespaced
+// +kubebuilder:printcolumn:name="Class",type="string",JSONPath=".spec.class"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+// +k8s:openapi-gen=true
+type VMAnomalyScheduler struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMAnomalySchedulerSpec `json:"spec"`
+	// +optional
+	Status VMAnomalySchedulerStatus `json:"status,omitempty"`
+}
+
+// GetStatusMetadata implements reconcile.objectWithStatus interface
+func (cr *VMAnomalyScheduler) GetStatusMetadata() *vmv1beta1.StatusMetadata {
+	return &cr.Status.StatusMetadata
+}
+
+// AsKey returns unique key for object
+func (cr *VMAnomalyScheduler) AsKey(_ bool) string {
+	return fmt.Sprintf("%s-%s", cr.Namespace, cr.Name)
+}
+
+// Validate performs syntax validation
+func (cr *VMAnomalyScheduler) Validate() error {
+	if vmv1beta1.MustSkipCRValidation(cr) {
+		return nil
+	}
+	if cr.Spec.Class == "" {
+		return fmt.Errorf("class cannot be empty")
+	}
+	return validateAnomalyParams(cr.Spec.Params)
+}
+
+// +kubebuilder:object:root=true
+
+// VMAnomalySchedulerList contains a list of VMAnomalyScheduler
+type VMAnomalySchedulerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMAnomalyScheduler `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&VMAnomalyScheduler{}, &VMAnomalySchedulerList{})
+}
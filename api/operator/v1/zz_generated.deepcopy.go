@@ -24,6 +24,8 @@ import (
 	"github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -1052,6 +1054,42 @@ func (in *VMAnomaly) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyAlertingSpec) DeepCopyInto(out *VMAnomalyAlertingSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RuleLabels != nil {
+		in, out := &in.RuleLabels, &out.RuleLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyAlertingSpec.
+func (in *VMAnomalyAlertingSpec) DeepCopy() *VMAnomalyAlertingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyAlertingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAnomalyHTTPClientSpec) DeepCopyInto(out *VMAnomalyHTTPClientSpec) {
 	*out = *in
@@ -1114,6 +1152,113 @@ func (in *VMAnomalyList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyModel) DeepCopyInto(out *VMAnomalyModel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyModel.
+func (in *VMAnomalyModel) DeepCopy() *VMAnomalyModel {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyModel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAnomalyModel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyModelList) DeepCopyInto(out *VMAnomalyModelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAnomalyModel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyModelList.
+func (in *VMAnomalyModelList) DeepCopy() *VMAnomalyModelList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyModelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAnomalyModelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyModelSpec) DeepCopyInto(out *VMAnomalyModelSpec) {
+	*out = *in
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schedulers != nil {
+		in, out := &in.Schedulers, &out.Schedulers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyModelSpec.
+func (in *VMAnomalyModelSpec) DeepCopy() *VMAnomalyModelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyModelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyModelStatus) DeepCopyInto(out *VMAnomalyModelStatus) {
+	*out = *in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyModelStatus.
+func (in *VMAnomalyModelStatus) DeepCopy() *VMAnomalyModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAnomalyMonitoringPullSpec) DeepCopyInto(out *VMAnomalyMonitoringPullSpec) {
 	*out = *in
@@ -1177,9 +1322,109 @@ func (in *VMAnomalyMonitoringSpec) DeepCopy() *VMAnomalyMonitoringSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyQuery) DeepCopyInto(out *VMAnomalyQuery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyQuery.
+func (in *VMAnomalyQuery) DeepCopy() *VMAnomalyQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAnomalyQuery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyQueryList) DeepCopyInto(out *VMAnomalyQueryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAnomalyQuery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyQueryList.
+func (in *VMAnomalyQueryList) DeepCopy() *VMAnomalyQueryList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyQueryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAnomalyQueryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyQuerySpec) DeepCopyInto(out *VMAnomalyQuerySpec) {
+	*out = *in
+	if in.DataRange != nil {
+		in, out := &in.DataRange, &out.DataRange
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyQuerySpec.
+func (in *VMAnomalyQuerySpec) DeepCopy() *VMAnomalyQuerySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyQuerySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyQueryStatus) DeepCopyInto(out *VMAnomalyQueryStatus) {
+	*out = *in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyQueryStatus.
+func (in *VMAnomalyQueryStatus) DeepCopy() *VMAnomalyQueryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyQueryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAnomalyReadersSpec) DeepCopyInto(out *VMAnomalyReadersSpec) {
 	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(v1beta1.DatasourceRef)
+		**out = **in
+	}
 	if in.ExtraFilters != nil {
 		in, out := &in.ExtraFilters, &out.ExtraFilters
 		*out = make([]string, len(*in))
@@ -1203,6 +1448,103 @@ func (in *VMAnomalyReadersSpec) DeepCopy() *VMAnomalyReadersSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalyScheduler) DeepCopyInto(out *VMAnomalyScheduler) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalyScheduler.
+func (in *VMAnomalyScheduler) DeepCopy() *VMAnomalyScheduler {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalyScheduler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAnomalyScheduler) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalySchedulerList) DeepCopyInto(out *VMAnomalySchedulerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAnomalyScheduler, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalySchedulerList.
+func (in *VMAnomalySchedulerList) DeepCopy() *VMAnomalySchedulerList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalySchedulerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAnomalySchedulerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalySchedulerSpec) DeepCopyInto(out *VMAnomalySchedulerSpec) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]apiextensionsv1.JSON, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalySchedulerSpec.
+func (in *VMAnomalySchedulerSpec) DeepCopy() *VMAnomalySchedulerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalySchedulerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAnomalySchedulerStatus) DeepCopyInto(out *VMAnomalySchedulerStatus) {
+	*out = *in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAnomalySchedulerStatus.
+func (in *VMAnomalySchedulerStatus) DeepCopy() *VMAnomalySchedulerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAnomalySchedulerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAnomalyServerSpec) DeepCopyInto(out *VMAnomalyServerSpec) {
 	*out = *in
@@ -1256,6 +1598,36 @@ func (in *VMAnomalySpec) DeepCopyInto(out *VMAnomalySpec) {
 		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ModelSelector != nil {
+		in, out := &in.ModelSelector, &out.ModelSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelNamespaceSelector != nil {
+		in, out := &in.ModelNamespaceSelector, &out.ModelNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchedulerSelector != nil {
+		in, out := &in.SchedulerSelector, &out.SchedulerSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchedulerNamespaceSelector != nil {
+		in, out := &in.SchedulerNamespaceSelector, &out.SchedulerNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QuerySelector != nil {
+		in, out := &in.QuerySelector, &out.QuerySelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueryNamespaceSelector != nil {
+		in, out := &in.QueryNamespaceSelector, &out.QueryNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Reader != nil {
 		in, out := &in.Reader, &out.Reader
 		*out = new(VMAnomalyReadersSpec)
@@ -1293,6 +1665,11 @@ func (in *VMAnomalySpec) DeepCopyInto(out *VMAnomalySpec) {
 		*out = new(VMAnomalyServerSpec)
 		**out = **in
 	}
+	if in.Alerting != nil {
+		in, out := &in.Alerting, &out.Alerting
+		*out = new(VMAnomalyAlertingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.License != nil {
 		in, out := &in.License, &out.License
 		*out = new(v1beta1.License)
@@ -1358,6 +1735,11 @@ func (in *VMAnomalyVMWriterMetricFormatSpec) DeepCopy() *VMAnomalyVMWriterMetric
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAnomalyWritersSpec) DeepCopyInto(out *VMAnomalyWritersSpec) {
 	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(v1beta1.DatasourceRef)
+		**out = **in
+	}
 	in.MetricFormat.DeepCopyInto(&out.MetricFormat)
 	in.VMAnomalyHTTPClientSpec.DeepCopyInto(&out.VMAnomalyHTTPClientSpec)
 }
@@ -0,0 +1,139 @@
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/VictoriaMetrics/operator/api/cron"
+)
+
+// maxMaintenanceWindowDuration limits duration of a single maintenance window
+const maxMaintenanceWindowDuration = 7 * 24 * time.Hour
+
+// MaintenanceWindow defines recurring time period, when disruptive changes of child objects,
+// like pod template or storage changes, are applied
+type MaintenanceWindow struct {
+	// Schedule defines start of window in cron format with minute, hour, day of month, month and day of week fields,
+	// e.g. "0 2 * * 6" starts window at 02:00 on Saturday
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Duration defines how long window lasts after start, e.g. 4h
+	// +kubebuilder:validation:MinLength=1
+	Duration string `json:"duration"`
+	// Timezone defines IANA time zone name for schedule, e.g. Europe/Berlin.
+	// UTC is used by default
+	// +optional
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// PendingChanges contains disruptive changes of child objects deferred until the next maintenance window
+type PendingChanges struct {
+	// ObservedGeneration defines generation of the object, for which changes were deferred
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Summary contains count of deferred changes
+	Summary string `json:"summary,omitempty"`
+	// NextWindow defines start time of the next maintenance window
+	// +optional
+	NextWindow *metav1.Time `json:"nextWindow,omitempty"`
+	// Changes contains deferred changes of child objects
+	// +optional
+	Changes []PlannedChange `json:"changes,omitempty"`
+}
+
+// Validate checks schedule, duration and timezone of window
+func (mw *MaintenanceWindow) Validate() error {
+	_, err := mw.parse()
+	return err
+}
+
+// IsActive checks if window is active at the given time
+func (mw *MaintenanceWindow) IsActive(t time.Time) (bool, error) {
+	pw, err := mw.parse()
+	if err != nil {
+		return false, err
+	}
+	return pw.isActive(t), nil
+}
+
+// NextStart returns the nearest start of window after the given time
+func (mw *MaintenanceWindow) NextStart(t time.Time) (time.Time, error) {
+	pw, err := mw.parse()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return pw.nextStart(t)
+}
+
+// ActiveMaintenanceWindow checks if any of windows is active at the given time.
+// Otherwise, it returns the nearest start of the next window
+func ActiveMaintenanceWindow(windows []MaintenanceWindow, t time.Time) (bool, time.Time, error) {
+	var next time.Time
+	for i := range windows {
+		pw, err := windows[i].parse()
+		if err != nil {
+			return false, next, fmt.Errorf("maintenanceWindows[%d]: %w", i, err)
+		}
+		if pw.isActive(t) {
+			return true, next, nil
+		}
+		start, err := pw.nextStart(t)
+		if err != nil {
+			return false, next, fmt.Errorf("maintenanceWindows[%d]: %w", i, err)
+		}
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+	return false, next, nil
+}
+
+type parsedMaintenanceWindow struct {
+	schedule *cron.Schedule
+	duration time.Duration
+	location *time.Location
+}
+
+func (mw *MaintenanceWindow) parse() (*parsedMaintenanceWindow, error) {
+	schedule, err := cron.Parse(mw.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse schedule=%q: %w", mw.Schedule, err)
+	}
+	duration, err := time.ParseDuration(mw.Duration)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse duration=%q: %w", mw.Duration, err)
+	}
+	if duration < time.Minute || duration > maxMaintenanceWindowDuration {
+		return nil, fmt.Errorf("duration=%q must be in range [1m, %s]", mw.Duration, maxMaintenanceWindowDuration)
+	}
+	location := time.UTC
+	if mw.Timezone != "" {
+		location, err = time.LoadLocation(mw.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load timezone=%q: %w", mw.Timezone, err)
+		}
+	}
+	return &parsedMaintenanceWindow{
+		schedule: schedule,
+		duration: duration,
+		location: location,
+	}, nil
+}
+
+// isActive checks if window started within duration before the given time
+func (pw *parsedMaintenanceWindow) isActive(t time.Time) bool {
+	t = t.In(pw.location)
+	start := t.Truncate(time.Minute)
+	for ; t.Sub(start) < pw.duration; start = start.Add(-time.Minute) {
+		if pw.schedule.Matches(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextStart returns the nearest time after t, which matches schedule
+func (pw *parsedMaintenanceWindow) nextStart(t time.Time) (time.Time, error) {
+	return pw.schedule.Next(t.In(pw.location))
+}
@@ -0,0 +1,148 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintenanceWindowValidate(t *testing.T) {
+	f := func(mw MaintenanceWindow, wantErr bool) {
+		t.Helper()
+		err := mw.Validate()
+		if wantErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	// valid window
+	f(MaintenanceWindow{Schedule: "0 2 * * 6", Duration: "4h"}, false)
+
+	// valid window with lists, ranges, steps and timezone
+	f(MaintenanceWindow{Schedule: "*/15 1-3,22 1,15 */2 1-5", Duration: "30m", Timezone: "Europe/Berlin"}, false)
+
+	// sunday as 7
+	f(MaintenanceWindow{Schedule: "0 0 * * 7", Duration: "1h"}, false)
+
+	// missing fields
+	f(MaintenanceWindow{Schedule: "0 2 * *", Duration: "4h"}, true)
+
+	// value out of range
+	f(MaintenanceWindow{Schedule: "60 2 * * *", Duration: "4h"}, true)
+
+	// reversed range
+	f(MaintenanceWindow{Schedule: "0 5-2 * * *", Duration: "4h"}, true)
+
+	// invalid step
+	f(MaintenanceWindow{Schedule: "*/0 2 * * *", Duration: "4h"}, true)
+
+	// invalid duration
+	f(MaintenanceWindow{Schedule: "0 2 * * *", Duration: "4 hours"}, true)
+
+	// too short duration
+	f(MaintenanceWindow{Schedule: "0 2 * * *", Duration: "30s"}, true)
+
+	// too long duration
+	f(MaintenanceWindow{Schedule: "0 2 * * *", Duration: "200h"}, true)
+
+	// unknown timezone
+	f(MaintenanceWindow{Schedule: "0 2 * * *", Duration: "4h", Timezone: "Mars/Olympus"}, true)
+}
+
+func TestMaintenanceWindowIsActive(t *testing.T) {
+	f := func(mw MaintenanceWindow, now string, want bool) {
+		t.Helper()
+		ts, err := time.Parse(time.RFC3339, now)
+		assert.NoError(t, err)
+		got, err := mw.IsActive(ts)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	// saturday 02:00-06:00
+	saturday := MaintenanceWindow{Schedule: "0 2 * * 6", Duration: "4h"}
+
+	// at start
+	f(saturday, "2026-10-17T02:00:00Z", true)
+
+	// inside window
+	f(saturday, "2026-10-17T05:59:59Z", true)
+
+	// at end
+	f(saturday, "2026-10-17T06:00:00Z", false)
+
+	// before start
+	f(saturday, "2026-10-17T01:59:00Z", false)
+
+	// another day
+	f(saturday, "2026-10-18T03:00:00Z", false)
+
+	// window crosses midnight
+	f(MaintenanceWindow{Schedule: "0 23 * * 6", Duration: "2h"}, "2026-10-18T00:30:00Z", true)
+
+	// timezone
+	berlin := MaintenanceWindow{Schedule: "0 2 * * *", Duration: "1h", Timezone: "Europe/Berlin"}
+	f(berlin, "2026-10-17T00:30:00Z", true)
+	f(berlin, "2026-10-17T02:30:00Z", false)
+
+	// day of month or day of week
+	f(MaintenanceWindow{Schedule: "0 0 1 * 1", Duration: "1h"}, "2026-10-01T00:10:00Z", true)
+	f(MaintenanceWindow{Schedule: "0 0 1 * 1", Duration: "1h"}, "2026-10-19T00:10:00Z", true)
+	f(MaintenanceWindow{Schedule: "0 0 1 * 1", Duration: "1h"}, "2026-10-20T00:10:00Z", false)
+}
+
+func TestMaintenanceWindowNextStart(t *testing.T) {
+	f := func(mw MaintenanceWindow, now, want string) {
+		t.Helper()
+		ts, err := time.Parse(time.RFC3339, now)
+		assert.NoError(t, err)
+		got, err := mw.NextStart(ts)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got.UTC().Format(time.RFC3339))
+	}
+
+	// the same day
+	f(MaintenanceWindow{Schedule: "0 2 * * 6", Duration: "4h"}, "2026-10-17T01:00:00Z", "2026-10-17T02:00:00Z")
+
+	// already started window returns the next one
+	f(MaintenanceWindow{Schedule: "0 2 * * 6", Duration: "4h"}, "2026-10-17T02:00:00Z", "2026-10-24T02:00:00Z")
+
+	// next month
+	f(MaintenanceWindow{Schedule: "30 3 1 * *", Duration: "1h"}, "2026-10-19T10:00:00Z", "2026-11-01T03:30:00Z")
+
+	// next year
+	f(MaintenanceWindow{Schedule: "0 0 1 1 *", Duration: "1h"}, "2026-10-19T10:00:00Z", "2027-01-01T00:00:00Z")
+
+	// leap day
+	f(MaintenanceWindow{Schedule: "0 0 29 2 *", Duration: "1h"}, "2026-10-19T10:00:00Z", "2028-02-29T00:00:00Z")
+
+	// timezone with half hour offset
+	f(MaintenanceWindow{Schedule: "0 2 * * *", Duration: "1h", Timezone: "Asia/Kolkata"}, "2026-10-19T10:00:00Z", "2026-10-19T20:30:00Z")
+
+	// impossible date
+	_, err := (&MaintenanceWindow{Schedule: "0 0 30 2 *", Duration: "1h"}).NextStart(time.Now())
+	assert.Error(t, err)
+}
+
+func TestActiveMaintenanceWindow(t *testing.T) {
+	now, err := time.Parse(time.RFC3339, "2026-10-19T10:00:00Z")
+	assert.NoError(t, err)
+	windows := []MaintenanceWindow{
+		{Schedule: "0 2 * * 6", Duration: "4h"},
+		{Schedule: "0 22 * * *", Duration: "1h"},
+	}
+	active, next, err := ActiveMaintenanceWindow(windows, now)
+	assert.NoError(t, err)
+	assert.False(t, active)
+	assert.Equal(t, "2026-10-19T22:00:00Z", next.UTC().Format(time.RFC3339))
+
+	windows = append(windows, MaintenanceWindow{Schedule: "0 9 * * 1", Duration: "2h"})
+	active, _, err = ActiveMaintenanceWindow(windows, now)
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	_, _, err = ActiveMaintenanceWindow([]MaintenanceWindow{{Schedule: "bad", Duration: "1h"}}, now)
+	assert.Error(t, err)
+}
@@ -77,6 +77,12 @@ type VMAgentSpec struct {
 	// RollingUpdate - overrides deployment update params.
 	// +optional
 	RollingUpdate *appsv1.RollingUpdateDeployment `json:"rollingUpdate,omitempty"`
+	// MaintenanceWindows defines time periods, when disruptive changes of child objects,
+	// like pod template or storage changes, are applied.
+	// Other changes are applied immediately. Deferred changes are published at status.pendingChanges.
+	// Operator default window is used if not set, see VM_MAINTENANCEWINDOW_SCHEDULE env variable
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
 	// PodDisruptionBudget created by operator
 	// +optional
 	PodDisruptionBudget *EmbeddedPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
@@ -126,6 +132,11 @@ func (cr *VMAgent) Validate() error {
 	if MustSkipCRValidation(cr) {
 		return nil
 	}
+	for i := range cr.Spec.MaintenanceWindows {
+		if err := cr.Spec.MaintenanceWindows[i].Validate(); err != nil {
+			return fmt.Errorf("spec.maintenanceWindows[%d]: %w", i, err)
+		}
+	}
 	if cr.Spec.ServiceSpec != nil && cr.Spec.ServiceSpec.Name == cr.PrefixedName() {
 		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", cr.PrefixedName())
 	}
@@ -393,6 +404,9 @@ type VMAgentStatus struct {
 	// +kubebuilder:validation:Schemaless
 	// +kubebuilder:pruning:PreserveUnknownFields
 	LastAppliedSpec *VMAgentSpec `json:"lastAppliedSpec,omitempty"`
+	// PendingChanges contains disruptive changes of child objects deferred until the next maintenance window
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
 }
 
 // GetStatusMetadata returns metadata for object status
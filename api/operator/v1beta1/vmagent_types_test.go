@@ -25,6 +25,18 @@ func TestVMAgent_Validate(t *testing.T) {
 	// rw empty url
 	f(VMAgentSpec{RemoteWrite: []VMAgentRemoteWriteSpec{{}}}, true)
 
+	// invalid maintenance window
+	f(VMAgentSpec{
+		RemoteWrite:        []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+		MaintenanceWindows: []MaintenanceWindow{{Schedule: "0 25 * * *", Duration: "1h"}},
+	}, true)
+
+	// valid maintenance window
+	f(VMAgentSpec{
+		RemoteWrite:        []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
+		MaintenanceWindows: []MaintenanceWindow{{Schedule: "0 2 * * 6", Duration: "4h"}},
+	}, false)
+
 	// bad inline cfg
 	f(VMAgentSpec{
 		RemoteWrite: []VMAgentRemoteWriteSpec{{URL: "http://some-rw"}},
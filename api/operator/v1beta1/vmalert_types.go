@@ -115,6 +115,11 @@ type VMAlertSpec struct {
 	RulePath []string `json:"rulePath,omitempty"`
 	// Datasource Victoria Metrics or VMSelect url. Required parameter. e.g. http://127.0.0.1:8428
 	Datasource VMAlertDatasourceSpec `json:"datasource"`
+	// Datasources defines additional named datasources, e.g. VictoriaLogs or VictoriaTraces.
+	// Rule groups select datasource with `datasource` field or with matching `type`.
+	// Operator evaluates groups of each datasource with a separate vmalert container
+	// +optional
+	Datasources []VMAlertNamedDatasource `json:"datasources,omitempty"`
 
 	// ExternalLabels in the form 'name: value' to add to all generated recording rules and alerts.
 	// +optional
@@ -187,12 +192,76 @@ func (cr *VMAlertSpec) UnmarshalJSON(src []byte) error {
 // VMAlertDatasourceSpec defines the remote storage configuration for VmAlert to read alerts from
 // +k8s:openapi-gen=true
 type VMAlertDatasourceSpec struct {
-	// Victoria Metrics or VMSelect url. Required parameter. E.g. http://127.0.0.1:8428
-	URL string `json:"url"`
+	// Victoria Metrics or VMSelect url. Required parameter, if ref is not defined. E.g. http://127.0.0.1:8428
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Ref defines VMSingle, VMCluster, VLSingle, VLCluster, VTSingle, VTCluster or VMAuth, which is used as a datasource instead of url.
+	// For VMCluster, VLCluster and VTCluster operator uses vmselect, vlselect or vtselect with ref.tenantID
+	// +optional
+	Ref *DatasourceRef `json:"ref,omitempty"`
 	// HTTPAuth generic auth methods
 	HTTPAuth `json:",inline,omitempty"`
 }
 
+// VMAlertNamedDatasource defines additional datasource of VMAlert
+// +k8s:openapi-gen=true
+type VMAlertNamedDatasource struct {
+	// Name of datasource, which could be used at VMRule group `datasource` field
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=32
+	Name string `json:"name"`
+	// Type defines type of rule groups evaluated with datasource.
+	// Defaults to vlogs for VLSingle, VLCluster, VTSingle and VTCluster refs and to prometheus otherwise
+	// +kubebuilder:validation:Enum=prometheus;graphite;vlogs
+	// +optional
+	Type string `json:"type,omitempty"`
+	// Resources container resource request and limits of vmalert container, which evaluates rules of datasource.
+	// Defaults are the same as for the main vmalert container if spec.useDefaultResources is enabled
+	// +optional
+	Resources             corev1.ResourceRequirements `json:"resources,omitempty"`
+	VMAlertDatasourceSpec `json:",inline"`
+}
+
+// RuleType returns type of rule groups evaluated with datasource
+func (ds *VMAlertNamedDatasource) RuleType() string {
+	if ds.Type != "" {
+		return ds.Type
+	}
+	return ds.VMAlertDatasourceSpec.RuleType()
+}
+
+// RuleType returns default type of rule groups evaluated with datasource
+func (ds *VMAlertDatasourceSpec) RuleType() string {
+	if ds.Ref != nil {
+		switch ds.Ref.Kind {
+		case "VLSingle", "VLCluster":
+			return "vlogs"
+		case "VTSingle", "VTCluster":
+			// VictoriaTraces serves LogsQL queries with the same API as VictoriaLogs
+			return "vlogs"
+		}
+	}
+	return "prometheus"
+}
+
+// validateRuleType checks if rule groups of the given type could be evaluated with datasource
+func (ds *VMAlertDatasourceSpec) validateRuleType(ruleType string) error {
+	if ds.Ref == nil || ruleType == "" {
+		return nil
+	}
+	switch ds.Ref.Kind {
+	case "VLSingle", "VLCluster", "VTSingle", "VTCluster":
+		if ruleType != "vlogs" {
+			return fmt.Errorf("type=%q isn't supported by ref.kind=%s, only vlogs rules could be evaluated", ruleType, ds.Ref.Kind)
+		}
+	case "VMSingle", "VMCluster":
+		if ruleType != "prometheus" {
+			return fmt.Errorf("type=%q isn't supported by ref.kind=%s, only prometheus rules could be evaluated", ruleType, ds.Ref.Kind)
+		}
+	}
+	return nil
+}
+
 // VMAlertNotifierSpec defines the notifier url for sending information about alerts
 // +k8s:openapi-gen=true
 type VMAlertNotifierSpec struct {
@@ -204,13 +273,21 @@ type VMAlertNotifierSpec struct {
 	// as statefulset pod.fqdn
 	// +optional
 	Selector *DiscoverySelector `json:"selector,omitempty"`
+	// Ref defines VMAuth, which proxies requests to alertmanager and is used instead of url
+	// +optional
+	Ref *DatasourceRef `json:"ref,omitempty"`
 
 	HTTPAuth `json:",inline,omitempty"`
 }
 
 func (ns *VMAlertNotifierSpec) validate() error {
-	if ns.URL == "" && ns.Selector == nil {
-		return fmt.Errorf("notifier.url and notifier.selector cannot be empty at the same time, provide at least one setting")
+	if ns.URL == "" && ns.Selector == nil && ns.Ref == nil {
+		return fmt.Errorf("notifier.url, notifier.selector and notifier.ref cannot be empty at the same time, provide at least one setting")
+	}
+	if ns.Ref != nil {
+		if err := validateVMAlertRef(ns.URL, ns.Ref, &ns.HTTPAuth, "VMAuth"); err != nil {
+			return fmt.Errorf("incorrect notifier.ref: %w", err)
+		}
 	}
 	if len(ns.URL) > 0 {
 		if _, err := url.Parse(ns.URL); err != nil {
@@ -225,8 +302,13 @@ func (ns *VMAlertNotifierSpec) validate() error {
 // VMAlertRemoteReadSpec defines the remote storage configuration for VmAlert to read alerts from
 // +k8s:openapi-gen=true
 type VMAlertRemoteReadSpec struct {
-	// URL of the endpoint to send samples to.
-	URL string `json:"url"`
+	// URL of the endpoint to read samples from. Required parameter, if ref is not defined.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Ref defines VMSingle, VMCluster or VMAuth, which is used instead of url.
+	// For VMCluster operator uses vmselect with ref.tenantID
+	// +optional
+	Ref *DatasourceRef `json:"ref,omitempty"`
 	// Lookback defines how far to look into past for alerts timeseries. For example, if lookback=1h then range from now() to now()-1h will be scanned. (default 1h0m0s)
 	// Applied only to RemoteReadSpec
 	// +optional
@@ -238,8 +320,13 @@ type VMAlertRemoteReadSpec struct {
 // VMAlertRemoteWriteSpec defines the remote storage configuration for VmAlert
 // +k8s:openapi-gen=true
 type VMAlertRemoteWriteSpec struct {
-	// URL of the endpoint to send samples to.
-	URL string `json:"url"`
+	// URL of the endpoint to send samples to. Required parameter, if ref is not defined.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// Ref defines VMSingle, VMCluster or VMAuth, which is used instead of url.
+	// For VMCluster operator uses vminsert with ref.tenantID
+	// +optional
+	Ref *DatasourceRef `json:"ref,omitempty"`
 	// Defines number of readers that concurrently write into remote storage (default 1)
 	// +optional
 	Concurrency *int32 `json:"concurrency,omitempty"`
@@ -361,8 +448,54 @@ func (cr *VMAlert) Validate() error {
 	if cr.Spec.ServiceSpec != nil && cr.Spec.ServiceSpec.Name == cr.PrefixedName() {
 		return fmt.Errorf("spec.serviceSpec.Name cannot be equal to prefixed name=%q", cr.PrefixedName())
 	}
-	if cr.Spec.Datasource.URL == "" {
-		return fmt.Errorf("spec.datasource.url cannot be empty")
+	if cr.Spec.Datasource.Ref == nil && cr.Spec.Datasource.URL == "" {
+		return fmt.Errorf("spec.datasource.url and spec.datasource.ref cannot be empty at the same time")
+	}
+	if cr.Spec.Datasource.Ref != nil {
+		if err := validateVMAlertRef(cr.Spec.Datasource.URL, cr.Spec.Datasource.Ref, &cr.Spec.Datasource.HTTPAuth, datasourceRefKinds...); err != nil {
+			return fmt.Errorf("incorrect spec.datasource.ref: %w", err)
+		}
+	}
+	uniqDatasources := make(map[string]struct{}, len(cr.Spec.Datasources))
+	for idx, ds := range cr.Spec.Datasources {
+		if ds.Name == "" {
+			return fmt.Errorf("spec.datasources[%d].name cannot be empty", idx)
+		}
+		if _, ok := uniqDatasources[ds.Name]; ok {
+			return fmt.Errorf("spec.datasources[%d] has duplicate name=%q", idx, ds.Name)
+		}
+		uniqDatasources[ds.Name] = struct{}{}
+		if ds.Ref == nil && ds.URL == "" {
+			return fmt.Errorf("spec.datasources[%d].url and spec.datasources[%d].ref cannot be empty at the same time", idx, idx)
+		}
+		if ds.Ref != nil {
+			if err := validateVMAlertRef(ds.URL, ds.Ref, &ds.HTTPAuth, datasourceRefKinds...); err != nil {
+				return fmt.Errorf("incorrect spec.datasources[%d].ref: %w", idx, err)
+			}
+			if err := ds.validateRuleType(ds.Type); err != nil {
+				return fmt.Errorf("incorrect spec.datasources[%d].type: %w", idx, err)
+			}
+		}
+	}
+	if rw := cr.Spec.RemoteWrite; rw != nil {
+		if rw.Ref == nil && rw.URL == "" {
+			return fmt.Errorf("spec.remoteWrite.url and spec.remoteWrite.ref cannot be empty at the same time")
+		}
+		if rw.Ref != nil {
+			if err := validateVMAlertRef(rw.URL, rw.Ref, &rw.HTTPAuth, "VMSingle", "VMCluster", "VMAuth"); err != nil {
+				return fmt.Errorf("incorrect spec.remoteWrite.ref: %w", err)
+			}
+		}
+	}
+	if rr := cr.Spec.RemoteRead; rr != nil {
+		if rr.Ref == nil && rr.URL == "" {
+			return fmt.Errorf("spec.remoteRead.url and spec.remoteRead.ref cannot be empty at the same time")
+		}
+		if rr.Ref != nil {
+			if err := validateVMAlertRef(rr.URL, rr.Ref, &rr.HTTPAuth, "VMSingle", "VMCluster", "VMAuth"); err != nil {
+				return fmt.Errorf("incorrect spec.remoteRead.ref: %w", err)
+			}
+		}
 	}
 
 	validateNotifierConfigs := func() error {
@@ -394,6 +527,21 @@ func (cr *VMAlert) Validate() error {
 	return nil
 }
 
+var datasourceRefKinds = []string{"VMSingle", "VMCluster", "VLSingle", "VLCluster", "VTSingle", "VTCluster", "VMAuth"}
+
+func validateVMAlertRef(url string, ref *DatasourceRef, auth *HTTPAuth, kinds ...string) error {
+	if url != "" {
+		return fmt.Errorf("url and ref cannot be defined at the same time")
+	}
+	if err := ref.Validate(kinds...); err != nil {
+		return err
+	}
+	if ref.User != "" && (auth.BasicAuth != nil || auth.BearerAuth != nil || auth.OAuth2 != nil) {
+		return fmt.Errorf("user cannot be used with basicAuth, bearer or oauth2")
+	}
+	return nil
+}
+
 func (cr *VMAlert) SelectorLabels() map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name":      "vmalert",
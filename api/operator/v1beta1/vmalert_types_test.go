@@ -52,6 +52,36 @@ func TestVMAlert_ValidateOk(t *testing.T) {
 			Key:                  "config.yaml",
 		},
 	})
+
+	// datasource, remote storage and notifier refs
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{
+			Ref: &DatasourceRef{Kind: "VLCluster", Name: "logs", TenantID: "1:2"},
+		},
+		RemoteWrite: &VMAlertRemoteWriteSpec{
+			Ref: &DatasourceRef{Kind: "VMCluster", Name: "main"},
+		},
+		RemoteRead: &VMAlertRemoteReadSpec{
+			Ref: &DatasourceRef{Kind: "VMAuth", Name: "main", User: "vmalert"},
+		},
+		Notifier: &VMAlertNotifierSpec{
+			Ref: &DatasourceRef{Kind: "VMAuth", Name: "alertmanager"},
+		},
+	})
+
+	// named datasources
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url"},
+		Datasources: []VMAlertNamedDatasource{
+			{Name: "logs", VMAlertDatasourceSpec: VMAlertDatasourceSpec{Ref: &DatasourceRef{Kind: "VLSingle", Name: "logs"}}},
+			{Name: "traces", VMAlertDatasourceSpec: VMAlertDatasourceSpec{Ref: &DatasourceRef{Kind: "VTCluster", Name: "traces", TenantID: "1"}}},
+			{Name: "spans", Type: "vlogs", VMAlertDatasourceSpec: VMAlertDatasourceSpec{Ref: &DatasourceRef{Kind: "VTSingle", Name: "traces"}}},
+			{Name: "graphite", Type: "graphite", VMAlertDatasourceSpec: VMAlertDatasourceSpec{URL: "http://graphite"}},
+		},
+		CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+		},
+	})
 }
 
 func TestVMAlert_ValidateFail(t *testing.T) {
@@ -108,4 +138,84 @@ func TestVMAlert_ValidateFail(t *testing.T) {
 			Key:                  "config.yaml",
 		},
 	})
+
+	// datasource url and ref
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{
+			URL: "http://some-url",
+			Ref: &DatasourceRef{Kind: "VMSingle", Name: "main"},
+		},
+		CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+		},
+	})
+
+	// remoteWrite ref to VictoriaLogs
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url"},
+		RemoteWrite: &VMAlertRemoteWriteSpec{
+			Ref: &DatasourceRef{Kind: "VLSingle", Name: "logs"},
+		},
+		CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+		},
+	})
+
+	// named datasource without url and ref
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url"},
+		Datasources: []VMAlertNamedDatasource{
+			{Name: "logs"},
+		},
+		CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+		},
+	})
+
+	// duplicate named datasources
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url"},
+		Datasources: []VMAlertNamedDatasource{
+			{Name: "logs", VMAlertDatasourceSpec: VMAlertDatasourceSpec{URL: "http://logs-1"}},
+			{Name: "logs", VMAlertDatasourceSpec: VMAlertDatasourceSpec{URL: "http://logs-2"}},
+		},
+		CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+		},
+	})
+
+	// prometheus rules for VictoriaTraces datasource
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url"},
+		Datasources: []VMAlertNamedDatasource{
+			{Name: "traces", Type: "prometheus", VMAlertDatasourceSpec: VMAlertDatasourceSpec{Ref: &DatasourceRef{Kind: "VTSingle", Name: "traces"}}},
+		},
+		CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+		},
+	})
+
+	// vlogs rules for VictoriaMetrics datasource
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{URL: "http://some-url"},
+		Datasources: []VMAlertNamedDatasource{
+			{Name: "metrics", Type: "vlogs", VMAlertDatasourceSpec: VMAlertDatasourceSpec{Ref: &DatasourceRef{Kind: "VMSingle", Name: "main"}}},
+		},
+		CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+		},
+	})
+
+	// ref user with basicAuth
+	f(VMAlertSpec{
+		Datasource: VMAlertDatasourceSpec{
+			Ref: &DatasourceRef{Kind: "VMAuth", Name: "main", User: "vmalert"},
+			HTTPAuth: HTTPAuth{
+				BasicAuth: &BasicAuth{},
+			},
+		},
+		CommonApplicationDeploymentParams: CommonApplicationDeploymentParams{
+			ExtraArgs: map[string]string{"notifier.blackhole": "true"},
+		},
+	})
 }
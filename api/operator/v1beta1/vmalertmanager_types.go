@@ -123,6 +123,12 @@ type VMAlertmanagerSpec struct {
 	ListenLocal bool `json:"listenLocal,omitempty"`
 	// AdditionalPeers allows injecting a set of additional Alertmanagers to peer with to form a highly available cluster.
 	AdditionalPeers []string `json:"additionalPeers,omitempty"`
+	// PeerRefs defines references to VMAlertmanager objects or headless services
+	// to peer with to form a highly available cluster.
+	// Operator builds cluster peer addresses for each replica of referenced VMAlertmanager
+	// and updates them on replicas count changes
+	// +optional
+	PeerRefs []VMAlertmanagerPeerRef `json:"peerRefs,omitempty"`
 	// ClusterAdvertiseAddress is the explicit address to advertise in cluster.
 	// Needs to be provided for non RFC1918 [1] (public) addresses.
 	// [1] RFC1918: https://tools.ietf.org/html/rfc1918
@@ -162,13 +168,33 @@ type VMAlertmanagerSpec struct {
 	// If both nil - behaviour controlled by selectAllByDefault
 	// +optional
 	ConfigNamespaceSelector *metav1.LabelSelector `json:"configNamespaceSelector,omitempty"`
+	// SilenceSelector defines selector for VMSilence, selected silences are synced with alertmanager API.
+	// Works in combination with NamespaceSelector.
+	// NamespaceSelector nil - only objects at VMAlertmanager namespace.
+	// Selector nil - only objects at NamespaceSelector namespaces.
+	// If both nil - behaviour controlled by selectAllByDefault
+	// +optional
+	SilenceSelector *metav1.LabelSelector `json:"silenceSelector,omitempty"`
+	// SilenceNamespaceSelector defines namespace selector for VMSilence.
+	// Works in combination with Selector.
+	// NamespaceSelector nil - only objects at VMAlertmanager namespace.
+	// Selector nil - only objects at NamespaceSelector namespaces.
+	// If both nil - behaviour controlled by selectAllByDefault
+	// +optional
+	SilenceNamespaceSelector *metav1.LabelSelector `json:"silenceNamespaceSelector,omitempty"`
+	// SilencesAPIClient defines client settings used by operator for VMSilence sync with alertmanager API.
+	// It must be defined if alertmanager webserver certificate isn't trusted by system CAs or alertmanager API requires basic auth or client certificate
+	// +optional
+	SilencesAPIClient *VMAlertmanagerAPIClient `json:"silencesAPIClient,omitempty"`
 
 	// DisableNamespaceMatcher disables adding top route label matcher "namespace = <VMAlertmanagerConfig.namespace>" for VMAlertmanagerConfig
+	// and silence matcher "namespace = <VMSilence.namespace>" for VMSilence
 	// It may be useful if alert doesn't have namespace label for some reason
 	// +optional
 	DisableNamespaceMatcher bool `json:"disableNamespaceMatcher,omitempty"`
 
 	// EnforcedNamespaceLabel defines the namespace label key for top route matcher for VMAlertmanagerConfig
+	// and for silence matcher for VMSilence
 	// Default is "namespace"
 	// +optional
 	EnforcedNamespaceLabel string `json:"enforcedNamespaceLabel,omitempty"`
@@ -263,6 +289,55 @@ type VMAlertmanagerList struct {
 	Items []VMAlertmanager `json:"items"`
 }
 
+// VMAlertmanagerAPIClient defines client settings for alertmanager API requests made by operator.
+// Secrets and configmaps are loaded from VMAlertmanager namespace,
+// file paths are not supported, since operator cannot read files from alertmanager pods
+type VMAlertmanagerAPIClient struct {
+	// TLSConfig defines CA bundle, client certificate, server name and insecureSkipVerify for alertmanager API
+	// +optional
+	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+	// BasicAuth defines credentials for alertmanager API protected with webConfig.basic_auth_users
+	// +optional
+	BasicAuth *BasicAuth `json:"basicAuth,omitempty"`
+	// Authorization defines Authorization header for alertmanager API
+	// +optional
+	Authorization *Authorization `json:"authorization,omitempty"`
+}
+
+func (c *VMAlertmanagerAPIClient) validate() error {
+	if tc := c.TLSConfig; tc != nil {
+		if err := tc.Validate(); err != nil {
+			return fmt.Errorf("invalid tlsConfig: %w", err)
+		}
+		if tc.CAFile != "" || tc.CertFile != "" || tc.KeyFile != "" {
+			return fmt.Errorf("tlsConfig file paths are not supported, use ca, cert and keySecret instead")
+		}
+		if (tc.Cert.PrefixedName() != "") != (tc.KeySecret != nil) {
+			return fmt.Errorf("tlsConfig cert and keySecret must be set together")
+		}
+	}
+	if c.BasicAuth != nil {
+		if c.BasicAuth.PasswordFile != "" {
+			return fmt.Errorf("basicAuth password_file is not supported, use password instead")
+		}
+		if c.BasicAuth.Username.Name == "" {
+			return fmt.Errorf("basicAuth username must be set")
+		}
+	}
+	if c.Authorization != nil {
+		if err := c.Authorization.validate(); err != nil {
+			return fmt.Errorf("invalid authorization: %w", err)
+		}
+		if c.Authorization.CredentialsFile != "" {
+			return fmt.Errorf("authorization credentialsFile is not supported, use credentials instead")
+		}
+		if c.BasicAuth != nil {
+			return fmt.Errorf("basicAuth and authorization are mutually exclusive")
+		}
+	}
+	return nil
+}
+
 // VMAlertmanagerStatus is the most recent observed status of the VMAlertmanager cluster
 // Operator API itself. More info:
 type VMAlertmanagerStatus struct {
@@ -511,6 +586,11 @@ func (cr *VMAlertmanager) Validate() error {
 	if cr.Spec.ConfigSecret == cr.ConfigSecretName() {
 		return fmt.Errorf("spec.configSecret uses the same name as built-in config secret used by operator. Please change it's name")
 	}
+	for idx, ref := range cr.Spec.PeerRefs {
+		if err := ref.validate(cr); err != nil {
+			return fmt.Errorf("incorrect spec.peerRefs at idx=%d: %w", idx, err)
+		}
+	}
 	if cr.Spec.WebConfig != nil {
 		if cr.Spec.WebConfig.HTTPServerConfig != nil {
 			if cr.Spec.WebConfig.HTTPServerConfig.HTTP2 && cr.Spec.WebConfig.TLSServerConfig == nil {
@@ -532,6 +612,11 @@ func (cr *VMAlertmanager) Validate() error {
 			}
 		}
 	}
+	if cr.Spec.SilencesAPIClient != nil {
+		if err := cr.Spec.SilencesAPIClient.validate(); err != nil {
+			return fmt.Errorf("incorrect spec.silencesAPIClient: %w", err)
+		}
+	}
 
 	if cr.Spec.GossipConfig != nil {
 		if cr.Spec.GossipConfig.TLSServerConfig != nil {
@@ -614,6 +699,40 @@ type VMAlertmanagerGossipConfig struct {
 	TLSClientConfig *TLSClientConfig `json:"tls_client_config,omitempty"`
 }
 
+// VMAlertmanagerPeerRef defines reference to alertmanager cluster peers.
+// Either name or serviceName must be set
+type VMAlertmanagerPeerRef struct {
+	// Name of VMAlertmanager object to peer with
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Namespace of referenced object, defaults to VMAlertmanager namespace
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// ServiceName of headless service, which resolves to addresses of external alertmanager peers
+	// +optional
+	ServiceName string `json:"serviceName,omitempty"`
+	// Port defines gossip port of serviceName peers, defaults to 9094
+	// +optional
+	Port *int32 `json:"port,omitempty"`
+}
+
+func (ref *VMAlertmanagerPeerRef) validate(cr *VMAlertmanager) error {
+	switch {
+	case ref.Name == "" && ref.ServiceName == "":
+		return fmt.Errorf("either name or serviceName must be set")
+	case ref.Name != "" && ref.ServiceName != "":
+		return fmt.Errorf("name and serviceName cannot be set at the same time")
+	case ref.Name != "" && ref.Port != nil:
+		return fmt.Errorf("port can be set only for serviceName")
+	case ref.Name == cr.Name && (ref.Namespace == "" || ref.Namespace == cr.Namespace):
+		return fmt.Errorf("VMAlertmanager cannot reference itself")
+	}
+	if ref.Port != nil && (*ref.Port <= 0 || *ref.Port > 65535) {
+		return fmt.Errorf("port=%d is out of range", *ref.Port)
+	}
+	return nil
+}
+
 // VMAlertmanagerWebConfig defines web server configuration for alertmanager
 type VMAlertmanagerWebConfig struct {
 	// TLSServerConfig defines server TLS configuration for alertmanager
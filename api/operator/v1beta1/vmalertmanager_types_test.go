@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestVMAlertmanagerValidate(t *testing.T) {
@@ -61,4 +63,121 @@ receivers:
 			},
 		},
 	})
+
+	// peer reference without name and serviceName
+	f(opts{
+		cr: &VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-suite",
+				Namespace: "test",
+			},
+			Spec: VMAlertmanagerSpec{
+				PeerRefs: []VMAlertmanagerPeerRef{{Namespace: "other"}},
+			},
+		},
+		wantErr: true,
+	})
+
+	// peer reference to itself
+	f(opts{
+		cr: &VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-suite",
+				Namespace: "test",
+			},
+			Spec: VMAlertmanagerSpec{
+				PeerRefs: []VMAlertmanagerPeerRef{{Name: "test-suite"}},
+			},
+		},
+		wantErr: true,
+	})
+
+	// port set for VMAlertmanager peer
+	f(opts{
+		cr: &VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-suite",
+				Namespace: "test",
+			},
+			Spec: VMAlertmanagerSpec{
+				PeerRefs: []VMAlertmanagerPeerRef{{Name: "eu", Port: ptr.To(int32(9094))}},
+			},
+		},
+		wantErr: true,
+	})
+
+	// correct peer references
+	f(opts{
+		cr: &VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-suite",
+				Namespace: "test",
+			},
+			Spec: VMAlertmanagerSpec{
+				PeerRefs: []VMAlertmanagerPeerRef{
+					{Name: "test-suite", Namespace: "region-eu"},
+					{ServiceName: "us-gossip", Namespace: "region-us", Port: ptr.To(int32(19094))},
+				},
+			},
+		},
+	})
+
+	// silences API client with file paths
+	f(opts{
+		cr: &VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-suite",
+				Namespace: "test",
+			},
+			Spec: VMAlertmanagerSpec{
+				SilencesAPIClient: &VMAlertmanagerAPIClient{
+					TLSConfig: &TLSConfig{CAFile: "/etc/tls/ca"},
+				},
+			},
+		},
+		wantErr: true,
+	})
+
+	// silences API client with both basic auth and authorization
+	f(opts{
+		cr: &VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-suite",
+				Namespace: "test",
+			},
+			Spec: VMAlertmanagerSpec{
+				SilencesAPIClient: &VMAlertmanagerAPIClient{
+					BasicAuth: &BasicAuth{
+						Username: corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "auth"}, Key: "username"},
+					},
+					Authorization: &Authorization{
+						Credentials: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "auth"}, Key: "token"},
+					},
+				},
+			},
+		},
+		wantErr: true,
+	})
+
+	// correct silences API client
+	f(opts{
+		cr: &VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-suite",
+				Namespace: "test",
+			},
+			Spec: VMAlertmanagerSpec{
+				SilencesAPIClient: &VMAlertmanagerAPIClient{
+					TLSConfig: &TLSConfig{
+						CA:        SecretOrConfigMap{ConfigMap: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "ca"}, Key: "ca.crt"}},
+						Cert:      SecretOrConfigMap{Secret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}, Key: "tls.crt"}},
+						KeySecret: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "tls"}, Key: "tls.key"},
+					},
+					Authorization: &Authorization{
+						Credentials: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "auth"}, Key: "token"},
+					},
+				},
+			},
+		},
+	})
 }
@@ -32,6 +32,7 @@ import (
 	amcfg "github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/alertmanager/matcher/compat"
 	amparse "github.com/prometheus/alertmanager/matcher/parse"
+	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -47,6 +48,10 @@ type VMAlertmanagerConfigSpec struct {
 	// Receivers defines alert receivers
 	// +optional
 	Receivers []Receiver `json:"receivers"`
+	// ReceiverRefs defines references to shared VMAlertmanagerReceiver and VMAlertmanagerClusterReceiver objects.
+	// Routes use referenced receivers by ref name in the same way as receivers from spec.receivers
+	// +optional
+	ReceiverRefs []VMAlertmanagerReceiverRef `json:"receiverRefs,omitempty" yaml:"receiverRefs,omitempty"`
 	// InhibitRules will only apply for alerts matching
 	// the resource's namespace.
 	// +optional
@@ -55,10 +60,120 @@ type VMAlertmanagerConfigSpec struct {
 	// See https://prometheus.io/docs/alerting/latest/configuration/#time_interval
 	// +optional
 	TimeIntervals []TimeIntervals `json:"time_intervals,omitempty" yaml:"time_intervals,omitempty"`
+	// Tests defines sample alerts, which are evaluated by operator
+	// against the merged configuration of VMAlertmanager.
+	// Results are reported at status.tests
+	// +optional
+	Tests []VMAlertmanagerConfigTest `json:"tests,omitempty" yaml:"tests,omitempty"`
 	// ParsingError contents error with context if operator was failed to parse json object from kubernetes api server
 	ParsingError string `json:"-" yaml:"-"`
 }
 
+// VMAlertmanagerReceiverRef defines reference to shared receiver
+type VMAlertmanagerReceiverRef struct {
+	// Name of the referenced object, routes use it as receiver name.
+	// Must be unique across spec.receivers and spec.receiverRefs
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Namespace of VMAlertmanagerReceiver, defaults to VMAlertmanagerConfig namespace.
+	// Must be empty for VMAlertmanagerClusterReceiver
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// Kind of the referenced object
+	// +kubebuilder:validation:Enum=VMAlertmanagerReceiver;VMAlertmanagerClusterReceiver
+	// +kubebuilder:default=VMAlertmanagerReceiver
+	// +optional
+	Kind string `json:"kind,omitempty"`
+}
+
+func (ref *VMAlertmanagerReceiverRef) validate() error {
+	if ref.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	switch ref.Kind {
+	case "", VMAlertmanagerReceiverKind:
+	case VMAlertmanagerClusterReceiverKind:
+		if ref.Namespace != "" {
+			return fmt.Errorf("namespace cannot be set for kind=%s", ref.Kind)
+		}
+	default:
+		return fmt.Errorf("unsupported kind=%q, want one of %s,%s", ref.Kind, VMAlertmanagerReceiverKind, VMAlertmanagerClusterReceiverKind)
+	}
+	return nil
+}
+
+// VMAlertmanagerConfigTest defines sample alert with routing and inhibition expectations
+type VMAlertmanagerConfigTest struct {
+	// Name of the test, must be unique per VMAlertmanagerConfig
+	Name string `json:"name"`
+	// Labels defines label set of the sample alert.
+	// Namespace label with VMAlertmanagerConfig namespace is added,
+	// unless it's already defined or namespace matcher is disabled at VMAlertmanager
+	Labels map[string]string `json:"labels"`
+	// Receivers defines names of receivers, which are expected to get alert, in routing order.
+	// Receivers of the given VMAlertmanagerConfig are referenced by names from spec.receivers,
+	// other receivers by names from the merged configuration
+	// +optional
+	Receivers []string `json:"receivers,omitempty"`
+	// Inhibitions defines expectations for sample alert inhibition
+	// +optional
+	Inhibitions []VMAlertmanagerConfigInhibitionTest `json:"inhibitions,omitempty"`
+}
+
+// VMAlertmanagerConfigInhibitionTest defines inhibition expectation for firing source alert
+type VMAlertmanagerConfigInhibitionTest struct {
+	// SourceLabels defines label set of the firing source alert.
+	// Namespace label is added in the same way as for test labels
+	SourceLabels map[string]string `json:"sourceLabels"`
+	// Inhibited defines if sample alert is expected to be inhibited by the source alert
+	// +optional
+	Inhibited bool `json:"inhibited,omitempty"`
+}
+
+// VMAlertmanagerConfigTestResult defines result of VMAlertmanagerConfigTest evaluation
+type VMAlertmanagerConfigTestResult struct {
+	// Name of the test
+	Name string `json:"name"`
+	// Passed is set if all test expectations are met
+	Passed bool `json:"passed"`
+	// Receivers defines receivers matched by routing tree
+	// +optional
+	Receivers []string `json:"receivers,omitempty"`
+	// Message describes failed expectations
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+func (ct *VMAlertmanagerConfigTest) validate() error {
+	if ct.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if len(ct.Labels) == 0 {
+		return fmt.Errorf("labels cannot be empty")
+	}
+	if err := validateTestLabels(ct.Labels); err != nil {
+		return fmt.Errorf("incorrect labels: %w", err)
+	}
+	for i, it := range ct.Inhibitions {
+		if len(it.SourceLabels) == 0 {
+			return fmt.Errorf("inhibitions[%d].sourceLabels cannot be empty", i)
+		}
+		if err := validateTestLabels(it.SourceLabels); err != nil {
+			return fmt.Errorf("incorrect inhibitions[%d].sourceLabels: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func validateTestLabels(lset map[string]string) error {
+	for k := range lset {
+		if !compat.IsValidLabelName(model.LabelName(k)) {
+			return fmt.Errorf("invalid label name %q", k)
+		}
+	}
+	return nil
+}
+
 // TimeIntervals for alerts
 type TimeIntervals struct {
 	// Name of interval
@@ -123,10 +238,30 @@ func (r *VMAlertmanagerConfig) Validate() error {
 			return fmt.Errorf("receiver at idx=%d is invalid: %w", idx, err)
 		}
 	}
+	for idx, ref := range r.Spec.ReceiverRefs {
+		if err := ref.validate(); err != nil {
+			return fmt.Errorf("receiverRefs at idx=%d is invalid: %w", idx, err)
+		}
+		if _, ok := receivers[ref.Name]; ok {
+			return fmt.Errorf("receiverRefs name %q is not unique", ref.Name)
+		}
+		receivers[ref.Name] = struct{}{}
+	}
 	tiNames, err := validateTimeIntervals(r.Spec.TimeIntervals)
 	if err != nil {
 		return err
 	}
+	testNames := make(map[string]struct{}, len(r.Spec.Tests))
+	for i := range r.Spec.Tests {
+		ct := &r.Spec.Tests[i]
+		if err := ct.validate(); err != nil {
+			return fmt.Errorf("incorrect spec.tests[%d]: %w", i, err)
+		}
+		if _, ok := testNames[ct.Name]; ok {
+			return fmt.Errorf("duplicate spec.tests name=%q", ct.Name)
+		}
+		testNames[ct.Name] = struct{}{}
+	}
 	if r.Spec.Route == nil {
 		return nil
 	}
@@ -164,6 +299,9 @@ type VMAlertmanagerConfigStatus struct {
 	// reconcile
 	StatusMetadata                  `json:",inline"`
 	LastErrorParentAlertmanagerName string `json:"lastErrorParentAlertmanagerName,omitempty"`
+	// Tests contains results of spec.tests evaluation
+	// +optional
+	Tests []VMAlertmanagerConfigTestResult `json:"tests,omitempty"`
 }
 
 // VMAlertmanagerConfig is the Schema for the vmalertmanagerconfigs API
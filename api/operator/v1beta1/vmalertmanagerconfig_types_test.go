@@ -396,6 +396,82 @@ func TestValidateVMAlertmanagerConfigFail(t *testing.T) {
         }
     }
 }`, `unknown field "insecure_skip_verify"`)
+
+	// test without labels
+	f(`{
+    "apiVersion": "v1",
+    "kind": "VMAlertmanagerConfig",
+    "metadata": {
+        "name": "test-fail"
+    },
+    "spec": {
+        "receivers": [{"name": "blackhole"}],
+        "route": {"receiver": "blackhole"},
+        "tests": [{"name": "empty"}]
+    }
+}`, `incorrect spec.tests[0]: labels cannot be empty`)
+
+	// test with invalid source label name
+	f(`{
+    "apiVersion": "v1",
+    "kind": "VMAlertmanagerConfig",
+    "metadata": {
+        "name": "test-fail"
+    },
+    "spec": {
+        "receivers": [{"name": "blackhole"}],
+        "route": {"receiver": "blackhole"},
+        "tests": [{
+            "name": "inhibit",
+            "labels": {"alertname": "DiskFull"},
+            "inhibitions": [{"sourceLabels": {"": "critical"}}]
+        }]
+    }
+}`, `incorrect inhibitions[0].sourceLabels: invalid label name ""`)
+
+	// duplicate test names
+	f(`{
+    "apiVersion": "v1",
+    "kind": "VMAlertmanagerConfig",
+    "metadata": {
+        "name": "test-fail"
+    },
+    "spec": {
+        "receivers": [{"name": "blackhole"}],
+        "route": {"receiver": "blackhole"},
+        "tests": [
+            {"name": "dup", "labels": {"alertname": "DiskFull"}},
+            {"name": "dup", "labels": {"alertname": "NodeDown"}}
+        ]
+    }
+}`, `duplicate spec.tests name="dup"`)
+
+	// receiverRefs name conflicts with receiver
+	f(`{
+    "apiVersion": "v1",
+    "kind": "VMAlertmanagerConfig",
+    "metadata": {
+        "name": "test-fail"
+    },
+    "spec": {
+        "receivers": [{"name": "oncall"}],
+        "receiverRefs": [{"name": "oncall"}],
+        "route": {"receiver": "oncall"}
+    }
+}`, `receiverRefs name "oncall" is not unique`)
+
+	// namespace set for cluster receiver
+	f(`{
+    "apiVersion": "v1",
+    "kind": "VMAlertmanagerConfig",
+    "metadata": {
+        "name": "test-fail"
+    },
+    "spec": {
+        "receiverRefs": [{"name": "platform", "namespace": "default", "kind": "VMAlertmanagerClusterReceiver"}],
+        "route": {"receiver": "platform"}
+    }
+}`, `receiverRefs at idx=0 is invalid: namespace cannot be set for kind=VMAlertmanagerClusterReceiver`)
 }
 
 func TestValidateVMAlertmanagerConfigOk(t *testing.T) {
@@ -987,4 +1063,43 @@ func TestValidateVMAlertmanagerConfigOk(t *testing.T) {
         }
     }
 }`)
+
+	// with tests
+	f(`{
+    "apiVersion": "v1",
+    "kind": "VMAlertmanagerConfig",
+    "metadata": {
+        "name": "tests"
+    },
+    "spec": {
+        "receivers": [{"name": "blackhole"}],
+        "route": {"receiver": "blackhole"},
+        "tests": [{
+            "name": "disk full",
+            "labels": {"alertname": "DiskFull", "severity": "warning"},
+            "receivers": ["blackhole"],
+            "inhibitions": [{"sourceLabels": {"alertname": "DiskFull", "severity": "critical"}, "inhibited": true}]
+        }]
+    }
+}`)
+
+	// with receiverRefs
+	f(`{
+    "apiVersion": "v1",
+    "kind": "VMAlertmanagerConfig",
+    "metadata": {
+        "name": "refs"
+    },
+    "spec": {
+        "receivers": [{"name": "blackhole"}],
+        "receiverRefs": [
+            {"name": "pager", "namespace": "shared"},
+            {"name": "platform", "kind": "VMAlertmanagerClusterReceiver"}
+        ],
+        "route": {
+            "receiver": "blackhole",
+            "routes": [{"receiver": "pager"}, {"receiver": "platform"}]
+        }
+    }
+}`)
 }
@@ -0,0 +1,122 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VMAlertmanagerReceiverKind defines kind of namespaced shared receiver
+	VMAlertmanagerReceiverKind = "VMAlertmanagerReceiver"
+	// VMAlertmanagerClusterReceiverKind defines kind of cluster-scoped shared receiver
+	VMAlertmanagerClusterReceiverKind = "VMAlertmanagerClusterReceiver"
+)
+
+// VMAlertmanagerReceiverSpec defines shared receiver configuration
+// and namespaces of VMAlertmanagerConfig objects, which are allowed to reference it
+type VMAlertmanagerReceiverSpec struct {
+	// Receiver defines notification integrations.
+	// It's rendered once into alertmanager configuration
+	// and could be referenced by VMAlertmanagerConfig with spec.receiverRefs
+	Receiver Receiver `json:"receiver"`
+	// AllowedNamespaces defines namespaces of VMAlertmanagerConfig objects,
+	// which are allowed to reference receiver. "*" allows any namespace.
+	// VMAlertmanagerConfig objects from the VMAlertmanagerReceiver namespace are always allowed
+	// +optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+	// AllowedNamespaceSelector defines selector for namespaces of VMAlertmanagerConfig objects,
+	// which are allowed to reference receiver.
+	// It's ignored if operator has no cluster-wide access
+	// +optional
+	AllowedNamespaceSelector *metav1.LabelSelector `json:"allowedNamespaceSelector,omitempty"`
+}
+
+// Validate performs syntax validation of shared receiver spec
+func (spec *VMAlertmanagerReceiverSpec) Validate() error {
+	if err := validateReceiver(spec.Receiver); err != nil {
+		return fmt.Errorf("receiver is invalid: %w", err)
+	}
+	for i, ns := range spec.AllowedNamespaces {
+		if ns == "" {
+			return fmt.Errorf("allowedNamespaces[%d] cannot be empty", i)
+		}
+	}
+	if spec.AllowedNamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.AllowedNamespaceSelector); err != nil {
+			return fmt.Errorf("incorrect allowedNamespaceSelector: %w", err)
+		}
+	}
+	return nil
+}
+
+// VMAlertmanagerReceiver is the Schema for the vmalertmanagerreceivers API.
+// It defines receiver, which could be shared by VMAlertmanagerConfig objects from allowed namespaces
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=vmalertmanagerreceivers,scope=Namespaced
+type VMAlertmanagerReceiver struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMAlertmanagerReceiverSpec `json:"spec"`
+}
+
+// Validate performs syntax validation
+func (r *VMAlertmanagerReceiver) Validate() error {
+	if MustSkipCRValidation(r) {
+		return nil
+	}
+	return r.Spec.Validate()
+}
+
+// +kubebuilder:object:root=true
+
+// VMAlertmanagerReceiverList contains a list of VMAlertmanagerReceiver
+type VMAlertmanagerReceiverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMAlertmanagerReceiver `json:"items"`
+}
+
+// VMAlertmanagerClusterReceiver is the Schema for the vmalertmanagerclusterreceivers API.
+// It defines cluster-wide receiver, which could be shared by VMAlertmanagerConfig objects from allowed namespaces.
+// Secrets of receiver are loaded from the namespace of VMAlertmanager
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=vmalertmanagerclusterreceivers,scope=Cluster
+type VMAlertmanagerClusterReceiver struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VMAlertmanagerReceiverSpec `json:"spec"`
+}
+
+// Validate performs syntax validation
+func (r *VMAlertmanagerClusterReceiver) Validate() error {
+	if MustSkipCRValidation(r) {
+		return nil
+	}
+	return r.Spec.Validate()
+}
+
+// +kubebuilder:object:root=true
+
+// VMAlertmanagerClusterReceiverList contains a list of VMAlertmanagerClusterReceiver
+type VMAlertmanagerClusterReceiverList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMAlertmanagerClusterReceiver `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&VMAlertmanagerReceiver{}, &VMAlertmanagerReceiverList{},
+		&VMAlertmanagerClusterReceiver{}, &VMAlertmanagerClusterReceiverList{},
+	)
+}
@@ -0,0 +1,63 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestVMAlertmanagerReceiver_Validate(t *testing.T) {
+	f := func(spec VMAlertmanagerReceiverSpec, wantErr bool) {
+		t.Helper()
+		cr := &VMAlertmanagerReceiver{Spec: spec}
+		ccr := &VMAlertmanagerClusterReceiver{Spec: spec}
+		if wantErr {
+			assert.Error(t, cr.Validate())
+			assert.Error(t, ccr.Validate())
+		} else {
+			assert.NoError(t, cr.Validate())
+			assert.NoError(t, ccr.Validate())
+		}
+	}
+	receiver := Receiver{
+		Name: "oncall",
+		WebhookConfigs: []WebhookConfig{{
+			URL: ptr.To("http://oncall:8080"),
+		}},
+	}
+
+	// missing receiver name
+	f(VMAlertmanagerReceiverSpec{
+		Receiver: Receiver{
+			WebhookConfigs: receiver.WebhookConfigs,
+		},
+	}, true)
+
+	// empty allowed namespace
+	f(VMAlertmanagerReceiverSpec{
+		Receiver:          receiver,
+		AllowedNamespaces: []string{"team-a", ""},
+	}, true)
+
+	// invalid namespace selector
+	f(VMAlertmanagerReceiverSpec{
+		Receiver: receiver,
+		AllowedNamespaceSelector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{{
+				Key:      "team",
+				Operator: "Unknown",
+			}},
+		},
+	}, true)
+
+	// ok
+	f(VMAlertmanagerReceiverSpec{
+		Receiver:          receiver,
+		AllowedNamespaces: []string{"*"},
+		AllowedNamespaceSelector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"alerting": "shared"},
+		},
+	}, false)
+}
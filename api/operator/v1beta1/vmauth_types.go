@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -343,6 +344,13 @@ type VMUserConfigOptions struct {
 	// +optional
 	MaxConcurrentRequests *int `json:"max_concurrent_requests,omitempty" yaml:"max_concurrent_requests,omitempty"`
 
+	// RateLimit defines request rate limits for the user.
+	// It's rendered into vmauth config starting from v1.137.0 vmauth version,
+	// older versions only support concurrency limit and operator
+	// uses burst as max_concurrent_requests for the user.
+	// +optional
+	RateLimit *VMUserRateLimit `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+
 	// LoadBalancingPolicy defines load balancing policy to use for backend urls.
 	// Supported policies: least_loaded, first_available.
 	// See [here](https://docs.victoriametrics.com/victoriametrics/vmauth/#load-balancing) for more details (default "least_loaded")
@@ -364,6 +372,72 @@ type VMUserConfigOptions struct {
 	DumpRequestOnErrors *bool `json:"dump_request_on_errors,omitempty"`
 }
 
+// VMUserRateLimit defines request rate limits applied by vmauth to the user
+type VMUserRateLimit struct {
+	// RequestsPerSecond defines max average number of requests per second
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerSecond *int `json:"requests_per_second,omitempty" yaml:"requests_per_second,omitempty"`
+	// Burst defines max number of requests, which could be served at once above requests_per_second
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int `json:"burst,omitempty" yaml:"burst,omitempty"`
+	// Paths defines rate limits for requests matching given src_paths.
+	// Limits are applied in addition to the user limits.
+	// Per-path limits are supported only by vmauth config and cannot be applied to older vmauth versions.
+	// +optional
+	Paths []VMUserPathRateLimit `json:"paths,omitempty" yaml:"paths,omitempty"`
+}
+
+// VMUserPathRateLimit defines request rate limits for requests matching given paths
+type VMUserPathRateLimit struct {
+	// SrcPaths is a list of regular expressions, which must match the request path.
+	SrcPaths []string `json:"src_paths" yaml:"src_paths"`
+	// RequestsPerSecond defines max average number of requests per second
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestsPerSecond *int `json:"requests_per_second,omitempty" yaml:"requests_per_second,omitempty"`
+	// Burst defines max number of requests, which could be served at once above requests_per_second
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Burst *int `json:"burst,omitempty" yaml:"burst,omitempty"`
+}
+
+func validateRateLimitValues(rps, burst *int) error {
+	if rps == nil && burst == nil {
+		return fmt.Errorf("at least one of requests_per_second or burst must be set")
+	}
+	if rps != nil && *rps <= 0 {
+		return fmt.Errorf("requests_per_second=%d must be positive", *rps)
+	}
+	if burst != nil && *burst <= 0 {
+		return fmt.Errorf("burst=%d must be positive", *burst)
+	}
+	return nil
+}
+
+func (rl *VMUserRateLimit) validate() error {
+	if rl.RequestsPerSecond != nil || rl.Burst != nil || len(rl.Paths) == 0 {
+		if err := validateRateLimitValues(rl.RequestsPerSecond, rl.Burst); err != nil {
+			return err
+		}
+	}
+	for idx, pl := range rl.Paths {
+		if len(pl.SrcPaths) == 0 {
+			return fmt.Errorf("src_paths cannot be empty at paths idx=%d", idx)
+		}
+		for _, srcPath := range pl.SrcPaths {
+			if _, err := regexp.Compile(srcPath); err != nil {
+				return fmt.Errorf("incorrect src_paths=%q regexp at paths idx=%d: %w", srcPath, idx, err)
+			}
+		}
+		if err := validateRateLimitValues(pl.RequestsPerSecond, pl.Burst); err != nil {
+			return fmt.Errorf("incorrect limits at paths idx=%d: %w", idx, err)
+		}
+	}
+	return nil
+}
+
 // Validate performs semantic syntax validation
 func (o *VMUserConfigOptions) validate() error {
 	for _, durl := range o.DefaultURLs {
@@ -382,6 +456,11 @@ func (o *VMUserConfigOptions) validate() error {
 	if err := validateHTTPHeaders(o.ResponseHeaders); err != nil {
 		return fmt.Errorf("incorrect 'response_headers' syntax: %w", err)
 	}
+	if o.RateLimit != nil {
+		if err := o.RateLimit.validate(); err != nil {
+			return fmt.Errorf("incorrect 'rate_limit' syntax: %w", err)
+		}
+	}
 	return nil
 }
 
@@ -659,11 +738,42 @@ func (cr *VMAuth) ConfigSecretName() string {
 	return fmt.Sprintf("vmauth-config-%s", cr.Name)
 }
 
+// vmauth up to v1.136.0 has no rate_limit section at users config
+// and fails to load config with it, since config is parsed in strict mode
+var vmauthRateLimitMinVersion = version.MustParseGeneric("v1.137.0")
+
+// SupportsUserRateLimits checks if vmauth version supports rate_limit section at users config
+// Unparsable versions, like latest, are treated as supported
+func (cr *VMAuth) SupportsUserRateLimits() bool {
+	v, err := version.ParseGeneric(cr.Spec.Image.Tag)
+	if err != nil {
+		return true
+	}
+	return v.AtLeast(vmauthRateLimitMinVersion)
+}
+
 // GetMetricsPath returns prefixed path for metric requests
 func (cr *VMAuth) GetMetricsPath() string {
 	return BuildPathWithPrefixFlag(cr.Spec.ExtraArgs, metricsPath)
 }
 
+// AsURL returns url for accessing vmauth
+func (cr *VMAuth) AsURL() string {
+	port := cr.Spec.Port
+	if port == "" {
+		port = "8427"
+	}
+	if cr.Spec.ServiceSpec != nil && cr.Spec.ServiceSpec.UseAsDefault {
+		for _, svcPort := range cr.Spec.ServiceSpec.Spec.Ports {
+			if svcPort.Name == "http" {
+				port = fmt.Sprintf("%d", svcPort.Port)
+				break
+			}
+		}
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%s", HTTPProtoFromFlags(cr.Spec.ExtraArgs), cr.PrefixedName(), cr.Namespace, port)
+}
+
 // UseTLS returns true if TLS is enabled
 func (cr *VMAuth) UseTLS() bool {
 	return UseTLS(cr.Spec.ExtraArgs)
@@ -67,6 +67,19 @@ type VMClusterSpec struct {
 	// going to be performed, except for delete actions.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
+	// ReconcileMode defines how operator handles changes of child objects.
+	// With plan mode operator computes changes of child objects without applying them
+	// and publishes them at status.plan and as kubernetes event.
+	// Changes are applied after switching it back to apply.
+	// Plan is advisory only: apply mode reconciles the current spec and doesn't check the published plan.
+	// +optional
+	ReconcileMode ReconcileMode `json:"reconcileMode,omitempty"`
+	// MaintenanceWindows defines time periods, when disruptive changes of child objects,
+	// like pod template or storage changes, are applied.
+	// Other changes are applied immediately. Deferred changes are published at status.pendingChanges.
+	// Operator default window is used if not set, see VM_MAINTENANCEWINDOW_SCHEDULE env variable
+	// +optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
 	// UseStrictSecurity enables strict security mode for component
 	// it restricts disk writes access
 	// uses non-root user out of the box
@@ -237,6 +250,12 @@ type VMClusterStatus struct {
 	// +kubebuilder:validation:Schemaless
 	// +kubebuilder:pruning:PreserveUnknownFields
 	LastAppliedSpec *VMClusterSpec `json:"lastAppliedSpec,omitempty"`
+	// Plan contains changes of child objects computed with plan reconcile mode
+	// +optional
+	Plan *ReconcilePlan `json:"plan,omitempty"`
+	// PendingChanges contains disruptive changes of child objects deferred until the next maintenance window
+	// +optional
+	PendingChanges *PendingChanges `json:"pendingChanges,omitempty"`
 }
 
 // GetStatusMetadata returns metadata for object status
@@ -622,6 +641,11 @@ func (cr *VMCluster) Validate() error {
 	if MustSkipCRValidation(cr) {
 		return nil
 	}
+	for i := range cr.Spec.MaintenanceWindows {
+		if err := cr.Spec.MaintenanceWindows[i].Validate(); err != nil {
+			return fmt.Errorf("spec.maintenanceWindows[%d]: %w", i, err)
+		}
+	}
 	if cr.Spec.VMSelect != nil {
 		vms := cr.Spec.VMSelect
 		name := cr.PrefixedName(ClusterComponentSelect)
@@ -8,6 +8,7 @@ import (
 	"path"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -70,6 +71,14 @@ const (
 	VMAuthLBServiceProxyTargetLabel  = "operator.victoriametrics.com/vmauthlb-proxy-name"
 	VMAuthLBServiceProxyJobNameLabel = "operator.victoriametrics.com/vmauthlb-proxy-job-name"
 	KubeNodeEnvName                  = "KUBE_NODE_NAME"
+	// DriftPolicyAnnotation defines how operator handles manual changes of child objects
+	DriftPolicyAnnotation = "operator.victoriametrics.com/drift-policy"
+	// DriftPolicyRevert reports manual changes of child objects and reverts them
+	DriftPolicyRevert = "revert"
+	// DriftPolicyReport only reports manual changes of child objects
+	DriftPolicyReport = "report"
+	// ShardLabel assigns namespace to the operator shard with the given index
+	ShardLabel = "operator.victoriametrics.com/shard"
 )
 
 const (
@@ -1314,6 +1323,8 @@ type StatusMetadata struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 	// CurrentSyncError holds an error occurred during reconcile loop
 	CurrentSyncError string `json:"-"`
+	// CurrentSyncWarning holds a non-fatal issue found during reconcile loop
+	CurrentSyncWarning string `json:"-"`
 	// Known .status.conditions.type are: "Available", "Progressing", and "Degraded"
 	// +patchMergeKey=type
 	// +patchStrategy=merge
@@ -1322,6 +1333,52 @@ type StatusMetadata struct {
 	Conditions []Condition `json:"conditions,omitempty"`
 }
 
+// ReconcileMode defines how operator handles changes of child objects.
+// Plan mode is supported only by VMCluster
+// +kubebuilder:validation:Enum=apply;plan
+type ReconcileMode string
+
+const (
+	// ReconcileModeApply applies changes to child objects
+	ReconcileModeApply ReconcileMode = "apply"
+	// ReconcileModePlan only computes changes of child objects and publishes them at status
+	ReconcileModePlan ReconcileMode = "plan"
+)
+
+// PlannedAction defines an action, which operator performs for child object
+type PlannedAction string
+
+const (
+	PlannedActionCreate   PlannedAction = "create"
+	PlannedActionUpdate   PlannedAction = "update"
+	PlannedActionRecreate PlannedAction = "recreate"
+	PlannedActionDelete   PlannedAction = "delete"
+)
+
+// ReconcilePlan contains changes of child objects computed with plan reconcile mode
+type ReconcilePlan struct {
+	// ObservedGeneration defines generation of the object, for which plan was computed
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Summary contains count of planned changes for each action
+	Summary string `json:"summary,omitempty"`
+	// Changes contains planned changes of child objects
+	// +optional
+	Changes []PlannedChange `json:"changes,omitempty"`
+}
+
+// PlannedChange defines a single change of child object
+type PlannedChange struct {
+	// Kind of child object
+	Kind string `json:"kind"`
+	// Name of child object
+	Name string `json:"name"`
+	// Action which will be performed for child object
+	Action PlannedAction `json:"action"`
+	// Fields contains paths of changed fields for update action
+	// +optional
+	Fields []string `json:"fields,omitempty"`
+}
+
 // ManagedObjectsMetadata contains Labels and Annotations
 type ManagedObjectsMetadata struct {
 	// Labels Map of string keys and values that can be used to organize and categorize
@@ -1446,3 +1503,42 @@ func (bs *BytesString) String() string {
 	}
 	return string(*bs)
 }
+
+// DatasourceRef defines reference to VictoriaMetrics, VictoriaLogs or VictoriaTraces component,
+// which is resolved by operator into access URL and credentials
+type DatasourceRef struct {
+	// Kind of referenced object
+	// +kubebuilder:validation:Enum=VMSingle;VMCluster;VMAuth;VLSingle;VLCluster;VTSingle;VTCluster
+	Kind string `json:"kind"`
+	// Name of referenced object
+	Name string `json:"name"`
+	// Namespace of referenced object.
+	// Defaults to the namespace of the object with reference
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// User defines name of VMUser, which credentials are used for requests to referenced VMAuth.
+	// VMUser must be in the same namespace as the object with reference
+	// +optional
+	User string `json:"user,omitempty"`
+	// TenantID defines tenant of referenced VMCluster, VLCluster or VTCluster in form of accountID[:projectID]
+	// VMAnomaly uses tenantID of reader and writer instead
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// Validate checks if reference is correct and points to one of given kinds
+func (r *DatasourceRef) Validate(kinds ...string) error {
+	if r.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if !slices.Contains(kinds, r.Kind) {
+		return fmt.Errorf("unsupported kind=%q, supported kinds: %s", r.Kind, strings.Join(kinds, ","))
+	}
+	if r.User != "" && r.Kind != "VMAuth" {
+		return fmt.Errorf("user can be defined only for VMAuth kind, got kind=%q", r.Kind)
+	}
+	if r.TenantID != "" && r.Kind != "VMCluster" && r.Kind != "VLCluster" && r.Kind != "VTCluster" {
+		return fmt.Errorf("tenantID can be defined only for VMCluster, VLCluster and VTCluster kinds, got kind=%q", r.Kind)
+	}
+	return nil
+}
@@ -257,3 +257,66 @@ func TestEmbeddedVPAValidation(t *testing.T) {
 		wantErr: false,
 	})
 }
+
+func TestDatasourceRefValidate(t *testing.T) {
+	type opts struct {
+		ref     DatasourceRef
+		kinds   []string
+		wantErr bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		err := o.ref.Validate(o.kinds...)
+		if o.wantErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	// supported kind
+	f(opts{
+		ref:   DatasourceRef{Kind: "VMCluster", Name: "main"},
+		kinds: []string{"VMSingle", "VMCluster"},
+	})
+
+	// VMAuth with user
+	f(opts{
+		ref:   DatasourceRef{Kind: "VMAuth", Name: "main", User: "reader"},
+		kinds: []string{"VMAuth"},
+	})
+
+	// empty name
+	f(opts{
+		ref:     DatasourceRef{Kind: "VMSingle"},
+		kinds:   []string{"VMSingle"},
+		wantErr: true,
+	})
+
+	// unsupported kind
+	f(opts{
+		ref:     DatasourceRef{Kind: "VLSingle", Name: "main"},
+		kinds:   []string{"VMSingle", "VMCluster"},
+		wantErr: true,
+	})
+
+	// user for non VMAuth kind
+	f(opts{
+		ref:     DatasourceRef{Kind: "VMSingle", Name: "main", User: "reader"},
+		kinds:   []string{"VMSingle"},
+		wantErr: true,
+	})
+
+	// tenant for cluster kind
+	f(opts{
+		ref:   DatasourceRef{Kind: "VLCluster", Name: "main", TenantID: "1:2"},
+		kinds: []string{"VLCluster"},
+	})
+
+	// tenant for single kind
+	f(opts{
+		ref:     DatasourceRef{Kind: "VMSingle", Name: "main", TenantID: "1"},
+		kinds:   []string{"VMSingle"},
+		wantErr: true,
+	})
+}
@@ -0,0 +1,320 @@
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VMOperatorConfigKind defines kind of operator configuration object
+	VMOperatorConfigKind = "VMOperatorConfig"
+	// VMOperatorConfigName defines name of the singleton VMOperatorConfig object.
+	// Objects with other names are ignored by operator
+	VMOperatorConfigName = "default"
+)
+
+// VMOperatorConfigSpec defines operator configuration overrides.
+// Fields mirror operator configuration env variables, see https://docs.victoriametrics.com/operator/configuration/#environment-variables.
+// Fields not defined at spec keep values from env variables or defaults.
+// Changes are applied without operator restart
+type VMOperatorConfigSpec struct {
+	// MetricsVersion overrides VM_METRICS_VERSION, default version of VictoriaMetrics components
+	// +optional
+	MetricsVersion *string `json:"metricsVersion,omitempty"`
+	// LogsVersion overrides VM_LOGS_VERSION, default version of VictoriaLogs components
+	// +optional
+	LogsVersion *string `json:"logsVersion,omitempty"`
+	// AnomalyVersion overrides VM_ANOMALY_VERSION, default version of vmanomaly
+	// +optional
+	AnomalyVersion *string `json:"anomalyVersion,omitempty"`
+	// TracesVersion overrides VM_TRACES_VERSION, default version of VictoriaTraces components
+	// +optional
+	TracesVersion *string `json:"tracesVersion,omitempty"`
+	// OperatorVersion overrides VM_OPERATOR_VERSION, default version of config-reloader
+	// +optional
+	OperatorVersion *string `json:"operatorVersion,omitempty"`
+	// ContainerRegistry overrides VM_CONTAINERREGISTRY
+	// +optional
+	ContainerRegistry *string `json:"containerRegistry,omitempty"`
+	// EnableTCP6 overrides VM_ENABLETCP6
+	// +optional
+	EnableTCP6 *bool `json:"enableTCP6,omitempty"`
+	// ConfigReloader overrides VM_CONFIG_RELOADER_* variables
+	// +optional
+	ConfigReloader *OperatorConfigReloader `json:"configReloader,omitempty"`
+	// VLogs overrides VM_VLOGSDEFAULT_* variables
+	// +optional
+	VLogs *OperatorConfigApp `json:"vlogs,omitempty"`
+	// VLAgent overrides VM_VLAGENTDEFAULT_* variables
+	// +optional
+	VLAgent *OperatorConfigApp `json:"vlagent,omitempty"`
+	// VLSingle overrides VM_VLSINGLEDEFAULT_* variables
+	// +optional
+	VLSingle *OperatorConfigApp `json:"vlsingle,omitempty"`
+	// VTSingle overrides VM_VTSINGLEDEFAULT_* variables
+	// +optional
+	VTSingle *OperatorConfigApp `json:"vtsingle,omitempty"`
+	// VMAlert overrides VM_VMALERTDEFAULT_* variables
+	// +optional
+	VMAlert *OperatorConfigApp `json:"vmalert,omitempty"`
+	// VMServiceScrape overrides VM_VMSERVICESCRAPEDEFAULT_* variables
+	// +optional
+	VMServiceScrape *OperatorConfigServiceScrape `json:"vmservicescrape,omitempty"`
+	// VMAgent overrides VM_VMAGENTDEFAULT_* variables
+	// +optional
+	VMAgent *OperatorConfigApp `json:"vmagent,omitempty"`
+	// VMAnomaly overrides VM_VMANOMALYDEFAULT_* variables
+	// +optional
+	VMAnomaly *OperatorConfigApp `json:"vmanomaly,omitempty"`
+	// VMSingle overrides VM_VMSINGLEDEFAULT_* variables
+	// +optional
+	VMSingle *OperatorConfigApp `json:"vmsingle,omitempty"`
+	// VMCluster overrides VM_VMCLUSTERDEFAULT_* variables
+	// +optional
+	VMCluster *OperatorConfigVMCluster `json:"vmcluster,omitempty"`
+	// VMAlertmanager overrides VM_VMALERTMANAGER_* variables
+	// +optional
+	VMAlertmanager *OperatorConfigApp `json:"vmalertmanager,omitempty"`
+	// DisableSelfServiceScrapeCreation overrides VM_DISABLESELFSERVICESCRAPECREATION
+	// +optional
+	DisableSelfServiceScrapeCreation *bool `json:"disableSelfServiceScrapeCreation,omitempty"`
+	// VMBackup overrides VM_VMBACKUP_* variables
+	// +optional
+	VMBackup *OperatorConfigApp `json:"vmbackup,omitempty"`
+	// VMAuth overrides VM_VMAUTHDEFAULT_* variables
+	// +optional
+	VMAuth *OperatorConfigApp `json:"vmauth,omitempty"`
+	// VLCluster overrides VM_VLCLUSTERDEFAULT_* variables
+	// +optional
+	VLCluster *OperatorConfigCluster `json:"vlcluster,omitempty"`
+	// VTCluster overrides VM_VTCLUSTERDEFAULT_* variables
+	// +optional
+	VTCluster *OperatorConfigCluster `json:"vtcluster,omitempty"`
+	// EnabledPrometheusConverter overrides VM_ENABLEDPROMETHEUSCONVERTER_* variables
+	// +optional
+	EnabledPrometheusConverter *OperatorConfigPrometheusConverter `json:"enabledPrometheusConverter,omitempty"`
+	// PrometheusConverterAddArgoCDIgnoreAnnotations overrides VM_PROMETHEUSCONVERTERADDARGOCDIGNOREANNOTATIONS
+	// +optional
+	PrometheusConverterAddArgoCDIgnoreAnnotations *bool `json:"prometheusConverterAddArgoCDIgnoreAnnotations,omitempty"`
+	// EnabledPrometheusConverterOwnerReferences overrides VM_ENABLEDPROMETHEUSCONVERTEROWNERREFERENCES
+	// +optional
+	EnabledPrometheusConverterOwnerReferences *bool `json:"enabledPrometheusConverterOwnerReferences,omitempty"`
+	// FilterPrometheusConverterLabelPrefixes overrides VM_FILTERPROMETHEUSCONVERTERLABELPREFIXES
+	// +optional
+	FilterPrometheusConverterLabelPrefixes []string `json:"filterPrometheusConverterLabelPrefixes,omitempty"`
+	// FilterPrometheusConverterAnnotationPrefixes overrides VM_FILTERPROMETHEUSCONVERTERANNOTATIONPREFIXES
+	// +optional
+	FilterPrometheusConverterAnnotationPrefixes []string `json:"filterPrometheusConverterAnnotationPrefixes,omitempty"`
+	// ClusterDomainName overrides VM_CLUSTERDOMAINNAME
+	// +optional
+	ClusterDomainName *string `json:"clusterDomainName,omitempty"`
+	// ForceResyncInterval overrides VM_FORCERESYNCINTERVAL
+	// +optional
+	ForceResyncInterval *metav1.Duration `json:"forceResyncInterval,omitempty"`
+	// EnableVMAlertRulesHealth overrides VM_ENABLEVMALERTRULESHEALTH
+	// +optional
+	EnableVMAlertRulesHealth *bool `json:"enableVMAlertRulesHealth,omitempty"`
+	// EnableServerSideApply overrides VM_ENABLESERVERSIDEAPPLY
+	// +optional
+	EnableServerSideApply *bool `json:"enableServerSideApply,omitempty"`
+	// MaintenanceWindow overrides VM_MAINTENANCEWINDOW_* variables.
+	// It's used as default window for VMCluster and VMAgent without spec.maintenanceWindows
+	// +optional
+	MaintenanceWindow *MaintenanceWindow `json:"maintenanceWindow,omitempty"`
+	// EnableStrictSecurity overrides VM_ENABLESTRICTSECURITY
+	// +optional
+	EnableStrictSecurity *bool `json:"enableStrictSecurity,omitempty"`
+}
+
+// OperatorConfigResourceValues defines default resource quantities, unlimited disables resource
+type OperatorConfigResourceValues struct {
+	// +optional
+	Mem *string `json:"mem,omitempty"`
+	// +optional
+	CPU *string `json:"cpu,omitempty"`
+	// +optional
+	EphemeralStorage *string `json:"ephemeralStorage,omitempty"`
+}
+
+// OperatorConfigResource defines default resources of containers
+type OperatorConfigResource struct {
+	// +optional
+	Limit *OperatorConfigResourceValues `json:"limit,omitempty"`
+	// +optional
+	Request *OperatorConfigResourceValues `json:"request,omitempty"`
+}
+
+// OperatorConfigReloader defines defaults of config-reloader containers
+type OperatorConfigReloader struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Resource *OperatorConfigResource `json:"resource,omitempty"`
+}
+
+// OperatorConfigApp defines defaults of application
+type OperatorConfigApp struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Version *string `json:"version,omitempty"`
+	// +optional
+	Port *string `json:"port,omitempty"`
+	// +optional
+	UseDefaultResources *bool `json:"useDefaultResources,omitempty"`
+	// +optional
+	Resource *OperatorConfigResource `json:"resource,omitempty"`
+}
+
+// OperatorConfigComponent defines defaults of cluster component
+type OperatorConfigComponent struct {
+	// +optional
+	Image *string `json:"image,omitempty"`
+	// +optional
+	Version *string `json:"version,omitempty"`
+	// +optional
+	Port *string `json:"port,omitempty"`
+	// +optional
+	Resource *OperatorConfigResource `json:"resource,omitempty"`
+}
+
+// OperatorConfigVMStorage defines defaults of vmstorage
+type OperatorConfigVMStorage struct {
+	OperatorConfigComponent `json:",inline"`
+	// +optional
+	VMInsertPort *string `json:"vminsertPort,omitempty"`
+	// +optional
+	VMSelectPort *string `json:"vmselectPort,omitempty"`
+}
+
+// OperatorConfigVMCluster defines defaults of VMCluster components
+type OperatorConfigVMCluster struct {
+	// +optional
+	UseDefaultResources *bool `json:"useDefaultResources,omitempty"`
+	// +optional
+	Select *OperatorConfigComponent `json:"select,omitempty"`
+	// +optional
+	Storage *OperatorConfigVMStorage `json:"storage,omitempty"`
+	// +optional
+	Insert *OperatorConfigComponent `json:"insert,omitempty"`
+}
+
+// OperatorConfigCluster defines defaults of VLCluster and VTCluster components
+type OperatorConfigCluster struct {
+	// +optional
+	UseDefaultResources *bool `json:"useDefaultResources,omitempty"`
+	// +optional
+	Select *OperatorConfigComponent `json:"select,omitempty"`
+	// +optional
+	Storage *OperatorConfigComponent `json:"storage,omitempty"`
+	// +optional
+	Insert *OperatorConfigComponent `json:"insert,omitempty"`
+}
+
+// OperatorConfigServiceScrape defines defaults of VMServiceScrape
+type OperatorConfigServiceScrape struct {
+	// EnforceEndpointSlices uses endpointslices instead of endpoints as discovery role
+	// +optional
+	EnforceEndpointSlices *bool `json:"enforceEndpointSlices,omitempty"`
+}
+
+// OperatorConfigPrometheusConverter enables conversion of prometheus-operator objects by kind
+type OperatorConfigPrometheusConverter struct {
+	// +optional
+	PodMonitor *bool `json:"podMonitor,omitempty"`
+	// +optional
+	ServiceScrape *bool `json:"serviceScrape,omitempty"`
+	// +optional
+	PrometheusRule *bool `json:"prometheusRule,omitempty"`
+	// +optional
+	Probe *bool `json:"probe,omitempty"`
+	// +optional
+	AlertmanagerConfig *bool `json:"alertmanagerConfig,omitempty"`
+	// +optional
+	ScrapeConfig *bool `json:"scrapeConfig,omitempty"`
+	// +optional
+	Prometheus *bool `json:"prometheus,omitempty"`
+	// +optional
+	PrometheusAgent *bool `json:"prometheusAgent,omitempty"`
+	// +optional
+	Alertmanager *bool `json:"alertmanager,omitempty"`
+}
+
+// VMOperatorConfigStatus defines the observed state of VMOperatorConfig
+type VMOperatorConfigStatus struct {
+	StatusMetadata `json:",inline"`
+	// EffectiveConfig contains values of all operator configuration variables
+	// with applied overrides from spec
+	// +optional
+	EffectiveConfig map[string]string `json:"effectiveConfig,omitempty"`
+}
+
+// GetStatusMetadata implements reconcile.StatusWithMetadata interface
+func (st *VMOperatorConfigStatus) GetStatusMetadata() *StatusMetadata {
+	return &st.StatusMetadata
+}
+
+// VMOperatorConfig is the Schema for the vmoperatorconfigs API.
+// It defines operator configuration, which overrides env variables at runtime.
+// Only object with name default is used by operator
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +kubebuilder:resource:path=vmoperatorconfigs,scope=Cluster
+type VMOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMOperatorConfigSpec   `json:"spec,omitempty"`
+	Status VMOperatorConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMOperatorConfigList contains a list of VMOperatorConfig
+type VMOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMOperatorConfig `json:"items"`
+}
+
+// GetStatus implements reconcile.ObjectWithDeepCopyAndStatus interface
+func (cr *VMOperatorConfig) GetStatus() *VMOperatorConfigStatus {
+	return &cr.Status
+}
+
+// DefaultStatusFields implements reconcile.ObjectWithDeepCopyAndStatus interface
+func (cr *VMOperatorConfig) DefaultStatusFields(vs *VMOperatorConfigStatus) {
+}
+
+// GetStatusMetadata implements reconcile.objectWithStatus interface
+func (cr *VMOperatorConfig) GetStatusMetadata() *StatusMetadata {
+	return &cr.Status.StatusMetadata
+}
+
+// Validate performs syntax validation of object.
+// Resulting configuration is validated by operator
+func (cr *VMOperatorConfig) Validate() error {
+	if MustSkipCRValidation(cr) {
+		return nil
+	}
+	if cr.Name != VMOperatorConfigName {
+		return fmt.Errorf("unsupported name=%q, only %q is allowed", cr.Name, VMOperatorConfigName)
+	}
+	if cr.Spec.MaintenanceWindow != nil {
+		if err := cr.Spec.MaintenanceWindow.Validate(); err != nil {
+			return fmt.Errorf("incorrect spec.maintenanceWindow: %w", err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&VMOperatorConfig{}, &VMOperatorConfigList{})
+}
@@ -0,0 +1,48 @@
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestVMOperatorConfig_Validate(t *testing.T) {
+	f := func(name string, spec VMOperatorConfigSpec, wantErr bool) {
+		t.Helper()
+		cr := &VMOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       spec,
+		}
+		if wantErr {
+			assert.Error(t, cr.Validate())
+		} else {
+			assert.NoError(t, cr.Validate())
+		}
+	}
+
+	// empty spec
+	f(VMOperatorConfigName, VMOperatorConfigSpec{}, false)
+
+	// valid overrides
+	f(VMOperatorConfigName, VMOperatorConfigSpec{
+		VMSingle:             &OperatorConfigApp{Version: ptr.To("v1.120.0")},
+		EnableStrictSecurity: ptr.To(true),
+		MaintenanceWindow: &MaintenanceWindow{
+			Schedule: "0 2 * * 6",
+			Duration: "2h",
+		},
+	}, false)
+
+	// unsupported name
+	f("custom", VMOperatorConfigSpec{}, true)
+
+	// invalid maintenance window
+	f(VMOperatorConfigName, VMOperatorConfigSpec{
+		MaintenanceWindow: &MaintenanceWindow{
+			Schedule: "0 2 * *",
+			Duration: "2h",
+		},
+	}, true)
+}
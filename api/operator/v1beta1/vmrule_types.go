@@ -3,6 +3,7 @@ package v1beta1
 import (
 	"fmt"
 	"net/url"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -21,6 +22,8 @@ var MaxConfigMapDataSize = int(float64(corev1.MaxSecretSize) * 0.5)
 
 var initVMAlertTemplatesOnce sync.Once
 
+var datasourceNameRegex = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
 // VMRuleSpec defines the desired state of VMRule
 type VMRuleSpec struct {
 	// Groups list of group rules
@@ -71,8 +74,16 @@ type RuleGroup struct {
 	Params url.Values `json:"params,omitempty" yaml:"params,omitempty"`
 	// Type defines datasource type for enterprise version of vmalert
 	// possible values - prometheus,graphite,vlogs
+	// Expressions of vlogs groups are validated as LogsQL.
+	// If datasource is not set, group is evaluated with the first VMAlert datasource of the same type
 	// +optional
 	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Datasource defines name of VMAlert datasource from spec.datasources, which evaluates the group.
+	// Group is evaluated with spec.datasource, if it's not set and there is no datasource with matching type.
+	// Group is skipped by VMAlert without datasource with the given name
+	// +kubebuilder:validation:Pattern:="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +optional
+	Datasource string `json:"datasource,omitempty" yaml:"datasource,omitempty"`
 	// Headers contains optional HTTP headers added to each rule request
 	// Must be in form `header-name: value`
 	// For example:
@@ -131,6 +142,34 @@ type Rule struct {
 // VMRuleStatus defines the observed state of VMRule
 type VMRuleStatus struct {
 	StatusMetadata `json:",inline"`
+	// Groups contains evaluation health of rule groups
+	// reported by VMAlert instances, which selected this rule
+	// +optional
+	Groups []VMRuleGroupStatus `json:"groups,omitempty"`
+}
+
+// VMRuleGroupStatus defines evaluation health of rule group at VMAlert.
+// Values are aggregated across all VMAlert replicas
+type VMRuleGroupStatus struct {
+	// Name of the rule group
+	Name string `json:"name"`
+	// VMAlert defines namespace/name of VMAlert, which evaluates the group
+	VMAlert string `json:"vmalert"`
+	// LastEvaluation defines the most recent time of group evaluation
+	// +optional
+	LastEvaluation *metav1.Time `json:"lastEvaluation,omitempty"`
+	// LastError defines the last evaluation error of group rules
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+	// LastDuration defines total evaluation time of group rules at the last evaluation
+	// +optional
+	LastDuration string `json:"lastDuration,omitempty"`
+	// FiringAlerts defines number of currently firing alerts of the group
+	// +optional
+	FiringAlerts int32 `json:"firingAlerts,omitempty"`
+	// EvaluationMisses defines number of group evaluation intervals passed since the last evaluation
+	// +optional
+	EvaluationMisses int32 `json:"evaluationMisses,omitempty"`
 }
 
 // GetStatusMetadata implements reconcile.objectWithStatus interface
@@ -172,6 +211,13 @@ func (cr *VMRule) Validate() error {
 			return fmt.Errorf("duplicate group name: %s", errContext)
 		}
 		uniqNames[group.Name] = struct{}{}
+		// datasource is resolved by operator and isn't supported by vmalert lib
+		if group.Datasource != "" {
+			if !datasourceNameRegex.MatchString(group.Datasource) {
+				return fmt.Errorf("incorrect datasource name=%q, it must match %q: %s", group.Datasource, datasourceNameRegex, errContext)
+			}
+			group.Datasource = ""
+		}
 		groupBytes, err := yaml.Marshal(group)
 		if err != nil {
 			return fmt.Errorf("cannot marshal %s, err: %w", errContext, err)
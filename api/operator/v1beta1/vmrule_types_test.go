@@ -182,4 +182,56 @@ spec:
           annotations: 
             description: "Service nginx on env test accepted {{$labels.requests}} requests in the last 5 minutes"`,
 	})
+
+	// vlogs group with named datasource
+	f(opts{
+		src: `
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMRule
+metadata:
+  name: vlogs-alert-2
+spec:
+  groups:
+    - name: log-stats
+      type: vlogs
+      datasource: logs
+      rules:
+        - alert: TooManyErrors
+          expr: 'level: error | stats count(*) as errors | filter errors:>10'`,
+	})
+
+	// bad LogsQL expression
+	f(opts{
+		src: `
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMRule
+metadata:
+  name: vlogs-bad-expr
+spec:
+  groups:
+    - name: log-stats
+      type: vlogs
+      rules:
+        - alert: TooManyErrors
+          expr: 'rate(errors_total[5m]) > 0'`,
+		wantErr: "bad LogsQL expr",
+	})
+
+	// bad datasource name
+	f(opts{
+		src: `
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMRule
+metadata:
+  name: bad-datasource
+spec:
+  groups:
+    - name: log-stats
+      type: vlogs
+      datasource: Logs_1
+      rules:
+        - alert: TooManyErrors
+          expr: 'level: error | stats count(*) as errors'`,
+		wantErr: `incorrect datasource name="Logs_1"`,
+	})
 }
@@ -0,0 +1,155 @@
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+
+	amparse "github.com/prometheus/alertmanager/matcher/parse"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SilenceStateActive defines silence, which mutes alerts at the moment
+	SilenceStateActive = "active"
+	// SilenceStatePending defines silence, which starts in future
+	SilenceStatePending = "pending"
+	// SilenceStateExpired defines silence, which already ended
+	SilenceStateExpired = "expired"
+)
+
+// VMSilenceSpec defines the desired state of VMSilence
+type VMSilenceSpec struct {
+	// Matchers defines alert label matchers in alertmanager format
+	// For example, ['alertname="Watchdog"', 'severity=~"info|warning"']
+	// Operator adds namespace matcher for the VMSilence namespace,
+	// unless it is disabled by VMAlertmanager with disableNamespaceMatcher
+	// +kubebuilder:validation:MinItems=1
+	Matchers []string `json:"matchers"`
+	// StartsAt defines the time when silence becomes active.
+	// Defaults to VMSilence creation time
+	// +optional
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+	// EndsAt defines the time when silence expires.
+	// Required if schedule is not set
+	// +optional
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+	// Schedule defines recurring time intervals, when silence is active.
+	// Operator creates silence for the current or the next interval occurrence
+	// and re-creates it once occurrence is over. It cannot be used with startsAt and endsAt
+	// +optional
+	Schedule []TimeInterval `json:"schedule,omitempty"`
+	// Comment defines silence description
+	// +kubebuilder:validation:MinLength=1
+	Comment string `json:"comment"`
+	// CreatedBy defines author of silence
+	// Defaults to vm-operator
+	// +optional
+	CreatedBy string `json:"createdBy,omitempty"`
+}
+
+// VMSilenceStatus defines the observed state of VMSilence
+type VMSilenceStatus struct {
+	StatusMetadata `json:",inline"`
+	// Silences contains silence state at each selected VMAlertmanager
+	// +optional
+	Silences []VMSilenceState `json:"silences,omitempty"`
+}
+
+// VMSilenceState defines silence state at the VMAlertmanager
+type VMSilenceState struct {
+	// Alertmanager is VMAlertmanager in namespace/name format
+	Alertmanager string `json:"alertmanager"`
+	// ID of silence returned by alertmanager API
+	// +optional
+	ID string `json:"id,omitempty"`
+	// State of silence - active, pending or expired
+	// +optional
+	State string `json:"state,omitempty"`
+	// StartsAt defines start time of synced silence
+	// +optional
+	StartsAt *metav1.Time `json:"startsAt,omitempty"`
+	// EndsAt defines end time of synced silence
+	// +optional
+	EndsAt *metav1.Time `json:"endsAt,omitempty"`
+}
+
+// GetStatusMetadata implements reconcile.StatusWithMetadata interface
+func (st *VMSilenceStatus) GetStatusMetadata() *StatusMetadata {
+	return &st.StatusMetadata
+}
+
+// VMSilence is the Schema for the vmsilences API
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.updateStatus"
+// +kubebuilder:printcolumn:name="Sync Error",type="string",JSONPath=".status.reason"
+// +genclient
+type VMSilence struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VMSilenceSpec   `json:"spec,omitempty"`
+	Status VMSilenceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VMSilenceList contains a list of VMSilence
+type VMSilenceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VMSilence `json:"items"`
+}
+
+// GetStatus implements reconcile.ObjectWithDeepCopyAndStatus interface
+func (cr *VMSilence) GetStatus() *VMSilenceStatus {
+	return &cr.Status
+}
+
+// DefaultStatusFields implements reconcile.ObjectWithDeepCopyAndStatus interface
+func (cr *VMSilence) DefaultStatusFields(vs *VMSilenceStatus) {
+}
+
+// GetStatusMetadata implements reconcile.objectWithStatus interface
+func (cr *VMSilence) GetStatusMetadata() *StatusMetadata {
+	return &cr.Status.StatusMetadata
+}
+
+// Validate performs semantic validation of object
+func (cr *VMSilence) Validate() error {
+	if MustSkipCRValidation(cr) {
+		return nil
+	}
+	if len(cr.Spec.Matchers) == 0 {
+		return fmt.Errorf("at least one matcher must be defined")
+	}
+	for idx, m := range cr.Spec.Matchers {
+		if _, err := amparse.Matcher(m); err != nil {
+			return fmt.Errorf("cannot parse matcher=%q idx=%d: %w", m, idx, err)
+		}
+	}
+	if cr.Spec.Comment == "" {
+		return fmt.Errorf("comment cannot be empty")
+	}
+	if len(cr.Spec.Schedule) > 0 {
+		if cr.Spec.StartsAt != nil || cr.Spec.EndsAt != nil {
+			return fmt.Errorf("schedule cannot be used with startsAt and endsAt")
+		}
+		if err := validateTimeIntervalsEntry(&TimeIntervals{Name: "schedule", TimeIntervals: cr.Spec.Schedule}); err != nil {
+			return fmt.Errorf("incorrect schedule: %w", err)
+		}
+		return nil
+	}
+	if cr.Spec.EndsAt == nil {
+		return fmt.Errorf("endsAt or schedule must be defined")
+	}
+	if cr.Spec.StartsAt != nil && !cr.Spec.StartsAt.Before(cr.Spec.EndsAt) {
+		return fmt.Errorf("startsAt=%s must be before endsAt=%s", cr.Spec.StartsAt.Format(time.RFC3339), cr.Spec.EndsAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func init() {
+	SchemeBuilder.Register(&VMSilence{}, &VMSilenceList{})
+}
@@ -0,0 +1,90 @@
+package v1beta1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVMSilence_Validate(t *testing.T) {
+	f := func(spec VMSilenceSpec, wantErr bool) {
+		t.Helper()
+		cr := &VMSilence{Spec: spec}
+		if wantErr {
+			assert.Error(t, cr.Validate())
+		} else {
+			assert.NoError(t, cr.Validate())
+		}
+	}
+	now := time.Now()
+	startsAt := &metav1.Time{Time: now}
+	endsAt := &metav1.Time{Time: now.Add(time.Hour)}
+
+	// missing matchers
+	f(VMSilenceSpec{
+		EndsAt:  endsAt,
+		Comment: "maintenance",
+	}, true)
+
+	// invalid matcher
+	f(VMSilenceSpec{
+		Matchers: []string{`alertname=~"(unclosed"`},
+		EndsAt:   endsAt,
+		Comment:  "maintenance",
+	}, true)
+
+	// missing comment
+	f(VMSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`},
+		EndsAt:   endsAt,
+	}, true)
+
+	// missing endsAt
+	f(VMSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`},
+		Comment:  "maintenance",
+	}, true)
+
+	// startsAt after endsAt
+	f(VMSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`},
+		StartsAt: endsAt,
+		EndsAt:   startsAt,
+		Comment:  "maintenance",
+	}, true)
+
+	// schedule with endsAt
+	f(VMSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`},
+		EndsAt:   endsAt,
+		Schedule: []TimeInterval{{Weekdays: []string{"saturday", "sunday"}}},
+		Comment:  "maintenance",
+	}, true)
+
+	// invalid schedule
+	f(VMSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`},
+		Schedule: []TimeInterval{{Weekdays: []string{"someday"}}},
+		Comment:  "maintenance",
+	}, true)
+
+	// fixed window
+	f(VMSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`, `severity=~"info|warning"`},
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Comment:  "maintenance",
+	}, false)
+
+	// recurring schedule
+	f(VMSilenceSpec{
+		Matchers: []string{`alertname="Watchdog"`},
+		Schedule: []TimeInterval{{
+			Times:    []TimeRange{{StartTime: "01:00", EndTime: "03:00"}},
+			Weekdays: []string{"saturday", "sunday"},
+		}},
+		Comment: "weekly maintenance",
+	}, false)
+}
@@ -2,6 +2,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -51,6 +52,60 @@ type VMUserSpec struct {
 	// ManagedMetadata defines metadata that will be added to the all objects
 	// created by operator for the given CustomResource
 	ManagedMetadata *ManagedObjectsMetadata `json:"managedMetadata,omitempty"`
+
+	// Tests defines sample requests, which are routed by operator
+	// with the rendered vmauth configuration of the given user.
+	// Matched backends are reported at status.tests
+	// +optional
+	Tests []VMUserRoutingTest `json:"tests,omitempty"`
+}
+
+// VMUserRoutingTest defines sample request for vmauth routing config check
+type VMUserRoutingTest struct {
+	// Name of the test, must be unique per VMUser
+	Name string `json:"name"`
+	// Host defines request hostname, it's matched against src_hosts
+	// +optional
+	Host string `json:"host,omitempty"`
+	// Path defines request path with optional query args,
+	// it's matched against src_paths and src_query_args
+	Path string `json:"path"`
+	// Headers defines request headers, they're matched against src_headers
+	// +optional
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+func (rt *VMUserRoutingTest) validate() error {
+	if rt.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	if !strings.HasPrefix(rt.Path, "/") {
+		return fmt.Errorf("path=%q must start with /", rt.Path)
+	}
+	if _, err := url.ParseRequestURI(rt.Path); err != nil {
+		return fmt.Errorf("cannot parse path=%q: %w", rt.Path, err)
+	}
+	return nil
+}
+
+// VMUserRoutingTestResult defines result of VMUserRoutingTest evaluation
+type VMUserRoutingTestResult struct {
+	// Name of the test
+	Name string `json:"name"`
+	// VMAuth defines namespace/name of VMAuth, which user config was used for routing.
+	// VMUser selected by multiple VMAuths has results for each of them
+	// +optional
+	VMAuth string `json:"vmauth,omitempty"`
+	// Route defines matched section of user config,
+	// url_map[N], url_prefix or default_url
+	// +optional
+	Route string `json:"route,omitempty"`
+	// Backends defines url prefixes of the matched route
+	// +optional
+	Backends []string `json:"backends,omitempty"`
+	// Error defines reason why request was not routed
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // TargetRef describes target for user traffic forwarding.
@@ -188,6 +243,9 @@ type TargetRefBasicAuth struct {
 // VMUserStatus defines the observed state of VMUser
 type VMUserStatus struct {
 	StatusMetadata `json:",inline"`
+	// Tests contains results of spec.tests evaluation per VMAuth
+	// +optional
+	Tests []VMUserRoutingTestResult `json:"tests,omitempty"`
 }
 
 // VMUser is the Schema for the vmusers API
@@ -328,6 +386,22 @@ func (cr *VMUser) Validate() error {
 	if err := validateHTTPHeaders(cr.Spec.ResponseHeaders); err != nil {
 		return fmt.Errorf("failed to parse vmuser response headers: %w", err)
 	}
+	if cr.Spec.RateLimit != nil {
+		if err := cr.Spec.RateLimit.validate(); err != nil {
+			return fmt.Errorf("incorrect spec.rate_limit: %w", err)
+		}
+	}
+	testNames := make(map[string]struct{}, len(cr.Spec.Tests))
+	for i := range cr.Spec.Tests {
+		rt := &cr.Spec.Tests[i]
+		if err := rt.validate(); err != nil {
+			return fmt.Errorf("incorrect spec.tests[%d]: %w", i, err)
+		}
+		if _, ok := testNames[rt.Name]; ok {
+			return fmt.Errorf("duplicate spec.tests name=%q", rt.Name)
+		}
+		testNames[rt.Name] = struct{}{}
+	}
 	return nil
 }
 
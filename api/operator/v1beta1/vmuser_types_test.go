@@ -103,4 +103,119 @@ func TestVMUser_Validate(t *testing.T) {
 			},
 		},
 	}, false)
+
+	// incorrect rate limit
+	f(&VMUser{
+		Spec: VMUserSpec{
+			TargetRefs: []TargetRef{
+				{
+					Static: &StaticRef{
+						URL: "http://some-url",
+					},
+				},
+			},
+			VMUserConfigOptions: VMUserConfigOptions{
+				RateLimit: &VMUserRateLimit{
+					RequestsPerSecond: ptr.To(0),
+				},
+			},
+		},
+	}, true)
+
+	// incorrect rate limit path regexp
+	f(&VMUser{
+		Spec: VMUserSpec{
+			TargetRefs: []TargetRef{
+				{
+					Static: &StaticRef{
+						URL: "http://some-url",
+					},
+				},
+			},
+			VMUserConfigOptions: VMUserConfigOptions{
+				RateLimit: &VMUserRateLimit{
+					Paths: []VMUserPathRateLimit{
+						{
+							SrcPaths: []string{"/api/v1/query("},
+							Burst:    ptr.To(10),
+						},
+					},
+				},
+			},
+		},
+	}, true)
+
+	// correct rate limit
+	f(&VMUser{
+		Spec: VMUserSpec{
+			TargetRefs: []TargetRef{
+				{
+					Static: &StaticRef{
+						URL: "http://some-url",
+					},
+				},
+			},
+			VMUserConfigOptions: VMUserConfigOptions{
+				RateLimit: &VMUserRateLimit{
+					RequestsPerSecond: ptr.To(10),
+					Burst:             ptr.To(50),
+					Paths: []VMUserPathRateLimit{
+						{
+							SrcPaths:          []string{"/api/v1/query_range"},
+							RequestsPerSecond: ptr.To(1),
+						},
+					},
+				},
+			},
+		},
+	}, false)
+
+	// duplicate test names
+	f(&VMUser{
+		Spec: VMUserSpec{
+			TargetRefs: []TargetRef{
+				{
+					Static: &StaticRef{
+						URL: "http://some-url",
+					},
+				},
+			},
+			Tests: []VMUserRoutingTest{
+				{Name: "query", Path: "/api/v1/query"},
+				{Name: "query", Path: "/api/v1/query_range"},
+			},
+		},
+	}, true)
+
+	// incorrect test path
+	f(&VMUser{
+		Spec: VMUserSpec{
+			TargetRefs: []TargetRef{
+				{
+					Static: &StaticRef{
+						URL: "http://some-url",
+					},
+				},
+			},
+			Tests: []VMUserRoutingTest{
+				{Name: "query", Path: "api/v1/query"},
+			},
+		},
+	}, true)
+
+	// correct tests
+	f(&VMUser{
+		Spec: VMUserSpec{
+			TargetRefs: []TargetRef{
+				{
+					Static: &StaticRef{
+						URL: "http://some-url",
+					},
+				},
+			},
+			Tests: []VMUserRoutingTest{
+				{Name: "query", Host: "vmauth.example.com", Path: "/api/v1/query?query=up", Headers: map[string]string{"X-Tenant": "team-a"}},
+			},
+		},
+	}, false)
 }
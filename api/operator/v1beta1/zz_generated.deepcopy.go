@@ -962,6 +962,21 @@ func (in *DNSSDConfig) DeepCopy() *DNSSDConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatasourceRef) DeepCopyInto(out *DatasourceRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatasourceRef.
+func (in *DatasourceRef) DeepCopy() *DatasourceRef {
+	if in == nil {
+		return nil
+	}
+	out := new(DatasourceRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DigitalOceanSDConfig) DeepCopyInto(out *DigitalOceanSDConfig) {
 	*out = *in
@@ -2143,6 +2158,21 @@ func (in *MSTeamsV2Config) DeepCopy() *MSTeamsV2Config {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedObjectsMetadata) DeepCopyInto(out *ManagedObjectsMetadata) {
 	*out = *in
@@ -2416,249 +2446,626 @@ func (in *OpenStackSDConfig) DeepCopy() *OpenStackSDConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpsGenieConfig) DeepCopyInto(out *OpsGenieConfig) {
+func (in *OperatorConfigApp) DeepCopyInto(out *OperatorConfigApp) {
 	*out = *in
-	if in.SendResolved != nil {
-		in, out := &in.SendResolved, &out.SendResolved
-		*out = new(bool)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
 		**out = **in
 	}
-	if in.APIKey != nil {
-		in, out := &in.APIKey, &out.APIKey
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
 	}
-	if in.Details != nil {
-		in, out := &in.Details, &out.Details
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(string)
+		**out = **in
 	}
-	if in.Responders != nil {
-		in, out := &in.Responders, &out.Responders
-		*out = make([]OpsGenieConfigResponder, len(*in))
-		copy(*out, *in)
+	if in.UseDefaultResources != nil {
+		in, out := &in.UseDefaultResources, &out.UseDefaultResources
+		*out = new(bool)
+		**out = **in
 	}
-	if in.HTTPConfig != nil {
-		in, out := &in.HTTPConfig, &out.HTTPConfig
-		*out = new(HTTPConfig)
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(OperatorConfigResource)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsGenieConfig.
-func (in *OpsGenieConfig) DeepCopy() *OpsGenieConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigApp.
+func (in *OperatorConfigApp) DeepCopy() *OperatorConfigApp {
 	if in == nil {
 		return nil
 	}
-	out := new(OpsGenieConfig)
+	out := new(OperatorConfigApp)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OpsGenieConfigResponder) DeepCopyInto(out *OpsGenieConfigResponder) {
+func (in *OperatorConfigCluster) DeepCopyInto(out *OperatorConfigCluster) {
 	*out = *in
+	if in.UseDefaultResources != nil {
+		in, out := &in.UseDefaultResources, &out.UseDefaultResources
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Select != nil {
+		in, out := &in.Select, &out.Select
+		*out = new(OperatorConfigComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(OperatorConfigComponent)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Insert != nil {
+		in, out := &in.Insert, &out.Insert
+		*out = new(OperatorConfigComponent)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsGenieConfigResponder.
-func (in *OpsGenieConfigResponder) DeepCopy() *OpsGenieConfigResponder {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigCluster.
+func (in *OperatorConfigCluster) DeepCopy() *OperatorConfigCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(OpsGenieConfigResponder)
+	out := new(OperatorConfigCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PagerDutyConfig) DeepCopyInto(out *PagerDutyConfig) {
+func (in *OperatorConfigComponent) DeepCopyInto(out *OperatorConfigComponent) {
 	*out = *in
-	if in.SendResolved != nil {
-		in, out := &in.SendResolved, &out.SendResolved
-		*out = new(bool)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
 		**out = **in
 	}
-	if in.RoutingKey != nil {
-		in, out := &in.RoutingKey, &out.RoutingKey
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ServiceKey != nil {
-		in, out := &in.ServiceKey, &out.ServiceKey
-		*out = new(v1.SecretKeySelector)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Images != nil {
-		in, out := &in.Images, &out.Images
-		*out = make([]ImageConfig, len(*in))
-		copy(*out, *in)
-	}
-	if in.Links != nil {
-		in, out := &in.Links, &out.Links
-		*out = make([]LinkConfig, len(*in))
-		copy(*out, *in)
+	if in.Version != nil {
+		in, out := &in.Version, &out.Version
+		*out = new(string)
+		**out = **in
 	}
-	if in.Details != nil {
-		in, out := &in.Details, &out.Details
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(string)
+		**out = **in
 	}
-	if in.HTTPConfig != nil {
-		in, out := &in.HTTPConfig, &out.HTTPConfig
-		*out = new(HTTPConfig)
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(OperatorConfigResource)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyConfig.
-func (in *PagerDutyConfig) DeepCopy() *PagerDutyConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigComponent.
+func (in *OperatorConfigComponent) DeepCopy() *OperatorConfigComponent {
 	if in == nil {
 		return nil
 	}
-	out := new(PagerDutyConfig)
+	out := new(OperatorConfigComponent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodMetricsEndpoint) DeepCopyInto(out *PodMetricsEndpoint) {
+func (in *OperatorConfigPrometheusConverter) DeepCopyInto(out *OperatorConfigPrometheusConverter) {
 	*out = *in
-	if in.Port != nil {
-		in, out := &in.Port, &out.Port
-		*out = new(string)
+	if in.PodMonitor != nil {
+		in, out := &in.PodMonitor, &out.PodMonitor
+		*out = new(bool)
 		**out = **in
 	}
-	if in.PortNumber != nil {
-		in, out := &in.PortNumber, &out.PortNumber
-		*out = new(int32)
+	if in.ServiceScrape != nil {
+		in, out := &in.ServiceScrape, &out.ServiceScrape
+		*out = new(bool)
 		**out = **in
 	}
-	if in.TargetPort != nil {
-		in, out := &in.TargetPort, &out.TargetPort
-		*out = new(intstr.IntOrString)
+	if in.PrometheusRule != nil {
+		in, out := &in.PrometheusRule, &out.PrometheusRule
+		*out = new(bool)
 		**out = **in
 	}
-	in.EndpointRelabelings.DeepCopyInto(&out.EndpointRelabelings)
-	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
-	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
-	in.AttachMetadata.DeepCopyInto(&out.AttachMetadata)
-	if in.FilterRunning != nil {
-		in, out := &in.FilterRunning, &out.FilterRunning
+	if in.Probe != nil {
+		in, out := &in.Probe, &out.Probe
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AlertmanagerConfig != nil {
+		in, out := &in.AlertmanagerConfig, &out.AlertmanagerConfig
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ScrapeConfig != nil {
+		in, out := &in.ScrapeConfig, &out.ScrapeConfig
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PrometheusAgent != nil {
+		in, out := &in.PrometheusAgent, &out.PrometheusAgent
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Alertmanager != nil {
+		in, out := &in.Alertmanager, &out.Alertmanager
 		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetricsEndpoint.
-func (in *PodMetricsEndpoint) DeepCopy() *PodMetricsEndpoint {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigPrometheusConverter.
+func (in *OperatorConfigPrometheusConverter) DeepCopy() *OperatorConfigPrometheusConverter {
 	if in == nil {
 		return nil
 	}
-	out := new(PodMetricsEndpoint)
+	out := new(OperatorConfigPrometheusConverter)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyAuth) DeepCopyInto(out *ProxyAuth) {
+func (in *OperatorConfigReloader) DeepCopyInto(out *OperatorConfigReloader) {
 	*out = *in
-	if in.BasicAuth != nil {
-		in, out := &in.BasicAuth, &out.BasicAuth
-		*out = new(BasicAuth)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resource != nil {
+		in, out := &in.Resource, &out.Resource
+		*out = new(OperatorConfigResource)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.BearerToken != nil {
-		in, out := &in.BearerToken, &out.BearerToken
-		*out = new(v1.SecretKeySelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigReloader.
+func (in *OperatorConfigReloader) DeepCopy() *OperatorConfigReloader {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigReloader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigResource) DeepCopyInto(out *OperatorConfigResource) {
+	*out = *in
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(OperatorConfigResourceValues)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.TLSConfig != nil {
-		in, out := &in.TLSConfig, &out.TLSConfig
-		*out = new(TLSConfig)
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(OperatorConfigResourceValues)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyAuth.
-func (in *ProxyAuth) DeepCopy() *ProxyAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigResource.
+func (in *OperatorConfigResource) DeepCopy() *OperatorConfigResource {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyAuth)
+	out := new(OperatorConfigResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PushoverConfig) DeepCopyInto(out *PushoverConfig) {
+func (in *OperatorConfigResourceValues) DeepCopyInto(out *OperatorConfigResourceValues) {
 	*out = *in
-	if in.SendResolved != nil {
-		in, out := &in.SendResolved, &out.SendResolved
+	if in.Mem != nil {
+		in, out := &in.Mem, &out.Mem
+		*out = new(string)
+		**out = **in
+	}
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		*out = new(string)
+		**out = **in
+	}
+	if in.EphemeralStorage != nil {
+		in, out := &in.EphemeralStorage, &out.EphemeralStorage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigResourceValues.
+func (in *OperatorConfigResourceValues) DeepCopy() *OperatorConfigResourceValues {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigResourceValues)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigServiceScrape) DeepCopyInto(out *OperatorConfigServiceScrape) {
+	*out = *in
+	if in.EnforceEndpointSlices != nil {
+		in, out := &in.EnforceEndpointSlices, &out.EnforceEndpointSlices
 		*out = new(bool)
 		**out = **in
 	}
-	if in.UserKey != nil {
-		in, out := &in.UserKey, &out.UserKey
-		*out = new(v1.SecretKeySelector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigServiceScrape.
+func (in *OperatorConfigServiceScrape) DeepCopy() *OperatorConfigServiceScrape {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorConfigServiceScrape)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorConfigVMCluster) DeepCopyInto(out *OperatorConfigVMCluster) {
+	*out = *in
+	if in.UseDefaultResources != nil {
+		in, out := &in.UseDefaultResources, &out.UseDefaultResources
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Select != nil {
+		in, out := &in.Select, &out.Select
+		*out = new(OperatorConfigComponent)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Token != nil {
-		in, out := &in.Token, &out.Token
-		*out = new(v1.SecretKeySelector)
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(OperatorConfigVMStorage)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.HTTPConfig != nil {
-		in, out := &in.HTTPConfig, &out.HTTPConfig
-		*out = new(HTTPConfig)
+	if in.Insert != nil {
+		in, out := &in.Insert, &out.Insert
+		*out = new(OperatorConfigComponent)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushoverConfig.
-func (in *PushoverConfig) DeepCopy() *PushoverConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigVMCluster.
+func (in *OperatorConfigVMCluster) DeepCopy() *OperatorConfigVMCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(PushoverConfig)
+	out := new(OperatorConfigVMCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *QueryArg) DeepCopyInto(out *QueryArg) {
+func (in *OperatorConfigVMStorage) DeepCopyInto(out *OperatorConfigVMStorage) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	in.OperatorConfigComponent.DeepCopyInto(&out.OperatorConfigComponent)
+	if in.VMInsertPort != nil {
+		in, out := &in.VMInsertPort, &out.VMInsertPort
+		*out = new(string)
+		**out = **in
+	}
+	if in.VMSelectPort != nil {
+		in, out := &in.VMSelectPort, &out.VMSelectPort
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryArg.
-func (in *QueryArg) DeepCopy() *QueryArg {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorConfigVMStorage.
+func (in *OperatorConfigVMStorage) DeepCopy() *OperatorConfigVMStorage {
 	if in == nil {
 		return nil
 	}
-	out := new(QueryArg)
+	out := new(OperatorConfigVMStorage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Receiver) DeepCopyInto(out *Receiver) {
+func (in *OpsGenieConfig) DeepCopyInto(out *OpsGenieConfig) {
 	*out = *in
-	if in.EmailConfigs != nil {
-		in, out := &in.EmailConfigs, &out.EmailConfigs
-		*out = make([]EmailConfig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.PagerDutyConfigs != nil {
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Details != nil {
+		in, out := &in.Details, &out.Details
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Responders != nil {
+		in, out := &in.Responders, &out.Responders
+		*out = make([]OpsGenieConfigResponder, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(HTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsGenieConfig.
+func (in *OpsGenieConfig) DeepCopy() *OpsGenieConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsGenieConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpsGenieConfigResponder) DeepCopyInto(out *OpsGenieConfigResponder) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpsGenieConfigResponder.
+func (in *OpsGenieConfigResponder) DeepCopy() *OpsGenieConfigResponder {
+	if in == nil {
+		return nil
+	}
+	out := new(OpsGenieConfigResponder)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PagerDutyConfig) DeepCopyInto(out *PagerDutyConfig) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RoutingKey != nil {
+		in, out := &in.RoutingKey, &out.RoutingKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceKey != nil {
+		in, out := &in.ServiceKey, &out.ServiceKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]ImageConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.Links != nil {
+		in, out := &in.Links, &out.Links
+		*out = make([]LinkConfig, len(*in))
+		copy(*out, *in)
+	}
+	if in.Details != nil {
+		in, out := &in.Details, &out.Details
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(HTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PagerDutyConfig.
+func (in *PagerDutyConfig) DeepCopy() *PagerDutyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PagerDutyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingChanges) DeepCopyInto(out *PendingChanges) {
+	*out = *in
+	if in.NextWindow != nil {
+		in, out := &in.NextWindow, &out.NextWindow
+		*out = (*in).DeepCopy()
+	}
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]PlannedChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingChanges.
+func (in *PendingChanges) DeepCopy() *PendingChanges {
+	if in == nil {
+		return nil
+	}
+	out := new(PendingChanges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlannedChange) DeepCopyInto(out *PlannedChange) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlannedChange.
+func (in *PlannedChange) DeepCopy() *PlannedChange {
+	if in == nil {
+		return nil
+	}
+	out := new(PlannedChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMetricsEndpoint) DeepCopyInto(out *PodMetricsEndpoint) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(string)
+		**out = **in
+	}
+	if in.PortNumber != nil {
+		in, out := &in.PortNumber, &out.PortNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetPort != nil {
+		in, out := &in.TargetPort, &out.TargetPort
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	in.EndpointRelabelings.DeepCopyInto(&out.EndpointRelabelings)
+	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
+	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
+	in.AttachMetadata.DeepCopyInto(&out.AttachMetadata)
+	if in.FilterRunning != nil {
+		in, out := &in.FilterRunning, &out.FilterRunning
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetricsEndpoint.
+func (in *PodMetricsEndpoint) DeepCopy() *PodMetricsEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMetricsEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyAuth) DeepCopyInto(out *ProxyAuth) {
+	*out = *in
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyAuth.
+func (in *ProxyAuth) DeepCopy() *ProxyAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PushoverConfig) DeepCopyInto(out *PushoverConfig) {
+	*out = *in
+	if in.SendResolved != nil {
+		in, out := &in.SendResolved, &out.SendResolved
+		*out = new(bool)
+		**out = **in
+	}
+	if in.UserKey != nil {
+		in, out := &in.UserKey, &out.UserKey
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HTTPConfig != nil {
+		in, out := &in.HTTPConfig, &out.HTTPConfig
+		*out = new(HTTPConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PushoverConfig.
+func (in *PushoverConfig) DeepCopy() *PushoverConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PushoverConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueryArg) DeepCopyInto(out *QueryArg) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueryArg.
+func (in *QueryArg) DeepCopy() *QueryArg {
+	if in == nil {
+		return nil
+	}
+	out := new(QueryArg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Receiver) DeepCopyInto(out *Receiver) {
+	*out = *in
+	if in.EmailConfigs != nil {
+		in, out := &in.EmailConfigs, &out.EmailConfigs
+		*out = make([]EmailConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PagerDutyConfigs != nil {
 		in, out := &in.PagerDutyConfigs, &out.PagerDutyConfigs
 		*out = make([]PagerDutyConfig, len(*in))
 		for i := range *in {
@@ -2782,6 +3189,28 @@ func (in *Receiver) DeepCopy() *Receiver {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcilePlan) DeepCopyInto(out *ReconcilePlan) {
+	*out = *in
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]PlannedChange, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcilePlan.
+func (in *ReconcilePlan) DeepCopy() *ReconcilePlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcilePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RelabelConfig) DeepCopyInto(out *RelabelConfig) {
 	*out = *in
@@ -4406,6 +4835,11 @@ func (in *VMAgentSpec) DeepCopyInto(out *VMAgentSpec) {
 		*out = new(appsv1.RollingUpdateDeployment)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.PodDisruptionBudget != nil {
 		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
 		*out = new(EmbeddedPodDisruptionBudgetSpec)
@@ -4464,6 +4898,11 @@ func (in *VMAgentStatus) DeepCopyInto(out *VMAgentStatus) {
 		*out = new(VMAgentSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAgentStatus.
@@ -4506,6 +4945,11 @@ func (in *VMAlert) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertDatasourceSpec) DeepCopyInto(out *VMAlertDatasourceSpec) {
 	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(DatasourceRef)
+		**out = **in
+	}
 	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
 }
 
@@ -4551,6 +4995,23 @@ func (in *VMAlertList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertNamedDatasource) DeepCopyInto(out *VMAlertNamedDatasource) {
+	*out = *in
+	in.Resources.DeepCopyInto(&out.Resources)
+	in.VMAlertDatasourceSpec.DeepCopyInto(&out.VMAlertDatasourceSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertNamedDatasource.
+func (in *VMAlertNamedDatasource) DeepCopy() *VMAlertNamedDatasource {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertNamedDatasource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertNotifierSpec) DeepCopyInto(out *VMAlertNotifierSpec) {
 	*out = *in
@@ -4559,6 +5020,11 @@ func (in *VMAlertNotifierSpec) DeepCopyInto(out *VMAlertNotifierSpec) {
 		*out = new(DiscoverySelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(DatasourceRef)
+		**out = **in
+	}
 	in.HTTPAuth.DeepCopyInto(&out.HTTPAuth)
 }
 
@@ -4575,6 +5041,11 @@ func (in *VMAlertNotifierSpec) DeepCopy() *VMAlertNotifierSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertRemoteReadSpec) DeepCopyInto(out *VMAlertRemoteReadSpec) {
 	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(DatasourceRef)
+		**out = **in
+	}
 	if in.Lookback != nil {
 		in, out := &in.Lookback, &out.Lookback
 		*out = new(string)
@@ -4596,6 +5067,11 @@ func (in *VMAlertRemoteReadSpec) DeepCopy() *VMAlertRemoteReadSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertRemoteWriteSpec) DeepCopyInto(out *VMAlertRemoteWriteSpec) {
 	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(DatasourceRef)
+		**out = **in
+	}
 	if in.Concurrency != nil {
 		in, out := &in.Concurrency, &out.Concurrency
 		*out = new(int32)
@@ -4685,6 +5161,13 @@ func (in *VMAlertSpec) DeepCopyInto(out *VMAlertSpec) {
 		copy(*out, *in)
 	}
 	in.Datasource.DeepCopyInto(&out.Datasource)
+	if in.Datasources != nil {
+		in, out := &in.Datasources, &out.Datasources
+		*out = make([]VMAlertNamedDatasource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ExternalLabels != nil {
 		in, out := &in.ExternalLabels, &out.ExternalLabels
 		*out = make(map[string]string, len(*in))
@@ -4790,6 +5273,94 @@ func (in *VMAlertmanager) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerAPIClient) DeepCopyInto(out *VMAlertmanagerAPIClient) {
+	*out = *in
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(Authorization)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerAPIClient.
+func (in *VMAlertmanagerAPIClient) DeepCopy() *VMAlertmanagerAPIClient {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerAPIClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerClusterReceiver) DeepCopyInto(out *VMAlertmanagerClusterReceiver) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerClusterReceiver.
+func (in *VMAlertmanagerClusterReceiver) DeepCopy() *VMAlertmanagerClusterReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerClusterReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerClusterReceiver) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerClusterReceiverList) DeepCopyInto(out *VMAlertmanagerClusterReceiverList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAlertmanagerClusterReceiver, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerClusterReceiverList.
+func (in *VMAlertmanagerClusterReceiverList) DeepCopy() *VMAlertmanagerClusterReceiverList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerClusterReceiverList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerClusterReceiverList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertmanagerConfig) DeepCopyInto(out *VMAlertmanagerConfig) {
 	*out = *in
@@ -4817,6 +5388,28 @@ func (in *VMAlertmanagerConfig) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerConfigInhibitionTest) DeepCopyInto(out *VMAlertmanagerConfigInhibitionTest) {
+	*out = *in
+	if in.SourceLabels != nil {
+		in, out := &in.SourceLabels, &out.SourceLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigInhibitionTest.
+func (in *VMAlertmanagerConfigInhibitionTest) DeepCopy() *VMAlertmanagerConfigInhibitionTest {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerConfigInhibitionTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertmanagerConfigList) DeepCopyInto(out *VMAlertmanagerConfigList) {
 	*out = *in
@@ -4864,6 +5457,11 @@ func (in *VMAlertmanagerConfigSpec) DeepCopyInto(out *VMAlertmanagerConfigSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ReceiverRefs != nil {
+		in, out := &in.ReceiverRefs, &out.ReceiverRefs
+		*out = make([]VMAlertmanagerReceiverRef, len(*in))
+		copy(*out, *in)
+	}
 	if in.InhibitRules != nil {
 		in, out := &in.InhibitRules, &out.InhibitRules
 		*out = make([]InhibitRule, len(*in))
@@ -4878,6 +5476,13 @@ func (in *VMAlertmanagerConfigSpec) DeepCopyInto(out *VMAlertmanagerConfigSpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]VMAlertmanagerConfigTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigSpec.
@@ -4894,6 +5499,13 @@ func (in *VMAlertmanagerConfigSpec) DeepCopy() *VMAlertmanagerConfigSpec {
 func (in *VMAlertmanagerConfigStatus) DeepCopyInto(out *VMAlertmanagerConfigStatus) {
 	*out = *in
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]VMAlertmanagerConfigTestResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigStatus.
@@ -4906,6 +5518,60 @@ func (in *VMAlertmanagerConfigStatus) DeepCopy() *VMAlertmanagerConfigStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerConfigTest) DeepCopyInto(out *VMAlertmanagerConfigTest) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Inhibitions != nil {
+		in, out := &in.Inhibitions, &out.Inhibitions
+		*out = make([]VMAlertmanagerConfigInhibitionTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigTest.
+func (in *VMAlertmanagerConfigTest) DeepCopy() *VMAlertmanagerConfigTest {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerConfigTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerConfigTestResult) DeepCopyInto(out *VMAlertmanagerConfigTestResult) {
+	*out = *in
+	if in.Receivers != nil {
+		in, out := &in.Receivers, &out.Receivers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerConfigTestResult.
+func (in *VMAlertmanagerConfigTestResult) DeepCopy() *VMAlertmanagerConfigTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerConfigTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertmanagerGossipConfig) DeepCopyInto(out *VMAlertmanagerGossipConfig) {
 	*out = *in
@@ -4954,37 +5620,156 @@ func (in *VMAlertmanagerHTTPConfig) DeepCopy() *VMAlertmanagerHTTPConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMAlertmanagerList) DeepCopyInto(out *VMAlertmanagerList) {
+func (in *VMAlertmanagerList) DeepCopyInto(out *VMAlertmanagerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMAlertmanager, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerList.
+func (in *VMAlertmanagerList) DeepCopy() *VMAlertmanagerList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerPeerRef) DeepCopyInto(out *VMAlertmanagerPeerRef) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerPeerRef.
+func (in *VMAlertmanagerPeerRef) DeepCopy() *VMAlertmanagerPeerRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerPeerRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerReceiver) DeepCopyInto(out *VMAlertmanagerReceiver) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerReceiver.
+func (in *VMAlertmanagerReceiver) DeepCopy() *VMAlertmanagerReceiver {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerReceiver)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMAlertmanagerReceiver) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerReceiverList) DeepCopyInto(out *VMAlertmanagerReceiverList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VMAlertmanager, len(*in))
+		*out = make([]VMAlertmanagerReceiver, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerList.
-func (in *VMAlertmanagerList) DeepCopy() *VMAlertmanagerList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerReceiverList.
+func (in *VMAlertmanagerReceiverList) DeepCopy() *VMAlertmanagerReceiverList {
 	if in == nil {
 		return nil
 	}
-	out := new(VMAlertmanagerList)
+	out := new(VMAlertmanagerReceiverList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMAlertmanagerList) DeepCopyObject() runtime.Object {
+func (in *VMAlertmanagerReceiverList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerReceiverRef) DeepCopyInto(out *VMAlertmanagerReceiverRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerReceiverRef.
+func (in *VMAlertmanagerReceiverRef) DeepCopy() *VMAlertmanagerReceiverRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerReceiverRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMAlertmanagerReceiverSpec) DeepCopyInto(out *VMAlertmanagerReceiverSpec) {
+	*out = *in
+	in.Receiver.DeepCopyInto(&out.Receiver)
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedNamespaceSelector != nil {
+		in, out := &in.AllowedNamespaceSelector, &out.AllowedNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMAlertmanagerReceiverSpec.
+func (in *VMAlertmanagerReceiverSpec) DeepCopy() *VMAlertmanagerReceiverSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMAlertmanagerReceiverSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
 	*out = *in
@@ -5018,6 +5803,13 @@ func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PeerRefs != nil {
+		in, out := &in.PeerRefs, &out.PeerRefs
+		*out = make([]VMAlertmanagerPeerRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.ServiceSpec != nil {
 		in, out := &in.ServiceSpec, &out.ServiceSpec
 		*out = new(AdditionalServiceSpec)
@@ -5048,6 +5840,21 @@ func (in *VMAlertmanagerSpec) DeepCopyInto(out *VMAlertmanagerSpec) {
 		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SilenceSelector != nil {
+		in, out := &in.SilenceSelector, &out.SilenceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SilenceNamespaceSelector != nil {
+		in, out := &in.SilenceNamespaceSelector, &out.SilenceNamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SilencesAPIClient != nil {
+		in, out := &in.SilencesAPIClient, &out.SilencesAPIClient
+		*out = new(VMAlertmanagerAPIClient)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnforcedTopRouteMatchers != nil {
 		in, out := &in.EnforcedTopRouteMatchers, &out.EnforcedTopRouteMatchers
 		*out = make([]string, len(*in))
@@ -5660,6 +6467,11 @@ func (in *VMClusterSpec) DeepCopyInto(out *VMClusterSpec) {
 		*out = new(VMStorage)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.UseStrictSecurity != nil {
 		in, out := &in.UseStrictSecurity, &out.UseStrictSecurity
 		*out = new(bool)
@@ -5692,6 +6504,16 @@ func (in *VMClusterStatus) DeepCopyInto(out *VMClusterStatus) {
 		*out = new(VMClusterSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = new(ReconcilePlan)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PendingChanges != nil {
+		in, out := &in.PendingChanges, &out.PendingChanges
+		*out = new(PendingChanges)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMClusterStatus.
@@ -5795,66 +6617,338 @@ func (in *VMNodeScrape) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
-	return nil
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMNodeScrapeList) DeepCopyInto(out *VMNodeScrapeList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]VMNodeScrape, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMNodeScrapeList) DeepCopyInto(out *VMNodeScrapeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMNodeScrape, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrapeList.
+func (in *VMNodeScrapeList) DeepCopy() *VMNodeScrapeList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMNodeScrapeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMNodeScrapeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMNodeScrapeSpec) DeepCopyInto(out *VMNodeScrapeSpec) {
+	*out = *in
+	if in.TargetLabels != nil {
+		in, out := &in.TargetLabels, &out.TargetLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.EndpointRelabelings.DeepCopyInto(&out.EndpointRelabelings)
+	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
+	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.ScrapeClassName != nil {
+		in, out := &in.ScrapeClassName, &out.ScrapeClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrapeSpec.
+func (in *VMNodeScrapeSpec) DeepCopy() *VMNodeScrapeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMNodeScrapeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMOperatorConfig) DeepCopyInto(out *VMOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMOperatorConfig.
+func (in *VMOperatorConfig) DeepCopy() *VMOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(VMOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMOperatorConfigList) DeepCopyInto(out *VMOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMOperatorConfigList.
+func (in *VMOperatorConfigList) DeepCopy() *VMOperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMOperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMOperatorConfigSpec) DeepCopyInto(out *VMOperatorConfigSpec) {
+	*out = *in
+	if in.MetricsVersion != nil {
+		in, out := &in.MetricsVersion, &out.MetricsVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogsVersion != nil {
+		in, out := &in.LogsVersion, &out.LogsVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.AnomalyVersion != nil {
+		in, out := &in.AnomalyVersion, &out.AnomalyVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.TracesVersion != nil {
+		in, out := &in.TracesVersion, &out.TracesVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.OperatorVersion != nil {
+		in, out := &in.OperatorVersion, &out.OperatorVersion
+		*out = new(string)
+		**out = **in
+	}
+	if in.ContainerRegistry != nil {
+		in, out := &in.ContainerRegistry, &out.ContainerRegistry
+		*out = new(string)
+		**out = **in
+	}
+	if in.EnableTCP6 != nil {
+		in, out := &in.EnableTCP6, &out.EnableTCP6
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfigReloader != nil {
+		in, out := &in.ConfigReloader, &out.ConfigReloader
+		*out = new(OperatorConfigReloader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VLogs != nil {
+		in, out := &in.VLogs, &out.VLogs
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VLAgent != nil {
+		in, out := &in.VLAgent, &out.VLAgent
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VLSingle != nil {
+		in, out := &in.VLSingle, &out.VLSingle
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VTSingle != nil {
+		in, out := &in.VTSingle, &out.VTSingle
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMAlert != nil {
+		in, out := &in.VMAlert, &out.VMAlert
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMServiceScrape != nil {
+		in, out := &in.VMServiceScrape, &out.VMServiceScrape
+		*out = new(OperatorConfigServiceScrape)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMAgent != nil {
+		in, out := &in.VMAgent, &out.VMAgent
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMAnomaly != nil {
+		in, out := &in.VMAnomaly, &out.VMAnomaly
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMSingle != nil {
+		in, out := &in.VMSingle, &out.VMSingle
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMCluster != nil {
+		in, out := &in.VMCluster, &out.VMCluster
+		*out = new(OperatorConfigVMCluster)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMAlertmanager != nil {
+		in, out := &in.VMAlertmanager, &out.VMAlertmanager
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisableSelfServiceScrapeCreation != nil {
+		in, out := &in.DisableSelfServiceScrapeCreation, &out.DisableSelfServiceScrapeCreation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VMBackup != nil {
+		in, out := &in.VMBackup, &out.VMBackup
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VMAuth != nil {
+		in, out := &in.VMAuth, &out.VMAuth
+		*out = new(OperatorConfigApp)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VLCluster != nil {
+		in, out := &in.VLCluster, &out.VLCluster
+		*out = new(OperatorConfigCluster)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VTCluster != nil {
+		in, out := &in.VTCluster, &out.VTCluster
+		*out = new(OperatorConfigCluster)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EnabledPrometheusConverter != nil {
+		in, out := &in.EnabledPrometheusConverter, &out.EnabledPrometheusConverter
+		*out = new(OperatorConfigPrometheusConverter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusConverterAddArgoCDIgnoreAnnotations != nil {
+		in, out := &in.PrometheusConverterAddArgoCDIgnoreAnnotations, &out.PrometheusConverterAddArgoCDIgnoreAnnotations
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnabledPrometheusConverterOwnerReferences != nil {
+		in, out := &in.EnabledPrometheusConverterOwnerReferences, &out.EnabledPrometheusConverterOwnerReferences
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FilterPrometheusConverterLabelPrefixes != nil {
+		in, out := &in.FilterPrometheusConverterLabelPrefixes, &out.FilterPrometheusConverterLabelPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FilterPrometheusConverterAnnotationPrefixes != nil {
+		in, out := &in.FilterPrometheusConverterAnnotationPrefixes, &out.FilterPrometheusConverterAnnotationPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterDomainName != nil {
+		in, out := &in.ClusterDomainName, &out.ClusterDomainName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ForceResyncInterval != nil {
+		in, out := &in.ForceResyncInterval, &out.ForceResyncInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.EnableVMAlertRulesHealth != nil {
+		in, out := &in.EnableVMAlertRulesHealth, &out.EnableVMAlertRulesHealth
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableServerSideApply != nil {
+		in, out := &in.EnableServerSideApply, &out.EnableServerSideApply
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaintenanceWindow != nil {
+		in, out := &in.MaintenanceWindow, &out.MaintenanceWindow
+		*out = new(MaintenanceWindow)
+		**out = **in
+	}
+	if in.EnableStrictSecurity != nil {
+		in, out := &in.EnableStrictSecurity, &out.EnableStrictSecurity
+		*out = new(bool)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrapeList.
-func (in *VMNodeScrapeList) DeepCopy() *VMNodeScrapeList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMOperatorConfigSpec.
+func (in *VMOperatorConfigSpec) DeepCopy() *VMOperatorConfigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VMNodeScrapeList)
+	out := new(VMOperatorConfigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VMNodeScrapeList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VMNodeScrapeSpec) DeepCopyInto(out *VMNodeScrapeSpec) {
+func (in *VMOperatorConfigStatus) DeepCopyInto(out *VMOperatorConfigStatus) {
 	*out = *in
-	if in.TargetLabels != nil {
-		in, out := &in.TargetLabels, &out.TargetLabels
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	in.EndpointRelabelings.DeepCopyInto(&out.EndpointRelabelings)
-	in.EndpointAuth.DeepCopyInto(&out.EndpointAuth)
-	in.EndpointScrapeParams.DeepCopyInto(&out.EndpointScrapeParams)
-	in.Selector.DeepCopyInto(&out.Selector)
-	if in.ScrapeClassName != nil {
-		in, out := &in.ScrapeClassName, &out.ScrapeClassName
-		*out = new(string)
-		**out = **in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+	if in.EffectiveConfig != nil {
+		in, out := &in.EffectiveConfig, &out.EffectiveConfig
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMNodeScrapeSpec.
-func (in *VMNodeScrapeSpec) DeepCopy() *VMNodeScrapeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMOperatorConfigStatus.
+func (in *VMOperatorConfigStatus) DeepCopy() *VMOperatorConfigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VMNodeScrapeSpec)
+	out := new(VMOperatorConfigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -6231,6 +7325,25 @@ func (in *VMRule) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMRuleGroupStatus) DeepCopyInto(out *VMRuleGroupStatus) {
+	*out = *in
+	if in.LastEvaluation != nil {
+		in, out := &in.LastEvaluation, &out.LastEvaluation
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleGroupStatus.
+func (in *VMRuleGroupStatus) DeepCopy() *VMRuleGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMRuleGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMRuleList) DeepCopyInto(out *VMRuleList) {
 	*out = *in
@@ -6289,6 +7402,13 @@ func (in *VMRuleSpec) DeepCopy() *VMRuleSpec {
 func (in *VMRuleStatus) DeepCopyInto(out *VMRuleStatus) {
 	*out = *in
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]VMRuleGroupStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMRuleStatus.
@@ -6695,6 +7815,146 @@ func (in *VMServiceScrapeSpec) DeepCopy() *VMServiceScrapeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSilence) DeepCopyInto(out *VMSilence) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSilence.
+func (in *VMSilence) DeepCopy() *VMSilence {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSilence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMSilence) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSilenceList) DeepCopyInto(out *VMSilenceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VMSilence, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSilenceList.
+func (in *VMSilenceList) DeepCopy() *VMSilenceList {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSilenceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VMSilenceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSilenceSpec) DeepCopyInto(out *VMSilenceSpec) {
+	*out = *in
+	if in.Matchers != nil {
+		in, out := &in.Matchers, &out.Matchers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartsAt != nil {
+		in, out := &in.StartsAt, &out.StartsAt
+		*out = (*in).DeepCopy()
+	}
+	if in.EndsAt != nil {
+		in, out := &in.EndsAt, &out.EndsAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = make([]TimeInterval, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSilenceSpec.
+func (in *VMSilenceSpec) DeepCopy() *VMSilenceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSilenceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSilenceState) DeepCopyInto(out *VMSilenceState) {
+	*out = *in
+	if in.StartsAt != nil {
+		in, out := &in.StartsAt, &out.StartsAt
+		*out = (*in).DeepCopy()
+	}
+	if in.EndsAt != nil {
+		in, out := &in.EndsAt, &out.EndsAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSilenceState.
+func (in *VMSilenceState) DeepCopy() *VMSilenceState {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSilenceState)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMSilenceStatus) DeepCopyInto(out *VMSilenceStatus) {
+	*out = *in
+	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+	if in.Silences != nil {
+		in, out := &in.Silences, &out.Silences
+		*out = make([]VMSilenceState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMSilenceStatus.
+func (in *VMSilenceStatus) DeepCopy() *VMSilenceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VMSilenceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMSingle) DeepCopyInto(out *VMSingle) {
 	*out = *in
@@ -7096,6 +8356,11 @@ func (in *VMUserConfigOptions) DeepCopyInto(out *VMUserConfigOptions) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(VMUserRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.LoadBalancingPolicy != nil {
 		in, out := &in.LoadBalancingPolicy, &out.LoadBalancingPolicy
 		*out = new(string)
@@ -7180,6 +8445,110 @@ func (in *VMUserList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserPathRateLimit) DeepCopyInto(out *VMUserPathRateLimit) {
+	*out = *in
+	if in.SrcPaths != nil {
+		in, out := &in.SrcPaths, &out.SrcPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequestsPerSecond != nil {
+		in, out := &in.RequestsPerSecond, &out.RequestsPerSecond
+		*out = new(int)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserPathRateLimit.
+func (in *VMUserPathRateLimit) DeepCopy() *VMUserPathRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserPathRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserRateLimit) DeepCopyInto(out *VMUserRateLimit) {
+	*out = *in
+	if in.RequestsPerSecond != nil {
+		in, out := &in.RequestsPerSecond, &out.RequestsPerSecond
+		*out = new(int)
+		**out = **in
+	}
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int)
+		**out = **in
+	}
+	if in.Paths != nil {
+		in, out := &in.Paths, &out.Paths
+		*out = make([]VMUserPathRateLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserRateLimit.
+func (in *VMUserRateLimit) DeepCopy() *VMUserRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserRoutingTest) DeepCopyInto(out *VMUserRoutingTest) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserRoutingTest.
+func (in *VMUserRoutingTest) DeepCopy() *VMUserRoutingTest {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserRoutingTest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VMUserRoutingTestResult) DeepCopyInto(out *VMUserRoutingTestResult) {
+	*out = *in
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserRoutingTestResult.
+func (in *VMUserRoutingTestResult) DeepCopy() *VMUserRoutingTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(VMUserRoutingTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VMUserSpec) DeepCopyInto(out *VMUserSpec) {
 	*out = *in
@@ -7233,6 +8602,13 @@ func (in *VMUserSpec) DeepCopyInto(out *VMUserSpec) {
 		*out = new(ManagedObjectsMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]VMUserRoutingTest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserSpec.
@@ -7249,6 +8625,13 @@ func (in *VMUserSpec) DeepCopy() *VMUserSpec {
 func (in *VMUserStatus) DeepCopyInto(out *VMUserStatus) {
 	*out = *in
 	in.StatusMetadata.DeepCopyInto(&out.StatusMetadata)
+	if in.Tests != nil {
+		in, out := &in.Tests, &out.Tests
+		*out = make([]VMUserRoutingTestResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VMUserStatus.
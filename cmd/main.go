@@ -18,12 +18,14 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 
 	"github.com/VictoriaMetrics/operator/internal/manager"
+	"github.com/VictoriaMetrics/operator/internal/render"
 )
 
 var (
@@ -38,6 +40,14 @@ func main() {
 		cancel()
 	}()
 
+	if len(os.Args) > 1 && os.Args[1] == render.Command {
+		if err := render.Run(ctx, os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "cannot render objects: %s\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	err := manager.RunManager(ctx)
 	if err != nil {
 		setupLog.Error(err, "cannot setup manager")
@@ -9,13 +9,17 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/buildinfo"
 	"github.com/caarlos0/env/v11"
 	"github.com/prometheus/client_golang/prometheus"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/VictoriaMetrics/operator/api/cron"
 )
 
 const (
@@ -31,7 +35,7 @@ func getVersion(defaultVersion string) string {
 }
 
 var (
-	opConf   *BaseOperatorConf
+	opConf   atomic.Pointer[BaseOperatorConf]
 	initConf sync.Once
 
 	defaultEnvs = map[string]string{
@@ -108,6 +112,11 @@ type BaseOperatorConf struct {
 	// Operator don't perform any cluster wide API calls if namespaces not empty.
 	// In case of empty list it performs only clusterwide api calls.
 	WatchNamespaces []string `default:"" env:"WATCH_NAMESPACE"`
+	// Defines a label selector for namespaces to be watched by operator, e.g. team=platform.
+	// Operator starts and stops watching namespaces matching selector at runtime
+	// and don't perform any cluster wide API calls except for namespaces.
+	// Cannot be used together with WATCH_NAMESPACE.
+	WatchNamespaceSelector string `default:"" env:"WATCH_NAMESPACE_SELECTOR"`
 
 	// container registry name prefix, e.g. docker.io
 	ContainerRegistry string `default:"" env:"VM_CONTAINERREGISTRY"`
@@ -529,6 +538,12 @@ type BaseOperatorConf struct {
 		Probe              bool `default:"true" env:"PROBE"`
 		AlertmanagerConfig bool `default:"true" env:"ALERTMANAGERCONFIG"`
 		ScrapeConfig       bool `default:"true" env:"SCRAPECONFIG"`
+		// converts Prometheus into VMAgent and VMSingle, disabled by default
+		Prometheus bool `default:"false" env:"PROMETHEUS"`
+		// converts PrometheusAgent into VMAgent, disabled by default
+		PrometheusAgent bool `default:"false" env:"PROMETHEUSAGENT"`
+		// converts Alertmanager into VMAlertmanager, disabled by default
+		Alertmanager bool `default:"false" env:"ALERTMANAGER"`
 	} `prefix:"VM_ENABLEDPROMETHEUSCONVERTER_"`
 	// adds compare-options and sync-options for prometheus objects converted by operator.
 	// It helps to properly use converter with ArgoCD
@@ -553,6 +568,24 @@ type BaseOperatorConf struct {
 	PodWaitReadyIntervalCheck time.Duration `default:"5s" env:"VM_PODWAITREADYINTERVALCHECK"`
 	// configures force resync interval for VMAgent, VMAlert, VMAlertmanager and VMAuth.
 	ForceResyncInterval time.Duration `default:"60s" env:"VM_FORCERESYNCINTERVAL"`
+	// fetches state of rule groups from vmalert pods on each VMAlert resync
+	// and reports evaluation health of groups at VMRule status
+	EnableVMAlertRulesHealth bool `default:"false" env:"VM_ENABLEVMALERTRULESHEALTH"`
+	// applies Deployments, StatefulSets, Services, ConfigMaps and Secrets with server-side apply
+	// using dedicated field manager. Fields owned by other managers are preserved
+	// and conflicts are reported at status of custom resource
+	EnableServerSideApply bool `default:"false" env:"VM_ENABLESERVERSIDEAPPLY"`
+	// default maintenance window for VMCluster and VMAgent without spec.maintenanceWindows
+	MaintenanceWindow struct {
+		// Cron schedule of default maintenance window start for VMCluster and VMAgent, e.g. "0 2 * * 6".
+		// Disruptive changes of child objects, like pod template or storage changes, are deferred until the window starts.
+		// Changes are applied immediately if schedule is empty
+		Schedule string `default:"" env:"SCHEDULE"`
+		// Duration of default maintenance window
+		Duration string `default:"1h" env:"DURATION"`
+		// IANA time zone name of default maintenance window schedule
+		Timezone string `default:"UTC" env:"TIMEZONE"`
+	} `prefix:"VM_MAINTENANCEWINDOW_"`
 	// EnableStrictSecurity will add default `securityContext` to pods and containers created by operator
 	// Default PodSecurityContext include:
 	// 1. RunAsNonRoot: true
@@ -598,6 +631,25 @@ func (boc BaseOperatorConf) validate() error {
 			return fmt.Errorf("namespace=%q doesn't match regex=%q", ns, validNamespaceRegex.String())
 		}
 	}
+	if boc.WatchNamespaceSelector != "" {
+		if len(boc.WatchNamespaces) > 0 {
+			return fmt.Errorf("WATCH_NAMESPACE and WATCH_NAMESPACE_SELECTOR cannot be set at the same time")
+		}
+		if _, err := labels.Parse(boc.WatchNamespaceSelector); err != nil {
+			return fmt.Errorf("cannot parse WATCH_NAMESPACE_SELECTOR=%q: %w", boc.WatchNamespaceSelector, err)
+		}
+	}
+	if boc.MaintenanceWindow.Schedule != "" {
+		if _, err := cron.Parse(boc.MaintenanceWindow.Schedule); err != nil {
+			return fmt.Errorf("cannot parse VM_MAINTENANCEWINDOW_SCHEDULE=%q: %w", boc.MaintenanceWindow.Schedule, err)
+		}
+		if _, err := time.ParseDuration(boc.MaintenanceWindow.Duration); err != nil {
+			return fmt.Errorf("cannot parse VM_MAINTENANCEWINDOW_DURATION=%q: %w", boc.MaintenanceWindow.Duration, err)
+		}
+		if _, err := time.LoadLocation(boc.MaintenanceWindow.Timezone); err != nil {
+			return fmt.Errorf("cannot load VM_MAINTENANCEWINDOW_TIMEZONE=%q: %w", boc.MaintenanceWindow.Timezone, err)
+		}
+	}
 	validateResource := func(name string, res Resource) error {
 		if res.Request.Mem != UnlimitedQuantity {
 			if _, err := resource.ParseQuantity(res.Request.Mem); err != nil {
@@ -694,22 +746,17 @@ func (boc BaseOperatorConf) validate() error {
 	return nil
 }
 
-// MustGetBaseConfig returns operator configuration with default values populated from env variables
+// MustGetBaseConfig returns operator configuration with default values populated from env variables.
+// Configuration could be changed at runtime with Reload, so callers must not cache returned value
 func MustGetBaseConfig() *BaseOperatorConf {
 	initConf.Do(func() {
-		c, err := env.ParseAsWithOptions[BaseOperatorConf](getEnvOpts())
+		c, err := ParseWithOverrides(nil)
 		if err != nil {
 			panic(err)
 		}
-		if c.CustomConfigReloaderImage != "" {
-			c.ConfigReloader.Image = c.CustomConfigReloaderImage
-		}
-		if err := c.validate(); err != nil {
-			panic(err)
-		}
-		opConf = &c
+		opConf.Store(c)
 	})
-	return opConf
+	return opConf.Load()
 }
 
 // GetLocalhost returns localhost value depending on global configuration
@@ -726,7 +773,7 @@ var validNamespaceRegex = regexp.MustCompile(`[a-z0-9]([-a-z0-9]*[a-z0-9])?`)
 // IsClusterWideAccessAllowed checks if cluster wide access for components is needed
 func IsClusterWideAccessAllowed() bool {
 	cfg := MustGetBaseConfig()
-	return len(cfg.WatchNamespaces) == 0
+	return len(cfg.WatchNamespaces) == 0 && cfg.WatchNamespaceSelector == ""
 }
 
 type Labels struct {
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"slices"
+	"sync"
+)
+
+// selectedNamespaces holds namespaces matched by WATCH_NAMESPACE_SELECTOR
+var selectedNamespaces = struct {
+	mu      sync.RWMutex
+	names   []string
+	changed chan struct{}
+}{
+	changed: make(chan struct{}),
+}
+
+// SetSelectedNamespaces updates namespaces matched by WATCH_NAMESPACE_SELECTOR
+// and notifies subscribers of WatchNamespacesChanged
+func SetSelectedNamespaces(names []string) {
+	names = slices.Clone(names)
+	slices.Sort(names)
+	selectedNamespaces.mu.Lock()
+	defer selectedNamespaces.mu.Unlock()
+	if slices.Equal(selectedNamespaces.names, names) {
+		return
+	}
+	selectedNamespaces.names = names
+	close(selectedNamespaces.changed)
+	selectedNamespaces.changed = make(chan struct{})
+}
+
+// WatchNamespacesChanged returns a channel, which is closed on the next change of watched namespaces.
+// Namespaces could change only with WATCH_NAMESPACE_SELECTOR
+func WatchNamespacesChanged() <-chan struct{} {
+	selectedNamespaces.mu.RLock()
+	defer selectedNamespaces.mu.RUnlock()
+	return selectedNamespaces.changed
+}
+
+// GetWatchNamespaces returns namespaces watched by operator.
+// Empty list means cluster wide access, if IsClusterWideAccessAllowed
+func (boc *BaseOperatorConf) GetWatchNamespaces() []string {
+	if boc.WatchNamespaceSelector == "" {
+		return boc.WatchNamespaces
+	}
+	selectedNamespaces.mu.RLock()
+	defer selectedNamespaces.mu.RUnlock()
+	return slices.Clone(selectedNamespaces.names)
+}
+
+// IsWatchedNamespace checks if operator watches objects at the given namespace
+func (boc *BaseOperatorConf) IsWatchedNamespace(namespace string) bool {
+	if boc.WatchNamespaceSelector == "" && len(boc.WatchNamespaces) == 0 {
+		return true
+	}
+	return slices.Contains(boc.GetWatchNamespaces(), namespace)
+}
@@ -0,0 +1,234 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/caarlos0/env/v11"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// startupOnlyVariables cannot be changed at runtime,
+// since they're used for operator initialization
+var startupOnlyVariables = []string{
+	"WATCH_NAMESPACE",
+	"WATCH_NAMESPACE_SELECTOR",
+	"VM_APPREADYTIMEOUT",
+	"VM_PODWAITREADYTIMEOUT",
+	"VM_PODWAITREADYINTERVALCHECK",
+}
+
+func isStartupOnlyVariable(key string) bool {
+	return slices.Contains(startupOnlyVariables, key)
+}
+
+// fieldAliases maps names of override fields to names of BaseOperatorConf fields
+var fieldAliases = map[string]string{
+	"TracesVersion": "TracesVertsion",
+	"CPU":           "Cpu",
+}
+
+var durationType = reflect.TypeFor[metav1.Duration]()
+
+// OverridesFromSpec converts typed configuration overrides into env variables overrides.
+// Fields of spec must mirror fields of BaseOperatorConf by name,
+// nil pointers and empty values are not overridden
+func OverridesFromSpec(spec any) (map[string]string, error) {
+	v := reflect.Indirect(reflect.ValueOf(spec))
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("BUG: unexpected type=%T of overrides, want struct", spec)
+	}
+	overrides := make(map[string]string)
+	if err := collectOverrides(v, reflect.TypeFor[BaseOperatorConf](), "", overrides); err != nil {
+		return nil, err
+	}
+	return overrides, nil
+}
+
+func collectOverrides(src reflect.Value, dstType reflect.Type, prefix string, overrides map[string]string) error {
+	for i := range src.NumField() {
+		sf := src.Type().Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		v := src.Field(i)
+		isPointer := v.Kind() == reflect.Pointer
+		if isPointer {
+			if v.IsNil() {
+				continue
+			}
+			v = v.Elem()
+		}
+		if sf.Anonymous {
+			if err := collectOverrides(v, dstType, prefix, overrides); err != nil {
+				return err
+			}
+			continue
+		}
+		name := sf.Name
+		if alias, ok := fieldAliases[name]; ok {
+			name = alias
+		}
+		df, ok := dstType.FieldByName(name)
+		if !ok {
+			return fmt.Errorf("BUG: field=%s has no matching field at operator configuration", sf.Name)
+		}
+		if v.Kind() == reflect.Struct && v.Type() != durationType {
+			if err := collectOverrides(v, df.Type, prefix+df.Tag.Get("prefix"), overrides); err != nil {
+				return err
+			}
+			continue
+		}
+		if !isPointer && v.IsZero() {
+			continue
+		}
+		key, _, _ := strings.Cut(df.Tag.Get("env"), ",")
+		if key == "" {
+			key = toEnvName(df.Name)
+		}
+		var value string
+		switch {
+		case v.Type() == durationType:
+			value = v.Interface().(metav1.Duration).Duration.String()
+		case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.String:
+			value = strings.Join(v.Interface().([]string), ",")
+		case v.Kind() == reflect.String, v.Kind() == reflect.Bool:
+			value = fmt.Sprint(v.Interface())
+		default:
+			return fmt.Errorf("BUG: unsupported type=%s of field=%s", v.Type(), sf.Name)
+		}
+		overrides[prefix+key] = value
+	}
+	return nil
+}
+
+// toEnvName converts field name into env variable name the same way as env.Parse does, e.g. EphemeralStorage into EPHEMERAL_STORAGE
+func toEnvName(input string) string {
+	var output []rune
+	for i, c := range input {
+		if c == '_' {
+			continue
+		}
+		if len(output) > 0 && unicode.IsUpper(c) {
+			if len(input) > i+1 {
+				peek := rune(input[i+1])
+				if unicode.IsLower(peek) || unicode.IsLower(rune(input[i-1])) {
+					output = append(output, '_')
+				}
+			}
+		}
+		output = append(output, unicode.ToUpper(c))
+	}
+	return string(output)
+}
+
+// getEnvOptsWithOverrides returns env options with overrides applied on top of env variables
+func getEnvOptsWithOverrides(overrides map[string]string) (env.Options, error) {
+	opts := getEnvOpts()
+	if len(overrides) == 0 {
+		return opts, nil
+	}
+	params, err := env.GetFieldParamsWithOptions(&BaseOperatorConf{}, opts)
+	if err != nil {
+		return opts, fmt.Errorf("BUG: failed to get global variables: %w", err)
+	}
+	known := make(map[string]struct{}, len(params))
+	for _, p := range params {
+		known[p.Key] = struct{}{}
+	}
+	for _, key := range slices.Sorted(maps.Keys(overrides)) {
+		if _, ok := known[key]; !ok {
+			return opts, fmt.Errorf("unknown variable=%q", key)
+		}
+		if isStartupOnlyVariable(key) {
+			return opts, fmt.Errorf("variable=%q cannot be changed at runtime, use env variable instead", key)
+		}
+	}
+	maps.Copy(opts.Environment, overrides)
+	return opts, nil
+}
+
+// ParseWithOverrides builds operator configuration from env variables with given overrides applied
+// and validates it. Keys of overrides are env variable names, e.g. VM_VMSINGLEDEFAULT_VERSION
+func ParseWithOverrides(overrides map[string]string) (*BaseOperatorConf, error) {
+	opts, err := getEnvOptsWithOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	c, err := env.ParseAsWithOptions[BaseOperatorConf](opts)
+	if err != nil {
+		return nil, err
+	}
+	if c.CustomConfigReloaderImage != "" {
+		c.ConfigReloader.Image = c.CustomConfigReloaderImage
+	}
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Reload replaces operator configuration returned by MustGetBaseConfig
+// with configuration built from env variables and given overrides.
+// Configuration is left unchanged in case of error
+func Reload(overrides map[string]string) error {
+	// make sure, that initial configuration is loaded and won't override reloaded one
+	MustGetBaseConfig()
+	c, err := ParseWithOverrides(overrides)
+	if err != nil {
+		return err
+	}
+	opConf.Store(c)
+	reloaded.mu.Lock()
+	defer reloaded.mu.Unlock()
+	close(reloaded.ch)
+	reloaded.ch = make(chan struct{})
+	return nil
+}
+
+var reloaded = struct {
+	mu sync.Mutex
+	ch chan struct{}
+}{
+	ch: make(chan struct{}),
+}
+
+// Reloaded returns a channel, which is closed on the next configuration reload
+func Reloaded() <-chan struct{} {
+	reloaded.mu.Lock()
+	defer reloaded.mu.Unlock()
+	return reloaded.ch
+}
+
+// EffectiveVariables returns values of all config variables
+// with given overrides applied on top of env variables and defaults
+func EffectiveVariables(overrides map[string]string) (map[string]string, error) {
+	opts, err := getEnvOptsWithOverrides(overrides)
+	if err != nil {
+		return nil, err
+	}
+	mapper := func(v string) string {
+		return opts.Environment[v]
+	}
+	params, err := env.GetFieldParamsWithOptions(&BaseOperatorConf{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("BUG: failed to get global variables: %w", err)
+	}
+	vars := make(map[string]string, len(params))
+	for _, p := range params {
+		value := p.DefaultValue
+		if v, ok := opts.Environment[p.Key]; ok {
+			value = v
+		} else if p.Expand {
+			value = os.Expand(value, mapper)
+		}
+		vars[p.Key] = value
+	}
+	return vars, nil
+}
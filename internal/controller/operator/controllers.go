@@ -6,25 +6,36 @@ import (
 	"flag"
 	"fmt"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
@@ -34,6 +45,10 @@ import (
 func BindFlags(f *flag.FlagSet) {
 	cacheSyncTimeout = f.Duration("controller.cacheSyncTimeout", *cacheSyncTimeout, "controls timeout for caches to be synced.")
 	maxConcurrency = f.Int("controller.maxConcurrentReconciles", *maxConcurrency, "Configures number of concurrent reconciles. It should improve performance for clusters with many objects.")
+	shardsCount = f.Int("controller.shardsCount", *shardsCount, "Configures number of operator shards. Each shard reconciles objects only from the subset of namespaces. "+
+		"Operator replicas acquire shards dynamically via per-shard Leases, shard 0 handles cluster-scoped objects.")
+	shardBy = f.String("controller.shardBy", *shardBy, "Configures how namespaces are assigned to shards. Supported values: hash, label. "+
+		"With label, namespace is assigned to the shard from operator.victoriametrics.com/shard label value and falls back to hash if label is missing.")
 }
 
 var (
@@ -63,11 +78,102 @@ var (
 		Name: "operator_controller_reconcile_errors_total",
 		Help: "Counts number context.Canceled errors",
 	})
+	reconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "operator_controller_reconcile_duration_seconds",
+		Help:    "Duration of objects reconciliation by controller",
+		Buckets: []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600},
+	}, []string{"controller"})
+	lastSuccessfulReconcileTimestamp = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "operator_controller_object_last_successful_reconcile_timestamp_seconds",
+		Help: "Unix timestamp of the last successful reconciliation of object",
+	}, []string{"controller", "namespace", "name"})
+	objectUpdateStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "operator_controller_object_update_status",
+		Help: "Current update status of object. Value is 1 for the current status and 0 for others",
+	}, []string{"controller", "namespace", "name", "status"})
+	objectReconcileErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "operator_controller_object_reconcile_errors_total",
+		Help: "Counts number of objects reconciliation errors by reason",
+	}, []string{"controller", "reason"})
 )
 
 // InitMetrics adds metrics to the Registry
 func init() {
-	metrics.Registry.MustRegister(parseObjectErrorsTotal, getObjectsErrorsTotal, conflictErrorsTotal, contextCancelErrorsTotal)
+	metrics.Registry.MustRegister(parseObjectErrorsTotal, getObjectsErrorsTotal, conflictErrorsTotal, contextCancelErrorsTotal,
+		reconcileDuration, lastSuccessfulReconcileTimestamp, objectUpdateStatus, objectReconcileErrorsTotal)
+}
+
+// updateStatuses defines statuses reported by operator_controller_object_update_status metric
+var updateStatuses = []vmv1beta1.UpdateStatus{
+	vmv1beta1.UpdateStatusExpanding,
+	vmv1beta1.UpdateStatusOperational,
+	vmv1beta1.UpdateStatusFailed,
+	vmv1beta1.UpdateStatusPaused,
+}
+
+const (
+	reconcileErrorReasonParsing    = "parsing"
+	reconcileErrorReasonValidation = "validation"
+	reconcileErrorReasonConflict   = "conflict"
+	reconcileErrorReasonApply      = "apply_conflict"
+	reconcileErrorReasonTimeout    = "timeout"
+	reconcileErrorReasonOther      = "other"
+)
+
+// reconcileErrorReason classifies reconciliation error for operator_controller_object_reconcile_errors_total metric
+func reconcileErrorReason(err error) string {
+	var pe *parsingError
+	var ve *build.ValidationError
+	switch {
+	case errors.As(err, &pe):
+		return reconcileErrorReasonParsing
+	case errors.As(err, &ve):
+		return reconcileErrorReasonValidation
+	case reconcile.IsApplyConflict(err):
+		return reconcileErrorReasonApply
+	case wait.Interrupted(err) || errors.Is(err, context.DeadlineExceeded):
+		// timeout waiting for pods or workload readiness
+		return reconcileErrorReasonTimeout
+	case reconcile.IsRetryable(err):
+		return reconcileErrorReasonConflict
+	default:
+		return reconcileErrorReasonOther
+	}
+}
+
+// recordReconcileError increments operator_controller_object_reconcile_errors_total metric with classified reason of err
+func recordReconcileError(controller string, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	objectReconcileErrorsTotal.WithLabelValues(controller, reconcileErrorReason(err)).Inc()
+}
+
+// recordObjectUpdateStatus sets operator_controller_object_update_status metric for the given object
+func recordObjectUpdateStatus(controller string, object client.Object, status vmv1beta1.UpdateStatus) {
+	for _, st := range updateStatuses {
+		var v float64
+		if st == status {
+			v = 1
+		}
+		objectUpdateStatus.WithLabelValues(controller, object.GetNamespace(), object.GetName(), string(st)).Set(v)
+	}
+}
+
+// deleteObjectReconcileMetrics removes per object metrics of the given controller
+func deleteObjectReconcileMetrics(name, ns, controller string) {
+	objLabels := prometheus.Labels{"controller": controller, "namespace": ns, "name": name}
+	lastSuccessfulReconcileTimestamp.DeletePartialMatch(objLabels)
+	objectUpdateStatus.DeletePartialMatch(objLabels)
+}
+
+// controllerNameForObject returns controller name used as metrics label for the given object
+func controllerNameForObject(c client.Client, object client.Object) string {
+	gvk, err := apiutil.GVKForObject(object, c.Scheme())
+	if err != nil {
+		return "unknown"
+	}
+	return strings.ToLower(gvk.Kind)
 }
 
 func getDefaultOptions() controller.Options {
@@ -77,10 +183,41 @@ func getDefaultOptions() controller.Options {
 			CacheSyncTimeout:        *cacheSyncTimeout,
 			MaxConcurrentReconciles: *maxConcurrency,
 		}
+		if IsShardingEnabled() {
+			defaultOptions.NewQueue = newShardQueue
+		}
 	})
 	return *defaultOptions
 }
 
+// newConfigReloadSource returns source, which enqueues all objects of the given list kind on operator configuration reload,
+// so changed defaults are applied without waiting for resync
+func newConfigReloadSource[T client.ObjectList](rclient client.Reader, newList func() T) source.Source {
+	return source.Func(func(ctx context.Context, queue workqueue.TypedRateLimitingInterface[k8sreconcile.Request]) error {
+		go func() {
+			for {
+				reloaded := config.Reloaded()
+				select {
+				case <-ctx.Done():
+					return
+				case <-reloaded:
+				}
+				list := newList()
+				if err := rclient.List(ctx, list); err != nil {
+					logger.WithContext(ctx).Error(err, "cannot list objects for reconcile after operator configuration reload")
+					continue
+				}
+				_ = apimeta.EachListItem(list, func(o runtime.Object) error {
+					obj := o.(client.Object)
+					queue.Add(k8sreconcile.Request{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}})
+					return nil
+				})
+			}
+		}()
+		return nil
+	})
+}
+
 // parsingError usually occurs in case of x-preserve-unknow-fields option enable to CRD
 // in this case k8s api server cannot perform proper validation and it may result in bad user input for some fields
 type parsingError struct {
@@ -136,6 +273,7 @@ func handleReconcileErr[T client.Object, ST reconcile.StatusWithMetadata[STC], S
 			}
 		}
 		parseObjectErrorsTotal.WithLabelValues(pe.controller, namespacedName).Inc()
+		objectReconcileErrorsTotal.WithLabelValues(pe.controller, reconcileErrorReasonParsing).Inc()
 	case errors.As(err, &ge):
 		deregisterObjectByCollector(ge.requestObject.Name, ge.requestObject.Namespace, ge.controller)
 		getObjectsErrorsTotal.WithLabelValues(ge.controller, ge.requestObject.String()).Inc()
@@ -154,13 +292,17 @@ func handleReconcileErr[T client.Object, ST reconcile.StatusWithMetadata[STC], S
 		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
 	}
 	if object != nil && !reflect.ValueOf(object).IsNil() && object.GetNamespace() != "" {
+		reason := "ReconciliationError"
+		if reconcile.IsApplyConflict(err) {
+			reason = "ApplyConflict"
+		}
 		errEvent := &corev1.Event{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "victoria-metrics-operator-" + uuid.New().String(),
 				Namespace: object.GetNamespace(),
 			},
 			Type:    corev1.EventTypeWarning,
-			Reason:  "ReconciliationError",
+			Reason:  reason,
 			Message: err.Error(),
 			Source: corev1.EventSource{
 				Component: "victoria-metrics-operator",
@@ -216,13 +358,17 @@ func handleReconcileErrWithoutStatus(
 		return ctrl.Result{RequeueAfter: time.Second * 5}, nil
 	}
 	if object != nil && !reflect.ValueOf(object).IsNil() && object.GetNamespace() != "" {
+		reason := "ReconciliationError"
+		if reconcile.IsApplyConflict(err) {
+			reason = "ApplyConflict"
+		}
 		errEvent := &corev1.Event{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      "victoria-metrics-operator-" + uuid.New().String(),
 				Namespace: object.GetNamespace(),
 			},
 			Type:    corev1.EventTypeWarning,
-			Reason:  "ReconciliationError",
+			Reason:  reason,
 			Message: err.Error(),
 			Source: corev1.EventSource{
 				Component: "victoria-metrics-operator",
@@ -252,6 +398,8 @@ func isNamespaceSelectorMatches(ctx context.Context, rclient client.Client, sour
 			return true, nil
 		}
 		return false, nil
+	case !cfg.IsWatchedNamespace(targetCRD.GetNamespace()):
+		return false, nil
 	case len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0:
 		return true, nil
 	case len(cfg.WatchNamespaces) > 0:
@@ -276,6 +424,40 @@ func isNamespaceSelectorMatches(ctx context.Context, rclient client.Client, sour
 	return false, nil
 }
 
+// isDatasourceRefMatches checks if ref points to the given object
+// VMUser matches ref only if it's located at the namespace of the object with reference
+func isDatasourceRefMatches(ref *vmv1beta1.DatasourceRef, obj client.Object, defaultNamespace string) bool {
+	if ref == nil {
+		return false
+	}
+	var kind string
+	switch obj.(type) {
+	case *vmv1beta1.VMUser:
+		return ref.Kind == "VMAuth" && ref.User == obj.GetName() && obj.GetNamespace() == defaultNamespace
+	case *vmv1beta1.VMSingle:
+		kind = "VMSingle"
+	case *vmv1beta1.VMCluster:
+		kind = "VMCluster"
+	case *vmv1beta1.VMAuth:
+		kind = "VMAuth"
+	case *vmv1.VLSingle:
+		kind = "VLSingle"
+	case *vmv1.VLCluster:
+		kind = "VLCluster"
+	case *vmv1.VTSingle:
+		kind = "VTSingle"
+	case *vmv1.VTCluster:
+		kind = "VTCluster"
+	default:
+		return false
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return ref.Kind == kind && ref.Name == obj.GetName() && namespace == obj.GetNamespace()
+}
+
 // isSelectorsMatchesTargetCRD checks if targetCRD matches sourceCRD by entity selectors and selectAll.
 // see https://docs.victoriametrics.com/operator/resources/vmagent/#scraping for details
 func isSelectorsMatchesTargetCRD(ctx context.Context, rclient client.Client, sourceCRD, targetCRD client.Object, opts *k8stools.SelectorOpts) (bool, error) {
@@ -319,52 +501,41 @@ type objectWithStatusTrack[T client.Object, ST reconcile.StatusWithMetadata[STC]
 }
 
 func createGenericEventForObject(ctx context.Context, c client.Client, object client.Object, message string) error {
-	ev := &corev1.Event{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "victoria-metrics-operator-" + uuid.New().String(),
-			Namespace: object.GetNamespace(),
-		},
-		Type:    corev1.EventTypeNormal,
-		Reason:  "ReconcileEvent",
-		Message: message,
-		Source: corev1.EventSource{
-			Component: "victoria-metrics-operator",
-		},
-		LastTimestamp: metav1.NewTime(time.Now()),
-		InvolvedObject: corev1.ObjectReference{
-			Kind:            object.GetObjectKind().GroupVersionKind().Kind,
-			Namespace:       object.GetNamespace(),
-			Name:            object.GetName(),
-			UID:             object.GetUID(),
-			ResourceVersion: object.GetResourceVersion(),
-		},
-	}
-	if err := c.Create(ctx, ev); err != nil {
-		return fmt.Errorf("cannot create generic event at k8s api for object: %q: %w", object.GetObjectKind().GroupVersionKind().GroupKind(), err)
-	}
-	return nil
+	return createEventForObject(ctx, c, object, corev1.EventTypeNormal, "ReconcileEvent", message)
+}
+
+func createEventForObject(ctx context.Context, c client.Client, object client.Object, eventType, reason, message string) error {
+	return events.Create(ctx, c, object, eventType, reason, message)
 }
 
 func reconcileAndTrackStatus[T client.Object, ST reconcile.StatusWithMetadata[STC], STC any](
 	ctx context.Context,
 	c client.Client,
 	object objectWithStatusTrack[T, ST, STC],
-	cb func() (ctrl.Result, error),
+	cb func(ctx context.Context) (ctrl.Result, error),
 ) (result ctrl.Result, resultErr error) {
+	controllerName := controllerNameForObject(c, object)
 	if object.Paused() {
 		if err := reconcile.UpdateObjectStatus(ctx, c, object, vmv1beta1.UpdateStatusPaused, nil); err != nil {
 			resultErr = fmt.Errorf("failed to update object status: %w", err)
 			return
 		}
+		recordObjectUpdateStatus(controllerName, object, vmv1beta1.UpdateStatusPaused)
 		return
 	}
 	specChanged := object.LastSpecUpdated()
 	resultStatus := vmv1beta1.UpdateStatusOperational
+	startTime := time.Now()
 	defer func() {
+		reconcileDuration.WithLabelValues(controllerName).Observe(time.Since(startTime).Seconds())
 		if err := reconcile.UpdateObjectStatus(ctx, c, object, resultStatus, resultErr); err != nil {
 			resultErr = fmt.Errorf("failed to update object status: %w", err)
 			return
 		}
+		recordObjectUpdateStatus(controllerName, object, resultStatus)
+		if resultStatus == vmv1beta1.UpdateStatusOperational {
+			lastSuccessfulReconcileTimestamp.WithLabelValues(controllerName, object.GetNamespace(), object.GetName()).SetToCurrentTime()
+		}
 	}()
 
 	if specChanged {
@@ -379,8 +550,12 @@ func reconcileAndTrackStatus[T client.Object, ST reconcile.StatusWithMetadata[ST
 	}
 
 	var err error
-	result, err = cb()
+	ctx = events.AddToContext(ctx, c, object)
+	ctx = reconcile.WithDriftTracker(ctx, object.GetAnnotations()[vmv1beta1.DriftPolicyAnnotation] == vmv1beta1.DriftPolicyReport)
+	result, err = cb(ctx)
+	reportChildObjectsDrift(ctx, c, object, reconcile.TrackedDrifts(ctx))
 	if err != nil {
+		recordReconcileError(controllerName, err)
 		// do not change status on conflict to failed
 		// it should be retried on the next loop
 		if reconcile.IsRetryable(err) {
@@ -399,3 +574,54 @@ func reconcileAndTrackStatus[T client.Object, ST reconcile.StatusWithMetadata[ST
 	}
 	return result, nil
 }
+
+// reconcilePlan computes changes of child objects made by cb without applying them
+// and publishes them at status.plan of the object and as kubernetes event
+func reconcilePlan(ctx context.Context, c client.Client, object client.Object, prevPlan *vmv1beta1.ReconcilePlan, cb func(rclient client.Client) error) (resultErr error) {
+	controllerName := controllerNameForObject(c, object)
+	startTime := time.Now()
+	defer func() {
+		reconcileDuration.WithLabelValues(controllerName).Observe(time.Since(startTime).Seconds())
+		if resultErr != nil {
+			recordReconcileError(controllerName, resultErr)
+			return
+		}
+		lastSuccessfulReconcileTimestamp.WithLabelValues(controllerName, object.GetNamespace(), object.GetName()).SetToCurrentTime()
+	}()
+	pc := reconcile.NewPlanClient(c)
+	if err := cb(pc); err != nil {
+		return fmt.Errorf("cannot compute reconcile plan: %w", err)
+	}
+	plan := pc.Plan()
+	plan.ObservedGeneration = object.GetGeneration()
+	if equality.Semantic.DeepEqual(prevPlan, plan) {
+		return nil
+	}
+	if prevPlan == nil || !equality.Semantic.DeepEqual(prevPlan.Changes, plan.Changes) {
+		if err := createGenericEventForObject(ctx, c, object, formatPlanMessage(plan)); err != nil {
+			logger.WithContext(ctx).Error(err, "cannot create k8s api event")
+		}
+		logger.WithContext(ctx).Info(fmt.Sprintf("computed reconcile plan: %s", plan.Summary))
+	}
+	return reconcile.UpdateObjectPlan(ctx, c, object, plan)
+}
+
+// maxPlanMessageChanges limits count of changes listed at event message
+const maxPlanMessageChanges = 10
+
+func formatPlanMessage(plan *vmv1beta1.ReconcilePlan) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "reconcile plan: %s", plan.Summary)
+	for i, ch := range plan.Changes {
+		if i == maxPlanMessageChanges {
+			fmt.Fprintf(&sb, ", and %d more changes at status.plan", len(plan.Changes)-maxPlanMessageChanges)
+			break
+		}
+		sep := ", "
+		if i == 0 {
+			sep = ": "
+		}
+		fmt.Fprintf(&sb, "%s%s %s=%s", sep, ch.Action, ch.Kind, ch.Name)
+	}
+	return sb.String()
+}
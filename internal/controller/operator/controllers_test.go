@@ -2,15 +2,24 @@ package operator
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 )
 
@@ -314,3 +323,151 @@ func TestIsSelectorsMatchesTargetCRD(t *testing.T) {
 		isMatch: true,
 	})
 }
+
+func TestIsDatasourceRefMatches(t *testing.T) {
+	type opts struct {
+		ref     *vmv1beta1.DatasourceRef
+		obj     client.Object
+		isMatch bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		assert.Equal(t, o.isMatch, isDatasourceRefMatches(o.ref, o.obj, "default"))
+	}
+
+	// nil ref
+	f(opts{
+		obj: &vmv1beta1.VMSingle{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}},
+	})
+
+	// match with default namespace
+	f(opts{
+		ref:     &vmv1beta1.DatasourceRef{Kind: "VMSingle", Name: "main"},
+		obj:     &vmv1beta1.VMSingle{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}},
+		isMatch: true,
+	})
+
+	// match with explicit namespace
+	f(opts{
+		ref:     &vmv1beta1.DatasourceRef{Kind: "VLCluster", Name: "logs", Namespace: "logging"},
+		obj:     &vmv1.VLCluster{ObjectMeta: metav1.ObjectMeta{Name: "logs", Namespace: "logging"}},
+		isMatch: true,
+	})
+
+	// match traces
+	f(opts{
+		ref:     &vmv1beta1.DatasourceRef{Kind: "VTSingle", Name: "traces"},
+		obj:     &vmv1.VTSingle{ObjectMeta: metav1.ObjectMeta{Name: "traces", Namespace: "default"}},
+		isMatch: true,
+	})
+
+	// kind mismatch
+	f(opts{
+		ref: &vmv1beta1.DatasourceRef{Kind: "VMCluster", Name: "main"},
+		obj: &vmv1beta1.VMSingle{ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"}},
+	})
+
+	// vmuser of vmauth ref
+	f(opts{
+		ref:     &vmv1beta1.DatasourceRef{Kind: "VMAuth", Name: "main", Namespace: "auth", User: "reader"},
+		obj:     &vmv1beta1.VMUser{ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "default"}},
+		isMatch: true,
+	})
+
+	// vmuser from namespace of vmauth
+	f(opts{
+		ref: &vmv1beta1.DatasourceRef{Kind: "VMAuth", Name: "main", Namespace: "auth", User: "reader"},
+		obj: &vmv1beta1.VMUser{ObjectMeta: metav1.ObjectMeta{Name: "reader", Namespace: "auth"}},
+	})
+}
+
+func TestReconcileErrorReason(t *testing.T) {
+	f := func(err error, want string) {
+		t.Helper()
+		assert.Equal(t, want, reconcileErrorReason(err))
+	}
+	gr := schema.GroupResource{Group: "apps", Resource: "statefulsets"}
+
+	f(&parsingError{origin: "bad json", controller: "vmagent"}, reconcileErrorReasonParsing)
+	f(fmt.Errorf("cannot reconcile: %w", build.NewValidationError(fmt.Errorf("bad spec"))), reconcileErrorReasonValidation)
+	f(fmt.Errorf("cannot wait for statefulSet=vmstorage to become ready: %w", wait.ErrorInterrupted(context.DeadlineExceeded)), reconcileErrorReasonTimeout)
+	f(fmt.Errorf("cannot update: %w", k8serrors.NewConflict(gr, "vmstorage", fmt.Errorf("object was modified"))), reconcileErrorReasonConflict)
+	f(fmt.Errorf("some error"), reconcileErrorReasonOther)
+}
+
+func TestReconcileAndTrackStatusMetrics(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "metrics",
+			Namespace: "default",
+		},
+	}
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr})
+	errorsMetric := objectReconcileErrorsTotal.WithLabelValues("vmsingle", reconcileErrorReasonValidation)
+	initialErrors := testutil.ToFloat64(errorsMetric)
+	statusValue := func(status vmv1beta1.UpdateStatus) float64 {
+		t.Helper()
+		return testutil.ToFloat64(objectUpdateStatus.WithLabelValues("vmsingle", cr.Namespace, cr.Name, string(status)))
+	}
+
+	// failed reconcile
+	_, err := reconcileAndTrackStatus(ctx, fclient, cr.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
+		return ctrl.Result{}, build.NewValidationError(fmt.Errorf("bad spec"))
+	})
+	assert.Error(t, err)
+	assert.Equal(t, initialErrors+1, testutil.ToFloat64(errorsMetric))
+	assert.Equal(t, float64(1), statusValue(vmv1beta1.UpdateStatusFailed))
+	assert.Equal(t, float64(0), statusValue(vmv1beta1.UpdateStatusOperational))
+	assert.Equal(t, float64(0), testutil.ToFloat64(lastSuccessfulReconcileTimestamp.WithLabelValues("vmsingle", cr.Namespace, cr.Name)))
+
+	// successful reconcile
+	assert.NoError(t, fclient.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, cr))
+	_, err = reconcileAndTrackStatus(ctx, fclient, cr.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
+		return ctrl.Result{}, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, initialErrors+1, testutil.ToFloat64(errorsMetric))
+	assert.Equal(t, float64(0), statusValue(vmv1beta1.UpdateStatusFailed))
+	assert.Equal(t, float64(1), statusValue(vmv1beta1.UpdateStatusOperational))
+	assert.Greater(t, testutil.ToFloat64(lastSuccessfulReconcileTimestamp.WithLabelValues("vmsingle", cr.Namespace, cr.Name)), float64(0))
+
+	// per object metrics are removed with object
+	seriesCount := testutil.CollectAndCount(objectUpdateStatus)
+	deregisterObjectByCollector(cr.Name, cr.Namespace, "vmsingle")
+	assert.Equal(t, seriesCount-len(updateStatuses), testutil.CollectAndCount(objectUpdateStatus))
+}
+
+func TestReconcilePlanMetrics(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "plan-metrics",
+			Namespace: "default",
+		},
+	}
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr})
+	errorsMetric := objectReconcileErrorsTotal.WithLabelValues("vmcluster", reconcileErrorReasonValidation)
+	initialErrors := testutil.ToFloat64(errorsMetric)
+	lastSuccess := func() float64 {
+		t.Helper()
+		return testutil.ToFloat64(lastSuccessfulReconcileTimestamp.WithLabelValues("vmcluster", cr.Namespace, cr.Name))
+	}
+	defer deregisterObjectByCollector(cr.Name, cr.Namespace, "vmcluster")
+
+	// failed plan
+	err := reconcilePlan(ctx, fclient, cr, nil, func(_ client.Client) error {
+		return build.NewValidationError(fmt.Errorf("bad spec"))
+	})
+	assert.Error(t, err)
+	assert.Equal(t, initialErrors+1, testutil.ToFloat64(errorsMetric))
+	assert.Equal(t, float64(0), lastSuccess())
+
+	// successful plan
+	err = reconcilePlan(ctx, fclient, cr, nil, func(_ client.Client) error {
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, initialErrors+1, testutil.ToFloat64(errorsMetric))
+	assert.Greater(t, lastSuccess(), float64(0))
+}
@@ -0,0 +1,237 @@
+package converter
+
+import (
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+// ConvertAlertmanager creates VMAlertmanager from Alertmanager
+//
+// Alertmanager specific settings, such as image, version, limits and global configuration are ignored.
+// Paths of ignored and dropped fields are returned as well
+func ConvertAlertmanager(am *promv1.Alertmanager, conf *config.BaseOperatorConf) (*vmv1beta1.VMAlertmanager, []string) {
+	var dropped DroppedFields
+	dropped.Unconverted("spec", &am.Spec,
+		"configSecret", "logLevel", "logFormat", "retention", "persistentVolumeClaimRetentionPolicy", "externalUrl",
+		"routePrefix", "listenLocal", "additionalPeers", "clusterAdvertiseAddress", "portName",
+		"alertmanagerConfigSelector", "alertmanagerConfigNamespaceSelector", "alertmanagerConfigMatcherStrategy",
+		"serviceAccountName", "storage", "web", "clusterTLS", "resources", "affinity", "tolerations", "priorityClassName",
+		"hostNetwork", "nodeSelector", "topologySpreadConstraints", "imagePullSecrets", "terminationGracePeriodSeconds",
+		"replicas", "containers", "initContainers", "secrets", "configMaps", "volumes", "volumeMounts", "paused",
+		"podMetadata", "minReadySeconds", "securityContext", "dnsPolicy", "automountServiceAccountToken", "hostAliases",
+	)
+	vmAM := &vmv1beta1.VMAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   am.Namespace,
+			Name:        am.Name,
+			Labels:      FilterPrefixes(am.Labels, conf.FilterPrometheusConverterLabelPrefixes),
+			Annotations: FilterPrefixes(am.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes),
+		},
+		Spec: vmv1beta1.VMAlertmanagerSpec{
+			ConfigSecret:                         am.Spec.ConfigSecret,
+			LogLevel:                             am.Spec.LogLevel,
+			LogFormat:                            am.Spec.LogFormat,
+			Retention:                            string(am.Spec.Retention),
+			PersistentVolumeClaimRetentionPolicy: am.Spec.PersistentVolumeClaimRetentionPolicy,
+			ExternalURL:                          am.Spec.ExternalURL,
+			RoutePrefix:                          am.Spec.RoutePrefix,
+			ListenLocal:                          am.Spec.ListenLocal,
+			AdditionalPeers:                      am.Spec.AdditionalPeers,
+			ClusterAdvertiseAddress:              am.Spec.ClusterAdvertiseAddress,
+			PortName:                             am.Spec.PortName,
+			ConfigSelector:                       am.Spec.AlertmanagerConfigSelector,
+			ConfigNamespaceSelector:              am.Spec.AlertmanagerConfigNamespaceSelector,
+			DisableNamespaceMatcher:              am.Spec.AlertmanagerConfigMatcherStrategy.Type == promv1.NoneConfigMatcherStrategyType,
+			ServiceAccountName:                   am.Spec.ServiceAccountName,
+			Storage:                              convertStorage(am.Spec.Storage),
+			WebConfig:                            convertAlertmanagerWebConfig(am.Spec.Web, &dropped),
+			GossipConfig:                         convertClusterTLSConfig(am.Spec.ClusterTLS, &dropped),
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+				Resources: am.Spec.Resources,
+			},
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				Affinity:                      am.Spec.Affinity,
+				Tolerations:                   am.Spec.Tolerations,
+				PriorityClassName:             am.Spec.PriorityClassName,
+				HostNetwork:                   am.Spec.HostNetwork,
+				NodeSelector:                  am.Spec.NodeSelector,
+				TopologySpreadConstraints:     am.Spec.TopologySpreadConstraints,
+				ImagePullSecrets:              am.Spec.ImagePullSecrets,
+				TerminationGracePeriodSeconds: am.Spec.TerminationGracePeriodSeconds,
+				ReplicaCount:                  am.Spec.Replicas,
+				Containers:                    am.Spec.Containers,
+				InitContainers:                am.Spec.InitContainers,
+				Secrets:                       am.Spec.Secrets,
+				ConfigMaps:                    am.Spec.ConfigMaps,
+				Volumes:                       am.Spec.Volumes,
+				VolumeMounts:                  am.Spec.VolumeMounts,
+				Paused:                        am.Spec.Paused,
+			},
+		},
+	}
+	if vmAM.Spec.ConfigSecret == "" {
+		// Alertmanager reads configuration from the secret with predefined name by default
+		vmAM.Spec.ConfigSecret = "alertmanager-" + am.Name
+	}
+	if am.Spec.PodMetadata != nil {
+		vmAM.Spec.PodMetadata = &vmv1beta1.EmbeddedObjectMetadata{
+			Name:        am.Spec.PodMetadata.Name,
+			Labels:      am.Spec.PodMetadata.Labels,
+			Annotations: am.Spec.PodMetadata.Annotations,
+		}
+	}
+	if am.Spec.MinReadySeconds != nil {
+		vmAM.Spec.MinReadySeconds = *am.Spec.MinReadySeconds
+	}
+	if am.Spec.SecurityContext != nil {
+		vmAM.Spec.SecurityContext = &vmv1beta1.SecurityContext{
+			PodSecurityContext: am.Spec.SecurityContext,
+		}
+	}
+	if am.Spec.DNSPolicy != nil {
+		vmAM.Spec.DNSPolicy = corev1.DNSPolicy(*am.Spec.DNSPolicy)
+	}
+	if am.Spec.AutomountServiceAccountToken != nil {
+		vmAM.Spec.DisableAutomountServiceAccountToken = !*am.Spec.AutomountServiceAccountToken
+	}
+	for _, ha := range am.Spec.HostAliases {
+		vmAM.Spec.HostAliases = append(vmAM.Spec.HostAliases, corev1.HostAlias{
+			IP:        ha.IP,
+			Hostnames: ha.Hostnames,
+		})
+	}
+	if conf.EnabledPrometheusConverterOwnerReferences {
+		vmAM.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion:         promv1.SchemeGroupVersion.String(),
+				Kind:               promv1.AlertmanagersKind,
+				Name:               am.Name,
+				UID:                am.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		}
+	}
+	vmAM.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, vmAM.Annotations)
+	return vmAM, dropped
+}
+
+// convertStorage converts storage spec
+// ephemeral volumes are not supported and replaced with emptyDir
+func convertStorage(src *promv1.StorageSpec) *vmv1beta1.StorageSpec {
+	if src == nil {
+		return nil
+	}
+	if src.EmptyDir != nil || src.Ephemeral != nil {
+		emptyDir := src.EmptyDir
+		if emptyDir == nil {
+			emptyDir = &corev1.EmptyDirVolumeSource{}
+		}
+		return &vmv1beta1.StorageSpec{
+			EmptyDir: emptyDir,
+		}
+	}
+	vct := src.VolumeClaimTemplate
+	return &vmv1beta1.StorageSpec{
+		VolumeClaimTemplate: vmv1beta1.EmbeddedPersistentVolumeClaim{
+			TypeMeta: vct.TypeMeta,
+			EmbeddedObjectMetadata: vmv1beta1.EmbeddedObjectMetadata{
+				Name:        vct.Name,
+				Labels:      vct.Labels,
+				Annotations: vct.Annotations,
+			},
+			Spec: vct.Spec,
+		},
+	}
+}
+
+func convertAlertmanagerWebConfig(src *promv1.AlertmanagerWebSpec, dropped *DroppedFields) *vmv1beta1.VMAlertmanagerWebConfig {
+	if src == nil {
+		return nil
+	}
+	dropped.Unconverted("spec.web", src)
+	if src.TLSConfig == nil && src.HTTPConfig == nil {
+		return nil
+	}
+	wc := &vmv1beta1.VMAlertmanagerWebConfig{
+		TLSServerConfig: convertWebTLSConfig(src.TLSConfig, dropped, "spec.web.tlsConfig"),
+	}
+	if src.HTTPConfig != nil {
+		wc.HTTPServerConfig = &vmv1beta1.VMAlertmanagerHTTPConfig{
+			HTTP2: ptr.Deref(src.HTTPConfig.HTTP2, false),
+		}
+		if h := src.HTTPConfig.Headers; h != nil {
+			headers := make(map[string]string)
+			for k, v := range map[string]string{
+				"Content-Security-Policy":   h.ContentSecurityPolicy,
+				"X-Frame-Options":           h.XFrameOptions,
+				"X-Content-Type-Options":    h.XContentTypeOptions,
+				"X-XSS-Protection":          h.XXSSProtection,
+				"Strict-Transport-Security": h.StrictTransportSecurity,
+			} {
+				if v != "" {
+					headers[k] = v
+				}
+			}
+			if len(headers) > 0 {
+				wc.HTTPServerConfig.Headers = headers
+			}
+		}
+	}
+	return wc
+}
+
+// convertWebTLSConfig converts web server TLS configuration
+// VMAlertmanager supports only secret references for certificates, configmap references are ignored
+func convertWebTLSConfig(src *promv1.WebTLSConfig, dropped *DroppedFields, path string) *vmv1beta1.TLSServerConfig {
+	if src == nil {
+		return nil
+	}
+	dropped.Add(path+".cert.configMap", src.Cert.ConfigMap != nil)
+	dropped.Add(path+".client_ca.configMap", src.ClientCA.ConfigMap != nil)
+	tc := &vmv1beta1.TLSServerConfig{
+		ClientCASecretRef:        src.ClientCA.Secret,
+		ClientCAFile:             ptr.Deref(src.ClientCAFile, ""),
+		ClientAuthType:           ptr.Deref(src.ClientAuthType, ""),
+		MinVersion:               ptr.Deref(src.MinVersion, ""),
+		MaxVersion:               ptr.Deref(src.MaxVersion, ""),
+		CipherSuites:             src.CipherSuites,
+		CurvePreferences:         src.CurvePreferences,
+		PreferServerCipherSuites: ptr.Deref(src.PreferServerCipherSuites, false),
+		Certs: vmv1beta1.Certs{
+			CertSecretRef: src.Cert.Secret,
+			CertFile:      ptr.Deref(src.CertFile, ""),
+			KeyFile:       ptr.Deref(src.KeyFile, ""),
+		},
+	}
+	if src.KeySecret.Name != "" {
+		tc.KeySecretRef = src.KeySecret.DeepCopy()
+	}
+	return tc
+}
+
+func convertClusterTLSConfig(src *promv1.ClusterTLSConfig, dropped *DroppedFields) *vmv1beta1.VMAlertmanagerGossipConfig {
+	if src == nil {
+		return nil
+	}
+	dropped.Unconverted("spec.clusterTLS.client", &src.ClientTLS, "ca", "cert", "keySecret", "serverName", "insecureSkipVerify")
+	dropped.Add("spec.clusterTLS.client.ca.configMap", src.ClientTLS.CA.ConfigMap != nil)
+	dropped.Add("spec.clusterTLS.client.cert.configMap", src.ClientTLS.Cert.ConfigMap != nil)
+	return &vmv1beta1.VMAlertmanagerGossipConfig{
+		TLSServerConfig: convertWebTLSConfig(&src.ServerTLS, dropped, "spec.clusterTLS.server"),
+		TLSClientConfig: &vmv1beta1.TLSClientConfig{
+			CASecretRef:        src.ClientTLS.CA.Secret,
+			InsecureSkipVerify: ptr.Deref(src.ClientTLS.InsecureSkipVerify, false),
+			ServerName:         ptr.Deref(src.ClientTLS.ServerName, ""),
+			Certs: vmv1beta1.Certs{
+				CertSecretRef: src.ClientTLS.Cert.Secret,
+				KeySecretRef:  src.ClientTLS.KeySecret,
+			},
+		},
+	}
+}
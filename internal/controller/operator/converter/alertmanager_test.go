@@ -0,0 +1,214 @@
+package converter
+
+import (
+	"testing"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+func TestConvertAlertmanager(t *testing.T) {
+	type opts struct {
+		am   *promv1.Alertmanager
+		want vmv1beta1.VMAlertmanager
+	}
+	f := func(o opts) {
+		t.Helper()
+		got, _ := ConvertAlertmanager(o.am, &config.BaseOperatorConf{
+			FilterPrometheusConverterLabelPrefixes: []string{"helm.sh"},
+		})
+		assert.Equal(t, o.want, *got)
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:      "main",
+		Namespace: "monitoring",
+		Labels: map[string]string{
+			"app":           "alertmanager",
+			"helm.sh/chart": "kube-prometheus-stack",
+		},
+	}
+	wantMeta := metav1.ObjectMeta{
+		Name:      "main",
+		Namespace: "monitoring",
+		Labels: map[string]string{
+			"app": "alertmanager",
+		},
+	}
+
+	// minimal spec
+	f(opts{
+		am: &promv1.Alertmanager{
+			ObjectMeta: objectMeta,
+		},
+		want: vmv1beta1.VMAlertmanager{
+			ObjectMeta: wantMeta,
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				ConfigSecret: "alertmanager-main",
+			},
+		},
+	})
+
+	// ephemeral storage replaced with emptyDir
+	f(opts{
+		am: &promv1.Alertmanager{
+			ObjectMeta: objectMeta,
+			Spec: promv1.AlertmanagerSpec{
+				Storage: &promv1.StorageSpec{
+					Ephemeral: &corev1.EphemeralVolumeSource{},
+				},
+			},
+		},
+		want: vmv1beta1.VMAlertmanager{
+			ObjectMeta: wantMeta,
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				ConfigSecret: "alertmanager-main",
+				Storage: &vmv1beta1.StorageSpec{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+		},
+	})
+
+	// emptyDir storage
+	f(opts{
+		am: &promv1.Alertmanager{
+			ObjectMeta: objectMeta,
+			Spec: promv1.AlertmanagerSpec{
+				Storage: &promv1.StorageSpec{
+					EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+				},
+			},
+		},
+		want: vmv1beta1.VMAlertmanager{
+			ObjectMeta: wantMeta,
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				ConfigSecret: "alertmanager-main",
+				Storage: &vmv1beta1.StorageSpec{
+					EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory},
+				},
+			},
+		},
+	})
+
+	// with storage, selectors, web and cluster settings
+	storageSpec := corev1.PersistentVolumeClaimSpec{
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("1Gi"),
+			},
+		},
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"alertmanager": "main"}}
+	certSecret := &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "am-tls"},
+		Key:                  "tls.crt",
+	}
+	keySecret := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "am-tls"},
+		Key:                  "tls.key",
+	}
+	f(opts{
+		am: &promv1.Alertmanager{
+			ObjectMeta: objectMeta,
+			Spec: promv1.AlertmanagerSpec{
+				Replicas:                            ptr.To[int32](3),
+				ConfigSecret:                        "am-config",
+				Retention:                           "240h",
+				LogLevel:                            "warn",
+				AlertmanagerConfigSelector:          selector,
+				AlertmanagerConfigNamespaceSelector: &metav1.LabelSelector{},
+				AlertmanagerConfigMatcherStrategy: promv1.AlertmanagerConfigMatcherStrategy{
+					Type: promv1.NoneConfigMatcherStrategyType,
+				},
+				AdditionalPeers: []string{"am-0.example.com:9094"},
+				Storage: &promv1.StorageSpec{
+					VolumeClaimTemplate: promv1.EmbeddedPersistentVolumeClaim{
+						EmbeddedObjectMetadata: promv1.EmbeddedObjectMetadata{Name: "am-db"},
+						Spec:                   storageSpec,
+					},
+				},
+				Web: &promv1.AlertmanagerWebSpec{
+					WebConfigFileFields: promv1.WebConfigFileFields{
+						TLSConfig: &promv1.WebTLSConfig{
+							Cert:      promv1.SecretOrConfigMap{Secret: certSecret},
+							KeySecret: keySecret,
+						},
+						HTTPConfig: &promv1.WebHTTPConfig{
+							HTTP2: ptr.To(true),
+							Headers: &promv1.WebHTTPHeaders{
+								XFrameOptions: "Deny",
+							},
+						},
+					},
+				},
+				ClusterTLS: &promv1.ClusterTLSConfig{
+					ServerTLS: promv1.WebTLSConfig{
+						Cert:      promv1.SecretOrConfigMap{Secret: certSecret},
+						KeySecret: keySecret,
+					},
+					ClientTLS: promv1.SafeTLSConfig{
+						Cert:               promv1.SecretOrConfigMap{Secret: certSecret},
+						KeySecret:          &keySecret,
+						InsecureSkipVerify: ptr.To(true),
+					},
+				},
+			},
+		},
+		want: vmv1beta1.VMAlertmanager{
+			ObjectMeta: wantMeta,
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				ConfigSecret:            "am-config",
+				Retention:               "240h",
+				LogLevel:                "warn",
+				ConfigSelector:          selector,
+				ConfigNamespaceSelector: &metav1.LabelSelector{},
+				DisableNamespaceMatcher: true,
+				AdditionalPeers:         []string{"am-0.example.com:9094"},
+				Storage: &vmv1beta1.StorageSpec{
+					VolumeClaimTemplate: vmv1beta1.EmbeddedPersistentVolumeClaim{
+						EmbeddedObjectMetadata: vmv1beta1.EmbeddedObjectMetadata{Name: "am-db"},
+						Spec:                   storageSpec,
+					},
+				},
+				WebConfig: &vmv1beta1.VMAlertmanagerWebConfig{
+					TLSServerConfig: &vmv1beta1.TLSServerConfig{
+						Certs: vmv1beta1.Certs{
+							CertSecretRef: certSecret,
+							KeySecretRef:  &keySecret,
+						},
+					},
+					HTTPServerConfig: &vmv1beta1.VMAlertmanagerHTTPConfig{
+						HTTP2:   true,
+						Headers: map[string]string{"X-Frame-Options": "Deny"},
+					},
+				},
+				GossipConfig: &vmv1beta1.VMAlertmanagerGossipConfig{
+					TLSServerConfig: &vmv1beta1.TLSServerConfig{
+						Certs: vmv1beta1.Certs{
+							CertSecretRef: certSecret,
+							KeySecretRef:  &keySecret,
+						},
+					},
+					TLSClientConfig: &vmv1beta1.TLSClientConfig{
+						InsecureSkipVerify: true,
+						Certs: vmv1beta1.Certs{
+							CertSecretRef: certSecret,
+							KeySecretRef:  &keySecret,
+						},
+					},
+				},
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To[int32](3),
+				},
+			},
+		},
+	})
+}
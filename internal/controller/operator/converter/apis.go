@@ -1,6 +1,7 @@
 package converter
 
 import (
+	"fmt"
 	"math"
 	"strings"
 
@@ -101,7 +102,9 @@ func MaybeAddArgoCDIgnoreAnnotations(mustAdd bool, dst map[string]string) map[st
 }
 
 // ConvertServiceMonitor create VMServiceScrape from ServiceMonitor
-func ConvertServiceMonitor(serviceMon *promv1.ServiceMonitor, conf *config.BaseOperatorConf) *vmv1beta1.VMServiceScrape {
+// and returns paths of dropped fields
+func ConvertServiceMonitor(serviceMon *promv1.ServiceMonitor, conf *config.BaseOperatorConf) (*vmv1beta1.VMServiceScrape, []string) {
+	var dropped DroppedFields
 	cs := &vmv1beta1.VMServiceScrape{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        serviceMon.Name,
@@ -114,7 +117,7 @@ func ConvertServiceMonitor(serviceMon *promv1.ServiceMonitor, conf *config.BaseO
 			TargetLabels:    serviceMon.Spec.TargetLabels,
 			PodTargetLabels: serviceMon.Spec.PodTargetLabels,
 			Selector:        serviceMon.Spec.Selector,
-			Endpoints:       convertEndpoint(serviceMon.Spec.Endpoints),
+			Endpoints:       convertEndpoint(serviceMon.Spec.Endpoints, &dropped),
 			NamespaceSelector: vmv1beta1.NamespaceSelector{
 				Any:        serviceMon.Spec.NamespaceSelector.Any,
 				MatchNames: serviceMon.Spec.NamespaceSelector.MatchNames,
@@ -146,7 +149,7 @@ func ConvertServiceMonitor(serviceMon *promv1.ServiceMonitor, conf *config.BaseO
 		}
 	}
 	cs.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, cs.Annotations)
-	return cs
+	return cs, dropped
 }
 
 // ReplacePromDirPath replace prometheus directory path for config maps and secrets to VM one
@@ -201,9 +204,10 @@ func convertBearerToken(src *corev1.SecretKeySelector) *corev1.SecretKeySelector
 	return src
 }
 
-func convertEndpoint(promEndpoint []promv1.Endpoint) []vmv1beta1.Endpoint {
+func convertEndpoint(promEndpoint []promv1.Endpoint, dropped *DroppedFields) []vmv1beta1.Endpoint {
 	endpoints := make([]vmv1beta1.Endpoint, 0, len(promEndpoint))
-	for _, endpoint := range promEndpoint {
+	for i, endpoint := range promEndpoint {
+		path := fmt.Sprintf("spec.endpoints[%d]", i)
 		ep := vmv1beta1.Endpoint{
 			Port:       endpoint.Port,
 			TargetPort: endpoint.TargetPort,
@@ -231,8 +235,8 @@ func convertEndpoint(promEndpoint []promv1.Endpoint) []vmv1beta1.Endpoint {
 				BearerTokenSecret: convertBearerToken(endpoint.BearerTokenSecret),
 			},
 			EndpointRelabelings: vmv1beta1.EndpointRelabelings{
-				MetricRelabelConfigs: ConvertRelabelConfig(endpoint.MetricRelabelConfigs),
-				RelabelConfigs:       ConvertRelabelConfig(endpoint.RelabelConfigs),
+				MetricRelabelConfigs: ConvertRelabelConfig(endpoint.MetricRelabelConfigs, dropped, path+".metricRelabelings"),
+				RelabelConfigs:       ConvertRelabelConfig(endpoint.RelabelConfigs, dropped, path+".relabelings"),
 			},
 		}
 
@@ -303,10 +307,12 @@ func convertSecretOrConfigmap(promSCM promv1.SecretOrConfigMap) vmv1beta1.Secret
 }
 
 // ConvertRelabelConfig converts Prometheus relabel config to VM one
-func ConvertRelabelConfig(promRelabelConfig []promv1.RelabelConfig) []*vmv1beta1.RelabelConfig {
+// unsupported relabel configs are filtered and recorded into dropped under the given path
+func ConvertRelabelConfig(promRelabelConfig []promv1.RelabelConfig, dropped *DroppedFields, path string) []*vmv1beta1.RelabelConfig {
 	if promRelabelConfig == nil {
 		return nil
 	}
+	dropped.relabelings(path, promRelabelConfig)
 	relabelCfg := []*vmv1beta1.RelabelConfig{}
 	sourceLabelsToStringSlice := func(src []promv1.LabelName) []string {
 		if len(src) == 0 {
@@ -334,12 +340,13 @@ func ConvertRelabelConfig(promRelabelConfig []promv1.RelabelConfig) []*vmv1beta1
 	return filterUnsupportedRelabelCfg(relabelCfg)
 }
 
-func convertPodEndpoints(promPodEnpoints []promv1.PodMetricsEndpoint) []vmv1beta1.PodMetricsEndpoint {
+func convertPodEndpoints(promPodEnpoints []promv1.PodMetricsEndpoint, dropped *DroppedFields) []vmv1beta1.PodMetricsEndpoint {
 	if promPodEnpoints == nil {
 		return nil
 	}
 	endPoints := make([]vmv1beta1.PodMetricsEndpoint, 0, len(promPodEnpoints))
-	for _, promEndPoint := range promPodEnpoints {
+	for i, promEndPoint := range promPodEnpoints {
+		path := fmt.Sprintf("spec.podMetricsEndpoints[%d]", i)
 		var safeTLS *promv1.SafeTLSConfig
 		if promEndPoint.TLSConfig != nil {
 			safeTLS = promEndPoint.TLSConfig
@@ -364,8 +371,8 @@ func convertPodEndpoints(promPodEnpoints []promv1.PodMetricsEndpoint) []vmv1beta
 				FollowRedirects: promEndPoint.FollowRedirects,
 			},
 			EndpointRelabelings: vmv1beta1.EndpointRelabelings{
-				RelabelConfigs:       ConvertRelabelConfig(promEndPoint.RelabelConfigs),
-				MetricRelabelConfigs: ConvertRelabelConfig(promEndPoint.MetricRelabelConfigs),
+				RelabelConfigs:       ConvertRelabelConfig(promEndPoint.RelabelConfigs, dropped, path+".relabelings"),
+				MetricRelabelConfigs: ConvertRelabelConfig(promEndPoint.MetricRelabelConfigs, dropped, path+".metricRelabelings"),
 			},
 
 			EndpointAuth: vmv1beta1.EndpointAuth{
@@ -384,7 +391,9 @@ func convertPodEndpoints(promPodEnpoints []promv1.PodMetricsEndpoint) []vmv1beta
 }
 
 // ConvertPodMonitor create VMPodScrape from PodMonitor
-func ConvertPodMonitor(podMon *promv1.PodMonitor, conf *config.BaseOperatorConf) *vmv1beta1.VMPodScrape {
+// and returns paths of dropped fields
+func ConvertPodMonitor(podMon *promv1.PodMonitor, conf *config.BaseOperatorConf) (*vmv1beta1.VMPodScrape, []string) {
+	var dropped DroppedFields
 	cs := &vmv1beta1.VMPodScrape{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        podMon.Name,
@@ -400,7 +409,7 @@ func ConvertPodMonitor(podMon *promv1.PodMonitor, conf *config.BaseOperatorConf)
 				Any:        podMon.Spec.NamespaceSelector.Any,
 				MatchNames: podMon.Spec.NamespaceSelector.MatchNames,
 			},
-			PodMetricsEndpoints: convertPodEndpoints(podMon.Spec.PodMetricsEndpoints),
+			PodMetricsEndpoints: convertPodEndpoints(podMon.Spec.PodMetricsEndpoints, &dropped),
 			ScrapeClassName:     podMon.Spec.ScrapeClassName,
 		},
 	}
@@ -425,14 +434,16 @@ func ConvertPodMonitor(podMon *promv1.PodMonitor, conf *config.BaseOperatorConf)
 		}
 	}
 	cs.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, cs.Annotations)
-	return cs
+	return cs, dropped
 }
 
 // ConvertProbe creates VMProbe from prometheus probe
-func ConvertProbe(probe *promv1.Probe, conf *config.BaseOperatorConf) *vmv1beta1.VMProbe {
+// and returns paths of dropped fields
+func ConvertProbe(probe *promv1.Probe, conf *config.BaseOperatorConf) (*vmv1beta1.VMProbe, []string) {
 	var (
 		k8sTargets    []*vmv1beta1.VMProbeTargetKubernetes
 		staticTargets *vmv1beta1.VMProbeTargetStatic
+		dropped       DroppedFields
 	)
 	if probe.Spec.Targets.Ingress != nil {
 		k8sTargets = append(k8sTargets, &vmv1beta1.VMProbeTargetKubernetes{
@@ -442,14 +453,14 @@ func ConvertProbe(probe *promv1.Probe, conf *config.BaseOperatorConf) *vmv1beta1
 				Any:        probe.Spec.Targets.Ingress.NamespaceSelector.Any,
 				MatchNames: probe.Spec.Targets.Ingress.NamespaceSelector.MatchNames,
 			},
-			RelabelConfigs: ConvertRelabelConfig(probe.Spec.Targets.Ingress.RelabelConfigs),
+			RelabelConfigs: ConvertRelabelConfig(probe.Spec.Targets.Ingress.RelabelConfigs, &dropped, "spec.targets.ingress.relabelingConfigs"),
 		})
 	}
 	if probe.Spec.Targets.StaticConfig != nil {
 		staticTargets = &vmv1beta1.VMProbeTargetStatic{
 			Targets:        probe.Spec.Targets.StaticConfig.Targets,
 			Labels:         probe.Spec.Targets.StaticConfig.Labels,
-			RelabelConfigs: ConvertRelabelConfig(probe.Spec.Targets.StaticConfig.RelabelConfigs),
+			RelabelConfigs: ConvertRelabelConfig(probe.Spec.Targets.StaticConfig.RelabelConfigs, &dropped, "spec.targets.staticConfig.relabelingConfigs"),
 		}
 	}
 	var safeTLS *promv1.SafeTLSConfig
@@ -479,7 +490,7 @@ func ConvertProbe(probe *promv1.Probe, conf *config.BaseOperatorConf) *vmv1beta1
 				Interval:      string(probe.Spec.Interval),
 				ScrapeTimeout: string(probe.Spec.ScrapeTimeout),
 			},
-			MetricRelabelConfigs: ConvertRelabelConfig(probe.Spec.MetricRelabelConfigs),
+			MetricRelabelConfigs: ConvertRelabelConfig(probe.Spec.MetricRelabelConfigs, &dropped, "spec.metricRelabelings"),
 			EndpointAuth: vmv1beta1.EndpointAuth{
 				BasicAuth:         ConvertBasicAuth(probe.Spec.BasicAuth),
 				BearerTokenSecret: convertBearerToken(probe.Spec.BearerTokenSecret), //nolint:staticcheck
@@ -509,18 +520,15 @@ func ConvertProbe(probe *promv1.Probe, conf *config.BaseOperatorConf) *vmv1beta1
 		}
 	}
 	cp.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, cp.Annotations)
-	return cp
+	return cp, dropped
 }
 
 func filterUnsupportedRelabelCfg(relabelCfgs []*vmv1beta1.RelabelConfig) []*vmv1beta1.RelabelConfig {
 	newRelabelCfg := make([]*vmv1beta1.RelabelConfig, 0, len(relabelCfgs))
 	for _, r := range relabelCfgs {
-		switch r.Action {
-		case "keep", "hashmod", "drop":
-			if len(r.SourceLabels) == 0 {
-				log.Info("filtering unsupported format of relabelConfig", "action", r.Action, "reason", "source labels are empty")
-				continue
-			}
+		if isUnsupportedRelabelConfig(r.Action, len(r.SourceLabels)) {
+			log.Info("filtering unsupported format of relabelConfig", "action", r.Action, "reason", "source labels are empty")
+			continue
 		}
 		newRelabelCfg = append(newRelabelCfg, r)
 	}
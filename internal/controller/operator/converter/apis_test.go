@@ -71,7 +71,7 @@ func TestConvertRelabelConfig(t *testing.T) {
 
 	f := func(opts opts) {
 		t.Helper()
-		got := ConvertRelabelConfig(opts.prc)
+		got := ConvertRelabelConfig(opts.prc, nil, "")
 		assert.Equal(t, got, opts.want)
 	}
 
@@ -118,7 +118,7 @@ func TestConvertEndpoint(t *testing.T) {
 	}
 	f := func(opts opts) {
 		t.Helper()
-		got := convertEndpoint(opts.pe)
+		got := convertEndpoint(opts.pe, nil)
 		assert.Equal(t, opts.want, got)
 	}
 
@@ -160,7 +160,7 @@ func TestConvertServiceMonitor(t *testing.T) {
 	}
 	f := func(opts opts) {
 		t.Helper()
-		got := ConvertServiceMonitor(opts.sm, &config.BaseOperatorConf{
+		got, _ := ConvertServiceMonitor(opts.sm, &config.BaseOperatorConf{
 			FilterPrometheusConverterLabelPrefixes:      []string{"app.kubernetes", "helm.sh"},
 			FilterPrometheusConverterAnnotationPrefixes: []string{"another-annotation-filter", "app.kubernetes"},
 		})
@@ -236,7 +236,7 @@ func TestConvertPodEndpoints(t *testing.T) {
 	}
 	f := func(opts opts) {
 		t.Helper()
-		got := convertPodEndpoints(opts.pe)
+		got := convertPodEndpoints(opts.pe, nil)
 		assert.Equal(t, opts.want, got)
 	}
 
@@ -347,7 +347,7 @@ func TestConvertProbe(t *testing.T) {
 	}
 	f := func(opts opts) {
 		t.Helper()
-		got := ConvertProbe(opts.pp, &config.BaseOperatorConf{
+		got, _ := ConvertProbe(opts.pp, &config.BaseOperatorConf{
 			FilterPrometheusConverterLabelPrefixes:      []string{"helm.sh"},
 			FilterPrometheusConverterAnnotationPrefixes: []string{"app.kubernetes"},
 		})
@@ -0,0 +1,65 @@
+package converter
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+)
+
+// isUnsupportedRelabelConfig checks if relabel config cannot be converted into VictoriaMetrics format
+func isUnsupportedRelabelConfig(action string, sourceLabelsLen int) bool {
+	switch action {
+	case "keep", "hashmod", "drop":
+		return sourceLabelsLen == 0
+	}
+	return false
+}
+
+// DroppedFields collects paths of source object fields, which are not supported by VictoriaMetrics
+// and were dropped or ignored during conversion
+//
+// nil DroppedFields is valid and discards all paths
+type DroppedFields []string
+
+// Add records path of dropped field if isSet
+func (d *DroppedFields) Add(path string, isSet bool) {
+	if d == nil || !isSet {
+		return
+	}
+	*d = append(*d, path)
+}
+
+// Unconverted records fields of src struct, which are set, but not listed at converted by json name.
+// Fields of inlined structs are skipped and must be checked separately
+//
+// It reports fields added to upstream types automatically, until conversion for them is added
+func (d *DroppedFields) Unconverted(path string, src any, converted ...string) {
+	if d == nil {
+		return
+	}
+	v := reflect.Indirect(reflect.ValueOf(src))
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous || !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if slices.Contains(converted, name) {
+			continue
+		}
+		d.Add(path+"."+name, !v.Field(i).IsZero())
+	}
+}
+
+func (d *DroppedFields) relabelings(path string, rcs []promv1.RelabelConfig) {
+	for i, rc := range rcs {
+		d.Add(fmt.Sprintf("%s[%d]", path, i), isUnsupportedRelabelConfig(rc.Action, len(rc.SourceLabels)))
+	}
+}
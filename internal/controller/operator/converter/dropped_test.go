@@ -0,0 +1,334 @@
+package converter
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+func TestDroppedFields(t *testing.T) {
+	f := func(obj any, want []string) {
+		t.Helper()
+		var got []string
+		conf := &config.BaseOperatorConf{}
+		switch o := obj.(type) {
+		case *promv1.ServiceMonitor:
+			_, got = ConvertServiceMonitor(o, conf)
+		case *promv1.PodMonitor:
+			_, got = ConvertPodMonitor(o, conf)
+		case *promv1.Probe:
+			_, got = ConvertProbe(o, conf)
+		case *promv1.Prometheus:
+			_, _, got = ConvertPrometheus(o, conf)
+		case *promv1.Alertmanager:
+			_, got = ConvertAlertmanager(o, conf)
+		default:
+			t.Fatalf("unexpected object type %T", obj)
+		}
+		assert.Equal(t, want, got)
+	}
+
+	unsupported := promv1.RelabelConfig{Action: "keep"}
+	supported := promv1.RelabelConfig{Action: "keep", SourceLabels: []promv1.LabelName{"job"}}
+
+	// nothing dropped
+	f(&promv1.ServiceMonitor{
+		Spec: promv1.ServiceMonitorSpec{
+			Endpoints: []promv1.Endpoint{
+				{RelabelConfigs: []promv1.RelabelConfig{supported}},
+			},
+		},
+	}, nil)
+
+	// unsupported relabelings
+	f(&promv1.ServiceMonitor{
+		Spec: promv1.ServiceMonitorSpec{
+			Endpoints: []promv1.Endpoint{
+				{RelabelConfigs: []promv1.RelabelConfig{supported}},
+				{
+					RelabelConfigs:       []promv1.RelabelConfig{supported, unsupported},
+					MetricRelabelConfigs: []promv1.RelabelConfig{unsupported},
+				},
+			},
+		},
+	}, []string{
+		"spec.endpoints[1].metricRelabelings[0]",
+		"spec.endpoints[1].relabelings[1]",
+	})
+	f(&promv1.PodMonitor{
+		Spec: promv1.PodMonitorSpec{
+			PodMetricsEndpoints: []promv1.PodMetricsEndpoint{
+				{RelabelConfigs: []promv1.RelabelConfig{unsupported}},
+			},
+		},
+	}, []string{"spec.podMetricsEndpoints[0].relabelings[0]"})
+	f(&promv1.Probe{
+		Spec: promv1.ProbeSpec{
+			MetricRelabelConfigs: []promv1.RelabelConfig{unsupported},
+			Targets: promv1.ProbeTargets{
+				StaticConfig: &promv1.ProbeTargetStaticConfig{
+					RelabelConfigs: []promv1.RelabelConfig{unsupported},
+				},
+			},
+		},
+	}, []string{
+		"spec.targets.staticConfig.relabelingConfigs[0]",
+		"spec.metricRelabelings[0]",
+	})
+
+	// ignored prometheus fields
+	f(&promv1.Prometheus{
+		Spec: promv1.PrometheusSpec{
+			CommonPrometheusFields: promv1.CommonPrometheusFields{
+				Image: ptr.To("prometheus:v3"),
+				RemoteWrite: []promv1.RemoteWriteSpec{
+					{Authorization: &promv1.Authorization{SafeAuthorization: promv1.SafeAuthorization{Type: "Bearer"}}},
+					{
+						Authorization: &promv1.Authorization{SafeAuthorization: promv1.SafeAuthorization{Type: "Basic"}},
+						QueueConfig:   &promv1.QueueConfig{},
+					},
+				},
+				Storage: &promv1.StorageSpec{
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				},
+			},
+			RuleSelector: &metav1.LabelSelector{},
+			Thanos:       &promv1.ThanosSpec{},
+		},
+	}, []string{
+		"spec.ruleSelector",
+		"spec.thanos",
+		"spec.image",
+		"spec.remoteWrite[1].queueConfig",
+		"spec.remoteWrite[1].authorization",
+	})
+
+	// configmap references for alertmanager tls
+	f(&promv1.Alertmanager{
+		Spec: promv1.AlertmanagerSpec{
+			Web: &promv1.AlertmanagerWebSpec{
+				WebConfigFileFields: promv1.WebConfigFileFields{
+					TLSConfig: &promv1.WebTLSConfig{
+						Cert: promv1.SecretOrConfigMap{ConfigMap: &corev1.ConfigMapKeySelector{}},
+					},
+				},
+			},
+			Limits: &promv1.AlertmanagerLimitsSpec{},
+		},
+	}, []string{
+		"spec.limits",
+		"spec.web.tlsConfig.cert.configMap",
+	})
+}
+
+// TestDroppedFieldsConsistency sets fields of source objects one by one and checks,
+// that field is reported as dropped if and only if it doesn't change conversion result
+func TestDroppedFieldsConsistency(t *testing.T) {
+	type opts struct {
+		path      string
+		newSrc    func() any
+		target    func(src any) reflect.Value
+		convert   func(src any) (any, []string)
+		overrides map[string]any
+	}
+	f := func(o opts) {
+		t.Helper()
+		base, _ := o.convert(o.newSrc())
+		for _, name := range structFieldNames(o.target(o.newSrc()).Type()) {
+			src := o.newSrc()
+			field := fieldByJSONName(o.target(src), name)
+			if v, ok := o.overrides[name]; ok {
+				field.Set(reflect.ValueOf(v))
+			} else {
+				fillValue(field, 0)
+			}
+			if field.IsZero() {
+				continue
+			}
+			path := o.path + "." + name
+			got, dropped := o.convert(src)
+			isDropped := slices.ContainsFunc(dropped, func(p string) bool {
+				return p == path || strings.HasPrefix(p, path+".")
+			})
+			if reflect.DeepEqual(base, got) {
+				assert.Truef(t, isDropped, "field %s doesn't change conversion result, but it isn't reported as dropped", path)
+			} else {
+				assert.NotContainsf(t, dropped, path, "field %s changes conversion result, but it's reported as dropped", path)
+			}
+		}
+	}
+	conf := &config.BaseOperatorConf{}
+	convertPrometheus := func(src any) (any, []string) {
+		vmAgent, vmSingle, dropped := ConvertPrometheus(src.(*promv1.Prometheus), conf)
+		return []any{vmAgent.Spec, vmSingle.Spec}, dropped
+	}
+	convertAlertmanager := func(src any) (any, []string) {
+		vmAM, dropped := ConvertAlertmanager(src.(*promv1.Alertmanager), conf)
+		return vmAM.Spec, dropped
+	}
+
+	// prometheus spec
+	f(opts{
+		path: "spec",
+		newSrc: func() any {
+			return &promv1.Prometheus{}
+		},
+		target: func(src any) reflect.Value {
+			return reflect.ValueOf(&src.(*promv1.Prometheus).Spec).Elem()
+		},
+		convert: convertPrometheus,
+	})
+
+	// prometheus remote write
+	f(opts{
+		path: "spec.remoteWrite[0]",
+		newSrc: func() any {
+			return &promv1.Prometheus{Spec: promv1.PrometheusSpec{CommonPrometheusFields: promv1.CommonPrometheusFields{
+				RemoteWrite: []promv1.RemoteWriteSpec{{}},
+			}}}
+		},
+		target: func(src any) reflect.Value {
+			return reflect.ValueOf(&src.(*promv1.Prometheus).Spec.RemoteWrite[0]).Elem()
+		},
+		convert: convertPrometheus,
+	})
+
+	// prometheus scrape class
+	f(opts{
+		path: "spec.scrapeClasses[0]",
+		newSrc: func() any {
+			return &promv1.Prometheus{Spec: promv1.PrometheusSpec{CommonPrometheusFields: promv1.CommonPrometheusFields{
+				ScrapeClasses: []promv1.ScrapeClass{{}},
+			}}}
+		},
+		target: func(src any) reflect.Value {
+			return reflect.ValueOf(&src.(*promv1.Prometheus).Spec.ScrapeClasses[0]).Elem()
+		},
+		convert: convertPrometheus,
+	})
+
+	// alertmanager spec
+	f(opts{
+		path: "spec",
+		newSrc: func() any {
+			return &promv1.Alertmanager{}
+		},
+		target: func(src any) reflect.Value {
+			return reflect.ValueOf(&src.(*promv1.Alertmanager).Spec).Elem()
+		},
+		convert: convertAlertmanager,
+		overrides: map[string]any{
+			// only None strategy has VMAlertmanager counterpart, OnNamespace is the default
+			"alertmanagerConfigMatcherStrategy": promv1.AlertmanagerConfigMatcherStrategy{Type: promv1.NoneConfigMatcherStrategyType},
+			"automountServiceAccountToken":      ptr.To(false),
+		},
+	})
+
+	// alertmanager web
+	f(opts{
+		path: "spec.web",
+		newSrc: func() any {
+			return &promv1.Alertmanager{Spec: promv1.AlertmanagerSpec{Web: &promv1.AlertmanagerWebSpec{}}}
+		},
+		target: func(src any) reflect.Value {
+			return reflect.ValueOf(src.(*promv1.Alertmanager).Spec.Web).Elem()
+		},
+		convert: convertAlertmanager,
+	})
+
+	// alertmanager gossip client tls
+	f(opts{
+		path: "spec.clusterTLS.client",
+		newSrc: func() any {
+			return &promv1.Alertmanager{Spec: promv1.AlertmanagerSpec{ClusterTLS: &promv1.ClusterTLSConfig{}}}
+		},
+		target: func(src any) reflect.Value {
+			return reflect.ValueOf(&src.(*promv1.Alertmanager).Spec.ClusterTLS.ClientTLS).Elem()
+		},
+		convert: convertAlertmanager,
+	})
+}
+
+// structFieldNames returns json names of struct fields, including fields of inlined structs
+func structFieldNames(t reflect.Type) []string {
+	var names []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous {
+			names = append(names, structFieldNames(f.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.IsExported() && name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func fieldByJSONName(v reflect.Value, name string) reflect.Value {
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Anonymous {
+			if fv := fieldByJSONName(v.Field(i), name); fv.IsValid() {
+				return fv
+			}
+			continue
+		}
+		if n, _, _ := strings.Cut(f.Tag.Get("json"), ","); n == name {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// fillValue sets non-zero values to the given value and all its nested fields
+func fillValue(v reflect.Value, depth int) {
+	if depth > 10 || !v.CanSet() {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.String:
+		v.SetString("2")
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(2)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(2)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(2)
+	case reflect.Pointer:
+		e := reflect.New(v.Type().Elem())
+		fillValue(e.Elem(), depth+1)
+		v.Set(e)
+	case reflect.Slice:
+		e := reflect.New(v.Type().Elem()).Elem()
+		fillValue(e, depth+1)
+		v.Set(reflect.Append(reflect.MakeSlice(v.Type(), 0, 1), e))
+	case reflect.Map:
+		k := reflect.New(v.Type().Key()).Elem()
+		fillValue(k, depth+1)
+		e := reflect.New(v.Type().Elem()).Elem()
+		fillValue(e, depth+1)
+		m := reflect.MakeMap(v.Type())
+		m.SetMapIndex(k, e)
+		v.Set(m)
+	case reflect.Struct:
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				fillValue(v.Field(i), depth+1)
+			}
+		}
+	}
+}
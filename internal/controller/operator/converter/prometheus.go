@@ -0,0 +1,309 @@
+package converter
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+// ConvertPrometheus creates VMAgent and VMSingle from Prometheus
+//
+// VMAgent performs scraping and forwards collected samples to the VMSingle,
+// which replaces Prometheus local storage. Paths of dropped fields are returned as well
+func ConvertPrometheus(prom *promv1.Prometheus, conf *config.BaseOperatorConf) (*vmv1beta1.VMAgent, *vmv1beta1.VMSingle, []string) {
+	var dropped DroppedFields
+	dropped.Unconverted("spec", &prom.Spec, "retention")
+	vmSingle := &vmv1beta1.VMSingle{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   prom.Namespace,
+			Name:        prom.Name,
+			Labels:      FilterPrefixes(prom.Labels, conf.FilterPrometheusConverterLabelPrefixes),
+			Annotations: FilterPrefixes(prom.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes),
+		},
+		Spec: vmv1beta1.VMSingleSpec{
+			RetentionPeriod: convertRetention(prom.Spec.Retention),
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				Affinity:          prom.Spec.Affinity,
+				Tolerations:       prom.Spec.Tolerations,
+				NodeSelector:      prom.Spec.NodeSelector,
+				PriorityClassName: prom.Spec.PriorityClassName,
+				ImagePullSecrets:  prom.Spec.ImagePullSecrets,
+			},
+		},
+	}
+	if prom.Spec.Storage != nil {
+		// VMSingle uses emptyDir without custom settings, if storage isn't set
+		dropped.Add("spec.storage.emptyDir", prom.Spec.Storage.EmptyDir != nil && *prom.Spec.Storage.EmptyDir != corev1.EmptyDirVolumeSource{})
+		dropped.Add("spec.storage.ephemeral", prom.Spec.Storage.Ephemeral != nil)
+	}
+	if prom.Spec.Storage != nil && prom.Spec.Storage.EmptyDir == nil && prom.Spec.Storage.Ephemeral == nil {
+		vct := prom.Spec.Storage.VolumeClaimTemplate
+		vmSingle.Spec.Storage = vct.Spec.DeepCopy()
+		vmSingle.Spec.StorageMetadata = vmv1beta1.EmbeddedObjectMetadata{
+			Name:        vct.Name,
+			Labels:      vct.Labels,
+			Annotations: vct.Annotations,
+		}
+	}
+	if conf.EnabledPrometheusConverterOwnerReferences {
+		vmSingle.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion:         promv1.SchemeGroupVersion.String(),
+				Kind:               promv1.PrometheusesKind,
+				Name:               prom.Name,
+				UID:                prom.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		}
+	}
+	vmSingle.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, vmSingle.Annotations)
+
+	vmAgent := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   prom.Namespace,
+			Name:        prom.Name,
+			Labels:      FilterPrefixes(prom.Labels, conf.FilterPrometheusConverterLabelPrefixes),
+			Annotations: FilterPrefixes(prom.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes),
+		},
+	}
+	ConvertCommonPrometheusFields(&prom.Spec.CommonPrometheusFields, &vmAgent.Spec, &dropped, "storage")
+	vmAgent.Spec.RemoteWrite = append(vmAgent.Spec.RemoteWrite, vmv1beta1.VMAgentRemoteWriteSpec{
+		URL: vmSingle.AsURL() + "/api/v1/write",
+	})
+	if conf.EnabledPrometheusConverterOwnerReferences {
+		vmAgent.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion:         promv1.SchemeGroupVersion.String(),
+				Kind:               promv1.PrometheusesKind,
+				Name:               prom.Name,
+				UID:                prom.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		}
+	}
+	vmAgent.Annotations = MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, vmAgent.Annotations)
+	return vmAgent, vmSingle, dropped
+}
+
+// ConvertCommonPrometheusFields converts fields shared by Prometheus and PrometheusAgent into VMAgent spec
+//
+// Prometheus specific settings, such as image, version, web and TSDB configuration are ignored
+// and recorded into dropped, except fields listed at converted, which are converted by the caller
+func ConvertCommonPrometheusFields(src *promv1.CommonPrometheusFields, dst *vmv1beta1.VMAgentSpec, dropped *DroppedFields, converted ...string) {
+	converted = append(converted,
+		"podMetadata", "logLevel", "logFormat", "serviceAccountName", "shards", "replicas", "remoteWrite",
+		"serviceMonitorSelector", "serviceMonitorNamespaceSelector", "podMonitorSelector", "podMonitorNamespaceSelector",
+		"probeSelector", "probeNamespaceSelector", "scrapeConfigSelector", "scrapeConfigNamespaceSelector",
+		"scrapeInterval", "scrapeTimeout", "sampleLimit", "externalLabels", "prometheusExternalLabelName",
+		"additionalScrapeConfigs", "scrapeClasses", "overrideHonorLabels", "overrideHonorTimestamps",
+		"ignoreNamespaceSelectors", "enforcedNamespaceLabel", "arbitraryFSAccessThroughSMs",
+		"resources", "affinity", "tolerations", "nodeSelector", "priorityClassName", "hostNetwork", "imagePullSecrets",
+		"secrets", "configMaps", "volumes", "volumeMounts", "terminationGracePeriodSeconds", "minReadySeconds",
+		"securityContext", "dnsPolicy", "topologySpreadConstraints", "hostAliases",
+	)
+	dropped.Unconverted("spec", src, converted...)
+	if src.PodMetadata != nil {
+		dst.PodMetadata = &vmv1beta1.EmbeddedObjectMetadata{
+			Name:        src.PodMetadata.Name,
+			Labels:      src.PodMetadata.Labels,
+			Annotations: src.PodMetadata.Annotations,
+		}
+	}
+	dst.LogLevel = convertLogLevel(src.LogLevel)
+	dst.LogFormat = convertLogFormat(src.LogFormat)
+	dst.ServiceAccountName = src.ServiceAccountName
+	if src.Shards != nil && *src.Shards > 1 {
+		dst.ShardCount = ptr.To(int(*src.Shards))
+	}
+	dst.ReplicaCount = src.Replicas
+	for i, rw := range src.RemoteWrite {
+		dst.RemoteWrite = append(dst.RemoteWrite, convertRemoteWrite(rw, dropped, fmt.Sprintf("spec.remoteWrite[%d]", i)))
+	}
+
+	dst.ServiceScrapeSelector = src.ServiceMonitorSelector
+	dst.ServiceScrapeNamespaceSelector = src.ServiceMonitorNamespaceSelector
+	dst.PodScrapeSelector = src.PodMonitorSelector
+	dst.PodScrapeNamespaceSelector = src.PodMonitorNamespaceSelector
+	dst.ProbeSelector = src.ProbeSelector
+	dst.ProbeNamespaceSelector = src.ProbeNamespaceSelector
+	dst.ScrapeConfigSelector = src.ScrapeConfigSelector
+	dst.ScrapeConfigNamespaceSelector = src.ScrapeConfigNamespaceSelector
+	dst.ScrapeInterval = string(src.ScrapeInterval)
+	dst.ScrapeTimeout = string(src.ScrapeTimeout)
+	if src.SampleLimit != nil {
+		dst.SampleLimit = int(min(*src.SampleLimit, math.MaxInt32))
+	}
+	dst.ExternalLabels = src.ExternalLabels
+	if src.PrometheusExternalLabelName != nil && *src.PrometheusExternalLabelName != "" {
+		dst.ExternalLabelName = src.PrometheusExternalLabelName
+	}
+	dst.AdditionalScrapeConfigs = src.AdditionalScrapeConfigs
+	for i, sc := range src.ScrapeClasses {
+		dst.ScrapeClasses = append(dst.ScrapeClasses, convertScrapeClass(sc, dropped, fmt.Sprintf("spec.scrapeClasses[%d]", i)))
+	}
+	dst.OverrideHonorLabels = src.OverrideHonorLabels
+	dst.OverrideHonorTimestamps = src.OverrideHonorTimestamps
+	dst.IgnoreNamespaceSelectors = src.IgnoreNamespaceSelectors
+	dst.EnforcedNamespaceLabel = src.EnforcedNamespaceLabel
+	dst.ArbitraryFSAccessThroughSMs.Deny = src.ArbitraryFSAccessThroughSMs.Deny
+
+	dst.Resources = src.Resources
+	dst.Affinity = src.Affinity
+	dst.Tolerations = src.Tolerations
+	dst.NodeSelector = src.NodeSelector
+	dst.PriorityClassName = src.PriorityClassName
+	dst.HostNetwork = src.HostNetwork
+	dst.ImagePullSecrets = src.ImagePullSecrets
+	dst.Secrets = src.Secrets
+	dst.ConfigMaps = src.ConfigMaps
+	dst.Volumes = src.Volumes
+	dst.VolumeMounts = src.VolumeMounts
+	dst.TerminationGracePeriodSeconds = src.TerminationGracePeriodSeconds
+	if src.MinReadySeconds != nil {
+		dst.MinReadySeconds = *src.MinReadySeconds
+	}
+	if src.SecurityContext != nil {
+		dst.SecurityContext = &vmv1beta1.SecurityContext{
+			PodSecurityContext: src.SecurityContext,
+		}
+	}
+	if src.DNSPolicy != nil {
+		dst.DNSPolicy = corev1.DNSPolicy(*src.DNSPolicy)
+	}
+	for _, tsc := range src.TopologySpreadConstraints {
+		dst.TopologySpreadConstraints = append(dst.TopologySpreadConstraints, corev1.TopologySpreadConstraint(tsc.CoreV1TopologySpreadConstraint))
+	}
+	for _, ha := range src.HostAliases {
+		dst.HostAliases = append(dst.HostAliases, corev1.HostAlias{
+			IP:        ha.IP,
+			Hostnames: ha.Hostnames,
+		})
+	}
+}
+
+func convertRemoteWrite(src promv1.RemoteWriteSpec, dropped *DroppedFields, path string) vmv1beta1.VMAgentRemoteWriteSpec {
+	dropped.Unconverted(path, &src, "url", "basicAuth", "oauth2", "tlsConfig", "writeRelabelConfigs", "remoteTimeout", "authorization", "headers")
+	dropped.Unconverted(path, &src.ProxyConfig, "proxyUrl")
+	rw := vmv1beta1.VMAgentRemoteWriteSpec{
+		URL:                    src.URL,
+		BasicAuth:              ConvertBasicAuth(src.BasicAuth),
+		OAuth2:                 ConvertOAuth(src.OAuth2),
+		TLSConfig:              ConvertTLSConfig(src.TLSConfig),
+		InlineUrlRelabelConfig: ConvertRelabelConfig(src.WriteRelabelConfigs, dropped, path+".writeRelabelConfigs"),
+		ProxyURL:               src.ProxyURL,
+	}
+	if src.RemoteTimeout != nil {
+		rw.SendTimeout = ptr.To(string(*src.RemoteTimeout))
+	}
+	if src.Authorization != nil {
+		if src.Authorization.Type == "" || strings.EqualFold(src.Authorization.Type, "Bearer") {
+			rw.BearerTokenSecret = src.Authorization.Credentials
+		} else {
+			// remote write supports only bearer token authorization
+			dropped.Add(path+".authorization", true)
+		}
+	}
+	for k, v := range src.Headers {
+		rw.Headers = append(rw.Headers, fmt.Sprintf("%s: %s", k, v))
+	}
+	sort.Strings(rw.Headers)
+	return rw
+}
+
+func convertScrapeClass(src promv1.ScrapeClass, dropped *DroppedFields, path string) vmv1beta1.ScrapeClass {
+	dropped.Unconverted(path, &src, "name", "default", "tlsConfig", "authorization", "relabelings", "metricRelabelings", "attachMetadata")
+	sc := vmv1beta1.ScrapeClass{
+		Name:    src.Name,
+		Default: src.Default,
+		EndpointAuth: vmv1beta1.EndpointAuth{
+			TLSConfig:     ConvertTLSConfig(src.TLSConfig),
+			Authorization: ConvertAuthorization(nil, src.Authorization),
+		},
+		EndpointRelabelings: vmv1beta1.EndpointRelabelings{
+			RelabelConfigs:       ConvertRelabelConfig(src.Relabelings, dropped, path+".relabelings"),
+			MetricRelabelConfigs: ConvertRelabelConfig(src.MetricRelabelings, dropped, path+".metricRelabelings"),
+		},
+	}
+	if src.AttachMetadata != nil {
+		sc.AttachMetadata = &vmv1beta1.AttachMetadata{
+			Node: src.AttachMetadata.Node,
+		}
+	}
+	return sc
+}
+
+func convertLogLevel(level string) string {
+	switch strings.ToLower(level) {
+	case "warn":
+		return "WARN"
+	case "error":
+		return "ERROR"
+	case "":
+		return ""
+	default:
+		// VictoriaMetrics components doesn't have debug level
+		return "INFO"
+	}
+}
+
+func convertLogFormat(format string) string {
+	switch format {
+	case "json":
+		return "json"
+	case "":
+		return ""
+	default:
+		return "default"
+	}
+}
+
+var (
+	retentionSingleUnitRe = regexp.MustCompile(`^[0-9]+[hdwy]$`)
+	retentionPartRe       = regexp.MustCompile(`([0-9]+)(ms|[ywdhms])`)
+	retentionUnits        = map[string]time.Duration{
+		"y":  365 * 24 * time.Hour,
+		"w":  7 * 24 * time.Hour,
+		"d":  24 * time.Hour,
+		"h":  time.Hour,
+		"m":  time.Minute,
+		"s":  time.Second,
+		"ms": time.Millisecond,
+	}
+)
+
+// convertRetention converts Prometheus duration into retentionPeriod format
+// Prometheus allows compound durations like 1d12h, which are not supported by VictoriaMetrics
+func convertRetention(retention promv1.Duration) string {
+	r := string(retention)
+	if r == "" || retentionSingleUnitRe.MatchString(r) {
+		return r
+	}
+	var total time.Duration
+	for _, m := range retentionPartRe.FindAllStringSubmatch(r, -1) {
+		v, err := strconv.Atoi(m[1])
+		if err != nil {
+			continue
+		}
+		total += time.Duration(v) * retentionUnits[m[2]]
+	}
+	// minimal supported retention is 1 day
+	hours := max(int(math.Ceil(total.Hours())), 24)
+	if hours%24 == 0 {
+		return fmt.Sprintf("%dd", hours/24)
+	}
+	return fmt.Sprintf("%dh", hours)
+}
@@ -0,0 +1,195 @@
+package converter
+
+import (
+	"testing"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+func TestConvertPrometheus(t *testing.T) {
+	type opts struct {
+		prom       *promv1.Prometheus
+		wantAgent  vmv1beta1.VMAgent
+		wantSingle vmv1beta1.VMSingle
+	}
+	f := func(o opts) {
+		t.Helper()
+		gotAgent, gotSingle, _ := ConvertPrometheus(o.prom, &config.BaseOperatorConf{
+			FilterPrometheusConverterLabelPrefixes: []string{"helm.sh"},
+		})
+		assert.Equal(t, o.wantAgent, *gotAgent)
+		assert.Equal(t, o.wantSingle, *gotSingle)
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:      "k8s",
+		Namespace: "monitoring",
+		Labels: map[string]string{
+			"app":             "prometheus",
+			"helm.sh/chart":   "kube-prometheus-stack",
+			"helm.sh/release": "monitoring",
+		},
+	}
+	wantMeta := metav1.ObjectMeta{
+		Name:      "k8s",
+		Namespace: "monitoring",
+		Labels: map[string]string{
+			"app": "prometheus",
+		},
+	}
+
+	// minimal spec
+	f(opts{
+		prom: &promv1.Prometheus{
+			ObjectMeta: objectMeta,
+		},
+		wantAgent: vmv1beta1.VMAgent{
+			ObjectMeta: wantMeta,
+			Spec: vmv1beta1.VMAgentSpec{
+				RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+					{URL: "http://vmsingle-k8s.monitoring.svc:8428/api/v1/write"},
+				},
+			},
+		},
+		wantSingle: vmv1beta1.VMSingle{
+			ObjectMeta: wantMeta,
+		},
+	})
+
+	// with storage, selectors and remote writes
+	storageSpec := corev1.PersistentVolumeClaimSpec{
+		StorageClassName: ptr.To("ssd"),
+		Resources: corev1.VolumeResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceStorage: resource.MustParse("50Gi"),
+			},
+		},
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"release": "monitoring"}}
+	f(opts{
+		prom: &promv1.Prometheus{
+			ObjectMeta: objectMeta,
+			Spec: promv1.PrometheusSpec{
+				CommonPrometheusFields: promv1.CommonPrometheusFields{
+					ServiceMonitorSelector:          selector,
+					ServiceMonitorNamespaceSelector: &metav1.LabelSelector{},
+					PodMonitorSelector:              selector,
+					Replicas:                        ptr.To[int32](2),
+					Shards:                          ptr.To[int32](3),
+					LogLevel:                        "debug",
+					LogFormat:                       "logfmt",
+					ScrapeInterval:                  "30s",
+					ExternalLabels:                  map[string]string{"cluster": "main"},
+					ServiceAccountName:              "prometheus-k8s",
+					RemoteWrite: []promv1.RemoteWriteSpec{
+						{
+							URL:           "http://remote-storage/api/v1/write",
+							RemoteTimeout: ptr.To(promv1.Duration("10s")),
+							Headers:       map[string]string{"X-Scope-OrgID": "team-a", "X-Env": "prod"},
+							Authorization: &promv1.Authorization{
+								SafeAuthorization: promv1.SafeAuthorization{
+									Credentials: &corev1.SecretKeySelector{
+										LocalObjectReference: corev1.LocalObjectReference{Name: "remote-auth"},
+										Key:                  "token",
+									},
+								},
+							},
+						},
+					},
+					Storage: &promv1.StorageSpec{
+						VolumeClaimTemplate: promv1.EmbeddedPersistentVolumeClaim{
+							Spec: storageSpec,
+						},
+					},
+					ScrapeClasses: []promv1.ScrapeClass{
+						{
+							Name:    "default",
+							Default: ptr.To(true),
+							Relabelings: []promv1.RelabelConfig{
+								{
+									Action:       "drop",
+									SourceLabels: []promv1.LabelName{"__meta_kubernetes_pod_label_skip"},
+								},
+							},
+						},
+					},
+				},
+				Retention: "1d12h",
+			},
+		},
+		wantAgent: vmv1beta1.VMAgent{
+			ObjectMeta: wantMeta,
+			Spec: vmv1beta1.VMAgentSpec{
+				LogLevel:           "INFO",
+				LogFormat:          "default",
+				ServiceAccountName: "prometheus-k8s",
+				ShardCount:         ptr.To(3),
+				RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+					{
+						URL:         "http://remote-storage/api/v1/write",
+						SendTimeout: ptr.To("10s"),
+						Headers:     []string{"X-Env: prod", "X-Scope-OrgID: team-a"},
+						BearerTokenSecret: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "remote-auth"},
+							Key:                  "token",
+						},
+					},
+					{URL: "http://vmsingle-k8s.monitoring.svc:8428/api/v1/write"},
+				},
+				CommonScrapeParams: vmv1beta1.CommonScrapeParams{
+					ScrapeInterval:                 "30s",
+					ServiceScrapeSelector:          selector,
+					ServiceScrapeNamespaceSelector: &metav1.LabelSelector{},
+					PodScrapeSelector:              selector,
+					ExternalLabels:                 map[string]string{"cluster": "main"},
+					ScrapeClasses: []vmv1beta1.ScrapeClass{
+						{
+							Name:    "default",
+							Default: ptr.To(true),
+							EndpointRelabelings: vmv1beta1.EndpointRelabelings{
+								RelabelConfigs: []*vmv1beta1.RelabelConfig{
+									{
+										Action:       "drop",
+										SourceLabels: []string{"__meta_kubernetes_pod_label_skip"},
+									},
+								},
+							},
+						},
+					},
+				},
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To[int32](2),
+				},
+			},
+		},
+		wantSingle: vmv1beta1.VMSingle{
+			ObjectMeta: wantMeta,
+			Spec: vmv1beta1.VMSingleSpec{
+				RetentionPeriod: "36h",
+				Storage:         &storageSpec,
+			},
+		},
+	})
+}
+
+func TestConvertRetention(t *testing.T) {
+	f := func(retention, want string) {
+		t.Helper()
+		assert.Equal(t, want, convertRetention(promv1.Duration(retention)))
+	}
+	f("", "")
+	f("15d", "15d")
+	f("2w", "2w")
+	f("36h", "36h")
+	f("1d12h", "36h")
+	f("2d24h", "3d")
+	f("30m", "1d")
+}
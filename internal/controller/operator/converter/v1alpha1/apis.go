@@ -138,7 +138,9 @@ func ConvertAlertmanagerConfig(promAMCfg *promv1alpha1.AlertmanagerConfig, conf
 }
 
 // ConvertScrapeConfig creates VMScrapeConfig from prometheus scrapeConfig
-func ConvertScrapeConfig(promscrapeConfig *promv1alpha1.ScrapeConfig, conf *config.BaseOperatorConf) *vmv1beta1.VMScrapeConfig {
+// and returns paths of dropped fields
+func ConvertScrapeConfig(promscrapeConfig *promv1alpha1.ScrapeConfig, conf *config.BaseOperatorConf) (*vmv1beta1.VMScrapeConfig, []string) {
+	var dropped converter.DroppedFields
 	cs := &vmv1beta1.VMScrapeConfig{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        promscrapeConfig.Name,
@@ -150,17 +152,17 @@ func ConvertScrapeConfig(promscrapeConfig *promv1alpha1.ScrapeConfig, conf *conf
 	data, err := json.Marshal(promscrapeConfig.Spec)
 	if err != nil {
 		log.Error(err, "POSSIBLE BUG: failed to marshal prometheus scrapeconfig for converting", "name", promscrapeConfig.Name, "namespace", promscrapeConfig.Namespace)
-		return cs
+		return cs, dropped
 	}
 	err = json.Unmarshal(data, &cs.Spec)
 	if err != nil {
 		log.Error(err, "POSSIBLE BUG: failed to convert prometheus scrapeconfig to VMScrapeConfig", "name", promscrapeConfig.Name, "namespace", promscrapeConfig.Namespace)
-		return cs
+		return cs, dropped
 	}
 	cs.Labels = converter.FilterPrefixes(promscrapeConfig.Labels, conf.FilterPrometheusConverterLabelPrefixes)
 	cs.Annotations = converter.FilterPrefixes(promscrapeConfig.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes)
-	cs.Spec.RelabelConfigs = converter.ConvertRelabelConfig(promscrapeConfig.Spec.RelabelConfigs)
-	cs.Spec.MetricRelabelConfigs = converter.ConvertRelabelConfig(promscrapeConfig.Spec.MetricRelabelConfigs)
+	cs.Spec.RelabelConfigs = converter.ConvertRelabelConfig(promscrapeConfig.Spec.RelabelConfigs, &dropped, "spec.relabelings")
+	cs.Spec.MetricRelabelConfigs = converter.ConvertRelabelConfig(promscrapeConfig.Spec.MetricRelabelConfigs, &dropped, "spec.metricRelabelings")
 	cs.Spec.Path = ptr.Deref(promscrapeConfig.Spec.MetricsPath, "")
 	for i := range cs.Spec.KubernetesSDConfigs {
 		sdCfg := &cs.Spec.KubernetesSDConfigs[i]
@@ -192,7 +194,42 @@ func ConvertScrapeConfig(promscrapeConfig *promv1alpha1.ScrapeConfig, conf *conf
 		}
 	}
 	cs.Annotations = converter.MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, cs.Annotations)
-	return cs
+	return cs, dropped
+}
+
+// ConvertPrometheusAgent creates VMAgent from PrometheusAgent
+// and returns paths of dropped fields
+func ConvertPrometheusAgent(promAgent *promv1alpha1.PrometheusAgent, conf *config.BaseOperatorConf) (*vmv1beta1.VMAgent, []string) {
+	var dropped converter.DroppedFields
+	dropped.Unconverted("spec", &promAgent.Spec, "mode")
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        promAgent.Name,
+			Namespace:   promAgent.Namespace,
+			Labels:      converter.FilterPrefixes(promAgent.Labels, conf.FilterPrometheusConverterLabelPrefixes),
+			Annotations: converter.FilterPrefixes(promAgent.Annotations, conf.FilterPrometheusConverterAnnotationPrefixes),
+		},
+	}
+	converter.ConvertCommonPrometheusFields(&promAgent.Spec.CommonPrometheusFields, &cr.Spec, &dropped)
+	if ptr.Deref(promAgent.Spec.Mode, "") == promv1alpha1.DaemonSetPrometheusAgentMode {
+		cr.Spec.DaemonSetMode = true
+		cr.Spec.ShardCount = nil
+		cr.Spec.ReplicaCount = nil
+	}
+	if conf.EnabledPrometheusConverterOwnerReferences {
+		cr.OwnerReferences = []metav1.OwnerReference{
+			{
+				APIVersion:         promv1alpha1.SchemeGroupVersion.String(),
+				Kind:               promv1alpha1.PrometheusAgentsKind,
+				Name:               promAgent.Name,
+				UID:                promAgent.UID,
+				Controller:         ptr.To(true),
+				BlockOwnerDeletion: ptr.To(true),
+			},
+		}
+	}
+	cr.Annotations = converter.MaybeAddArgoCDIgnoreAnnotations(conf.PrometheusConverterAddArgoCDIgnoreAnnotations, cr.Annotations)
+	return cr, dropped
 }
 
 func convertKVToMap(src []promv1alpha1.KeyValue) map[string]string {
@@ -93,7 +93,7 @@ func TestConvertScrapeConfig(t *testing.T) {
 	}
 	f := func(opts opts) {
 		t.Helper()
-		got := ConvertScrapeConfig(opts.scrapeConfig, &config.BaseOperatorConf{EnabledPrometheusConverterOwnerReferences: opts.ownerRef})
+		got, _ := ConvertScrapeConfig(opts.scrapeConfig, &config.BaseOperatorConf{EnabledPrometheusConverterOwnerReferences: opts.ownerRef})
 		assert.Equal(t, *got, opts.want)
 	}
 
@@ -533,3 +533,126 @@ func TestConvertScrapeConfig(t *testing.T) {
 		},
 	})
 }
+
+func TestConvertPrometheusAgent(t *testing.T) {
+	type opts struct {
+		promAgent *promv1alpha1.PrometheusAgent
+		want      vmv1beta1.VMAgent
+	}
+	f := func(o opts) {
+		t.Helper()
+		got, _ := ConvertPrometheusAgent(o.promAgent, &config.BaseOperatorConf{
+			EnabledPrometheusConverterOwnerReferences: true,
+		})
+		assert.Equal(t, o.want, *got)
+	}
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"release": "monitoring"}}
+
+	// statefulset mode
+	f(opts{
+		promAgent: &promv1alpha1.PrometheusAgent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "agent",
+				Namespace: "monitoring",
+				UID:       "agent-uid",
+			},
+			Spec: promv1alpha1.PrometheusAgentSpec{
+				CommonPrometheusFields: promv1.CommonPrometheusFields{
+					ServiceMonitorSelector: selector,
+					ProbeSelector:          selector,
+					Shards:                 ptr.To[int32](2),
+					RemoteWrite: []promv1.RemoteWriteSpec{
+						{
+							URL: "http://vminsert:8480/insert/0/prometheus/api/v1/write",
+							BasicAuth: &promv1.BasicAuth{
+								Username: corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "rw-auth"},
+									Key:                  "username",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		want: vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "agent",
+				Namespace: "monitoring",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion:         promv1alpha1.SchemeGroupVersion.String(),
+						Kind:               promv1alpha1.PrometheusAgentsKind,
+						Name:               "agent",
+						UID:                "agent-uid",
+						Controller:         ptr.To(true),
+						BlockOwnerDeletion: ptr.To(true),
+					},
+				},
+			},
+			Spec: vmv1beta1.VMAgentSpec{
+				ShardCount: ptr.To(2),
+				RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+					{
+						URL: "http://vminsert:8480/insert/0/prometheus/api/v1/write",
+						BasicAuth: &vmv1beta1.BasicAuth{
+							Username: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "rw-auth"},
+								Key:                  "username",
+							},
+						},
+					},
+				},
+				CommonScrapeParams: vmv1beta1.CommonScrapeParams{
+					ServiceScrapeSelector: selector,
+					ProbeSelector:         selector,
+				},
+			},
+		},
+	})
+
+	// daemonset mode
+	f(opts{
+		promAgent: &promv1alpha1.PrometheusAgent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "agent",
+				Namespace: "monitoring",
+			},
+			Spec: promv1alpha1.PrometheusAgentSpec{
+				Mode: ptr.To(promv1alpha1.DaemonSetPrometheusAgentMode),
+				CommonPrometheusFields: promv1.CommonPrometheusFields{
+					PodMonitorSelector: selector,
+					Replicas:           ptr.To[int32](3),
+					RemoteWrite: []promv1.RemoteWriteSpec{
+						{URL: "http://vmsingle:8428/api/v1/write"},
+					},
+				},
+			},
+		},
+		want: vmv1beta1.VMAgent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "agent",
+				Namespace: "monitoring",
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion:         promv1alpha1.SchemeGroupVersion.String(),
+						Kind:               promv1alpha1.PrometheusAgentsKind,
+						Name:               "agent",
+						Controller:         ptr.To(true),
+						BlockOwnerDeletion: ptr.To(true),
+					},
+				},
+			},
+			Spec: vmv1beta1.VMAgentSpec{
+				DaemonSetMode: true,
+				RemoteWrite: []vmv1beta1.VMAgentRemoteWriteSpec{
+					{URL: "http://vmsingle:8428/api/v1/write"},
+				},
+				CommonScrapeParams: vmv1beta1.CommonScrapeParams{
+					PodScrapeSelector: selector,
+				},
+			},
+		},
+	})
+}
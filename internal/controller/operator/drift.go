@@ -0,0 +1,89 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+// maxDriftMessageFields limits count of changed fields listed at event message
+const maxDriftMessageFields = 10
+
+var childObjectDriftTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "operator_child_object_drift_total",
+	Help: "Counts number of detected manual changes of child objects, which differ from the state defined by operator",
+}, []string{"kind", "controller"})
+
+func init() {
+	metrics.Registry.MustRegister(childObjectDriftTotal)
+}
+
+// reportedDrifts holds manual changes of child objects reported for each custom resource.
+// It prevents duplicate reports of changes, which are not reverted.
+var reportedDrifts sync.Map
+
+// reportChildObjectsDrift emits event and increments metric for each manual change of child objects of the given object,
+// which was not reported at previous reconcile
+func reportChildObjectsDrift(ctx context.Context, c client.Client, object client.Object, drifts []reconcile.Drift) {
+	gvk, err := apiutil.GVKForObject(object, c.Scheme())
+	if err != nil {
+		logger.WithContext(ctx).Error(err, "cannot report drift of child objects")
+		return
+	}
+	controller := strings.ToLower(gvk.Kind)
+	key := driftKey(object.GetName(), object.GetNamespace(), controller)
+	if len(drifts) == 0 {
+		reportedDrifts.Delete(key)
+		return
+	}
+	current := make(map[string]string, len(drifts))
+	var prev map[string]string
+	if v, ok := reportedDrifts.Load(key); ok {
+		prev = v.(map[string]string)
+	}
+	for _, d := range drifts {
+		child := d.Kind + "/" + d.Name
+		fields := strings.Join(d.Fields, ",")
+		current[child] = fields
+		if prev[child] == fields {
+			continue
+		}
+		childObjectDriftTotal.WithLabelValues(d.Kind, controller).Inc()
+		if err := createEventForObject(ctx, c, object, corev1.EventTypeWarning, "ChildObjectDrift", formatDriftMessage(d)); err != nil {
+			logger.WithContext(ctx).Error(err, "cannot create k8s api event")
+		}
+	}
+	reportedDrifts.Store(key, current)
+}
+
+// deleteReportedDrifts removes reported changes of child objects for the given custom resource
+func deleteReportedDrifts(name, ns, controller string) {
+	reportedDrifts.Delete(driftKey(name, ns, controller))
+}
+
+func driftKey(name, ns, controller string) string {
+	return fmt.Sprintf("%s/%s/%s", controller, ns, name)
+}
+
+func formatDriftMessage(d reconcile.Drift) string {
+	fields := d.Fields
+	if len(fields) > maxDriftMessageFields {
+		fields = append(slices.Clip(fields[:maxDriftMessageFields]), fmt.Sprintf("and %d more", len(d.Fields)-maxDriftMessageFields))
+	}
+	action := "reverted"
+	if !d.Reverted {
+		action = "kept according to drift policy"
+	}
+	return fmt.Sprintf("%s=%s was changed manually, changes were %s, fields: %s", d.Kind, d.Name, action, strings.Join(fields, ", "))
+}
@@ -0,0 +1,64 @@
+package operator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+func TestReportChildObjectsDrift(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "drift",
+			Namespace: "default",
+		},
+	}
+	fclient := k8stools.GetTestClientWithObjects(nil)
+	drift := reconcile.Drift{
+		Kind:   "StatefulSet",
+		Name:   "vmstorage-drift",
+		Fields: []string{"spec.replicas"},
+	}
+	metric := childObjectDriftTotal.WithLabelValues("StatefulSet", "vmcluster")
+	initial := testutil.ToFloat64(metric)
+	assertEvents := func(want int) {
+		t.Helper()
+		var events corev1.EventList
+		assert.NoError(t, fclient.List(ctx, &events))
+		assert.Len(t, events.Items, want)
+		for _, ev := range events.Items {
+			assert.Equal(t, corev1.EventTypeWarning, ev.Type)
+			assert.Equal(t, "ChildObjectDrift", ev.Reason)
+			assert.Equal(t, "StatefulSet=vmstorage-drift was changed manually, changes were kept according to drift policy, fields: spec.replicas", ev.Message)
+		}
+	}
+
+	reportChildObjectsDrift(ctx, fclient, cr, []reconcile.Drift{drift})
+	assertEvents(1)
+	assert.Equal(t, initial+1, testutil.ToFloat64(metric))
+
+	// the same drift is reported once
+	reportChildObjectsDrift(ctx, fclient, cr, []reconcile.Drift{drift})
+	assertEvents(1)
+	assert.Equal(t, initial+1, testutil.ToFloat64(metric))
+
+	// drift is reported again after it was resolved
+	reportChildObjectsDrift(ctx, fclient, cr, nil)
+	reportChildObjectsDrift(ctx, fclient, cr, []reconcile.Drift{drift})
+	assertEvents(2)
+	assert.Equal(t, initial+2, testutil.ToFloat64(metric))
+
+	// reported drift is removed with custom resource
+	deregisterObjectByCollector(cr.Name, cr.Namespace, "vmcluster")
+	_, ok := reportedDrifts.Load(driftKey(cr.Name, cr.Namespace, "vmcluster"))
+	assert.False(t, ok)
+}
@@ -45,6 +45,26 @@ func MustSkipRuntimeValidation() bool {
 	return mustSkipRuntimeValidation
 }
 
+// ValidationError indicates that object spec didn't pass runtime validation
+type ValidationError struct {
+	origin error
+}
+
+// NewValidationError wraps given error of object validation
+func NewValidationError(err error) error {
+	return &ValidationError{origin: err}
+}
+
+// Error implements errors.Error interface
+func (e *ValidationError) Error() string {
+	return e.origin.Error()
+}
+
+// Unwrap implements errors.Unwrap interface
+func (e *ValidationError) Unwrap() error {
+	return e.origin
+}
+
 type builderOpts interface {
 	client.Object
 	PrefixedName() string
@@ -407,6 +407,31 @@ func ConfigReloaderContainer(isInit bool, cr reloadable, mounts []corev1.VolumeM
 	return c
 }
 
+// SetConfigReloaderListenPort changes listen port of the given config-reloader container.
+// It's required for multiple config-reloader containers at the same pod
+func SetConfigReloaderListenPort(c *corev1.Container, portName string, port int32) {
+	c.Args = append(c.Args, fmt.Sprintf("--http.listenAddr=:%d", port))
+	sort.Strings(c.Args)
+	for i := range c.Ports {
+		if c.Ports[i].ContainerPort == int32(configReloaderDefaultPort) {
+			c.Ports[i].Name = portName
+			c.Ports[i].ContainerPort = port
+		}
+	}
+	handler := corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path:   configReloaderContainerProbe.HTTPGet.Path,
+			Scheme: configReloaderContainerProbe.HTTPGet.Scheme,
+			Port:   intstr.FromInt32(port),
+		},
+	}
+	for _, probe := range []*corev1.Probe{c.LivenessProbe, c.ReadinessProbe} {
+		if probe != nil {
+			probe.ProbeHandler = handler
+		}
+	}
+}
+
 // addPortProbesToConfigReloaderContainer conditionally adds readiness and liveness probes to the custom config-reloader image
 // exposes reloader-http port for container
 func addPortProbesToConfigReloaderContainer(crContainer *corev1.Container) {
@@ -0,0 +1,262 @@
+package build
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// DatasourceAccess defines type of requests to referenced datasource
+type DatasourceAccess int
+
+const (
+	// DatasourceRead is used for query requests
+	DatasourceRead DatasourceAccess = iota
+	// DatasourceWrite is used for ingestion requests
+	DatasourceWrite
+)
+
+// Datasource contains URL and credentials of referenced datasource
+type Datasource struct {
+	// URL of datasource, for cluster versions it contains tenant path
+	URL string
+	// TenantID must be passed to the client as is,
+	// it's set only for VMAuth, which routes requests by itself
+	TenantID    string
+	Username    string
+	Password    string
+	BearerToken string
+	// Headers must be sent with each request,
+	// it's used to pass tenant to VictoriaLogs cluster
+	Headers []string
+	// TLSConfig is set if referenced object serves requests with TLS.
+	// Operator doesn't know CA of the referenced object, so certificate verification is skipped by default,
+	// user could override it with explicit TLS config
+	TLSConfig *vmv1beta1.TLSConfig
+}
+
+// ResolveDatasourceRef fetches object referenced by ref and builds its access URL and credentials
+//
+// ns defines namespace of the object with reference, it's used as a default namespace of referenced object and
+// as a namespace of VMUser
+func ResolveDatasourceRef(ctx context.Context, rclient client.Client, ac *AssetsCache, ns string, ref *vmv1beta1.DatasourceRef, access DatasourceAccess, tenantID string) (*Datasource, error) {
+	nsn := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if nsn.Namespace == "" {
+		nsn.Namespace = ns
+	}
+	var ds Datasource
+	// extraArgs of referenced component, which serves requests
+	var extraArgs map[string]string
+	switch ref.Kind {
+	case "VMSingle":
+		var obj vmv1beta1.VMSingle
+		if err := getRefObject(ctx, rclient, ref.Kind, nsn, &obj); err != nil {
+			return nil, err
+		}
+		extraArgs = obj.Spec.ExtraArgs
+		ds.URL = obj.AsURL() + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, "")
+	case "VMCluster":
+		var obj vmv1beta1.VMCluster
+		if err := getRefObject(ctx, rclient, ref.Kind, nsn, &obj); err != nil {
+			return nil, err
+		}
+		tenant := tenantID
+		if tenant == "" {
+			tenant = "0"
+		}
+		switch access {
+		case DatasourceRead:
+			if obj.Spec.VMSelect == nil {
+				return nil, fmt.Errorf("referenced VMCluster=%s has no vmselect", nsn)
+			}
+			extraArgs = obj.Spec.VMSelect.ExtraArgs
+			ds.URL = obj.AsURL(vmv1beta1.ClusterComponentSelect) + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, fmt.Sprintf("/select/%s/prometheus", tenant))
+		case DatasourceWrite:
+			if obj.Spec.VMInsert == nil {
+				return nil, fmt.Errorf("referenced VMCluster=%s has no vminsert", nsn)
+			}
+			extraArgs = obj.Spec.VMInsert.ExtraArgs
+			ds.URL = obj.AsURL(vmv1beta1.ClusterComponentInsert) + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, fmt.Sprintf("/insert/%s/prometheus", tenant))
+		}
+	case "VLSingle":
+		var obj vmv1.VLSingle
+		if err := getRefObject(ctx, rclient, ref.Kind, nsn, &obj); err != nil {
+			return nil, err
+		}
+		extraArgs = obj.Spec.ExtraArgs
+		ds.URL = obj.AsURL() + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, "")
+	case "VLCluster":
+		var obj vmv1.VLCluster
+		if err := getRefObject(ctx, rclient, ref.Kind, nsn, &obj); err != nil {
+			return nil, err
+		}
+		switch access {
+		case DatasourceRead:
+			if obj.Spec.VLSelect == nil {
+				return nil, fmt.Errorf("referenced VLCluster=%s has no vlselect", nsn)
+			}
+			extraArgs = obj.Spec.VLSelect.ExtraArgs
+			ds.URL = obj.AsURL(vmv1beta1.ClusterComponentSelect) + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, "")
+		case DatasourceWrite:
+			if obj.Spec.VLInsert == nil {
+				return nil, fmt.Errorf("referenced VLCluster=%s has no vlinsert", nsn)
+			}
+			extraArgs = obj.Spec.VLInsert.ExtraArgs
+			ds.URL = obj.AsURL(vmv1beta1.ClusterComponentInsert) + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, "")
+		}
+		ds.Headers = tenantHeaders(tenantID)
+	case "VTSingle":
+		var obj vmv1.VTSingle
+		if err := getRefObject(ctx, rclient, ref.Kind, nsn, &obj); err != nil {
+			return nil, err
+		}
+		extraArgs = obj.Spec.ExtraArgs
+		ds.URL = obj.AsURL() + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, "")
+	case "VTCluster":
+		var obj vmv1.VTCluster
+		if err := getRefObject(ctx, rclient, ref.Kind, nsn, &obj); err != nil {
+			return nil, err
+		}
+		switch access {
+		case DatasourceRead:
+			if obj.Spec.Select == nil {
+				return nil, fmt.Errorf("referenced VTCluster=%s has no vtselect", nsn)
+			}
+			extraArgs = obj.Spec.Select.ExtraArgs
+			ds.URL = obj.AsURL(vmv1beta1.ClusterComponentSelect) + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, "")
+		case DatasourceWrite:
+			if obj.Spec.Insert == nil {
+				return nil, fmt.Errorf("referenced VTCluster=%s has no vtinsert", nsn)
+			}
+			extraArgs = obj.Spec.Insert.ExtraArgs
+			ds.URL = obj.AsURL(vmv1beta1.ClusterComponentInsert) + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, "")
+		}
+		ds.Headers = tenantHeaders(tenantID)
+	case "VMAuth":
+		var obj vmv1beta1.VMAuth
+		if err := getRefObject(ctx, rclient, ref.Kind, nsn, &obj); err != nil {
+			return nil, err
+		}
+		extraArgs = obj.Spec.ExtraArgs
+		ds.URL = obj.AsURL() + vmv1beta1.BuildPathWithPrefixFlag(extraArgs, "")
+		ds.TenantID = tenantID
+		if ref.User != "" {
+			if err := loadVMUserCreds(ctx, rclient, ac, types.NamespacedName{Name: ref.User, Namespace: ns}, &ds); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported datasource ref kind=%q", ref.Kind)
+	}
+	if vmv1beta1.UseTLS(extraArgs) {
+		ds.TLSConfig = &vmv1beta1.TLSConfig{InsecureSkipVerify: true}
+	}
+	return &ds, nil
+}
+
+// tenantHeaders returns headers with tenant for VictoriaLogs and VictoriaTraces,
+// which accept tenant only with headers
+func tenantHeaders(tenantID string) []string {
+	if tenantID == "" {
+		return nil
+	}
+	accountID, projectID, _ := strings.Cut(tenantID, ":")
+	if projectID == "" {
+		projectID = "0"
+	}
+	return []string{"AccountID:" + accountID, "ProjectID:" + projectID}
+}
+
+func getRefObject(ctx context.Context, rclient client.Client, kind string, nsn types.NamespacedName, obj client.Object) error {
+	if err := rclient.Get(ctx, nsn, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("referenced %s=%s does not exist", kind, nsn)
+		}
+		return fmt.Errorf("cannot get referenced %s=%s: %w", kind, nsn, err)
+	}
+	return nil
+}
+
+// loadVMUserCreds loads credentials of VMUser from secrets in the same way as vmauth config is built
+func loadVMUserCreds(ctx context.Context, rclient client.Client, ac *AssetsCache, nsn types.NamespacedName, ds *Datasource) error {
+	var user vmv1beta1.VMUser
+	if err := getRefObject(ctx, rclient, "VMUser", nsn, &user); err != nil {
+		return err
+	}
+	switch {
+	case user.Spec.TokenRef != nil:
+		token, err := ac.LoadKeyFromSecret(user.Namespace, user.Spec.TokenRef)
+		if err != nil {
+			return fmt.Errorf("cannot load token of VMUser=%s: %w", nsn, err)
+		}
+		ds.BearerToken = token
+	case user.Spec.BearerToken != nil:
+		ds.BearerToken = *user.Spec.BearerToken
+	default:
+		ds.Username = user.Name
+		if user.Spec.Username != nil && *user.Spec.Username != "" {
+			ds.Username = *user.Spec.Username
+		}
+		passwordRef := user.Spec.PasswordRef
+		if passwordRef == nil && user.Spec.Password == nil && user.Spec.GeneratePassword {
+			// password is generated by operator and stored at VMUser secret
+			passwordRef = &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: user.PrefixedName()},
+				Key:                  "password",
+			}
+		}
+		switch {
+		case passwordRef != nil:
+			password, err := ac.LoadKeyFromSecret(user.Namespace, passwordRef)
+			if err != nil {
+				return fmt.Errorf("cannot load password of VMUser=%s: %w", nsn, err)
+			}
+			ds.Password = password
+		case user.Spec.Password != nil:
+			ds.Password = *user.Spec.Password
+		}
+	}
+	return nil
+}
+
+// VMUserHTTPAuth returns auth settings, which refer to secrets with VMUser credentials
+//
+// Unlike loadVMUserCreds it doesn't load secret values, so they could be mounted as files
+func VMUserHTTPAuth(ctx context.Context, rclient client.Client, nsn types.NamespacedName) (*vmv1beta1.HTTPAuth, error) {
+	var user vmv1beta1.VMUser
+	if err := getRefObject(ctx, rclient, "VMUser", nsn, &user); err != nil {
+		return nil, err
+	}
+	// operator keeps VMUser credentials at the secret with prefixed name
+	userSecret := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: user.PrefixedName()},
+			Key:                  key,
+		}
+	}
+	switch {
+	case user.Spec.TokenRef != nil:
+		return &vmv1beta1.HTTPAuth{BearerAuth: &vmv1beta1.BearerAuth{TokenSecret: user.Spec.TokenRef}}, nil
+	case user.Spec.BearerToken != nil:
+		return &vmv1beta1.HTTPAuth{BearerAuth: &vmv1beta1.BearerAuth{TokenSecret: userSecret("bearerToken")}}, nil
+	}
+	basicAuth := &vmv1beta1.BasicAuth{
+		Username: *userSecret("username"),
+	}
+	switch {
+	case user.Spec.PasswordRef != nil:
+		basicAuth.Password = *user.Spec.PasswordRef
+	case user.Spec.Password != nil || user.Spec.GeneratePassword:
+		basicAuth.Password = *userSecret("password")
+	}
+	// user without password has no password key at the secret
+	return &vmv1beta1.HTTPAuth{BasicAuth: basicAuth}, nil
+}
@@ -0,0 +1,296 @@
+package build
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestResolveDatasourceRef(t *testing.T) {
+	type opts struct {
+		ref               *vmv1beta1.DatasourceRef
+		access            DatasourceAccess
+		tenantID          string
+		predefinedObjects []runtime.Object
+		want              *Datasource
+		wantErr           bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		ctx := context.TODO()
+		fclient := k8stools.GetTestClientWithObjects(o.predefinedObjects)
+		ac := NewAssetsCache(ctx, fclient, nil)
+		got, err := ResolveDatasourceRef(ctx, fclient, ac, "default", o.ref, o.access, o.tenantID)
+		if o.wantErr {
+			assert.Error(t, err)
+			return
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, o.want, got)
+	}
+
+	cluster := &vmv1beta1.VMCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "main",
+			Namespace: "monitoring",
+		},
+		Spec: vmv1beta1.VMClusterSpec{
+			VMSelect: &vmv1beta1.VMSelect{},
+			VMInsert: &vmv1beta1.VMInsert{},
+		},
+	}
+
+	// missing object
+	f(opts{
+		ref:     &vmv1beta1.DatasourceRef{Kind: "VMSingle", Name: "main"},
+		wantErr: true,
+	})
+
+	// vmsingle with path prefix
+	f(opts{
+		ref: &vmv1beta1.DatasourceRef{Kind: "VMSingle", Name: "main"},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMSingle{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: "default",
+				},
+				Spec: vmv1beta1.VMSingleSpec{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ExtraArgs: map[string]string{"http.pathPrefix": "/single"},
+					},
+				},
+			},
+		},
+		want: &Datasource{
+			URL: "http://vmsingle-main.default.svc:8428/single",
+		},
+	})
+
+	// vmcluster read with default tenant
+	f(opts{
+		ref:               &vmv1beta1.DatasourceRef{Kind: "VMCluster", Name: "main", Namespace: "monitoring"},
+		access:            DatasourceRead,
+		predefinedObjects: []runtime.Object{cluster},
+		want: &Datasource{
+			URL: "http://vmselect-main.monitoring.svc:8481/select/0/prometheus",
+		},
+	})
+
+	// vmcluster write with tenant
+	f(opts{
+		ref:               &vmv1beta1.DatasourceRef{Kind: "VMCluster", Name: "main", Namespace: "monitoring"},
+		access:            DatasourceWrite,
+		tenantID:          "1:2",
+		predefinedObjects: []runtime.Object{cluster},
+		want: &Datasource{
+			URL: "http://vminsert-main.monitoring.svc:8480/insert/1:2/prometheus",
+		},
+	})
+
+	// vmsingle with TLS
+	f(opts{
+		ref: &vmv1beta1.DatasourceRef{Kind: "VMSingle", Name: "main"},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMSingle{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: "default",
+				},
+				Spec: vmv1beta1.VMSingleSpec{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ExtraArgs: map[string]string{"tls": "true"},
+					},
+				},
+			},
+		},
+		want: &Datasource{
+			URL:       "https://vmsingle-main.default.svc:8428",
+			TLSConfig: &vmv1beta1.TLSConfig{InsecureSkipVerify: true},
+		},
+	})
+
+	// vmcluster write with TLS at vmselect only
+	f(opts{
+		ref:    &vmv1beta1.DatasourceRef{Kind: "VMCluster", Name: "main", Namespace: "monitoring"},
+		access: DatasourceWrite,
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: "monitoring",
+				},
+				Spec: vmv1beta1.VMClusterSpec{
+					VMSelect: &vmv1beta1.VMSelect{
+						CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+							ExtraArgs: map[string]string{"tls": "true"},
+						},
+					},
+					VMInsert: &vmv1beta1.VMInsert{},
+				},
+			},
+		},
+		want: &Datasource{
+			URL: "http://vminsert-main.monitoring.svc:8480/insert/0/prometheus",
+		},
+	})
+
+	// vmcluster without vmselect
+	f(opts{
+		ref:    &vmv1beta1.DatasourceRef{Kind: "VMCluster", Name: "main", Namespace: "monitoring"},
+		access: DatasourceRead,
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: "monitoring",
+				},
+			},
+		},
+		wantErr: true,
+	})
+
+	// vtcluster read with tenant
+	f(opts{
+		ref:      &vmv1beta1.DatasourceRef{Kind: "VTCluster", Name: "traces"},
+		access:   DatasourceRead,
+		tenantID: "3",
+		predefinedObjects: []runtime.Object{
+			&vmv1.VTCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "traces",
+					Namespace: "default",
+				},
+				Spec: vmv1.VTClusterSpec{
+					Select: &vmv1.VTSelect{},
+				},
+			},
+		},
+		want: &Datasource{
+			URL:     "http://vtselect-traces.default.svc:10471",
+			Headers: []string{"AccountID:3", "ProjectID:0"},
+		},
+	})
+
+	// vmauth with generated password of vmuser
+	f(opts{
+		ref:      &vmv1beta1.DatasourceRef{Kind: "VMAuth", Name: "main", User: "anomaly"},
+		tenantID: "5",
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMAuth{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: "default",
+				},
+			},
+			&vmv1beta1.VMUser{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "anomaly",
+					Namespace: "default",
+				},
+				Spec: vmv1beta1.VMUserSpec{
+					GeneratePassword: true,
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "vmuser-anomaly",
+					Namespace: "default",
+				},
+				Data: map[string][]byte{"password": []byte("generated")},
+			},
+		},
+		want: &Datasource{
+			URL:      "http://vmauth-main.default.svc:8427",
+			TenantID: "5",
+			Username: "anomaly",
+			Password: "generated",
+		},
+	})
+
+	// vmauth with bearer token of vmuser
+	f(opts{
+		ref: &vmv1beta1.DatasourceRef{Kind: "VMAuth", Name: "main", User: "anomaly"},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMAuth{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "main",
+					Namespace: "default",
+				},
+			},
+			&vmv1beta1.VMUser{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "anomaly",
+					Namespace: "default",
+				},
+				Spec: vmv1beta1.VMUserSpec{
+					BearerToken: ptr.To("secret-token"),
+				},
+			},
+		},
+		want: &Datasource{
+			URL:         "http://vmauth-main.default.svc:8427",
+			BearerToken: "secret-token",
+		},
+	})
+}
+
+func TestVMUserHTTPAuth(t *testing.T) {
+	f := func(spec vmv1beta1.VMUserSpec, want *vmv1beta1.HTTPAuth) {
+		t.Helper()
+		ctx := context.TODO()
+		fclient := k8stools.GetTestClientWithObjects([]runtime.Object{
+			&vmv1beta1.VMUser{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "user",
+					Namespace: "default",
+				},
+				Spec: spec,
+			},
+		})
+		got, err := VMUserHTTPAuth(ctx, fclient, types.NamespacedName{Name: "user", Namespace: "default"})
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+	userSecret := func(key string) corev1.SecretKeySelector {
+		return corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "vmuser-user"},
+			Key:                  key,
+		}
+	}
+
+	// user without password
+	f(vmv1beta1.VMUserSpec{}, &vmv1beta1.HTTPAuth{
+		BasicAuth: &vmv1beta1.BasicAuth{Username: userSecret("username")},
+	})
+
+	// user with generated password
+	f(vmv1beta1.VMUserSpec{GeneratePassword: true}, &vmv1beta1.HTTPAuth{
+		BasicAuth: &vmv1beta1.BasicAuth{Username: userSecret("username"), Password: userSecret("password")},
+	})
+
+	// user with password ref
+	passwordRef := corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "creds"},
+		Key:                  "pass",
+	}
+	f(vmv1beta1.VMUserSpec{PasswordRef: &passwordRef}, &vmv1beta1.HTTPAuth{
+		BasicAuth: &vmv1beta1.BasicAuth{Username: userSecret("username"), Password: passwordRef},
+	})
+
+	// user with bearer token
+	token := userSecret("bearerToken")
+	f(vmv1beta1.VMUserSpec{BearerToken: ptr.To("token")}, &vmv1beta1.HTTPAuth{
+		BearerAuth: &vmv1beta1.BearerAuth{TokenSecret: &token},
+	})
+}
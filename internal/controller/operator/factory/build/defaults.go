@@ -233,6 +233,10 @@ func addVMAlertDefaults(objI any) {
 	if cr.Spec.ConfigReloaderImage == "" {
 		panic("cannot be empty")
 	}
+	for i := range cr.Spec.Datasources {
+		ds := &cr.Spec.Datasources[i]
+		ds.Resources = Resources(ds.Resources, config.Resource(cv.Resource), ptr.Deref(cr.Spec.UseDefaultResources, false))
+	}
 }
 
 func addVMAgentDefaults(objI any) {
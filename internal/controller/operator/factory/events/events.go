@@ -0,0 +1,105 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// Reasons of events emitted for custom resource during reconcile of its child objects
+const (
+	// ReasonScaled indicates that replicas count of Deployment or StatefulSet was changed
+	ReasonScaled = "Scaled"
+	// ReasonRolloutStarted indicates that pods of Deployment or StatefulSet started update to the new revision
+	ReasonRolloutStarted = "RolloutStarted"
+	// ReasonRolloutFinished indicates that all pods of Deployment or StatefulSet were updated and became ready
+	ReasonRolloutFinished = "RolloutFinished"
+	// ReasonPodRecreated indicates that StatefulSet pod was evicted or deleted by operator and became ready with the new revision
+	ReasonPodRecreated = "PodRecreated"
+	// ReasonPVCExpanded indicates that size of PersistentVolumeClaim was increased
+	ReasonPVCExpanded = "PVCExpanded"
+	// ReasonStatefulSetRecreated indicates that StatefulSet was recreated with orphaned pods due to changes of immutable fields
+	ReasonStatefulSetRecreated = "StatefulSetRecreated"
+	// ReasonOrphanRemoved indicates that child object, which is no longer needed, was removed
+	ReasonOrphanRemoved = "OrphanRemoved"
+	// ReasonChangesDeferred indicates that disruptive changes of child objects were deferred until the next maintenance window
+	ReasonChangesDeferred = "ChangesDeferred"
+	// ReasonDeferredChangesApplied indicates that previously deferred changes of child objects were applied within maintenance window
+	ReasonDeferredChangesApplied = "DeferredChangesApplied"
+)
+
+type recorder struct {
+	rclient client.Client
+	object  client.Object
+}
+
+type recorderKey struct{}
+
+// AddToContext returns context with recorder, which emits events for the given custom resource
+func AddToContext(ctx context.Context, rclient client.Client, object client.Object) context.Context {
+	return context.WithValue(ctx, recorderKey{}, &recorder{rclient: rclient, object: object})
+}
+
+// Normal emits event with Normal type for custom resource stored at the given context.
+// It does nothing if context has no recorder
+func Normal(ctx context.Context, reason, message string) {
+	record(ctx, corev1.EventTypeNormal, reason, message)
+}
+
+// Warning emits event with Warning type for custom resource stored at the given context.
+// It does nothing if context has no recorder
+func Warning(ctx context.Context, reason, message string) {
+	record(ctx, corev1.EventTypeWarning, reason, message)
+}
+
+func record(ctx context.Context, eventType, reason, message string) {
+	r, ok := ctx.Value(recorderKey{}).(*recorder)
+	if !ok {
+		return
+	}
+	if err := Create(ctx, r.rclient, r.object, eventType, reason, message); err != nil {
+		logger.WithContext(ctx).Error(err, "cannot create k8s api event")
+	}
+}
+
+// Create creates event for the given object at kubernetes API
+func Create(ctx context.Context, rclient client.Client, object client.Object, eventType, reason, message string) error {
+	gvk := object.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		if v, err := apiutil.GVKForObject(object, rclient.Scheme()); err == nil {
+			gvk = v
+		}
+	}
+	ev := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "victoria-metrics-operator-" + uuid.New().String(),
+			Namespace: object.GetNamespace(),
+		},
+		Type:    eventType,
+		Reason:  reason,
+		Message: message,
+		Source: corev1.EventSource{
+			Component: "victoria-metrics-operator",
+		},
+		LastTimestamp: metav1.NewTime(time.Now()),
+		InvolvedObject: corev1.ObjectReference{
+			Kind:            gvk.Kind,
+			Namespace:       object.GetNamespace(),
+			Name:            object.GetName(),
+			UID:             object.GetUID(),
+			ResourceVersion: object.GetResourceVersion(),
+		},
+	}
+	if err := rclient.Create(ctx, ev); err != nil {
+		return fmt.Errorf("cannot create generic event at k8s api for object: %q: %w", gvk.GroupKind(), err)
+	}
+	return nil
+}
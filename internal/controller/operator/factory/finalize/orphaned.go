@@ -2,6 +2,7 @@ package finalize
 
 import (
 	"context"
+	"fmt"
 	"slices"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 )
 
 // RemoveOrphanedDeployments removes Deployments detached from given object
@@ -115,6 +117,19 @@ func RemoveOrphanedVMPodScrapes(ctx context.Context, rclient client.Client, cr c
 	return removeOrphaned(ctx, rclient, cr, gvk, keepNames, shouldRemove)
 }
 
+// RemoveOrphanedVMRules removes VMRules detached from given object
+func RemoveOrphanedVMRules(ctx context.Context, rclient client.Client, cr crObject, keepNames sets.Set[string], shouldRemove bool) error {
+	if build.IsControllerDisabled("VMRule") {
+		return nil
+	}
+	gvk := schema.GroupVersionKind{
+		Group:   "operator.victoriametrics.com",
+		Version: "v1beta1",
+		Kind:    "VMRule",
+	}
+	return removeOrphaned(ctx, rclient, cr, gvk, keepNames, shouldRemove)
+}
+
 // removeOrphaned removes orphaned resources
 func removeOrphaned(ctx context.Context, rclient client.Client, cr crObject, gvk schema.GroupVersionKind, keepNames sets.Set[string], shouldRemove bool) error {
 	var l unstructured.UnstructuredList
@@ -140,6 +155,7 @@ func removeOrphaned(ctx context.Context, rclient client.Client, cr crObject, gvk
 			if err := SafeDelete(ctx, rclient, item); err != nil {
 				return err
 			}
+			events.Normal(ctx, events.ReasonOrphanRemoved, fmt.Sprintf("removed orphaned %s=%s", gvk.Kind, item.GetName()))
 		}
 	}
 	return nil
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 )
 
@@ -26,12 +28,22 @@ func TestRemoveOrphanedDeployments(t *testing.T) {
 	f := func(o opts) {
 		t.Helper()
 		cl := k8stools.GetTestClientWithObjects(o.predefinedObjects)
-		ctx := context.TODO()
+		ctx := events.AddToContext(context.TODO(), cl, o.cr.(client.Object))
+		var prevDep appsv1.DeploymentList
+		lo := client.ListOptions{Namespace: o.cr.GetNamespace(), LabelSelector: labels.SelectorFromSet(o.cr.SelectorLabels())}
+		assert.NoError(t, cl.List(ctx, &prevDep, &lo))
 		assert.NoError(t, RemoveOrphanedDeployments(ctx, cl, o.cr, o.keepDeployments, true))
 		var existDep appsv1.DeploymentList
-		lo := client.ListOptions{Namespace: o.cr.GetNamespace(), LabelSelector: labels.SelectorFromSet(o.cr.SelectorLabels())}
 		assert.NoError(t, cl.List(ctx, &existDep, &lo))
 		assert.Len(t, existDep.Items, o.wantDepCount)
+
+		// each removed object is reported with event
+		var eventList corev1.EventList
+		assert.NoError(t, cl.List(ctx, &eventList))
+		assert.Len(t, eventList.Items, len(prevDep.Items)-o.wantDepCount)
+		for _, ev := range eventList.Items {
+			assert.Equal(t, events.ReasonOrphanRemoved, ev.Reason)
+		}
 	}
 
 	// remove nothing
@@ -18,6 +18,8 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/VictoriaMetrics/operator/internal/config"
 )
 
 var invalidDNS1123Characters = regexp.MustCompile("[^-a-z0-9]+")
@@ -113,6 +115,10 @@ func UpdatePodAnnotations(ctx context.Context, rclient client.Client, selector m
 func ListObjectsByNamespace[T any, PT listing[T]](ctx context.Context, rclient client.Client, nss []string, collect func(PT), opts ...client.ListOption) error {
 	dst := PT(new(T))
 	if len(nss) == 0 {
+		if !config.IsClusterWideAccessAllowed() {
+			// none of namespaces matched WATCH_NAMESPACE_SELECTOR
+			return nil
+		}
 		if err := rclient.List(ctx, dst, opts...); err != nil {
 			return fmt.Errorf("cannot list objects at cluster scope: %w", err)
 		}
@@ -203,7 +209,7 @@ func NewObjectWatcherForNamespaces[T any, PT listing[T]](ctx context.Context, rc
 		return nil
 	}
 
-	if len(namespaces) == 0 {
+	if len(namespaces) == 0 && config.IsClusterWideAccessAllowed() {
 		if err := addWatcher(""); err != nil {
 			return nil, fmt.Errorf("cannot start cluster-wide watcher: %w", err)
 		}
@@ -215,6 +221,20 @@ func NewObjectWatcherForNamespaces[T any, PT listing[T]](ctx context.Context, rc
 		}
 	}
 
+	if config.MustGetBaseConfig().WatchNamespaceSelector != "" {
+		// stop watch on namespaces change, watch must be started again with the new namespaces list
+		changed := config.WatchNamespacesChanged()
+		ownss.wg.Add(1)
+		go func() {
+			defer ownss.wg.Done()
+			select {
+			case <-localCtx.Done():
+			case <-changed:
+				cancel()
+			}
+		}()
+	}
+
 	go func() {
 		ownss.wg.Wait()
 		cancel()
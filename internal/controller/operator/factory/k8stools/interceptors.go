@@ -2,8 +2,12 @@ package k8stools
 
 import (
 	"context"
+	"encoding/json"
 
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
@@ -87,7 +91,51 @@ func GetInterceptorsWithObjects() interceptor.Funcs {
 			}
 			return nil
 		},
+		Apply: func(ctx context.Context, cl client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			if err := cl.Apply(ctx, obj, opts...); err != nil {
+				return err
+			}
+			kind, nsn := applyConfigurationKey(obj)
+			switch kind {
+			case "StatefulSet":
+				var v appsv1.StatefulSet
+				if err := cl.Get(ctx, nsn, &v); err != nil {
+					return err
+				}
+				v.Status.ObservedGeneration = v.Generation
+				v.Status.ReadyReplicas = ptr.Deref(v.Spec.Replicas, 0)
+				v.Status.UpdatedReplicas = ptr.Deref(v.Spec.Replicas, 0)
+				v.Status.CurrentReplicas = ptr.Deref(v.Spec.Replicas, 0)
+				v.Status.UpdateRevision = "v1"
+				v.Status.CurrentRevision = "v1"
+				return cl.Status().Update(ctx, &v)
+			case "Deployment":
+				var v appsv1.Deployment
+				if err := cl.Get(ctx, nsn, &v); err != nil {
+					return err
+				}
+				v.Status.ObservedGeneration = v.Generation
+				v.Status.UpdatedReplicas = ptr.Deref(v.Spec.Replicas, 0)
+				v.Status.ReadyReplicas = ptr.Deref(v.Spec.Replicas, 0)
+				v.Status.Replicas = ptr.Deref(v.Spec.Replicas, 0)
+				return cl.Status().Update(ctx, &v)
+			}
+			return nil
+		},
+	}
+}
+
+// applyConfigurationKey returns kind and name of object defined by apply configuration
+func applyConfigurationKey(obj runtime.ApplyConfiguration) (string, types.NamespacedName) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", types.NamespacedName{}
+	}
+	var u unstructured.Unstructured
+	if err := u.UnmarshalJSON(data); err != nil {
+		return "", types.NamespacedName{}
 	}
+	return u.GetKind(), types.NamespacedName{Name: u.GetName(), Namespace: u.GetNamespace()}
 }
 
 // NewActionRecordingInterceptor returns an interceptor that records actions to the provided slice pointer.
@@ -134,5 +182,13 @@ func NewActionRecordingInterceptor(actions *[]ClientAction, wrapped *interceptor
 			}
 			return cl.Patch(ctx, obj, patch, opts...)
 		},
+		Apply: func(ctx context.Context, cl client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			kind, nsn := applyConfigurationKey(obj)
+			*actions = append(*actions, ClientAction{Verb: "Apply", Kind: kind, Resource: nsn})
+			if wrapped != nil && wrapped.Apply != nil {
+				return wrapped.Apply(ctx, cl, obj, opts...)
+			}
+			return cl.Apply(ctx, obj, opts...)
+		},
 	}
 }
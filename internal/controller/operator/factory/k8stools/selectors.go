@@ -85,7 +85,7 @@ func discoverNamespaces(ctx context.Context, rclient client.Client, s *SelectorO
 	case s.NamespaceSelector != nil:
 		if len(s.NamespaceSelector.MatchExpressions) == 0 && len(s.NamespaceSelector.MatchLabels) == 0 {
 			// fast path, match everything
-			return &discoverNamespacesResponse{namespaces: namespaces}, nil
+			break
 		}
 		// perform a cluster wide request for namespaces with given filters
 		opts := &client.ListOptions{}
@@ -105,5 +105,19 @@ func discoverNamespaces(ctx context.Context, rclient client.Client, s *SelectorO
 			namespaces = append(namespaces, n.Name)
 		}
 	}
+	if cfg.WatchNamespaceSelector != "" {
+		// operator has access only to namespaces matched by WATCH_NAMESPACE_SELECTOR
+		// empty namespaces matches all watched namespaces
+		if len(namespaces) == 0 {
+			namespaces = cfg.GetWatchNamespaces()
+		} else {
+			namespaces = slices.DeleteFunc(namespaces, func(ns string) bool {
+				return !cfg.IsWatchedNamespace(ns)
+			})
+		}
+		if len(namespaces) == 0 {
+			return nil, nil
+		}
+	}
 	return &discoverNamespacesResponse{namespaces: namespaces}, nil
 }
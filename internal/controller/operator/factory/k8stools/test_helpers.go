@@ -37,6 +37,10 @@ func testGetScheme() *runtime.Scheme {
 		&vmv1beta1.VMUserList{},
 		&vmv1beta1.VMAuthList{},
 		&vmv1beta1.VMAlertmanagerConfigList{},
+		&vmv1beta1.VMSilenceList{},
+		&vmv1beta1.VMAlertmanagerReceiverList{},
+		&vmv1beta1.VMAlertmanagerClusterReceiverList{},
+		&vmv1beta1.VMOperatorConfigList{},
 		&vmv1beta1.VMScrapeConfigList{},
 		&vmv1beta1.VMClusterList{},
 		&vmv1beta1.VLogsList{},
@@ -54,6 +58,10 @@ func testGetScheme() *runtime.Scheme {
 		&vmv1beta1.VMUser{},
 		&vmv1beta1.VMAuth{},
 		&vmv1beta1.VMAlertmanagerConfig{},
+		&vmv1beta1.VMSilence{},
+		&vmv1beta1.VMAlertmanagerReceiver{},
+		&vmv1beta1.VMAlertmanagerClusterReceiver{},
+		&vmv1beta1.VMOperatorConfig{},
 		&vmv1beta1.VMScrapeConfig{},
 		&vmv1beta1.VMCluster{},
 		&vmv1beta1.VLogs{},
@@ -68,12 +76,18 @@ func testGetScheme() *runtime.Scheme {
 		&vmv1.VLClusterList{},
 		&vmv1.VTClusterList{},
 		&vmv1.VMAnomalyList{},
+		&vmv1.VMAnomalyModelList{},
+		&vmv1.VMAnomalySchedulerList{},
+		&vmv1.VMAnomalyQueryList{},
 		&vmv1.VLAgentList{},
 		&vmv1.VLSingle{},
 		&vmv1.VLCluster{},
 		&vmv1.VTSingle{},
 		&vmv1.VTCluster{},
 		&vmv1.VMAnomaly{},
+		&vmv1.VMAnomalyModel{},
+		&vmv1.VMAnomalyScheduler{},
+		&vmv1.VMAnomalyQuery{},
 		&vmv1.VLAgent{},
 	)
 	s.AddKnownTypes(gwapiv1.SchemeGroupVersion,
@@ -115,6 +129,8 @@ func getTestClient(predefinedObjects []runtime.Object, fns *interceptor.Funcs) c
 			&vmv1beta1.VMAgent{},
 			&vmv1beta1.VMAlertmanager{},
 			&vmv1beta1.VMAlertmanagerConfig{},
+			&vmv1beta1.VMSilence{},
+			&vmv1beta1.VMOperatorConfig{},
 			&vmv1beta1.VLogs{},
 			&vmv1beta1.VMServiceScrape{},
 			&vmv1beta1.VMPodScrape{},
@@ -128,6 +144,9 @@ func getTestClient(predefinedObjects []runtime.Object, fns *interceptor.Funcs) c
 			&vmv1.VTSingle{},
 			&vmv1.VTCluster{},
 			&vmv1.VMAnomaly{},
+			&vmv1.VMAnomalyModel{},
+			&vmv1.VMAnomalyScheduler{},
+			&vmv1.VMAnomalyQuery{},
 			&vmv1.VLAgent{},
 			&gwapiv1.HTTPRoute{},
 			&vpav1.VerticalPodAutoscaler{},
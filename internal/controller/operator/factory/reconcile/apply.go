@@ -0,0 +1,192 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// FieldManager is a name of field manager used by operator for server-side apply
+const FieldManager = "vm-operator"
+
+// handoverFieldManager keeps ownership of fields released by operator,
+// so they are not removed from object until other manager takes ownership
+const handoverFieldManager = "vm-operator-handover"
+
+// legacyFieldManager is a name of field manager, which is assigned by kubernetes API server
+// to update requests made by operator without server-side apply
+var legacyFieldManager, _, _ = strings.Cut(rest.DefaultKubernetesUserAgent(), "/")
+
+// useServerSideApply checks if changes of Deployments, StatefulSets, Services, ConfigMaps and Secrets
+// must be applied with server-side apply. It's read from the current operator configuration, since it could be reloaded.
+// Plan reconcile mode compares objects on client side
+func useServerSideApply(rclient client.Client) bool {
+	return config.MustGetBaseConfig().EnableServerSideApply && !isPlanning(rclient)
+}
+
+// errApplyConflict indicates that fields of object are owned by another field manager.
+// It must not be retried, since it requires manual action.
+type errApplyConflict struct {
+	msg string
+}
+
+// Error implements errors.Error interface
+func (e *errApplyConflict) Error() string {
+	return e.msg
+}
+
+// IsApplyConflict checks if server-side apply failed due to fields owned by another field manager
+func IsApplyConflict(err error) bool {
+	var ac *errApplyConflict
+	return errors.As(err, &ac)
+}
+
+// applyObject applies newObj with server-side apply and updates it with the response of API server.
+//
+// Fields omitted at newObj are removed only if operator was the only owner of them.
+// Fields, which operator set previously with update requests, are transferred to operator field manager at existingObj.
+// Ownership of fields at releasePaths is handed over before apply, so their current values are kept.
+// Ownership of conflicting fields is taken from other managers only if force is set, e.g. to revert manual changes.
+func applyObject(ctx context.Context, rclient client.Client, newObj, existingObj client.Object, owner *metav1.OwnerReference, force bool, releasePaths ...[]string) error {
+	gvk, err := apiutil.GVKForObject(newObj, rclient.Scheme())
+	if err != nil {
+		return err
+	}
+	nsn := types.NamespacedName{Name: newObj.GetName(), Namespace: newObj.GetNamespace()}
+	if existingObj != nil {
+		if err := upgradeManagedFields(ctx, rclient, existingObj); err != nil {
+			return fmt.Errorf("cannot transfer fields of %s=%s to field manager=%q: %w", gvk.Kind, nsn.String(), FieldManager, err)
+		}
+		for _, path := range releasePaths {
+			if err := releaseField(ctx, rclient, existingObj, path...); err != nil {
+				return fmt.Errorf("cannot release field=%s of %s=%s: %w", strings.Join(path, "."), gvk.Kind, nsn.String(), err)
+			}
+		}
+	}
+	if _, err := addOwnerReferenceIfAbsent(newObj, owner); err != nil {
+		return err
+	}
+	u, err := toApplyObject(newObj)
+	if err != nil {
+		return fmt.Errorf("cannot convert %s=%s for apply: %w", gvk.Kind, nsn.String(), err)
+	}
+	u.SetGroupVersionKind(gvk)
+	if existingObj == nil {
+		logger.WithContext(ctx).Info(fmt.Sprintf("creating new %s=%s with server-side apply", gvk.Kind, nsn.String()))
+	}
+	opts := []client.ApplyOption{client.FieldOwner(FieldManager)}
+	if force {
+		logger.WithContext(ctx).Info(fmt.Sprintf("reverting manual changes of %s=%s with forced server-side apply", gvk.Kind, nsn.String()))
+		opts = append(opts, client.ForceOwnership)
+	}
+	if err := rclient.Apply(ctx, client.ApplyConfigurationFromUnstructured(u), opts...); err != nil {
+		if k8serrors.IsConflict(err) {
+			return &errApplyConflict{msg: fmt.Sprintf("cannot apply %s=%s, fields are owned by another field manager, remove them from object or from the other manager: %s", gvk.Kind, nsn.String(), err)}
+		}
+		return fmt.Errorf("cannot apply %s=%s: %w", gvk.Kind, nsn.String(), err)
+	}
+	if existingObj != nil && existingObj.GetResourceVersion() != u.GetResourceVersion() {
+		logger.WithContext(ctx).Info(fmt.Sprintf("updated %s=%s with server-side apply", gvk.Kind, nsn.String()))
+	}
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, newObj)
+}
+
+// toApplyObject converts obj into unstructured object without fields managed by API server
+func toApplyObject(obj client.Object) (*unstructured.Unstructured, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+	u := &unstructured.Unstructured{Object: content}
+	delete(u.Object, "status")
+	for _, f := range []string{"resourceVersion", "uid", "generation", "creationTimestamp", "managedFields", "deletionTimestamp"} {
+		unstructured.RemoveNestedField(u.Object, "metadata", f)
+	}
+	unstructured.RemoveNestedField(u.Object, "spec", "template", "metadata", "creationTimestamp")
+	return u, nil
+}
+
+// upgradeManagedFields transfers fields owned by operator update requests to operator apply field manager.
+// Otherwise these fields cannot be removed or changed by apply without conflicts
+func upgradeManagedFields(ctx context.Context, rclient client.Client, existingObj client.Object) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(existingObj, sets.New(legacyFieldManager), FieldManager)
+	if err != nil {
+		return err
+	}
+	if patch == nil {
+		return nil
+	}
+	return rclient.Patch(ctx, existingObj, client.RawPatch(types.JSONPatchType, patch))
+}
+
+// releaseField hands over ownership of the field at the given path with its current value to handoverFieldManager,
+// if operator owns it. It allows to omit field at applied object without removing it from the cluster,
+// e.g. replicas managed by HorizontalPodAutoscaler.
+func releaseField(ctx context.Context, rclient client.Client, existingObj client.Object, path ...string) error {
+	if !ownsField(existingObj, FieldManager, path...) {
+		return nil
+	}
+	gvk, err := apiutil.GVKForObject(existingObj, rclient.Scheme())
+	if err != nil {
+		return err
+	}
+	current, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existingObj)
+	if err != nil {
+		return err
+	}
+	value, ok, err := unstructured.NestedFieldNoCopy(current, path...)
+	if err != nil || !ok {
+		return err
+	}
+	var u unstructured.Unstructured
+	u.SetGroupVersionKind(gvk)
+	u.SetName(existingObj.GetName())
+	u.SetNamespace(existingObj.GetNamespace())
+	if err := unstructured.SetNestedField(u.Object, value, path...); err != nil {
+		return err
+	}
+	logger.WithContext(ctx).Info(fmt.Sprintf("releasing field=%s of %s=%s/%s", strings.Join(path, "."), gvk.Kind, existingObj.GetNamespace(), existingObj.GetName()))
+	return rclient.Apply(ctx, client.ApplyConfigurationFromUnstructured(&u), client.FieldOwner(handoverFieldManager))
+}
+
+// ownsField checks if the given manager owns field at the given path of object
+func ownsField(obj client.Object, manager string, path ...string) bool {
+	for _, mf := range obj.GetManagedFields() {
+		if mf.Manager != manager || mf.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(mf.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		found := true
+		for _, p := range path {
+			next, ok := fields["f:"+p].(map[string]any)
+			if !ok {
+				found = false
+				break
+			}
+			fields = next
+		}
+		if found {
+			return true
+		}
+	}
+	return false
+}
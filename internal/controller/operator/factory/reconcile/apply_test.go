@@ -0,0 +1,156 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+// getTestClientWithManagedFields returns a client that tracks actions, updates status of objects
+// and returns managed fields of objects
+func getTestClientWithManagedFields(predefinedObjects []runtime.Object) *k8stools.ClientWithActions {
+	var cwa k8stools.ClientWithActions
+	objectInterceptors := k8stools.GetInterceptorsWithObjects()
+	cwa.Client = fake.NewClientBuilder().
+		WithScheme(clientgoscheme.Scheme).
+		WithRuntimeObjects(predefinedObjects...).
+		WithInterceptorFuncs(k8stools.NewActionRecordingInterceptor(&cwa.Actions, &objectInterceptors)).
+		WithReturnManagedFields().
+		Build()
+	return &cwa
+}
+
+func TestServerSideApply(t *testing.T) {
+	cfg := config.MustGetBaseConfig()
+	defaultCfg := *cfg
+	cfg.EnableServerSideApply = true
+	defer func() {
+		*config.MustGetBaseConfig() = defaultCfg
+	}()
+	ctx := context.Background()
+	nn := types.NamespacedName{Name: "test", Namespace: "default"}
+	owner := &metav1.OwnerReference{APIVersion: "operator.victoriametrics.com/v1beta1", Kind: "VMSingle", Name: "test"}
+	newCM := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nn.Name,
+				Namespace: nn.Namespace,
+				Labels:    map[string]string{"app": "test"},
+			},
+			Data: data,
+		}
+	}
+
+	// create and update configmap
+	cl := getTestClientWithManagedFields(nil)
+	updated, err := ConfigMap(ctx, cl, newCM(map[string]string{"key": "value"}), nil, owner)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.Equal(t, []k8stools.ClientAction{
+		{Verb: "Get", Kind: "ConfigMap", Resource: nn},
+		{Verb: "Apply", Kind: "ConfigMap", Resource: nn},
+	}, cl.Actions)
+	var got corev1.ConfigMap
+	assert.NoError(t, cl.Get(ctx, nn, &got))
+	assert.Equal(t, map[string]string{"key": "value"}, got.Data)
+	assert.Equal(t, []metav1.OwnerReference{*owner}, got.OwnerReferences)
+	assert.True(t, ownsField(&got, FieldManager, "data", "key"))
+
+	updated, err = ConfigMap(ctx, cl, newCM(map[string]string{"key": "value"}), nil, owner)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+
+	updated, err = ConfigMap(ctx, cl, newCM(map[string]string{"other": "value"}), nil, owner)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+	assert.NoError(t, cl.Get(ctx, nn, &got))
+	assert.Equal(t, map[string]string{"other": "value"}, got.Data)
+
+	// fields of other managers are preserved
+	patched := got.DeepCopy()
+	patched.Annotations = map[string]string{"external": "value"}
+	assert.NoError(t, cl.Patch(ctx, patched, client.MergeFrom(&got), client.FieldOwner("kubectl")))
+	_, err = ConfigMap(ctx, cl, newCM(map[string]string{"other": "value"}), nil, owner)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, nn, &got))
+	assert.Equal(t, "value", got.Annotations["external"])
+
+	// manual changes are reverted with forced apply
+	patched = got.DeepCopy()
+	patched.Data["other"] = "changed"
+	assert.NoError(t, cl.Patch(ctx, patched, client.MergeFrom(&got), client.FieldOwner("kubectl")))
+	driftCtx := WithDriftTracker(ctx, false)
+	_, err = ConfigMap(driftCtx, cl, newCM(map[string]string{"other": "value"}), nil, owner)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, nn, &got))
+	assert.Equal(t, map[string]string{"other": "value"}, got.Data)
+	assert.Equal(t, []Drift{{Kind: "ConfigMap", Name: nn.Name, Fields: []string{"data[other]"}, Reverted: true}}, TrackedDrifts(driftCtx))
+
+	// conflict with other manager
+	patched = got.DeepCopy()
+	patched.Data["other"] = "changed"
+	assert.NoError(t, cl.Patch(ctx, patched, client.MergeFrom(&got), client.FieldOwner("kubectl")))
+	_, err = ConfigMap(ctx, cl, newCM(map[string]string{"other": "new-value"}), nil, owner)
+	assert.ErrorContains(t, err, "fields are owned by another field manager")
+	assert.False(t, isConflict(err))
+	assert.True(t, IsApplyConflict(fmt.Errorf("cannot reconcile: %w", err)))
+
+	// transfer fields of previous updates to field manager
+	cl = getTestClientWithManagedFields(nil)
+	assert.NoError(t, cl.Create(ctx, newCM(map[string]string{"key": "value", "removed": "value"}), client.FieldOwner(legacyFieldManager)))
+	_, err = ConfigMap(ctx, cl, newCM(map[string]string{"key": "value"}), nil, owner)
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(ctx, nn, &got))
+	assert.Equal(t, map[string]string{"key": "value"}, got.Data)
+	assert.False(t, ownsField(&got, legacyFieldManager, "data"))
+
+	// keep replicas managed by HPA
+	newDep := func(replicas *int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nn.Name,
+				Namespace: nn.Namespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: "app:v1"}},
+					},
+				},
+			},
+		}
+	}
+	wcl := getTestClientWithManagedFields(nil)
+	assert.NoError(t, Deployment(ctx, wcl, newDep(ptr.To[int32](2)), nil, false, owner))
+	var dep appsv1.Deployment
+	assert.NoError(t, wcl.Get(ctx, nn, &dep))
+	scaled := dep.DeepCopy()
+	scaled.Spec.Replicas = ptr.To[int32](5)
+	assert.NoError(t, wcl.Patch(ctx, scaled, client.MergeFrom(&dep), client.FieldOwner("horizontal-pod-autoscaler")))
+	updatedDep := newDep(ptr.To[int32](2))
+	updatedDep.Spec.Template.Spec.Containers[0].Image = "app:v2"
+	assert.NoError(t, Deployment(ctx, wcl, updatedDep, nil, true, owner))
+	assert.NoError(t, wcl.Get(ctx, nn, &dep))
+	assert.Equal(t, int32(5), *dep.Spec.Replicas)
+	assert.Equal(t, "app:v2", dep.Spec.Template.Spec.Containers[0].Image)
+	assert.False(t, ownsField(&dep, FieldManager, "spec", "replicas"))
+	// replicas of desired object are kept for rollout checks
+	assert.Equal(t, int32(5), *updatedDep.Spec.Replicas)
+}
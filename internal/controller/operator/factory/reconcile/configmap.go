@@ -17,11 +17,18 @@ import (
 // ConfigMap reconciles configmap object
 func ConfigMap(ctx context.Context, rclient client.Client, newObj *corev1.ConfigMap, prevMeta *metav1.ObjectMeta, owner *metav1.OwnerReference) (bool, error) {
 	nsn := types.NamespacedName{Name: newObj.Name, Namespace: newObj.Namespace}
+	hash := dataHash(newObj.Data, newObj.BinaryData)
+	setDataHash(&newObj.ObjectMeta, hash)
 	updated := true
+	var driftFields []string
 	err := retryOnConflict(func() error {
+		driftFields = nil
 		var existingObj corev1.ConfigMap
 		if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
 			if k8serrors.IsNotFound(err) {
+				if useServerSideApply(rclient) {
+					return applyObject(ctx, rclient, newObj, nil, owner, false)
+				}
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating new ConfigMap=%s", nsn.String()))
 				return rclient.Create(ctx, newObj)
 			}
@@ -30,6 +37,21 @@ func ConfigMap(ctx context.Context, rclient client.Client, newObj *corev1.Config
 		if err := collectGarbage(ctx, rclient, &existingObj); err != nil {
 			return err
 		}
+		if existingObj.Annotations[dataHashAnnotation] == hash {
+			// desired data wasn't changed since previous reconcile
+			driftFields = append(dataFields("data", newObj.Data, existingObj.Data), dataFields("binaryData", newObj.BinaryData, existingObj.BinaryData)...)
+			if len(driftFields) > 0 && isDriftKept(ctx) {
+				updated = false
+				return nil
+			}
+		}
+		if useServerSideApply(rclient) {
+			if err := applyObject(ctx, rclient, newObj, &existingObj, owner, len(driftFields) > 0); err != nil {
+				return err
+			}
+			updated = len(diffDeepDerivative(newObj.Data, existingObj.Data)) > 0 || len(diffDeepDerivative(newObj.BinaryData, existingObj.BinaryData)) > 0
+			return nil
+		}
 		metaChanged, err := mergeMeta(&existingObj, newObj, prevMeta, owner, true)
 		if err != nil {
 			return err
@@ -53,5 +75,9 @@ func ConfigMap(ctx context.Context, rclient client.Client, newObj *corev1.Config
 		logger.WithContext(ctx).Info(fmt.Sprintf("updating ConfigMap %s", strings.Join(logMessageMetadata, ", ")))
 		return rclient.Update(ctx, &existingObj)
 	})
-	return updated, err
+	if err != nil {
+		return false, err
+	}
+	reportDrift(ctx, "ConfigMap", nsn, driftFields)
+	return updated, nil
 }
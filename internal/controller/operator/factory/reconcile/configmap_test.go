@@ -82,6 +82,9 @@ func TestConfigMapReconcile(t *testing.T) {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      nn.Name,
 					Namespace: nn.Namespace,
+					Annotations: map[string]string{
+						dataHashAnnotation: dataHash(map[string]string{"data": "test"}, nil),
+					},
 				},
 				Data: map[string]string{
 					"data": "test",
@@ -231,8 +234,9 @@ func TestConfigMapReconcile(t *testing.T) {
 					Name:      nn.Name,
 					Namespace: nn.Namespace,
 					Annotations: map[string]string{
-						"key":      "value",
-						"external": "value",
+						"key":              "value",
+						"external":         "value",
+						dataHashAnnotation: dataHash(map[string]string{"data": "test"}, nil),
 					},
 				},
 				Data: map[string]string{
@@ -27,7 +27,10 @@ func DaemonSet(ctx context.Context, rclient client.Client, newObj, prevObj *apps
 	}
 	rclient.Scheme().Default(newObj)
 	nsn := types.NamespacedName{Name: newObj.Name, Namespace: newObj.Namespace}
+	desiredUnchanged := prevObj != nil && isDesiredUnchanged(prevObj.Spec, newObj.Spec)
+	var driftFields []string
 	err := retryOnConflict(func() error {
+		driftFields = nil
 		var existingObj appsv1.DaemonSet
 		if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
 			if k8serrors.IsNotFound(err) {
@@ -43,13 +46,18 @@ func DaemonSet(ctx context.Context, rclient client.Client, newObj, prevObj *apps
 			return err
 		}
 		spec := &newObj.Spec
+		spec.Template.Annotations = mergeMaps(existingObj.Spec.Template.Annotations, newObj.Spec.Template.Annotations, prevTemplateAnnotations)
+		deferPodTemplateChange(ctx, "DaemonSet", nsn, &spec.Template, &existingObj.Spec.Template)
+		driftFields = detectDrift(desiredUnchanged, newObj.Spec, existingObj.Spec)
+		if len(driftFields) > 0 && isDriftKept(ctx) {
+			return nil
+		}
 		metaChanged, err := mergeMeta(&existingObj, newObj, prevMeta, owner, true)
 		if err != nil {
 			return err
 		}
 
 		logMessageMetadata := []string{fmt.Sprintf("name=%s, is_prev_nil=%t", nsn.String(), prevObj == nil)}
-		spec.Template.Annotations = mergeMaps(existingObj.Spec.Template.Annotations, newObj.Spec.Template.Annotations, prevTemplateAnnotations)
 		specDiff := diffDeepDerivative(newObj.Spec, existingObj.Spec)
 		needsUpdate := metaChanged || len(specDiff) > 0
 		logMessageMetadata = append(logMessageMetadata, fmt.Sprintf("spec_diff=%s", specDiff))
@@ -67,11 +75,19 @@ func DaemonSet(ctx context.Context, rclient client.Client, newObj, prevObj *apps
 	if err != nil {
 		return err
 	}
+	reportDrift(ctx, "DaemonSet", nsn, driftFields)
+	// manual changes are not reverted, rollout of desired state is not expected
+	if len(driftFields) > 0 && isDriftKept(ctx) {
+		return nil
+	}
 	return waitDaemonSetReady(ctx, rclient, newObj, appWaitReadyDeadline)
 }
 
 // waitDeploymentReady waits until deployment's replicaSet rollouts and all new pods is ready
 func waitDaemonSetReady(ctx context.Context, rclient client.Client, ds *appsv1.DaemonSet, deadline time.Duration) error {
+	if isPlanning(rclient) {
+		return nil
+	}
 	var isErrDealine bool
 	nsn := types.NamespacedName{Namespace: ds.Namespace, Name: ds.Name}
 	err := wait.PollUntilContextTimeout(ctx, time.Second, deadline, true, func(ctx context.Context) (done bool, err error) {
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 )
 
@@ -28,10 +29,21 @@ func Deployment(ctx context.Context, rclient client.Client, newObj, prevObj *app
 	}
 	rclient.Scheme().Default(newObj)
 	nsn := types.NamespacedName{Name: newObj.Name, Namespace: newObj.Namespace}
-	err := retryOnConflict(func() error {
+	desiredUnchanged := prevObj != nil && isDesiredUnchanged(prevObj.Spec, newObj.Spec)
+	var driftFields []string
+	var rolloutStarted bool
+	var scaledFrom, scaledTo *int32
+	// events are recorded after retries, otherwise they're recorded for each failed attempt
+	err := retryOnConflictWithDeferral(ctx, func(ctx context.Context) error {
+		driftFields = nil
+		rolloutStarted = false
+		scaledFrom, scaledTo = nil, nil
 		var existingObj appsv1.Deployment
 		if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
 			if k8serrors.IsNotFound(err) {
+				if useServerSideApply(rclient) {
+					return applyObject(ctx, rclient, newObj, nil, owner, false)
+				}
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating new Deployment=%s", nsn.String()))
 				if err := rclient.Create(ctx, newObj); err != nil {
 					return fmt.Errorf("cannot create new Deployment=%s: %w", nsn.String(), err)
@@ -43,38 +55,80 @@ func Deployment(ctx context.Context, rclient client.Client, newObj, prevObj *app
 		if err := collectGarbage(ctx, rclient, &existingObj); err != nil {
 			return err
 		}
-		spec := &newObj.Spec
 		if hasHPA {
-			spec.Replicas = existingObj.Spec.Replicas
+			// do not change replicas count
+			newObj.Spec.Replicas = existingObj.Spec.Replicas
+		}
+		// pod template annotations added by other tools are kept
+		desiredSpec := newObj.Spec.DeepCopy()
+		desiredSpec.Template.Annotations = mergeMaps(existingObj.Spec.Template.Annotations, newObj.Spec.Template.Annotations, prevTemplateAnnotations)
+		if deferPodTemplateChange(ctx, "Deployment", nsn, &desiredSpec.Template, &existingObj.Spec.Template) {
+			existingObj.Spec.Template.DeepCopyInto(&newObj.Spec.Template)
+		}
+		driftFields = detectDrift(desiredUnchanged, *desiredSpec, existingObj.Spec)
+		if len(driftFields) > 0 && isDriftKept(ctx) {
+			return nil
+		}
+		templateChanged := isPodTemplateChanged(&desiredSpec.Template, &existingObj.Spec.Template)
+		if useServerSideApply(rclient) {
+			applyObj := newObj.DeepCopy()
+			var releasePaths [][]string
+			if hasHPA {
+				// replicas are managed by HPA
+				applyObj.Spec.Replicas = nil
+				releasePaths = append(releasePaths, []string{"spec", "replicas"})
+			}
+			if err := applyObject(ctx, rclient, applyObj, &existingObj, owner, len(driftFields) > 0, releasePaths...); err != nil {
+				return err
+			}
+			scaledFrom, scaledTo = existingObj.Spec.Replicas, applyObj.Spec.Replicas
+			rolloutStarted = templateChanged
+			return nil
 		}
 		metaChanged, err := mergeMeta(&existingObj, newObj, prevMeta, owner, true)
 		if err != nil {
 			return err
 		}
 		logMessageMetadata := []string{fmt.Sprintf("name=%s, is_prev_nil=%t", nsn.String(), prevObj == nil)}
-		spec.Template.Annotations = mergeMaps(existingObj.Spec.Template.Annotations, newObj.Spec.Template.Annotations, prevTemplateAnnotations)
+		newObj.Spec.Template.Annotations = desiredSpec.Template.Annotations
 		specDiff := diffDeepDerivative(newObj.Spec, existingObj.Spec)
 		needsUpdate := metaChanged || len(specDiff) > 0
 		logMessageMetadata = append(logMessageMetadata, fmt.Sprintf("spec_diff=%s", specDiff))
 		if !needsUpdate {
 			return nil
 		}
+		prevReplicas := existingObj.Spec.Replicas
 		existingObj.Spec = newObj.Spec
 		logger.WithContext(ctx).Info(fmt.Sprintf("updating Deployment %s", strings.Join(logMessageMetadata, ", ")))
 		if err := rclient.Update(ctx, &existingObj); err != nil {
 			return fmt.Errorf("cannot update Deployment=%s: %w", nsn.String(), err)
 		}
+		scaledFrom, scaledTo = prevReplicas, newObj.Spec.Replicas
+		rolloutStarted = templateChanged
 		return nil
 	})
 	if err != nil {
 		return err
 	}
-	return waitForDeploymentReady(ctx, rclient, newObj, appWaitReadyDeadline)
+	recordScaling(ctx, "Deployment", nsn, scaledFrom, scaledTo)
+	recordRolloutStarted(ctx, "Deployment", nsn, rolloutStarted)
+	reportDrift(ctx, "Deployment", nsn, driftFields)
+	// manual changes are not reverted, rollout of desired state is not expected
+	if len(driftFields) > 0 && isDriftKept(ctx) {
+		return nil
+	}
+	if err := waitForDeploymentReady(ctx, rclient, newObj, appWaitReadyDeadline); err != nil {
+		return err
+	}
+	if rolloutStarted {
+		events.Normal(ctx, events.ReasonRolloutFinished, fmt.Sprintf("Deployment=%s rollout finished, all pods are ready", nsn.Name))
+	}
+	return nil
 }
 
 // waitForDeploymentReady waits until deployment's replicaSet rollouts and all new pods is ready
 func waitForDeploymentReady(ctx context.Context, rclient client.Client, newObj *appsv1.Deployment, deadline time.Duration) error {
-	if newObj.Spec.Replicas == nil {
+	if newObj.Spec.Replicas == nil || isPlanning(rclient) {
 		return nil
 	}
 	var isErrDeadline bool
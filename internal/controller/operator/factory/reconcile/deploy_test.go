@@ -2,16 +2,23 @@ package reconcile
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 )
 
@@ -153,3 +160,80 @@ func TestDeployReconcile(t *testing.T) {
 		},
 	})
 }
+
+func TestDeploymentEvents(t *testing.T) {
+	getDeploy := func(image string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vmselect-main",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"label": "value"},
+				},
+				Replicas: ptr.To(replicas),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"label": "value"},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "vmselect", Image: image}},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas:   replicas,
+				UpdatedReplicas: replicas,
+				Replicas:        replicas,
+			},
+		}
+	}
+	f := func(existing, newObj *appsv1.Deployment, conflicts int, wantReasons []string) {
+		t.Helper()
+		cr := &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "main",
+				Namespace: "default",
+			},
+		}
+		cl := k8stools.GetTestClientWithObjectsAndInterceptors([]runtime.Object{existing}, interceptor.Funcs{
+			Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				if _, ok := obj.(*appsv1.Deployment); ok && conflicts > 0 {
+					conflicts--
+					return k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"}, obj.GetName(), fmt.Errorf("object was modified"))
+				}
+				return cl.Update(ctx, obj, opts...)
+			},
+		})
+		ctx := events.AddToContext(context.Background(), cl, cr)
+		assert.NoError(t, Deployment(ctx, cl, newObj, nil, false, nil))
+		var eventList corev1.EventList
+		assert.NoError(t, cl.List(ctx, &eventList))
+		var gotReasons []string
+		for _, ev := range eventList.Items {
+			assert.Equal(t, "VMCluster", ev.InvolvedObject.Kind)
+			assert.Equal(t, cr.Name, ev.InvolvedObject.Name)
+			gotReasons = append(gotReasons, ev.Reason)
+		}
+		assert.ElementsMatch(t, wantReasons, gotReasons)
+	}
+
+	// no changes
+	f(getDeploy("vmselect:v1", 1), getDeploy("vmselect:v1", 1), 0, nil)
+
+	// image change
+	f(getDeploy("vmselect:v1", 1), getDeploy("vmselect:v2", 1), 0, []string{events.ReasonRolloutStarted, events.ReasonRolloutFinished})
+
+	// replicas change
+	existing := getDeploy("vmselect:v1", 1)
+	existing.Status.ReadyReplicas = 2
+	existing.Status.UpdatedReplicas = 2
+	f(existing, getDeploy("vmselect:v1", 2), 0, []string{events.ReasonScaled})
+
+	// events are recorded once after conflicts
+	existing = getDeploy("vmselect:v1", 1)
+	existing.Status.ReadyReplicas = 2
+	existing.Status.UpdatedReplicas = 2
+	f(existing, getDeploy("vmselect:v2", 2), 2, []string{events.ReasonScaled, events.ReasonRolloutStarted, events.ReasonRolloutFinished})
+}
@@ -3,6 +3,7 @@ package reconcile
 import (
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -11,6 +12,7 @@ import (
 type fieldDiffRecorder struct {
 	path              cmp.Path
 	diffs             []string
+	fields            []string
 	useDerivativeDiff bool
 }
 
@@ -28,28 +30,29 @@ func (r *fieldDiffRecorder) PopStep() {
 func (r *fieldDiffRecorder) Report(rs cmp.Result) {
 	if !rs.Equal() {
 		a1, a2 := r.path.Last().Values()
-		if r.useDerivativeDiff {
-			switch a1.Kind() {
-			case reflect.String:
-				if a1.Len() == 0 {
-					return
-				}
-			case reflect.Slice:
-				if a1.IsNil() || a1.Len() == 0 {
-					return
-				}
-			case reflect.Pointer:
-				if a1.IsNil() {
-					return
-				}
-			case reflect.Map:
-				if a1.IsNil() || a1.Len() == 0 {
-					return
-				}
-			}
+		if r.useDerivativeDiff && isUnsetValue(a1) {
+			return
 		}
 		r.diffs = append(r.diffs, fmt.Sprintf("%#v:-%q +%q", r.path, formatDiffValue(a2), formatDiffValue(a1)))
+		if field := formatFieldPath(r.path); !slices.Contains(r.fields, field) {
+			r.fields = append(r.fields, field)
+		}
+	}
+}
+
+// isUnsetValue checks if value must be ignored at semantic derivative comparison
+func isUnsetValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.String:
+		return v.Len() == 0
+	case reflect.Slice:
+		return v.IsNil() || v.Len() == 0
+	case reflect.Pointer:
+		return v.IsNil()
+	case reflect.Map:
+		return v.IsNil() || v.Len() == 0
 	}
+	return false
 }
 
 // String implements Stringer interface
@@ -57,6 +60,11 @@ func (r *fieldDiffRecorder) String() string {
 	return strings.Join(r.diffs, ",")
 }
 
+// DiffDeep returns changed fields of a2 compared to a1 in `path:-a2 +a1` format
+func DiffDeep(a1, a2 any) string {
+	return diffDeep(a1, a2)
+}
+
 func diffDeep(a1, a2 any) string {
 	return diffDeepInternal(a1, a2, false)
 
@@ -98,3 +106,73 @@ func formatDiffValue(v reflect.Value) string {
 	}
 	return fmt.Sprintf("%v", v)
 }
+
+// diffFields returns paths of changed fields between a1 and a2 in json notation,
+// e.g. `spec.template.spec.containers[0].image`
+func diffFields(a1, a2 any) []string {
+	var r fieldDiffRecorder
+	cmp.Diff(a1, a2, cmp.Reporter(&r))
+	return r.fields
+}
+
+// diffFieldsDerivative is similar to diffFields except that unset fields in a1 are ignored
+func diffFieldsDerivative(a1, a2 any) []string {
+	r := fieldDiffRecorder{
+		useDerivativeDiff: true,
+	}
+	cmp.Diff(a1, a2, cmp.Reporter(&r))
+	return r.fields
+}
+
+func formatFieldPath(p cmp.Path) string {
+	var sb strings.Builder
+	for i, ps := range p {
+		switch s := ps.(type) {
+		case cmp.StructField:
+			name := jsonFieldName(p[i-1].Type(), s.Name())
+			if name == "" {
+				continue
+			}
+			if sb.Len() > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(name)
+		case cmp.SliceIndex:
+			idx := s.Key()
+			if idx < 0 {
+				ix, iy := s.SplitKeys()
+				idx = max(ix, iy)
+			}
+			fmt.Fprintf(&sb, "[%d]", idx)
+		case cmp.MapIndex:
+			fmt.Fprintf(&sb, "[%v]", s.Key())
+		}
+	}
+	return sb.String()
+}
+
+// jsonFieldName returns json name of the given struct field
+// or empty string for inlined fields
+func jsonFieldName(t reflect.Type, fieldName string) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fieldName
+	}
+	f, ok := t.FieldByName(fieldName)
+	if !ok {
+		return fieldName
+	}
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "":
+		if f.Anonymous {
+			return ""
+		}
+		return fieldName
+	case "-":
+		return fieldName
+	}
+	return name
+}
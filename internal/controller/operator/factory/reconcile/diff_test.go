@@ -76,3 +76,38 @@ func TestDiffDeepDerivativeOk(t *testing.T) {
 	}
 	f(a1EmptyPtr, a2FilledPtr, ``)
 }
+
+func TestDiffFields(t *testing.T) {
+	f := func(a1, a2 any, expected []string) {
+		t.Helper()
+		got := diffFields(a1, a2)
+		assert.Equal(t, expected, got)
+	}
+	type Embedded struct {
+		Name string `json:"name,omitempty"`
+	}
+	type item struct {
+		Image string `json:"image"`
+	}
+	type cmpStruct struct {
+		Embedded `json:",inline"`
+		Items    []item            `json:"items,omitempty"`
+		Labels   map[string]string `json:"labels,omitempty"`
+		Field    int32
+	}
+	f(cmpStruct{}, cmpStruct{}, nil)
+	f(cmpStruct{Embedded: Embedded{Name: "new"}}, cmpStruct{}, []string{"name"})
+	f(cmpStruct{Field: 1}, cmpStruct{Field: 2}, []string{"Field"})
+	f(cmpStruct{
+		Items:  []item{{Image: "new"}},
+		Labels: map[string]string{"app": "new", "env": "prod"},
+	}, cmpStruct{
+		Items:  []item{{Image: "old"}},
+		Labels: map[string]string{"app": "old", "env": "prod"},
+	}, []string{"items[0].image", "labels[app]"})
+	f(cmpStruct{
+		Items: []item{{Image: "first"}, {Image: "second"}},
+	}, cmpStruct{
+		Items: []item{{Image: "first"}},
+	}, []string{"items[1]"})
+}
@@ -0,0 +1,140 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// Drift describes manual changes of child object, which differ from the state defined by operator
+type Drift struct {
+	Kind     string
+	Name     string
+	Fields   []string
+	Reverted bool
+}
+
+type driftTracker struct {
+	mu         sync.Mutex
+	reportOnly bool
+	drifts     []Drift
+}
+
+type driftTrackerKey struct{}
+
+// WithDriftTracker returns context, which records manual changes of child objects detected during reconcile.
+// Changes are not reverted if reportOnly is set
+func WithDriftTracker(ctx context.Context, reportOnly bool) context.Context {
+	return context.WithValue(ctx, driftTrackerKey{}, &driftTracker{reportOnly: reportOnly})
+}
+
+// TrackedDrifts returns manual changes of child objects recorded at the given context
+func TrackedDrifts(ctx context.Context) []Drift {
+	t, ok := ctx.Value(driftTrackerKey{}).(*driftTracker)
+	if !ok {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Drift(nil), t.drifts...)
+}
+
+// isDesiredUnchanged checks if desired state of object is the same as at previous reconcile.
+// Otherwise difference with existing object is caused by changes of custom resource
+func isDesiredUnchanged(prevSpec, newSpec any) bool {
+	return len(diffDeepDerivative(prevSpec, newSpec)) == 0
+}
+
+// isDriftKept checks if manual changes of child objects must not be reverted
+func isDriftKept(ctx context.Context) bool {
+	t, ok := ctx.Value(driftTrackerKey{}).(*driftTracker)
+	return ok && t.reportOnly
+}
+
+// detectDrift returns paths of spec fields of existing object, which were changed manually.
+// Changes are detected only if desired state of object is the same as at previous reconcile
+func detectDrift(desiredUnchanged bool, newSpec, existingSpec any) []string {
+	if !desiredUnchanged {
+		return nil
+	}
+	return specFields(newSpec, existingSpec)
+}
+
+// reportDrift records manual changes of fields for the given object.
+// It must be called outside of retryOnConflict, otherwise changes are recorded for each retry
+func reportDrift(ctx context.Context, kind string, nsn types.NamespacedName, fields []string) {
+	t, ok := ctx.Value(driftTrackerKey{}).(*driftTracker)
+	if !ok || len(fields) == 0 {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.drifts = append(t.drifts, Drift{
+		Kind:     kind,
+		Name:     nsn.Name,
+		Fields:   fields,
+		Reverted: !t.reportOnly,
+	})
+	logger.WithContext(ctx).Info(fmt.Sprintf("detected manual changes of %s=%s, fields=%s, reverted=%t", kind, nsn.String(), strings.Join(fields, ","), !t.reportOnly))
+}
+
+// dataHashAnnotation holds hash of data defined by operator for ConfigMap and Secret.
+// Previous desired data isn't stored at custom resource, so it's used to detect manual changes of data
+const dataHashAnnotation = "operator.victoriametrics.com/data-hash"
+
+// dataHash returns hash of the given data of ConfigMap or Secret
+func dataHash(data ...any) string {
+	h := xxhash.New()
+	for _, d := range data {
+		// error is not possible for maps with string keys
+		b, _ := json.Marshal(d)
+		_, _ = h.Write(b)
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// setDataHash adds hash of desired data to annotations of ConfigMap or Secret
+func setDataHash(meta *metav1.ObjectMeta, hash string) {
+	annotations := make(map[string]string, len(meta.Annotations)+1)
+	maps.Copy(annotations, meta.Annotations)
+	annotations[dataHashAnnotation] = hash
+	meta.Annotations = annotations
+}
+
+// dataFields returns paths of keys, which differ between new and existing data of ConfigMap or Secret.
+// Values aren't compared field by field, since they may contain sensitive data
+func dataFields[V any](field string, newData, existingData map[string]V) []string {
+	var fields []string
+	for k, v := range newData {
+		if ev, ok := existingData[k]; !ok || !equality.Semantic.DeepEqual(v, ev) {
+			fields = append(fields, fmt.Sprintf("%s[%s]", field, k))
+		}
+	}
+	for k := range existingData {
+		if _, ok := newData[k]; !ok {
+			fields = append(fields, fmt.Sprintf("%s[%s]", field, k))
+		}
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// specFields returns paths of changed fields of object spec
+func specFields(newSpec, existingSpec any) []string {
+	fields := diffFieldsDerivative(newSpec, existingSpec)
+	for i := range fields {
+		fields[i] = "spec." + fields[i]
+	}
+	return fields
+}
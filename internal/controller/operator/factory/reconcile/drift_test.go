@@ -0,0 +1,104 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestDeploymentDrift(t *testing.T) {
+	type opts struct {
+		new, prev         *appsv1.Deployment
+		reportOnly        bool
+		predefinedObjects []runtime.Object
+		wantDrifts        []Drift
+		wantImage         string
+	}
+	nn := types.NamespacedName{Name: "test", Namespace: "default"}
+	f := func(o opts) {
+		t.Helper()
+		ctx := WithDriftTracker(context.Background(), o.reportOnly)
+		cl := k8stools.GetTestClientWithObjects(o.predefinedObjects)
+		assert.NoError(t, Deployment(ctx, cl, o.new, o.prev, false, nil))
+		assert.Equal(t, o.wantDrifts, TrackedDrifts(ctx))
+		var got appsv1.Deployment
+		assert.NoError(t, cl.Get(ctx, nn, &got))
+		assert.Equal(t, o.wantImage, got.Spec.Template.Spec.Containers[0].Image)
+	}
+	newDep := func(image string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nn.Name,
+				Namespace: nn.Namespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: ptr.To[int32](1),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: image}},
+					},
+				},
+			},
+		}
+	}
+	existingDep := func(image string) *appsv1.Deployment {
+		d := k8stools.NewReadyDeployment(nn.Name, nn.Namespace)
+		d.Spec = newDep(image).Spec
+		return d
+	}
+
+	// no changes
+	f(opts{
+		new:               newDep("app:v1"),
+		prev:              newDep("app:v1"),
+		predefinedObjects: []runtime.Object{existingDep("app:v1")},
+		wantImage:         "app:v1",
+	})
+
+	// spec changed by custom resource
+	f(opts{
+		new:               newDep("app:v2"),
+		prev:              newDep("app:v1"),
+		predefinedObjects: []runtime.Object{existingDep("app:v1")},
+		wantImage:         "app:v2",
+	})
+
+	// manual change is reverted
+	f(opts{
+		new:               newDep("app:v1"),
+		prev:              newDep("app:v1"),
+		predefinedObjects: []runtime.Object{existingDep("app:manual")},
+		wantDrifts: []Drift{{
+			Kind:     "Deployment",
+			Name:     nn.Name,
+			Fields:   []string{"spec.template.spec.containers[0].image"},
+			Reverted: true,
+		}},
+		wantImage: "app:v1",
+	})
+
+	// manual change is kept
+	f(opts{
+		new:               newDep("app:v1"),
+		prev:              newDep("app:v1"),
+		reportOnly:        true,
+		predefinedObjects: []runtime.Object{existingDep("app:manual")},
+		wantDrifts: []Drift{{
+			Kind:   "Deployment",
+			Name:   nn.Name,
+			Fields: []string{"spec.template.spec.containers[0].image"},
+		}},
+		wantImage: "app:manual",
+	})
+}
@@ -0,0 +1,170 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+type maintenanceDeferral struct {
+	mu      sync.Mutex
+	changes []vmv1beta1.PlannedChange
+	// attempt defines deferral of a single attempt of retryOnConflictWithDeferral,
+	// its changes are logged once merged into the parent deferral
+	attempt bool
+}
+
+type maintenanceDeferralKey struct{}
+
+// WithMaintenanceDeferral returns context, which defers disruptive changes of child objects,
+// like pod template or storage changes, until the next maintenance window.
+// Other changes of child objects are applied as usual
+func WithMaintenanceDeferral(ctx context.Context) context.Context {
+	return context.WithValue(ctx, maintenanceDeferralKey{}, &maintenanceDeferral{})
+}
+
+// WithoutDriftTracker returns context, which doesn't report manual changes of child objects.
+// It must be used for applying of previously deferred changes, since desired state of child objects
+// is the same as at previous reconcile and difference with existing objects isn't caused by manual changes
+func WithoutDriftTracker(ctx context.Context) context.Context {
+	return context.WithValue(ctx, driftTrackerKey{}, nil)
+}
+
+// DeferredChanges returns changes of child objects deferred at the given context
+func DeferredChanges(ctx context.Context) []vmv1beta1.PlannedChange {
+	d, ok := ctx.Value(maintenanceDeferralKey{}).(*maintenanceDeferral)
+	if !ok {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	var changes []vmv1beta1.PlannedChange
+	for _, ch := range d.changes {
+		if len(ch.Fields) > maxPlannedFields {
+			more := len(ch.Fields) - maxPlannedFields
+			ch.Fields = append(ch.Fields[:maxPlannedFields:maxPlannedFields], fmt.Sprintf("and %d more", more))
+		}
+		changes = append(changes, ch)
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Kind != changes[j].Kind {
+			return changes[i].Kind < changes[j].Kind
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// isDeferring checks if disruptive changes must be deferred until the next maintenance window
+func isDeferring(ctx context.Context) bool {
+	_, ok := ctx.Value(maintenanceDeferralKey{}).(*maintenanceDeferral)
+	return ok
+}
+
+// deferChange records disruptive change of the given object.
+// It returns true if change must not be applied
+func deferChange(ctx context.Context, kind string, nsn types.NamespacedName, action vmv1beta1.PlannedAction, fields []string) bool {
+	d, ok := ctx.Value(maintenanceDeferralKey{}).(*maintenanceDeferral)
+	if !ok {
+		return false
+	}
+	d.add(ctx, vmv1beta1.PlannedChange{
+		Kind:   kind,
+		Name:   nsn.Name,
+		Action: action,
+		Fields: fields,
+	})
+	return true
+}
+
+func (d *maintenanceDeferral) add(ctx context.Context, change vmv1beta1.PlannedChange) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if !d.attempt {
+		logger.WithContext(ctx).Info(fmt.Sprintf("deferring %s of %s=%s until the next maintenance window, fields=%s", change.Action, change.Kind, change.Name, strings.Join(change.Fields, ",")))
+	}
+	for i := range d.changes {
+		prev := &d.changes[i]
+		if prev.Kind != change.Kind || prev.Name != change.Name {
+			continue
+		}
+		if change.Action == vmv1beta1.PlannedActionRecreate {
+			prev.Action = change.Action
+		}
+		for _, f := range change.Fields {
+			if !slices.Contains(prev.Fields, f) {
+				prev.Fields = append(prev.Fields, f)
+			}
+		}
+		return
+	}
+	d.changes = append(d.changes, change)
+}
+
+// retryOnConflictWithDeferral calls fn with retryOnConflict.
+// Changes deferred by fn are recorded only for the successful attempt,
+// since changes seen by attempt failed with conflict could be outdated
+func retryOnConflictWithDeferral(ctx context.Context, fn func(ctx context.Context) error) error {
+	d, ok := ctx.Value(maintenanceDeferralKey{}).(*maintenanceDeferral)
+	if !ok {
+		return retryOnConflict(func() error {
+			return fn(ctx)
+		})
+	}
+	return retryOnConflict(func() error {
+		attempt := &maintenanceDeferral{attempt: true}
+		if err := fn(context.WithValue(ctx, maintenanceDeferralKey{}, attempt)); err != nil {
+			return err
+		}
+		for _, change := range attempt.changes {
+			d.add(ctx, change)
+		}
+		return nil
+	})
+}
+
+// deferPodTemplateChange records change of pod template and restores existing template at newTemplate,
+// since any change of it triggers rollout of pods.
+// It returns true if change was deferred
+func deferPodTemplateChange(ctx context.Context, kind string, nsn types.NamespacedName, newTemplate, existingTemplate *corev1.PodTemplateSpec) bool {
+	if !isDeferring(ctx) || !isPodTemplateChanged(newTemplate, existingTemplate) {
+		return false
+	}
+	fields := diffFieldsDerivative(*newTemplate, *existingTemplate)
+	for i := range fields {
+		fields[i] = "spec.template." + fields[i]
+	}
+	if !deferChange(ctx, kind, nsn, vmv1beta1.PlannedActionUpdate, fields) {
+		return false
+	}
+	existingTemplate.DeepCopyInto(newTemplate)
+	return true
+}
+
+// UpdateObjectPendingChanges replaces status.pendingChanges of the given object.
+// Pending changes are removed from status if nil is provided
+func UpdateObjectPendingChanges(ctx context.Context, rclient client.Client, obj client.Object, pending *vmv1beta1.PendingChanges) error {
+	data, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"pendingChanges": pending,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("possible bug, cannot serialize pending changes patch as json: %w", err)
+	}
+	if err := rclient.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("cannot update status.pendingChanges: %w", err)
+	}
+	return nil
+}
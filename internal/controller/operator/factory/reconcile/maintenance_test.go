@@ -0,0 +1,286 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestDeploymentMaintenanceDeferral(t *testing.T) {
+	type opts struct {
+		new, prev    *appsv1.Deployment
+		existing     *appsv1.Deployment
+		deferring    bool
+		wantChanges  []vmv1beta1.PlannedChange
+		wantImage    string
+		wantReplicas int32
+	}
+	nn := types.NamespacedName{Name: "test", Namespace: "default"}
+	getDeploy := func(image string, replicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nn.Name,
+				Namespace: nn.Namespace,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "test"},
+				},
+				Replicas: ptr.To(replicas),
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "test"},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: image}},
+					},
+				},
+			},
+			Status: appsv1.DeploymentStatus{
+				ReadyReplicas:   2,
+				UpdatedReplicas: 2,
+				Replicas:        2,
+			},
+		}
+	}
+	f := func(o opts) {
+		t.Helper()
+		ctx := WithDriftTracker(context.Background(), false)
+		if o.deferring {
+			ctx = WithMaintenanceDeferral(ctx)
+		}
+		cl := k8stools.GetTestClientWithObjects([]runtime.Object{o.existing})
+		assert.NoError(t, Deployment(ctx, cl, o.new, o.prev, false, nil))
+		assert.Equal(t, o.wantChanges, DeferredChanges(ctx))
+		assert.Empty(t, TrackedDrifts(ctx))
+		var got appsv1.Deployment
+		assert.NoError(t, cl.Get(ctx, nn, &got))
+		assert.Equal(t, o.wantImage, got.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, o.wantReplicas, *got.Spec.Replicas)
+	}
+
+	// image change is applied without deferral
+	f(opts{
+		new:          getDeploy("app:v2", 2),
+		prev:         getDeploy("app:v1", 1),
+		existing:     getDeploy("app:v1", 1),
+		wantImage:    "app:v2",
+		wantReplicas: 2,
+	})
+
+	// image change is deferred, replicas change is applied
+	f(opts{
+		new:       getDeploy("app:v2", 2),
+		prev:      getDeploy("app:v1", 1),
+		existing:  getDeploy("app:v1", 1),
+		deferring: true,
+		wantChanges: []vmv1beta1.PlannedChange{{
+			Kind:   "Deployment",
+			Name:   nn.Name,
+			Action: vmv1beta1.PlannedActionUpdate,
+			Fields: []string{"spec.template.spec.containers[0].image"},
+		}},
+		wantImage:    "app:v1",
+		wantReplicas: 2,
+	})
+
+	// previously deferred change is deferred again and isn't reported as drift
+	f(opts{
+		new:       getDeploy("app:v2", 2),
+		prev:      getDeploy("app:v2", 2),
+		existing:  getDeploy("app:v1", 2),
+		deferring: true,
+		wantChanges: []vmv1beta1.PlannedChange{{
+			Kind:   "Deployment",
+			Name:   nn.Name,
+			Action: vmv1beta1.PlannedActionUpdate,
+			Fields: []string{"spec.template.spec.containers[0].image"},
+		}},
+		wantImage:    "app:v1",
+		wantReplicas: 2,
+	})
+
+	// no changes
+	f(opts{
+		new:          getDeploy("app:v1", 2),
+		prev:         getDeploy("app:v1", 2),
+		existing:     getDeploy("app:v1", 2),
+		deferring:    true,
+		wantImage:    "app:v1",
+		wantReplicas: 2,
+	})
+}
+
+func TestStatefulSetMaintenanceDeferral(t *testing.T) {
+	nn := types.NamespacedName{Name: "vmselect", Namespace: "default"}
+	getSts := func(image string) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nn.Name,
+				Namespace: nn.Namespace,
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To[int32](2),
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "vmselect"},
+				},
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+					Type: appsv1.OnDeleteStatefulSetStrategyType,
+				},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{
+						Labels: map[string]string{"app": "vmselect"},
+					},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "vmselect", Image: image}},
+					},
+				},
+			},
+			Status: appsv1.StatefulSetStatus{
+				CurrentRevision: "rev0",
+				UpdateRevision:  "rev1",
+				ReadyReplicas:   2,
+				UpdatedReplicas: 2,
+			},
+		}
+	}
+	getPod := func(name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: nn.Namespace,
+				Labels:    map[string]string{"app": "vmselect", podRevisionLabel: "rev0"},
+				OwnerReferences: []metav1.OwnerReference{{
+					Kind: "StatefulSet",
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{
+					Type:   corev1.PodReady,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+		}
+	}
+	f := func(image, wantRevision string, wantChanges []vmv1beta1.PlannedChange) {
+		t.Helper()
+		ctx := WithMaintenanceDeferral(context.Background())
+		existing := getSts("vmselect:v1")
+		cl := k8stools.GetTestClientWithObjectsAndInterceptors([]runtime.Object{existing, getPod("vmselect-0"), getPod("vmselect-1")}, interceptor.Funcs{
+			SubResourceCreate: func(ctx context.Context, cl client.Client, _ string, obj client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+				if pod, ok := obj.(*corev1.Pod); ok {
+					pod.Labels[podRevisionLabel] = existing.Status.UpdateRevision
+					return cl.Update(ctx, pod)
+				}
+				return nil
+			},
+		})
+		opts := STSOptions{
+			SelectorLabels: func() map[string]string { return map[string]string{"app": "vmselect"} },
+		}
+		assert.NoError(t, StatefulSet(ctx, cl, opts, getSts(image), existing.DeepCopy(), nil))
+		assert.Equal(t, wantChanges, DeferredChanges(ctx))
+		var pod corev1.Pod
+		assert.NoError(t, cl.Get(ctx, types.NamespacedName{Name: "vmselect-0", Namespace: nn.Namespace}, &pod))
+		assert.Equal(t, wantRevision, pod.Labels[podRevisionLabel])
+	}
+
+	// pods with outdated revision are updated without deferred changes
+	f("vmselect:v1", "rev1", nil)
+
+	// pods aren't updated, if template change is deferred
+	f("vmselect:v2", "rev0", []vmv1beta1.PlannedChange{{
+		Kind:   "StatefulSet",
+		Name:   nn.Name,
+		Action: vmv1beta1.PlannedActionUpdate,
+		Fields: []string{"spec.template.spec.containers[0].image"},
+	}})
+}
+
+func TestPersistentVolumeClaimMaintenanceDeferral(t *testing.T) {
+	nn := types.NamespacedName{Name: "test-pvc", Namespace: "default"}
+	getPVC := func(size string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        nn.Name,
+				Namespace:   nn.Namespace,
+				Annotations: map[string]string{vmv1beta1.PVCExpandableLabel: "true"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: []corev1.PersistentVolumeAccessMode{
+					corev1.ReadWriteOnce,
+				},
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(size),
+					},
+				},
+			},
+		}
+	}
+	f := func(deferring bool, wantSize string, wantChanges []vmv1beta1.PlannedChange) {
+		t.Helper()
+		ctx := context.Background()
+		if deferring {
+			ctx = WithMaintenanceDeferral(ctx)
+		}
+		cl := k8stools.GetTestClientWithObjects([]runtime.Object{getPVC("10Gi")})
+		assert.NoError(t, PersistentVolumeClaim(ctx, cl, getPVC("20Gi"), getPVC("10Gi"), nil))
+		assert.Equal(t, wantChanges, DeferredChanges(ctx))
+		var got corev1.PersistentVolumeClaim
+		assert.NoError(t, cl.Get(ctx, nn, &got))
+		assert.Equal(t, wantSize, got.Spec.Resources.Requests.Storage().String())
+	}
+
+	// expand without deferral
+	f(false, "20Gi", nil)
+
+	// expansion is deferred
+	f(true, "10Gi", []vmv1beta1.PlannedChange{{
+		Kind:   "PersistentVolumeClaim",
+		Name:   nn.Name,
+		Action: vmv1beta1.PlannedActionUpdate,
+		Fields: []string{"spec.resources.requests.storage"},
+	}})
+}
+
+func TestRetryOnConflictWithDeferral(t *testing.T) {
+	ctx := WithMaintenanceDeferral(context.Background())
+	nn := types.NamespacedName{Name: "test", Namespace: "default"}
+	var attempts int
+	err := retryOnConflictWithDeferral(ctx, func(ctx context.Context) error {
+		attempts++
+		if attempts == 1 {
+			deferChange(ctx, "StatefulSet", nn, vmv1beta1.PlannedActionRecreate, []string{"spec.selector"})
+			return k8serrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "statefulsets"}, nn.Name, fmt.Errorf("object was modified"))
+		}
+		deferChange(ctx, "StatefulSet", nn, vmv1beta1.PlannedActionUpdate, []string{"spec.template.spec.containers[0].image"})
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	// changes of failed attempt are not recorded
+	assert.Equal(t, []vmv1beta1.PlannedChange{{
+		Kind:   "StatefulSet",
+		Name:   nn.Name,
+		Action: vmv1beta1.PlannedActionUpdate,
+		Fields: []string{"spec.template.spec.containers[0].image"},
+	}}, DeferredChanges(ctx))
+}
@@ -0,0 +1,395 @@
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// maxPlannedFields limits count of changed fields reported for a single object
+const maxPlannedFields = 20
+
+type planKey struct {
+	gvk schema.GroupVersionKind
+	nsn types.NamespacedName
+}
+
+// PlanClient is a client, which records changes of objects instead of applying them.
+//
+// Read requests are served from recorded changes first and then from the wrapped client,
+// so reconcile functions observe the same state as they would after applying changes.
+type PlanClient struct {
+	client.Client
+
+	mu      sync.Mutex
+	objects map[planKey]client.Object
+	changes map[planKey]*vmv1beta1.PlannedChange
+	order   []planKey
+}
+
+// NewPlanClient returns PlanClient for the given client
+func NewPlanClient(rclient client.Client) *PlanClient {
+	return &PlanClient{
+		Client:  rclient,
+		objects: make(map[planKey]client.Object),
+		changes: make(map[planKey]*vmv1beta1.PlannedChange),
+	}
+}
+
+// isPlanning checks if changes are only recorded by the given client
+// and reconcile must not wait for rollout of child objects
+func isPlanning(rclient client.Client) bool {
+	_, ok := rclient.(*PlanClient)
+	return ok
+}
+
+func (c *PlanClient) keyFor(nsn types.NamespacedName, obj client.Object) (planKey, error) {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return planKey{}, err
+	}
+	return planKey{gvk: gvk, nsn: nsn}, nil
+}
+
+// Get implements client.Reader interface
+func (c *PlanClient) Get(ctx context.Context, nsn client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	key, err := c.keyFor(nsn, obj)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	stored, ok := c.objects[key]
+	c.mu.Unlock()
+	if !ok {
+		return c.Client.Get(ctx, nsn, obj, opts...)
+	}
+	if stored == nil {
+		return k8serrors.NewNotFound(schema.GroupResource{Group: key.gvk.Group, Resource: strings.ToLower(key.gvk.Kind)}, nsn.Name)
+	}
+	dst := reflect.ValueOf(obj)
+	src := reflect.ValueOf(stored.DeepCopyObject())
+	if dst.Type() != src.Type() {
+		return fmt.Errorf("BUG: unexpected type=%T for planned %s=%s, want %T", obj, key.gvk.Kind, nsn.String(), stored)
+	}
+	dst.Elem().Set(src.Elem())
+	return nil
+}
+
+// List implements client.Reader interface
+//
+// Objects recorded as created are added to the result and objects recorded as deleted are removed from it,
+// so objects pruned by reconcile functions match planned changes.
+func (c *PlanClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.Client.List(ctx, list, opts...); err != nil {
+		return err
+	}
+	listGVK, err := apiutil.GVKForObject(list, c.Scheme())
+	if err != nil {
+		return err
+	}
+	gvk := listGVK.GroupVersion().WithKind(strings.TrimSuffix(listGVK.Kind, "List"))
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return fmt.Errorf("cannot extract items of %s: %w", listGVK.Kind, err)
+	}
+	lo := (&client.ListOptions{}).ApplyOptions(opts)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	seen := make(map[planKey]struct{}, len(items))
+	result := items[:0]
+	for _, item := range items {
+		obj, ok := item.(client.Object)
+		if !ok {
+			return fmt.Errorf("BUG: unexpected type=%T of %s item", item, listGVK.Kind)
+		}
+		key := planKey{gvk: gvk, nsn: client.ObjectKeyFromObject(obj)}
+		seen[key] = struct{}{}
+		stored, ok := c.objects[key]
+		switch {
+		case !ok:
+			result = append(result, item)
+		case stored != nil && matchesListOptions(stored, lo):
+			result = append(result, stored.DeepCopyObject())
+		}
+	}
+	for _, key := range c.order {
+		if _, ok := seen[key]; ok || key.gvk != gvk {
+			continue
+		}
+		if stored := c.objects[key]; stored != nil && matchesListOptions(stored, lo) {
+			result = append(result, stored.DeepCopyObject())
+		}
+	}
+	if err := meta.SetList(list, result); err != nil {
+		return fmt.Errorf("cannot set planned items of %s: %w", listGVK.Kind, err)
+	}
+	return nil
+}
+
+// matchesListOptions checks if object matches namespace and label selector of list request
+func matchesListOptions(obj client.Object, lo *client.ListOptions) bool {
+	if lo.Namespace != "" && obj.GetNamespace() != lo.Namespace {
+		return false
+	}
+	return lo.LabelSelector == nil || lo.LabelSelector.Matches(labels.Set(obj.GetLabels()))
+}
+
+// Create implements client.Writer interface
+func (c *PlanClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	key, err := c.keyFor(client.ObjectKeyFromObject(obj), obj)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	action := vmv1beta1.PlannedActionCreate
+	if prev, ok := c.changes[key]; ok && prev.Action == vmv1beta1.PlannedActionDelete {
+		action = vmv1beta1.PlannedActionRecreate
+	}
+	c.objects[key] = obj.DeepCopyObject().(client.Object)
+	c.record(key, action, nil)
+	return nil
+}
+
+// Update implements client.Writer interface
+func (c *PlanClient) Update(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	nsn := client.ObjectKeyFromObject(obj)
+	key, err := c.keyFor(nsn, obj)
+	if err != nil {
+		return err
+	}
+	existingObj := obj.DeepCopyObject().(client.Object)
+	if err := c.Get(ctx, nsn, existingObj); err != nil {
+		return err
+	}
+	fields := diffFields(obj, existingObj)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[key] = obj.DeepCopyObject().(client.Object)
+	c.record(key, vmv1beta1.PlannedActionUpdate, fields)
+	return nil
+}
+
+// Delete implements client.Writer interface
+func (c *PlanClient) Delete(ctx context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	nsn := client.ObjectKeyFromObject(obj)
+	key, err := c.keyFor(nsn, obj)
+	if err != nil {
+		return err
+	}
+	existingObj := obj.DeepCopyObject().(client.Object)
+	if err := c.Get(ctx, nsn, existingObj); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[key] = nil
+	if prev, ok := c.changes[key]; ok && prev.Action == vmv1beta1.PlannedActionCreate {
+		// object was created during planning, nothing to change
+		delete(c.changes, key)
+		return nil
+	}
+	c.record(key, vmv1beta1.PlannedActionDelete, nil)
+	return nil
+}
+
+// Patch implements client.Writer interface
+//
+// Patch is applied to the recorded state of object and changed fields are recorded as update.
+func (c *PlanClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, _ ...client.PatchOption) error {
+	nsn := client.ObjectKeyFromObject(obj)
+	key, err := c.keyFor(nsn, obj)
+	if err != nil {
+		return err
+	}
+	existingObj := obj.DeepCopyObject().(client.Object)
+	if err := c.Get(ctx, nsn, existingObj); err != nil {
+		return err
+	}
+	data, err := patch.Data(obj)
+	if err != nil {
+		return fmt.Errorf("cannot build patch for %s=%s: %w", key.gvk.Kind, nsn.String(), err)
+	}
+	original, err := json.Marshal(existingObj)
+	if err != nil {
+		return fmt.Errorf("cannot serialize %s=%s: %w", key.gvk.Kind, nsn.String(), err)
+	}
+	var patched []byte
+	switch patch.Type() {
+	case types.JSONPatchType:
+		var jp jsonpatch.Patch
+		jp, err = jsonpatch.DecodePatch(data)
+		if err == nil {
+			patched, err = jp.Apply(original)
+		}
+	case types.MergePatchType:
+		patched, err = jsonpatch.MergePatch(original, data)
+	case types.StrategicMergePatchType:
+		patched, err = strategicpatch.StrategicMergePatch(original, data, existingObj)
+	default:
+		return fmt.Errorf("patch type=%s of %s=%s is not supported in plan reconcile mode", patch.Type(), key.gvk.Kind, nsn.String())
+	}
+	if err != nil {
+		return fmt.Errorf("cannot apply patch to %s=%s: %w", key.gvk.Kind, nsn.String(), err)
+	}
+	patchedObj := existingObj.DeepCopyObject().(client.Object)
+	reflect.ValueOf(patchedObj).Elem().SetZero()
+	if err := json.Unmarshal(patched, patchedObj); err != nil {
+		return fmt.Errorf("cannot parse patched %s=%s: %w", key.gvk.Kind, nsn.String(), err)
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(patchedObj.DeepCopyObject()).Elem())
+	fields := diffFields(patchedObj, existingObj)
+	if len(fields) == 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objects[key] = patchedObj
+	c.record(key, vmv1beta1.PlannedActionUpdate, fields)
+	return nil
+}
+
+// DeleteAllOf implements client.Writer interface
+func (c *PlanClient) DeleteAllOf(_ context.Context, obj client.Object, _ ...client.DeleteAllOfOption) error {
+	return fmt.Errorf("delete of all %T objects is not supported in plan reconcile mode", obj)
+}
+
+// Apply implements client.Writer interface
+func (c *PlanClient) Apply(_ context.Context, obj runtime.ApplyConfiguration, _ ...client.ApplyOption) error {
+	return fmt.Errorf("apply of %T is not supported in plan reconcile mode", obj)
+}
+
+// Status implements client.StatusClient interface
+func (c *PlanClient) Status() client.SubResourceWriter {
+	return &planSubResourceClient{SubResourceClient: c.Client.SubResource("status")}
+}
+
+// SubResource implements client.SubResourceClientConstructor interface
+func (c *PlanClient) SubResource(subResource string) client.SubResourceClient {
+	return &planSubResourceClient{SubResourceClient: c.Client.SubResource(subResource)}
+}
+
+// planSubResourceClient skips changes of sub-resources, like status or eviction
+type planSubResourceClient struct {
+	client.SubResourceClient
+}
+
+// Create implements client.SubResourceWriter interface
+func (*planSubResourceClient) Create(_ context.Context, _ client.Object, _ client.Object, _ ...client.SubResourceCreateOption) error {
+	return nil
+}
+
+// Update implements client.SubResourceWriter interface
+func (*planSubResourceClient) Update(_ context.Context, _ client.Object, _ ...client.SubResourceUpdateOption) error {
+	return nil
+}
+
+// Patch implements client.SubResourceWriter interface
+func (*planSubResourceClient) Patch(_ context.Context, _ client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	return nil
+}
+
+// Apply implements client.SubResourceWriter interface
+func (*planSubResourceClient) Apply(_ context.Context, _ runtime.ApplyConfiguration, _ ...client.SubResourceApplyOption) error {
+	return nil
+}
+
+// record merges change with previously recorded change for the same object
+func (c *PlanClient) record(key planKey, action vmv1beta1.PlannedAction, fields []string) {
+	prev, ok := c.changes[key]
+	if !ok {
+		c.changes[key] = &vmv1beta1.PlannedChange{
+			Kind:   key.gvk.Kind,
+			Name:   key.nsn.Name,
+			Action: action,
+			Fields: fields,
+		}
+		if !slices.Contains(c.order, key) {
+			c.order = append(c.order, key)
+		}
+		return
+	}
+	switch prev.Action {
+	case vmv1beta1.PlannedActionCreate, vmv1beta1.PlannedActionRecreate:
+		// object is created with the latest state, changed fields are not tracked
+	case vmv1beta1.PlannedActionUpdate:
+		if action != vmv1beta1.PlannedActionUpdate {
+			prev.Action = action
+			prev.Fields = nil
+			return
+		}
+		for _, f := range fields {
+			if !slices.Contains(prev.Fields, f) {
+				prev.Fields = append(prev.Fields, f)
+			}
+		}
+	default:
+		prev.Action = action
+		prev.Fields = fields
+	}
+}
+
+// Plan returns changes recorded by client
+func (c *PlanClient) Plan() *vmv1beta1.ReconcilePlan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var plan vmv1beta1.ReconcilePlan
+	counts := make(map[vmv1beta1.PlannedAction]int)
+	for _, key := range c.order {
+		change, ok := c.changes[key]
+		if !ok {
+			continue
+		}
+		ch := *change
+		if len(ch.Fields) > maxPlannedFields {
+			more := len(ch.Fields) - maxPlannedFields
+			ch.Fields = append(ch.Fields[:maxPlannedFields:maxPlannedFields], fmt.Sprintf("and %d more", more))
+		}
+		counts[ch.Action]++
+		plan.Changes = append(plan.Changes, ch)
+	}
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		if plan.Changes[i].Kind != plan.Changes[j].Kind {
+			return plan.Changes[i].Kind < plan.Changes[j].Kind
+		}
+		return plan.Changes[i].Name < plan.Changes[j].Name
+	})
+	plan.Summary = fmt.Sprintf("%d to create, %d to update, %d to recreate, %d to delete",
+		counts[vmv1beta1.PlannedActionCreate], counts[vmv1beta1.PlannedActionUpdate], counts[vmv1beta1.PlannedActionRecreate], counts[vmv1beta1.PlannedActionDelete])
+	return &plan
+}
+
+// UpdateObjectPlan replaces status.plan of the given object.
+// Plan is removed from status if nil is provided
+func UpdateObjectPlan(ctx context.Context, rclient client.Client, obj client.Object, plan *vmv1beta1.ReconcilePlan) error {
+	data, err := json.Marshal(map[string]any{
+		"status": map[string]any{
+			"plan": plan,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("possible bug, cannot serialize plan patch as json: %w", err)
+	}
+	if err := rclient.Status().Patch(ctx, obj, client.RawPatch(types.MergePatchType, data)); err != nil {
+		return fmt.Errorf("cannot update status.plan: %w", err)
+	}
+	return nil
+}
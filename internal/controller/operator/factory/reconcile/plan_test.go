@@ -0,0 +1,137 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestPlanClient(t *testing.T) {
+	getConfigMap := func(name string, fns ...func(cm *corev1.ConfigMap)) *corev1.ConfigMap {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Data: map[string]string{
+				"key": "value",
+			},
+		}
+		for _, fn := range fns {
+			fn(cm)
+		}
+		return cm
+	}
+	nsn := func(name string) types.NamespacedName {
+		return types.NamespacedName{Name: name, Namespace: "default"}
+	}
+	ctx := context.Background()
+	cl := k8stools.GetTestClientWithObjects([]runtime.Object{
+		getConfigMap("existing"),
+		getConfigMap("removed"),
+		getConfigMap("recreated"),
+		getConfigMap("patched"),
+	})
+	pc := NewPlanClient(cl)
+
+	// create is visible only for plan client
+	assert.NoError(t, pc.Create(ctx, getConfigMap("created")))
+	var got corev1.ConfigMap
+	assert.NoError(t, pc.Get(ctx, nsn("created"), &got))
+	assert.True(t, k8serrors.IsNotFound(cl.Get(ctx, nsn("created"), &got)))
+
+	// update is not applied to the cluster
+	var existing corev1.ConfigMap
+	assert.NoError(t, pc.Get(ctx, nsn("existing"), &existing))
+	existing.Labels = map[string]string{"app": "test"}
+	existing.Data["key"] = "new-value"
+	assert.NoError(t, pc.Update(ctx, &existing))
+	assert.NoError(t, pc.Get(ctx, nsn("existing"), &got))
+	assert.Equal(t, "new-value", got.Data["key"])
+	assert.NoError(t, cl.Get(ctx, nsn("existing"), &got))
+	assert.Equal(t, "value", got.Data["key"])
+
+	// patch is applied to the recorded state
+	var patched corev1.ConfigMap
+	assert.NoError(t, pc.Get(ctx, nsn("patched"), &patched))
+	base := patched.DeepCopy()
+	patched.Annotations = map[string]string{"restarted": "true"}
+	assert.NoError(t, pc.Patch(ctx, &patched, client.MergeFrom(base)))
+	assert.NoError(t, pc.Get(ctx, nsn("patched"), &got))
+	assert.Equal(t, "true", got.Annotations["restarted"])
+	assert.NoError(t, cl.Get(ctx, nsn("patched"), &got))
+	assert.Empty(t, got.Annotations)
+
+	// delete hides object only for plan client
+	assert.NoError(t, pc.Delete(ctx, getConfigMap("removed")))
+	assert.True(t, k8serrors.IsNotFound(pc.Get(ctx, nsn("removed"), &got)))
+	assert.True(t, k8serrors.IsNotFound(pc.Delete(ctx, getConfigMap("removed"))))
+	assert.NoError(t, cl.Get(ctx, nsn("removed"), &got))
+
+	// delete and create is reported as recreate
+	assert.NoError(t, pc.Delete(ctx, getConfigMap("recreated")))
+	assert.NoError(t, pc.Create(ctx, getConfigMap("recreated")))
+
+	// object created and removed during plan is not reported
+	assert.NoError(t, pc.Create(ctx, getConfigMap("temporary")))
+	assert.NoError(t, pc.Delete(ctx, getConfigMap("temporary")))
+
+	// list includes planned creates and excludes planned deletes
+	listNames := func(opts ...client.ListOption) []string {
+		t.Helper()
+		var cms corev1.ConfigMapList
+		assert.NoError(t, pc.List(ctx, &cms, opts...))
+		var names []string
+		for _, cm := range cms.Items {
+			names = append(names, cm.Name)
+		}
+		return names
+	}
+	assert.ElementsMatch(t, []string{"created", "existing", "patched", "recreated"}, listNames(client.InNamespace("default")))
+	assert.Equal(t, []string{"existing"}, listNames(client.MatchingLabels{"app": "test"}))
+	assert.Empty(t, listNames(client.InNamespace("other")))
+
+	assert.Equal(t, &vmv1beta1.ReconcilePlan{
+		Summary: "1 to create, 2 to update, 1 to recreate, 1 to delete",
+		Changes: []vmv1beta1.PlannedChange{
+			{Kind: "ConfigMap", Name: "created", Action: vmv1beta1.PlannedActionCreate},
+			{Kind: "ConfigMap", Name: "existing", Action: vmv1beta1.PlannedActionUpdate, Fields: []string{"metadata.labels", "data[key]"}},
+			{Kind: "ConfigMap", Name: "patched", Action: vmv1beta1.PlannedActionUpdate, Fields: []string{"metadata.annotations"}},
+			{Kind: "ConfigMap", Name: "recreated", Action: vmv1beta1.PlannedActionRecreate},
+			{Kind: "ConfigMap", Name: "removed", Action: vmv1beta1.PlannedActionDelete},
+		},
+	}, pc.Plan())
+}
+
+func TestPlanClientReconcile(t *testing.T) {
+	ctx := context.Background()
+	cl := k8stools.GetTestClientWithActions(nil)
+	pc := NewPlanClient(cl)
+	newSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-secret",
+			Namespace: "default",
+		},
+		Data: map[string][]byte{
+			"key": []byte("value"),
+		},
+	}
+	assert.NoError(t, Secret(ctx, pc, newSecret, nil, nil))
+	nn := types.NamespacedName{Name: "test-secret", Namespace: "default"}
+	assert.Equal(t, []k8stools.ClientAction{
+		{Verb: "Get", Kind: "Secret", Resource: nn},
+	}, cl.Actions)
+	assert.Equal(t, []vmv1beta1.PlannedChange{
+		{Kind: "Secret", Name: "test-secret", Action: vmv1beta1.PlannedActionCreate},
+	}, pc.Plan().Changes)
+}
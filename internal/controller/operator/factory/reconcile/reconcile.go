@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -18,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 )
@@ -119,6 +121,28 @@ func (e *errRecreate) Error() string {
 	return e.msg
 }
 
+// isPodTemplateChanged checks if new pod template differs from the existing one,
+// which triggers pods rollout
+func isPodTemplateChanged(newTemplate, existingTemplate *corev1.PodTemplateSpec) bool {
+	return len(diffDeepDerivative(newTemplate, existingTemplate)) > 0
+}
+
+// recordScaling emits event if replicas count of workload was changed
+func recordScaling(ctx context.Context, kind string, nsn types.NamespacedName, prevReplicas, newReplicas *int32) {
+	if prevReplicas == nil || newReplicas == nil || *prevReplicas == *newReplicas {
+		return
+	}
+	events.Normal(ctx, events.ReasonScaled, fmt.Sprintf("%s=%s scaled from %d to %d replicas", kind, nsn.Name, *prevReplicas, *newReplicas))
+}
+
+// recordRolloutStarted emits event if pod template of workload was changed
+func recordRolloutStarted(ctx context.Context, kind string, nsn types.NamespacedName, templateChanged bool) {
+	if !templateChanged {
+		return
+	}
+	events.Normal(ctx, events.ReasonRolloutStarted, fmt.Sprintf("%s=%s pod template was changed, rollout started", kind, nsn.Name))
+}
+
 // IsRetryable determines one of errors:
 // * error which indicates that timeout for app transition into Ready state reached and should be continued at the next reconcile loop
 // * k8s conflict error
@@ -143,6 +167,9 @@ func waitForStatus[T client.Object, ST StatusWithMetadata[STC], STC any](
 	interval time.Duration,
 	status vmv1beta1.UpdateStatus,
 ) error {
+	if isPlanning(rclient) {
+		return nil
+	}
 	lastStatus := obj.GetStatus().GetStatusMetadata()
 	nsn := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
 	err := wait.PollUntilContextCancel(ctx, interval, false, func(ctx context.Context) (done bool, err error) {
@@ -18,10 +18,17 @@ import (
 // Secret reconciles secret object
 func Secret(ctx context.Context, rclient client.Client, newObj *corev1.Secret, prevMeta *metav1.ObjectMeta, owner *metav1.OwnerReference) error {
 	nsn := types.NamespacedName{Name: newObj.Name, Namespace: newObj.Namespace}
-	return retryOnConflict(func() error {
+	hash := dataHash(newObj.Data)
+	setDataHash(&newObj.ObjectMeta, hash)
+	var driftFields []string
+	err := retryOnConflict(func() error {
+		driftFields = nil
 		var existingObj corev1.Secret
 		if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
 			if k8serrors.IsNotFound(err) {
+				if useServerSideApply(rclient) {
+					return applyObject(ctx, rclient, newObj, nil, owner, false)
+				}
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating new Secret=%s", nsn.String()))
 				return rclient.Create(ctx, newObj)
 			}
@@ -30,6 +37,16 @@ func Secret(ctx context.Context, rclient client.Client, newObj *corev1.Secret, p
 		if err := collectGarbage(ctx, rclient, &existingObj); err != nil {
 			return err
 		}
+		if existingObj.Annotations[dataHashAnnotation] == hash {
+			// desired data wasn't changed since previous reconcile
+			driftFields = dataFields("data", newObj.Data, existingObj.Data)
+			if len(driftFields) > 0 && isDriftKept(ctx) {
+				return nil
+			}
+		}
+		if useServerSideApply(rclient) {
+			return applyObject(ctx, rclient, newObj, &existingObj, owner, len(driftFields) > 0)
+		}
 		metaChanged, err := mergeMeta(&existingObj, newObj, prevMeta, owner, true)
 		if err != nil {
 			return err
@@ -46,4 +63,9 @@ func Secret(ctx context.Context, rclient client.Client, newObj *corev1.Secret, p
 		logger.WithContext(ctx).Info(fmt.Sprintf("updating Secret %s", strings.Join(logMessageMetadata, ", ")))
 		return rclient.Update(ctx, &existingObj)
 	})
+	if err != nil {
+		return err
+	}
+	reportDrift(ctx, "Secret", nsn, driftFields)
+	return nil
 }
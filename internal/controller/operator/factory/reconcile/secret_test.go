@@ -55,12 +55,16 @@ func TestSecretReconcile(t *testing.T) {
 		},
 	})
 
+	withDataHash := func(s *corev1.Secret) {
+		s.Annotations = map[string]string{dataHashAnnotation: dataHash(s.Data)}
+	}
+
 	// no updates
 	f(opts{
 		new:      getSecret(),
 		prevMeta: &getSecret().ObjectMeta,
 		predefinedObjects: []runtime.Object{
-			getSecret(),
+			getSecret(withDataHash),
 		},
 		actions: []k8stools.ClientAction{
 			{Verb: "Get", Kind: "Secret", Resource: nn},
@@ -80,4 +84,16 @@ func TestSecretReconcile(t *testing.T) {
 			{Verb: "Update", Kind: "Secret", Resource: nn},
 		},
 	})
+	// manual changes of data are kept according to drift policy
+	ctx := WithDriftTracker(context.Background(), true)
+	cl := k8stools.GetTestClientWithActions([]runtime.Object{
+		getSecret(withDataHash, func(s *corev1.Secret) {
+			s.Data["key"] = []byte("manual-value")
+		}),
+	})
+	assert.NoError(t, Secret(ctx, cl, getSecret(), nil, nil))
+	assert.Equal(t, []k8stools.ClientAction{
+		{Verb: "Get", Kind: "Secret", Resource: nn},
+	}, cl.Actions)
+	assert.Equal(t, []Drift{{Kind: "Secret", Name: nn.Name, Fields: []string{"data[key]"}}}, TrackedDrifts(ctx))
 }
@@ -23,28 +23,41 @@ import (
 // its users responsibility to define it correctly.
 func Service(ctx context.Context, rclient client.Client, newObj, prevObj *corev1.Service, owner *metav1.OwnerReference) error {
 	svcForReconcile := newObj.DeepCopy()
-	return retryOnConflict(func() error {
-		return reconcileService(ctx, rclient, svcForReconcile, prevObj, owner)
+	desiredUnchanged := prevObj != nil && isDesiredUnchanged(prevObj.Spec, newObj.Spec)
+	var driftFields []string
+	err := retryOnConflict(func() error {
+		var err error
+		driftFields, err = reconcileService(ctx, rclient, svcForReconcile, prevObj, owner, desiredUnchanged)
+		return err
 	})
+	if err != nil {
+		return err
+	}
+	reportDrift(ctx, "Service", types.NamespacedName{Name: svcForReconcile.Name, Namespace: svcForReconcile.Namespace}, driftFields)
+	return nil
 }
 
-func reconcileService(ctx context.Context, rclient client.Client, newObj, prevObj *corev1.Service, owner *metav1.OwnerReference) error {
+// reconcileService returns paths of fields changed manually at existing service
+func reconcileService(ctx context.Context, rclient client.Client, newObj, prevObj *corev1.Service, owner *metav1.OwnerReference, desiredUnchanged bool) ([]string, error) {
 	// helper for proper service deletion.
 	nsn := types.NamespacedName{Name: newObj.Name, Namespace: newObj.Namespace}
 	var existingObj corev1.Service
 	if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
 		if k8serrors.IsNotFound(err) {
+			if useServerSideApply(rclient) {
+				return nil, applyObject(ctx, rclient, newObj, nil, owner, false)
+			}
 			logger.WithContext(ctx).Info(fmt.Sprintf("creating new Service=%s", nsn.String()))
 			err := rclient.Create(ctx, newObj)
 			if err != nil {
-				return fmt.Errorf("cannot create new Service=%s: %w", nsn.String(), err)
+				return nil, fmt.Errorf("cannot create new Service=%s: %w", nsn.String(), err)
 			}
-			return nil
+			return nil, nil
 		}
-		return fmt.Errorf("cannot get service for existing Service=%s: %w", nsn.String(), err)
+		return nil, fmt.Errorf("cannot get service for existing Service=%s: %w", nsn.String(), err)
 	}
 	if err := collectGarbage(ctx, rclient, &existingObj); err != nil {
-		return err
+		return nil, err
 	}
 	var prevMeta *metav1.ObjectMeta
 	if prevObj != nil {
@@ -77,20 +90,20 @@ func reconcileService(ctx context.Context, rclient client.Client, newObj, prevOb
 	case newObj.Spec.Type != existingObj.Spec.Type:
 		// type mismatch.
 		// need to remove it and recreate.
-		return recreateService()
+		return nil, recreateService()
 	case newObj.Spec.ClusterIP != "" &&
 		newObj.Spec.ClusterIP != "None" &&
 		newObj.Spec.ClusterIP != existingObj.Spec.ClusterIP:
 		// ip was changed by user, remove old service and create new one.
-		return recreateService()
+		return nil, recreateService()
 	case newObj.Spec.ClusterIP == "None" && existingObj.Spec.ClusterIP != "None":
 		// serviceType changed from clusterIP to headless
-		return recreateService()
+		return nil, recreateService()
 	case newObj.Spec.ClusterIP == "" && existingObj.Spec.ClusterIP == "None":
 		// serviceType changes from headless to clusterIP
-		return recreateService()
+		return nil, recreateService()
 	case ptr.Deref(newObj.Spec.LoadBalancerClass, "") != ptr.Deref(existingObj.Spec.LoadBalancerClass, ""):
-		return recreateService()
+		return nil, recreateService()
 	}
 
 	// keep given clusterIP for service.
@@ -114,18 +127,25 @@ func reconcileService(ctx context.Context, rclient client.Client, newObj, prevOb
 	}
 
 	rclient.Scheme().Default(newObj)
+	driftFields := detectDrift(desiredUnchanged, newObj.Spec, existingObj.Spec)
+	if len(driftFields) > 0 && isDriftKept(ctx) {
+		return driftFields, nil
+	}
+	if useServerSideApply(rclient) {
+		return driftFields, applyObject(ctx, rclient, newObj, &existingObj, owner, len(driftFields) > 0)
+	}
 	metaChanged, err := mergeMeta(&existingObj, newObj, prevMeta, owner, true)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	logMessageMetadata := []string{fmt.Sprintf("name=%s, is_prev_nil=%t", nsn.String(), prevObj == nil)}
 	specDiff := diffDeepDerivative(newObj.Spec, existingObj.Spec)
 	needsUpdate := metaChanged || len(specDiff) > 0
 	logMessageMetadata = append(logMessageMetadata, fmt.Sprintf("spec_diff=%s", specDiff))
 	if !needsUpdate {
-		return nil
+		return nil, nil
 	}
 	existingObj.Spec = newObj.Spec
 	logger.WithContext(ctx).Info(fmt.Sprintf("updating Service %s", strings.Join(logMessageMetadata, ", ")))
-	return rclient.Update(ctx, &existingObj)
+	return driftFields, rclient.Update(ctx, &existingObj)
 }
@@ -22,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 )
 
@@ -38,7 +39,7 @@ type STSOptions struct {
 }
 
 func waitForStatefulSetReady(ctx context.Context, rclient client.Client, newObj *appsv1.StatefulSet) error {
-	if newObj.Spec.Replicas == nil {
+	if newObj.Spec.Replicas == nil || isPlanning(rclient) {
 		return nil
 	}
 	err := wait.PollUntilContextTimeout(ctx, podWaitReadyIntervalCheck, appWaitReadyDeadline, true, func(ctx context.Context) (done bool, err error) {
@@ -82,11 +83,32 @@ func StatefulSet(ctx context.Context, rclient client.Client, cr STSOptions, newO
 	rclient.Scheme().Default(newObj)
 	updateStrategy := newObj.Spec.UpdateStrategy.Type
 	nsn := types.NamespacedName{Name: newObj.Name, Namespace: newObj.Namespace}
+	desiredUnchanged := prevObj != nil && isDesiredUnchanged(prevObj.Spec, newObj.Spec)
+	var driftFields []string
+	var rolloutStarted bool
+	var scaledFrom, scaledTo *int32
+	var claimsSTS *appsv1.StatefulSet
 	var recreateSTS func() error
-	err := retryOnConflict(func() error {
+	var templateDeferred bool
+	// events are recorded after retries, otherwise they're recorded for each failed attempt
+	err := retryOnConflictWithDeferral(ctx, func(ctx context.Context) error {
+		driftFields = nil
+		rolloutStarted = false
+		mustRecreatePod = false
+		scaledFrom, scaledTo = nil, nil
+		claimsSTS = nil
+		recreateSTS = nil
+		templateDeferred = false
 		var existingObj appsv1.StatefulSet
 		if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
 			if k8serrors.IsNotFound(err) {
+				if useServerSideApply(rclient) {
+					if err := applyObject(ctx, rclient, newObj, nil, owner, false); err != nil {
+						return err
+					}
+					updateStrategy = appsv1.RollingUpdateStatefulSetStrategyType
+					return nil
+				}
 				logger.WithContext(ctx).Info(fmt.Sprintf("creating new StatefulSet=%s", nsn.String()))
 				if err = rclient.Create(ctx, newObj); err != nil {
 					return fmt.Errorf("cannot create new StatefulSet=%s: %w", nsn.String(), err)
@@ -114,48 +136,106 @@ func StatefulSet(ctx context.Context, rclient client.Client, cr STSOptions, newO
 
 		var mustRecreateSTS bool
 		mustRecreateSTS, mustRecreatePod = isSTSRecreateRequired(ctx, newObj, &existingObj)
+		if mustRecreateSTS && deferChange(ctx, "StatefulSet", nsn, vmv1beta1.PlannedActionRecreate, specFields(newObj.Spec, existingObj.Spec)) {
+			mustRecreatePod = false
+			templateDeferred = true
+			return nil
+		}
 		if mustRecreateSTS {
 			recreateSTS = func() error {
 				return removeStatefulSetKeepPods(ctx, rclient, newObj, &existingObj)
 			}
 			return nil
 		}
+		// pod template annotations added by other tools are kept
+		desiredSpec := newObj.Spec.DeepCopy()
+		desiredSpec.Template.Annotations = mergeMaps(existingObj.Spec.Template.Annotations, newObj.Spec.Template.Annotations, prevTemplateAnnotations)
+		if deferPodTemplateChange(ctx, "StatefulSet", nsn, &desiredSpec.Template, &existingObj.Spec.Template) {
+			existingObj.Spec.Template.DeepCopyInto(&newObj.Spec.Template)
+			templateDeferred = true
+		}
+		driftFields = detectDrift(desiredUnchanged, *desiredSpec, existingObj.Spec)
+		if len(driftFields) > 0 && isDriftKept(ctx) {
+			return nil
+		}
+		templateChanged := isPodTemplateChanged(&desiredSpec.Template, &existingObj.Spec.Template)
+		if useServerSideApply(rclient) {
+			applyObj := newObj.DeepCopy()
+			var releasePaths [][]string
+			if cr.HPA != nil {
+				// replicas are managed by HPA
+				applyObj.Spec.Replicas = nil
+				releasePaths = append(releasePaths, []string{"spec", "replicas"})
+			}
+			if err := applyObject(ctx, rclient, applyObj, &existingObj, owner, len(driftFields) > 0, releasePaths...); err != nil {
+				return err
+			}
+			scaledFrom, scaledTo = existingObj.Spec.Replicas, applyObj.Spec.Replicas
+			rolloutStarted = templateChanged
+			if cr.HasClaim {
+				claimsSTS = applyObj
+			}
+			return nil
+		}
 		metaChanged, err := mergeMeta(&existingObj, newObj, prevMeta, owner, true)
 		if err != nil {
 			return err
 		}
 		logMessageMetadata := []string{fmt.Sprintf("name=%s, is_prev_nil=%t", nsn.String(), prevObj == nil)}
-		spec.Template.Annotations = mergeMaps(existingObj.Spec.Template.Annotations, newObj.Spec.Template.Annotations, prevTemplateAnnotations)
+		spec.Template.Annotations = desiredSpec.Template.Annotations
 		specDiff := diffDeepDerivative(newObj.Spec, existingObj.Spec)
 		needsUpdate := metaChanged || len(specDiff) > 0
 		logMessageMetadata = append(logMessageMetadata, fmt.Sprintf("spec_diff=%s", specDiff))
 		if !needsUpdate {
 			return nil
 		}
+		prevReplicas := existingObj.Spec.Replicas
 		existingObj.Spec = newObj.Spec
 		logger.WithContext(ctx).Info(fmt.Sprintf("updating Statefulset %s", strings.Join(logMessageMetadata, ", ")))
 		if err := rclient.Update(ctx, &existingObj); err != nil {
 			return fmt.Errorf("cannot perform update on StatefulSet=%s: %w", nsn.String(), err)
 		}
-		// check if pvcs need to resize
+		scaledFrom, scaledTo = prevReplicas, newObj.Spec.Replicas
+		rolloutStarted = templateChanged
 		if cr.HasClaim {
-			return updateSTSPVC(ctx, rclient, &existingObj, owner)
+			claimsSTS = &existingObj
 		}
 		return nil
 	})
 	if err != nil {
 		return err
 	}
+	recordScaling(ctx, "StatefulSet", nsn, scaledFrom, scaledTo)
+	// check if pvcs need to resize
+	if claimsSTS != nil {
+		if err := retryOnConflictWithDeferral(ctx, func(ctx context.Context) error {
+			return updateSTSPVC(ctx, rclient, claimsSTS, owner)
+		}); err != nil {
+			return err
+		}
+	}
+	reportDrift(ctx, "StatefulSet", nsn, driftFields)
+	driftKept := len(driftFields) > 0 && isDriftKept(ctx)
 
 	if recreateSTS != nil {
 		if err = recreateSTS(); err != nil {
 			return err
 		}
 	}
+	// pods are not updated in plan reconcile mode
+	// or if manual changes are not reverted
+	if isPlanning(rclient) || driftKept {
+		return nil
+	}
 
 	// perform manual update only with OnDelete policy, which is default.
 	switch updateStrategy {
 	case appsv1.OnDeleteStatefulSetStrategyType:
+		// pods are updated at the next maintenance window, if template change was deferred.
+		// Otherwise, pods with outdated revision, e.g. left by interrupted rollout, are updated
+		if templateDeferred {
+			return nil
+		}
 		opts := rollingUpdateOpts{
 			recreate:       mustRecreatePod,
 			selector:       cr.SelectorLabels(),
@@ -176,9 +256,14 @@ func StatefulSet(ctx context.Context, rclient client.Client, cr STSOptions, newO
 		return nil
 	default:
 		logger.WithContext(ctx).Info(fmt.Sprintf("ignoring custom update behavior settings with update strategy=%s on StatefulSet=%s", updateStrategy, nsn.String()))
+		// pods are updated by kubernetes controller-manager
+		recordRolloutStarted(ctx, "StatefulSet", nsn, rolloutStarted)
 		if err := waitForStatefulSetReady(ctx, rclient, newObj); err != nil {
 			return fmt.Errorf("cannot ensure that statefulset is ready with strategy=%q: %w", updateStrategy, err)
 		}
+		if rolloutStarted {
+			events.Normal(ctx, events.ReasonRolloutFinished, fmt.Sprintf("StatefulSet=%s rollout finished, all pods are ready", nsn.Name))
+		}
 	}
 	return err
 }
@@ -285,6 +370,8 @@ func performRollingUpdateOnSts(ctx context.Context, rclient client.Client, obj *
 	}
 
 	l.Info(fmt.Sprintf("discovered already updated pods=%d, pods needed to be update=%d", len(updatedPods), len(podsForUpdate)))
+	// rolling update starts only if none of pods has the new revision, otherwise it was started by previous reconcile
+	rolloutStarts := len(podsForUpdate) > 0 && len(updatedPods) == 0 && len(readyPods) == 0
 
 	// check updated, by not ready pods
 	for _, pod := range updatedPods {
@@ -295,6 +382,10 @@ func performRollingUpdateOnSts(ctx context.Context, rclient client.Client, obj *
 		}
 	}
 
+	if rolloutStarts {
+		events.Normal(ctx, events.ReasonRolloutStarted, fmt.Sprintf("StatefulSet=%s rolling update started from revision=%s to revision=%s, pods to update=%d",
+			nsn.Name, sts.Status.CurrentRevision, stsVersion, len(podsForUpdate)))
+	}
 	// perform update for not updated pods in batches according to podMaxUnavailable
 	for batchStart := 0; batchStart < len(podsForUpdate); batchStart += o.maxUnavailable {
 		var batch []corev1.Pod
@@ -341,6 +432,7 @@ func performRollingUpdateOnSts(ctx context.Context, rclient client.Client, obj *
 					return fmt.Errorf("cannot wait for pod ready state during re-creation for pod %s: %w", pod.Name, err)
 				}
 				l.Info(fmt.Sprintf("pod %s was updated successfully", pod.Name))
+				events.Normal(ctx, events.ReasonPodRecreated, fmt.Sprintf("Pod=%s of StatefulSet=%s was recreated with revision=%s", pod.Name, nsn.Name, stsVersion))
 				return nil
 			})
 		}
@@ -350,6 +442,9 @@ func performRollingUpdateOnSts(ctx context.Context, rclient client.Client, obj *
 	}
 
 	l.Info(fmt.Sprintf("finished statefulset update from revision=%q to revision=%q", sts.Status.CurrentRevision, stsVersion))
+	if len(podsForUpdate) > 0 {
+		events.Normal(ctx, events.ReasonRolloutFinished, fmt.Sprintf("StatefulSet=%s rolling update finished, all pods are ready with revision=%s", nsn.Name, stsVersion))
+	}
 
 	return nil
 }
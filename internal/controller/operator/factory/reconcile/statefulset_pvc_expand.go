@@ -22,6 +22,7 @@ import (
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 )
@@ -183,12 +184,16 @@ func modifyPVC(ctx context.Context, rclient client.Client, existingObj, newObj,
 			l.Info(fmt.Sprintf("storage class=%s for PVC=%s doesn't support live resizing", sc, newObj.Name))
 			return metaChanged, nil
 		}
+		if deferChange(ctx, "PersistentVolumeClaim", types.NamespacedName{Namespace: existingObj.Namespace, Name: existingObj.Name}, vmv1beta1.PlannedActionUpdate, []string{"spec.resources.requests.storage"}) {
+			return metaChanged, nil
+		}
 		existingObj.Spec.Resources = *newObj.Spec.Resources.DeepCopy()
 	}
 	return true, nil
 }
 
 func updatePVC(ctx context.Context, rclient client.Client, existingObj, newObj, prevObj *corev1.PersistentVolumeClaim, owner *metav1.OwnerReference) error {
+	prevSize := existingObj.Spec.Resources.Requests.Storage().DeepCopy()
 	modified, err := modifyPVC(ctx, rclient, existingObj, newObj, prevObj, owner)
 	if err != nil {
 		return err
@@ -199,6 +204,9 @@ func updatePVC(ctx context.Context, rclient client.Client, existingObj, newObj,
 	if err := rclient.Update(ctx, existingObj); err != nil {
 		return fmt.Errorf("failed to expand size for pvc %s: %v", newObj.Name, err)
 	}
+	if newSize := existingObj.Spec.Resources.Requests.Storage(); newSize.Cmp(prevSize) > 0 {
+		events.Normal(ctx, events.ReasonPVCExpanded, fmt.Sprintf("PVC=%s was expanded from=%s to=%s", existingObj.Name, prevSize.String(), newSize.String()))
+	}
 	return nil
 }
 
@@ -331,5 +339,6 @@ func removeStatefulSetKeepPods(ctx context.Context, rclient client.Client, state
 		}
 		return fmt.Errorf("cannot create new StatefulSet=%s instead of replaced, perform manual action to handle this error or report BUG: %w", nsn.String(), err)
 	}
+	events.Normal(ctx, events.ReasonStatefulSetRecreated, fmt.Sprintf("StatefulSet=%s was recreated with orphaned pods due to changes of immutable fields", nsn.Name))
 	return nil
 }
@@ -19,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 )
 
@@ -660,6 +661,91 @@ func Test_performRollingUpdateOnSts(t *testing.T) {
 	})
 }
 
+func Test_performRollingUpdateOnStsEvents(t *testing.T) {
+	getPod := func(name, revision string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{"app": "vmselect", podRevisionLabel: revision},
+				OwnerReferences: []metav1.OwnerReference{{
+					Kind: "StatefulSet",
+				}},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: "True",
+					},
+				},
+			},
+		}
+	}
+	f := func(pods []runtime.Object, wantReasons []string) {
+		t.Helper()
+		sts := &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vmselect-sts",
+				Namespace: "default",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas: ptr.To(int32(len(pods))),
+			},
+			Status: appsv1.StatefulSetStatus{
+				CurrentRevision: "rev0",
+				UpdateRevision:  "rev1",
+			},
+		}
+		cr := &vmv1beta1.VMCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "main",
+				Namespace: "default",
+			},
+		}
+		fclient := k8stools.GetTestClientWithObjectsAndInterceptors(append(pods, sts), interceptor.Funcs{
+			SubResourceCreate: func(ctx context.Context, cl client.Client, _ string, obj client.Object, subResource client.Object, _ ...client.SubResourceCreateOption) error {
+				if pod, ok := obj.(*corev1.Pod); ok {
+					pod.Labels[podRevisionLabel] = sts.Status.UpdateRevision
+					return cl.Update(ctx, pod)
+				}
+				return nil
+			},
+		})
+		ctx := events.AddToContext(context.Background(), fclient, cr)
+		assert.NoError(t, performRollingUpdateOnSts(ctx, fclient, sts, rollingUpdateOpts{
+			selector:       map[string]string{"app": "vmselect"},
+			maxUnavailable: 1,
+		}))
+		var eventList corev1.EventList
+		assert.NoError(t, fclient.List(ctx, &eventList))
+		var gotReasons []string
+		for _, ev := range eventList.Items {
+			gotReasons = append(gotReasons, ev.Reason)
+		}
+		assert.ElementsMatch(t, wantReasons, gotReasons)
+	}
+
+	// rolling update starts
+	f([]runtime.Object{
+		getPod("vmselect-sts-0", "rev0"),
+		getPod("vmselect-sts-1", "rev0"),
+	}, []string{events.ReasonRolloutStarted, events.ReasonPodRecreated, events.ReasonPodRecreated, events.ReasonRolloutFinished})
+
+	// rolling update started by previous reconcile is continued
+	f([]runtime.Object{
+		getPod("vmselect-sts-0", "rev1"),
+		getPod("vmselect-sts-1", "rev0"),
+	}, []string{events.ReasonPodRecreated, events.ReasonRolloutFinished})
+
+	// pods are updated already
+	f([]runtime.Object{
+		getPod("vmselect-sts-0", "rev1"),
+		getPod("vmselect-sts-1", "rev1"),
+	}, nil)
+}
+
 func TestSortPodsByID(t *testing.T) {
 	f := func(unorderedPods []corev1.Pod, expectedOrder []corev1.Pod) {
 		t.Helper()
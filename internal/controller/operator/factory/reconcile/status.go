@@ -67,6 +67,7 @@ func StatusForChildObjects[T any, PT interface {
 		}
 		if st.CurrentSyncError == "" {
 			currCound.Status = "True"
+			currCound.Message = st.CurrentSyncWarning
 		} else {
 			currCound.Status = "False"
 			currCound.Message = st.CurrentSyncError
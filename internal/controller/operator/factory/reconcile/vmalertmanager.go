@@ -0,0 +1,64 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMAlertmanagerConfigTestsStatus updates status.tests of given VMAlertmanagerConfigs with evaluated spec.tests results
+//
+// configs with sync errors are skipped, since they weren't added to the alertmanager config
+func VMAlertmanagerConfigTestsStatus(ctx context.Context, rclient client.Client, configs []*vmv1beta1.VMAlertmanagerConfig) error {
+	return testsStatus(ctx, rclient, "VMAlertmanagerConfig", configs, func(cfg *vmv1beta1.VMAlertmanagerConfig) (string, *[]vmv1beta1.VMAlertmanagerConfigTestResult) {
+		return cfg.Status.CurrentSyncError, &cfg.Status.Tests
+	}, nil)
+}
+
+// testsStatus updates status.tests of given objects with evaluated spec.tests results.
+// fields returns sync error and status.tests of the object.
+// Optional merge combines evaluated results with results stored at the existing object
+func testsStatus[T any, PT interface {
+	*T
+	client.Object
+}, R any](ctx context.Context, rclient client.Client, kind string, objects []PT, fields func(PT) (string, *[]R), merge func(existingObj PT, existing, evaluated []R) []R) error {
+	for _, obj := range objects {
+		syncErr, tests := fields(obj)
+		if syncErr != "" {
+			continue
+		}
+		nsn := types.NamespacedName{Name: obj.GetName(), Namespace: obj.GetNamespace()}
+		err := retryOnConflict(func() error {
+			existingObj := PT(new(T))
+			if err := rclient.Get(ctx, nsn, existingObj); err != nil {
+				if k8serrors.IsNotFound(err) {
+					return nil
+				}
+				return fmt.Errorf("cannot get %s=%s: %w", kind, nsn.String(), err)
+			}
+			_, existingTests := fields(existingObj)
+			newTests := *tests
+			if merge != nil {
+				newTests = merge(existingObj, *existingTests, newTests)
+			}
+			if equality.Semantic.DeepEqual(*existingTests, newTests) {
+				return nil
+			}
+			*existingTests = newTests
+			if err := rclient.Status().Update(ctx, existingObj); err != nil {
+				return fmt.Errorf("cannot update status.tests of %s=%s: %w", kind, nsn.String(), err)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
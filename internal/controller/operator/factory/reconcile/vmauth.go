@@ -3,6 +3,8 @@ package reconcile
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"strings"
 
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -63,3 +65,39 @@ func VMAuth(ctx context.Context, rclient client.Client, newObj, prevObj *vmv1bet
 	}
 	return nil
 }
+
+// VMUserTestsStatus updates status.tests of given VMUsers with spec.tests results evaluated for the given VMAuth
+//
+// users with sync errors are skipped, since their config wasn't rendered
+func VMUserTestsStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAuth, users []*vmv1beta1.VMUser) error {
+	return testsStatus(ctx, rclient, "VMUser", users, func(user *vmv1beta1.VMUser) (string, *[]vmv1beta1.VMUserRoutingTestResult) {
+		return user.Status.CurrentSyncError, &user.Status.Tests
+	}, func(user *vmv1beta1.VMUser, existing, evaluated []vmv1beta1.VMUserRoutingTestResult) []vmv1beta1.VMUserRoutingTestResult {
+		return mergeVMUserTests(user, cr.Namespace+"/"+cr.Name, existing, evaluated)
+	})
+}
+
+// mergeVMUserTests replaces results of the given VMAuth and keeps results of other VMAuths,
+// which still select the user according to status conditions, so results of multiple VMAuths don't overwrite each other
+func mergeVMUserTests(user *vmv1beta1.VMUser, vmauth string, existing, evaluated []vmv1beta1.VMUserRoutingTestResult) []vmv1beta1.VMUserRoutingTestResult {
+	if len(user.Spec.Tests) == 0 {
+		return nil
+	}
+	var merged []vmv1beta1.VMUserRoutingTestResult
+	for _, r := range existing {
+		if r.VMAuth == "" || r.VMAuth == vmauth {
+			continue
+		}
+		namespace, name, _ := strings.Cut(r.VMAuth, "/")
+		condType := fmt.Sprintf("%s.%s.vmauth%s", name, namespace, vmv1beta1.ConditionDomainTypeAppliedSuffix)
+		if !slices.ContainsFunc(user.Status.Conditions, func(c vmv1beta1.Condition) bool { return c.Type == condType }) {
+			continue
+		}
+		merged = append(merged, r)
+	}
+	merged = append(merged, evaluated...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].VMAuth < merged[j].VMAuth
+	})
+	return merged
+}
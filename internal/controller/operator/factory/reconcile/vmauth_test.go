@@ -93,3 +93,58 @@ func TestVMAuthReconcile(t *testing.T) {
 		},
 	})
 }
+
+func TestVMUserTestsStatus(t *testing.T) {
+	ctx := context.Background()
+	appliedCond := func(vmauth string) vmv1beta1.Condition {
+		return vmv1beta1.Condition{Type: vmauth + ".default.vmauth" + vmv1beta1.ConditionDomainTypeAppliedSuffix, Status: "True"}
+	}
+	user := &vmv1beta1.VMUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "user",
+			Namespace: "default",
+		},
+		Spec: vmv1beta1.VMUserSpec{
+			Tests: []vmv1beta1.VMUserRoutingTest{{Name: "query", Path: "/api/v1/query"}},
+		},
+		Status: vmv1beta1.VMUserStatus{
+			StatusMetadata: vmv1beta1.StatusMetadata{
+				Conditions: []vmv1beta1.Condition{appliedCond("a"), appliedCond("b"), appliedCond("c")},
+			},
+			Tests: []vmv1beta1.VMUserRoutingTestResult{
+				{Name: "query", VMAuth: "default/b", Route: "url_prefix"},
+				{Name: "query", VMAuth: "default/removed", Route: "url_prefix"},
+				{Name: "query", Route: "url_prefix"},
+			},
+		},
+	}
+	rclient := k8stools.GetTestClientWithObjects([]runtime.Object{user.DeepCopy()})
+	update := func(vmauth, route string) []vmv1beta1.VMUserRoutingTestResult {
+		t.Helper()
+		cr := &vmv1beta1.VMAuth{ObjectMeta: metav1.ObjectMeta{Name: vmauth, Namespace: "default"}}
+		evaluated := user.DeepCopy()
+		evaluated.Status.Tests = []vmv1beta1.VMUserRoutingTestResult{{Name: "query", VMAuth: "default/" + vmauth, Route: route}}
+		assert.NoError(t, VMUserTestsStatus(ctx, rclient, cr, []*vmv1beta1.VMUser{evaluated}))
+		var got vmv1beta1.VMUser
+		assert.NoError(t, rclient.Get(ctx, types.NamespacedName{Name: user.Name, Namespace: user.Namespace}, &got))
+		return got.Status.Tests
+	}
+
+	// results of other selecting VMAuths are kept
+	assert.Equal(t, []vmv1beta1.VMUserRoutingTestResult{
+		{Name: "query", VMAuth: "default/b", Route: "url_prefix"},
+		{Name: "query", VMAuth: "default/c", Route: "url_map[0]"},
+	}, update("c", "url_map[0]"))
+	assert.Equal(t, []vmv1beta1.VMUserRoutingTestResult{
+		{Name: "query", VMAuth: "default/a", Route: "default_url"},
+		{Name: "query", VMAuth: "default/b", Route: "url_prefix"},
+		{Name: "query", VMAuth: "default/c", Route: "url_map[0]"},
+	}, update("a", "default_url"))
+
+	// results of the same VMAuth are replaced
+	assert.Equal(t, []vmv1beta1.VMUserRoutingTestResult{
+		{Name: "query", VMAuth: "default/a", Route: "default_url"},
+		{Name: "query", VMAuth: "default/b", Route: "url_map[1]"},
+		{Name: "query", VMAuth: "default/c", Route: "url_map[0]"},
+	}, update("b", "url_map[1]"))
+}
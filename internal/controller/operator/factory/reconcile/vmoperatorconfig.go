@@ -0,0 +1,47 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMOperatorConfigStatus updates status of VMOperatorConfig with effective operator configuration
+//
+// status is marked as failed if syncErr is not nil
+func VMOperatorConfigStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMOperatorConfig, effectiveConfig map[string]string, syncErr error) error {
+	nsn := types.NamespacedName{Name: cr.Name}
+	return retryOnConflict(func() error {
+		var existingObj vmv1beta1.VMOperatorConfig
+		if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("cannot get VMOperatorConfig=%s: %w", nsn.Name, err)
+		}
+		st := existingObj.Status.DeepCopy()
+		st.EffectiveConfig = effectiveConfig
+		st.ObservedGeneration = existingObj.Generation
+		st.UpdateStatus = vmv1beta1.UpdateStatusOperational
+		st.Reason = ""
+		if syncErr != nil {
+			st.UpdateStatus = vmv1beta1.UpdateStatusFailed
+			st.Reason = syncErr.Error()
+		}
+		if equality.Semantic.DeepEqual(&existingObj.Status, st) {
+			return nil
+		}
+		existingObj.Status = *st
+		if err := rclient.Status().Update(ctx, &existingObj); err != nil {
+			return fmt.Errorf("cannot update status of VMOperatorConfig=%s: %w", nsn.Name, err)
+		}
+		cr.Status = existingObj.Status
+		return nil
+	})
+}
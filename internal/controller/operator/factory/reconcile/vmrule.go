@@ -0,0 +1,98 @@
+package reconcile
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+// VMRule creates or updates given object
+func VMRule(ctx context.Context, rclient client.Client, newObj, prevObj *vmv1beta1.VMRule, owner *metav1.OwnerReference) error {
+	if build.IsControllerDisabled("VMRule") {
+		return nil
+	}
+	nsn := types.NamespacedName{Name: newObj.Name, Namespace: newObj.Namespace}
+	var prevMeta *metav1.ObjectMeta
+	if prevObj != nil {
+		prevMeta = &prevObj.ObjectMeta
+	}
+	return retryOnConflict(func() error {
+		var existingObj vmv1beta1.VMRule
+		if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				logger.WithContext(ctx).Info(fmt.Sprintf("creating VMRule=%s", nsn.String()))
+				return rclient.Create(ctx, newObj)
+			}
+			return err
+		}
+		if err := collectGarbage(ctx, rclient, &existingObj); err != nil {
+			return err
+		}
+		metaChanged, err := mergeMeta(&existingObj, newObj, prevMeta, owner, false)
+		if err != nil {
+			return err
+		}
+		logMessageMetadata := []string{fmt.Sprintf("name=%s, is_prev_nil=%t", nsn.String(), prevObj == nil)}
+		specDiff := diffDeepDerivative(newObj.Spec, existingObj.Spec)
+		needsUpdate := metaChanged || len(specDiff) > 0
+		logMessageMetadata = append(logMessageMetadata, fmt.Sprintf("spec_diff=%s", specDiff))
+		if !needsUpdate {
+			return nil
+		}
+		existingObj.Spec = newObj.Spec
+		logger.WithContext(ctx).Info(fmt.Sprintf("updating VMRule %s", strings.Join(logMessageMetadata, ", ")))
+		return rclient.Update(ctx, &existingObj)
+	})
+}
+
+// VMRuleGroupsStatus replaces status of rule groups evaluated by the given VMAlert with provided groups.
+// Status is updated only if health of groups changed, evaluation time and duration changes are ignored
+func VMRuleGroupsStatus(ctx context.Context, rclient client.Client, nsn types.NamespacedName, vmalert string, groups []vmv1beta1.VMRuleGroupStatus) error {
+	return retryOnConflict(func() error {
+		var rule vmv1beta1.VMRule
+		if err := rclient.Get(ctx, nsn, &rule); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		newGroups := slices.DeleteFunc(slices.Clone(rule.Status.Groups), func(g vmv1beta1.VMRuleGroupStatus) bool {
+			return g.VMAlert == vmalert
+		})
+		newGroups = append(newGroups, groups...)
+		slices.SortFunc(newGroups, func(a, b vmv1beta1.VMRuleGroupStatus) int {
+			return cmp.Or(strings.Compare(a.VMAlert, b.VMAlert), strings.Compare(a.Name, b.Name))
+		})
+		if isRuleGroupsHealthEqual(rule.Status.Groups, newGroups) {
+			return nil
+		}
+		rule.Status.Groups = newGroups
+		if err := rclient.Status().Update(ctx, &rule); err != nil {
+			return fmt.Errorf("cannot update groups status of VMRule=%s: %w", nsn, err)
+		}
+		return nil
+	})
+}
+
+// isRuleGroupsHealthEqual checks if groups have the same health state
+func isRuleGroupsHealthEqual(a, b []vmv1beta1.VMRuleGroupStatus) bool {
+	return slices.EqualFunc(a, b, func(x, y vmv1beta1.VMRuleGroupStatus) bool {
+		return x.Name == y.Name &&
+			x.VMAlert == y.VMAlert &&
+			x.LastError == y.LastError &&
+			x.FiringAlerts == y.FiringAlerts &&
+			x.EvaluationMisses == y.EvaluationMisses &&
+			(x.LastEvaluation == nil) == (y.LastEvaluation == nil)
+	})
+}
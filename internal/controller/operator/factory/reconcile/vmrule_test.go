@@ -0,0 +1,168 @@
+package reconcile
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestVMRule(t *testing.T) {
+	type opts struct {
+		new, prev         *vmv1beta1.VMRule
+		predefinedObjects []runtime.Object
+		actions           []k8stools.ClientAction
+	}
+	getVMRule := func(fns ...func(v *vmv1beta1.VMRule)) *vmv1beta1.VMRule {
+		v := &vmv1beta1.VMRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-vmrule",
+				Namespace: "default",
+			},
+			Spec: vmv1beta1.VMRuleSpec{
+				Groups: []vmv1beta1.RuleGroup{{
+					Name: "test",
+					Rules: []vmv1beta1.Rule{{
+						Alert: "Anomaly",
+						Expr:  "anomaly_score > 1",
+					}},
+				}},
+			},
+		}
+		for _, fn := range fns {
+			fn(v)
+		}
+		return v
+	}
+
+	f := func(o opts) {
+		t.Helper()
+		ctx := context.Background()
+		cl := k8stools.GetTestClientWithActionsAndObjects(o.predefinedObjects)
+		assert.NoError(t, VMRule(ctx, cl, o.new, o.prev, nil))
+		assert.Equal(t, o.actions, cl.Actions)
+	}
+
+	nn := types.NamespacedName{Name: "test-vmrule", Namespace: "default"}
+
+	// create
+	f(opts{
+		new: getVMRule(),
+		actions: []k8stools.ClientAction{
+			{Verb: "Get", Kind: "VMRule", Resource: nn},
+			{Verb: "Create", Kind: "VMRule", Resource: nn},
+		},
+	})
+
+	// no updates
+	f(opts{
+		new:  getVMRule(),
+		prev: getVMRule(),
+		predefinedObjects: []runtime.Object{
+			getVMRule(),
+		},
+		actions: []k8stools.ClientAction{
+			{Verb: "Get", Kind: "VMRule", Resource: nn},
+		},
+	})
+
+	// update spec
+	f(opts{
+		new: getVMRule(func(v *vmv1beta1.VMRule) {
+			v.Spec.Groups[0].Rules[0].Expr = "anomaly_score > 2"
+		}),
+		prev: getVMRule(),
+		predefinedObjects: []runtime.Object{
+			getVMRule(),
+		},
+		actions: []k8stools.ClientAction{
+			{Verb: "Get", Kind: "VMRule", Resource: nn},
+			{Verb: "Update", Kind: "VMRule", Resource: nn},
+		},
+	})
+}
+
+func TestVMRuleGroupsStatus(t *testing.T) {
+	type opts struct {
+		groups []vmv1beta1.VMRuleGroupStatus
+		prev   []vmv1beta1.VMRuleGroupStatus
+		want   []vmv1beta1.VMRuleGroupStatus
+	}
+	nn := types.NamespacedName{Name: "test-vmrule", Namespace: "default"}
+	f := func(o opts) {
+		t.Helper()
+		ctx := context.Background()
+		rule := &vmv1beta1.VMRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      nn.Name,
+				Namespace: nn.Namespace,
+			},
+			Status: vmv1beta1.VMRuleStatus{
+				Groups: o.prev,
+			},
+		}
+		cl := k8stools.GetTestClientWithObjects([]runtime.Object{rule})
+		assert.NoError(t, VMRuleGroupsStatus(ctx, cl, nn, "default/main", o.groups))
+		var got vmv1beta1.VMRule
+		assert.NoError(t, cl.Get(ctx, nn, &got))
+		assert.Equal(t, o.want, got.Status.Groups)
+	}
+
+	// add groups
+	f(opts{
+		groups: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "b", VMAlert: "default/main", FiringAlerts: 1},
+			{Name: "a", VMAlert: "default/main"},
+		},
+		want: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "default/main"},
+			{Name: "b", VMAlert: "default/main", FiringAlerts: 1},
+		},
+	})
+
+	// replace groups of the same vmalert and keep other vmalerts
+	f(opts{
+		prev: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "default/main", LastError: "stale"},
+			{Name: "old", VMAlert: "default/main"},
+			{Name: "a", VMAlert: "monitoring/other"},
+		},
+		groups: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "default/main", EvaluationMisses: 2},
+		},
+		want: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "default/main", EvaluationMisses: 2},
+			{Name: "a", VMAlert: "monitoring/other"},
+		},
+	})
+	// skip update if only evaluation time changed
+	f(opts{
+		prev: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "default/main", LastEvaluation: &metav1.Time{Time: time.Unix(100, 0)}, LastDuration: "1s"},
+		},
+		groups: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "default/main", LastEvaluation: &metav1.Time{Time: time.Unix(130, 0)}, LastDuration: "2s"},
+		},
+		want: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "default/main", LastEvaluation: &metav1.Time{Time: time.Unix(100, 0)}, LastDuration: "1s"},
+		},
+	})
+
+	// remove groups of vmalert
+	f(opts{
+		prev: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "default/main"},
+			{Name: "a", VMAlert: "monitoring/other"},
+		},
+		want: []vmv1beta1.VMRuleGroupStatus{
+			{Name: "a", VMAlert: "monitoring/other"},
+		},
+	})
+}
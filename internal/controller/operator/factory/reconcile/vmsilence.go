@@ -0,0 +1,47 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// VMSilenceStatus updates status of VMSilence with silences synced to alertmanagers
+//
+// status is marked as failed if syncErr is not nil
+func VMSilenceStatus(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMSilence, silences []vmv1beta1.VMSilenceState, syncErr error) error {
+	nsn := types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}
+	return retryOnConflict(func() error {
+		var existingObj vmv1beta1.VMSilence
+		if err := rclient.Get(ctx, nsn, &existingObj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil
+			}
+			return fmt.Errorf("cannot get VMSilence=%s: %w", nsn.String(), err)
+		}
+		st := existingObj.Status.DeepCopy()
+		st.Silences = silences
+		st.ObservedGeneration = existingObj.Generation
+		st.UpdateStatus = vmv1beta1.UpdateStatusOperational
+		st.Reason = ""
+		if syncErr != nil {
+			st.UpdateStatus = vmv1beta1.UpdateStatusFailed
+			st.Reason = syncErr.Error()
+		}
+		if equality.Semantic.DeepEqual(&existingObj.Status, st) {
+			return nil
+		}
+		existingObj.Status = *st
+		if err := rclient.Status().Update(ctx, &existingObj); err != nil {
+			return fmt.Errorf("cannot update status of VMSilence=%s: %w", nsn.String(), err)
+		}
+		cr.Status = existingObj.Status
+		return nil
+	})
+}
@@ -84,6 +84,11 @@ func buildScrape(cr *vmv1.VLAgent) *vmv1beta1.VMPodScrape {
 // CreateOrUpdate creates deployment for vlagent and configures it
 // waits for healthy state
 func CreateOrUpdate(ctx context.Context, cr *vmv1.VLAgent, rclient client.Client) error {
+	if !build.MustSkipRuntimeValidation() {
+		if err := cr.Validate(); err != nil {
+			return build.NewValidationError(err)
+		}
+	}
 	var prevCR *vmv1.VLAgent
 	if cr.Status.LastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
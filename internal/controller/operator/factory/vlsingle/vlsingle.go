@@ -61,6 +61,11 @@ func newPVC(r *vmv1.VLSingle) *corev1.PersistentVolumeClaim {
 
 // CreateOrUpdate performs an update for vlsingle resource
 func CreateOrUpdate(ctx context.Context, rclient client.Client, cr *vmv1.VLSingle) error {
+	if !build.MustSkipRuntimeValidation() {
+		if err := cr.Validate(); err != nil {
+			return build.NewValidationError(err)
+		}
+	}
 	var prevCR *vmv1.VLSingle
 	if cr.Status.LastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
@@ -116,6 +116,11 @@ func createOrUpdateService(ctx context.Context, rclient client.Client, cr, prevC
 // CreateOrUpdate creates deployment for vmagent and configures it
 // waits for healthy state
 func CreateOrUpdate(ctx context.Context, cr *vmv1beta1.VMAgent, rclient client.Client) error {
+	if !build.MustSkipRuntimeValidation() {
+		if err := cr.Validate(); err != nil {
+			return build.NewValidationError(err)
+		}
+	}
 	var prevCR *vmv1beta1.VMAgent
 	if cr.Status.LastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
@@ -6,10 +6,12 @@ import (
 	"hash/fnv"
 	"sort"
 	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
@@ -19,21 +21,22 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 )
 
-// CreateOrUpdateRuleConfigMaps conditionally selects vmrules and stores content at configmaps
-func CreateOrUpdateRuleConfigMaps(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, childCR *vmv1beta1.VMRule) ([]string, error) {
+// CreateOrUpdateRuleConfigMaps conditionally selects vmrules and stores content at configmaps.
+// It returns names of configmaps and selected vmrules by configmap keys
+func CreateOrUpdateRuleConfigMaps(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, childCR *vmv1beta1.VMRule) ([]string, map[string]types.NamespacedName, error) {
 	// fast path
 	if cr.IsUnmanaged() {
-		return nil, nil
+		return nil, nil, nil
 	}
-	newRules, err := reconcileVMAlertConfig(ctx, rclient, cr, childCR)
+	newRules, ruleKeys, err := reconcileVMAlertConfig(ctx, rclient, cr, childCR)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return newRules, nil
+	return newRules, ruleKeys, nil
 }
 
-func reconcileConfigsData(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, newRules map[string]string) ([]string, error) {
+func reconcileConfigsData(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, newRules map[string]map[string]string) ([]string, error) {
 	newConfigMaps := makeRulesConfigMaps(cr, newRules)
 	sort.Slice(newConfigMaps, func(i, j int) bool {
 		return newConfigMaps[i].Name < newConfigMaps[j].Name
@@ -60,15 +63,15 @@ func reconcileConfigsData(ctx context.Context, rclient client.Client, cr *vmv1be
 	return newConfigMapNames, nil
 }
 
-func reconcileVMAlertConfig(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, childCR *vmv1beta1.VMRule) ([]string, error) {
+func reconcileVMAlertConfig(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, childCR *vmv1beta1.VMRule) ([]string, map[string]types.NamespacedName, error) {
 	pos, data, err := selectRules(ctx, rclient, cr)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// perform config maps content update
 	cmNames, err := reconcileConfigsData(ctx, rclient, cr, data)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	parentObject := fmt.Sprintf("%s.%s.vmalert", cr.Name, cr.Namespace)
 	if childCR != nil {
@@ -76,22 +79,24 @@ func reconcileVMAlertConfig(ctx context.Context, rclient client.Client, cr *vmv1
 			// fast path update a single object that triggered event
 			// it should be fast path for the most cases
 			if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, []*vmv1beta1.VMRule{o}); err != nil {
-				return nil, err
+				return nil, nil, err
 			}
-			return cmNames, nil
+			return cmNames, pos.ruleKeys, nil
 		}
 	}
 	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, pos.rules.All()); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return cmNames, nil
+	return cmNames, pos.ruleKeys, nil
 }
 
 type parsedObjects struct {
 	rules *build.ChildObjects[*vmv1beta1.VMRule]
+	// ruleKeys maps configmap keys to valid vmrules
+	ruleKeys map[string]types.NamespacedName
 }
 
-func selectRules(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert) (*parsedObjects, map[string]string, error) {
+func selectRules(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert) (*parsedObjects, map[string]map[string]string, error) {
 	var rules []*vmv1beta1.VMRule
 	var nsn []string
 	if !build.IsControllerDisabled("VMRule") {
@@ -117,25 +122,99 @@ func selectRules(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAle
 			rules = deduplicateRules(ctx, rules)
 		}
 	}
-	pos := &parsedObjects{rules: build.NewChildObjects("vmrule", rules, nsn)}
-	data := make(map[string]string)
+	pos := &parsedObjects{
+		rules:    build.NewChildObjects("vmrule", rules, nsn),
+		ruleKeys: make(map[string]types.NamespacedName),
+	}
+	data := make(map[string]map[string]string)
 	pos.rules.ForEachCollectSkipInvalid(func(rule *vmv1beta1.VMRule) error {
+		// group type must match datasource, e.g. rules for VictoriaLogs must be validated and evaluated as vlogs
+		for i := range rule.Spec.Groups {
+			group := &rule.Spec.Groups[i]
+			if ds, ok := datasourceForGroup(cr, group); ok && group.Type == "" {
+				group.Type = datasourceRuleType(cr, ds)
+			}
+		}
 		if !build.MustSkipRuntimeValidation() {
 			if err := rule.Validate(); err != nil {
 				return err
 			}
 		}
-		content, err := generateContent(rule.Spec, cr.Spec.EnforcedNamespaceLabel, rule.Namespace)
-		if err != nil {
-			return err
+		groupsByDatasource := make(map[string][]vmv1beta1.RuleGroup)
+		if len(rule.Spec.Groups) == 0 {
+			groupsByDatasource[""] = nil
+		}
+		var skipped []string
+		for _, group := range rule.Spec.Groups {
+			ds, ok := datasourceForGroup(cr, &group)
+			if !ok {
+				logger.WithContext(ctx).Info(fmt.Sprintf("skipping group=%q of vmrule=%s/%s, datasource=%q is not defined at vmalert", group.Name, rule.Namespace, rule.Name, group.Datasource))
+				skipped = append(skipped, fmt.Sprintf("group=%q datasource=%q", group.Name, group.Datasource))
+				continue
+			}
+			// datasource is resolved by operator and isn't supported by vmalert
+			group.Datasource = ""
+			groupsByDatasource[ds] = append(groupsByDatasource[ds], group)
+		}
+		for ds, groups := range groupsByDatasource {
+			content, err := generateContent(vmv1beta1.VMRuleSpec{Groups: groups}, cr.Spec.EnforcedNamespaceLabel, rule.Namespace)
+			if err != nil {
+				return err
+			}
+			if _, ok := data[ds]; !ok {
+				data[ds] = make(map[string]string)
+			}
+			data[ds][rule.AsKey(false)] = content
+		}
+		pos.ruleKeys[rule.AsKey(false)] = types.NamespacedName{Namespace: rule.Namespace, Name: rule.Name}
+		// skipped groups are reported at status condition of the rule
+		if len(skipped) > 0 {
+			rule.Status.CurrentSyncWarning = fmt.Sprintf("skipped groups with datasources missing at vmalert: %s", strings.Join(skipped, ", "))
 		}
-		data[rule.AsKey(false)] = content
 		return nil
 	})
 	pos.rules.UpdateMetrics(ctx)
 	return pos, data, nil
 }
 
+// datasourceForGroup returns name of VMAlert datasource, which evaluates the given group.
+// Empty name stands for spec.datasource.
+// It returns false if group refers to datasource, which is missing at VMAlert
+func datasourceForGroup(cr *vmv1beta1.VMAlert, group *vmv1beta1.RuleGroup) (string, bool) {
+	if group.Datasource != "" {
+		for _, ds := range cr.Spec.Datasources {
+			if ds.Name == group.Datasource {
+				return ds.Name, true
+			}
+		}
+		return "", false
+	}
+	if group.Type != "" && group.Type != cr.Spec.Datasource.RuleType() {
+		for _, ds := range cr.Spec.Datasources {
+			if ds.RuleType() == group.Type {
+				return ds.Name, true
+			}
+		}
+	}
+	return "", true
+}
+
+// datasourceRuleType returns type of rule groups evaluated with the named VMAlert datasource.
+// Empty name stands for spec.datasource. Default prometheus type is returned as empty string
+func datasourceRuleType(cr *vmv1beta1.VMAlert, name string) string {
+	ruleType := cr.Spec.Datasource.RuleType()
+	for _, ds := range cr.Spec.Datasources {
+		if ds.Name == name {
+			ruleType = ds.RuleType()
+			break
+		}
+	}
+	if ruleType == "prometheus" {
+		return ""
+	}
+	return ruleType
+}
+
 func generateContent(promRule vmv1beta1.VMRuleSpec, enforcedNsLabel, ns string) (string, error) {
 	if enforcedNsLabel != "" {
 		for gi, group := range promRule.Groups {
@@ -154,14 +233,24 @@ func generateContent(promRule vmv1beta1.VMRuleSpec, enforcedNsLabel, ns string)
 	return string(content), nil
 }
 
-// makeRulesConfigMaps takes a VMAlert configuration and rule files and
-// returns a list of Kubernetes ConfigMaps to be later on mounted
+// makeRulesConfigMaps takes a VMAlert configuration and rule files grouped by datasource name and
+// returns a list of Kubernetes ConfigMaps to be later on mounted.
+// Rule files of each datasource are stored at separate ConfigMaps.
 // If the total size of rule files exceeds the Kubernetes ConfigMap limit,
 // they are split up via the simple first-fit [1] bin packing algorithm. In the
 // future this can be replaced by a more sophisticated algorithm, but for now
 // simplicity should be sufficient.
 // [1] https://en.wikipedia.org/wiki/Bin_packing_problem#First-fit_algorithm
-func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, ruleFiles map[string]string) []corev1.ConfigMap {
+func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, ruleFiles map[string]map[string]string) []corev1.ConfigMap {
+	ruleFileConfigMaps := makeDatasourceRulesConfigMaps(cr, ruleConfigMapName(cr.Name), ruleFiles[""])
+	for _, ds := range cr.Spec.Datasources {
+		cms := makeDatasourceRulesConfigMaps(cr, datasourceRuleConfigMapName(cr.Name, ds.Name), ruleFiles[ds.Name])
+		ruleFileConfigMaps = append(ruleFileConfigMaps, cms...)
+	}
+	return ruleFileConfigMaps
+}
+
+func makeDatasourceRulesConfigMaps(cr *vmv1beta1.VMAlert, name string, ruleFiles map[string]string) []corev1.ConfigMap {
 	buckets := []map[string]string{
 		{},
 	}
@@ -187,7 +276,7 @@ func makeRulesConfigMaps(cr *vmv1beta1.VMAlert, ruleFiles map[string]string) []c
 	ruleFileConfigMaps := make([]corev1.ConfigMap, 0, len(buckets))
 	for i, bucket := range buckets {
 		cm := makeRulesConfigMap(cr, bucket)
-		cm.Name = cm.Name + "-" + strconv.Itoa(i)
+		cm.Name = name + "-" + strconv.Itoa(i)
 		ruleFileConfigMaps = append(ruleFileConfigMaps, cm)
 	}
 
@@ -219,6 +308,38 @@ func ruleConfigMapName(vmName string) string {
 	return "vm-" + vmName + "-rulefiles"
 }
 
+func datasourceRuleConfigMapName(vmName, datasource string) string {
+	return ruleConfigMapName(vmName) + "-" + datasource
+}
+
+// splitRuleConfigMaps returns names of rule ConfigMaps for spec.datasource and for each of spec.datasources
+func splitRuleConfigMaps(cr *vmv1beta1.VMAlert, cmNames []string) ([]string, map[string][]string) {
+	var defaultNames []string
+	byDatasource := make(map[string][]string, len(cr.Spec.Datasources))
+	for _, name := range cmNames {
+		ds, ok := ruleConfigMapDatasource(cr, name)
+		if !ok {
+			defaultNames = append(defaultNames, name)
+			continue
+		}
+		byDatasource[ds] = append(byDatasource[ds], name)
+	}
+	return defaultNames, byDatasource
+}
+
+func ruleConfigMapDatasource(cr *vmv1beta1.VMAlert, cmName string) (string, bool) {
+	for _, ds := range cr.Spec.Datasources {
+		idx, ok := strings.CutPrefix(cmName, datasourceRuleConfigMapName(cr.Name, ds.Name)+"-")
+		if !ok {
+			continue
+		}
+		if _, err := strconv.Atoi(idx); err == nil {
+			return ds.Name, true
+		}
+	}
+	return "", false
+}
+
 // deduplicateRules - takes list of vmRules and modifies it
 // by removing duplicates.
 // possible duplicates:
@@ -0,0 +1,315 @@
+package vmalert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+const (
+	rulesAPIPath    = "/api/v1/rules"
+	rulesAPITimeout = 10 * time.Second
+)
+
+var rulesHTTPClient = &http.Client{
+	Timeout: rulesAPITimeout,
+}
+
+// rulesAPI is a client for vmalert /api/v1/rules API
+type rulesAPI struct {
+	c        *http.Client
+	username string
+	password string
+}
+
+// newRulesAPI returns client for vmalert API with settings of vmalert webserver defined at spec.extraArgs.
+// Webserver certificate mounted from spec.secrets is trusted in addition to system CAs, since it's usually self-signed
+func newRulesAPI(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert) (*rulesAPI, error) {
+	api := &rulesAPI{c: rulesHTTPClient}
+	ac := build.NewAssetsCache(ctx, rclient, nil)
+	if username, ok := cr.Spec.ExtraArgs["httpAuth.username"]; ok {
+		password, err := resolveFlagValue(ac, cr, cr.Spec.ExtraArgs["httpAuth.password"])
+		if err != nil {
+			return nil, fmt.Errorf("cannot load httpAuth.password: %w", err)
+		}
+		api.username = username
+		api.password = password
+	}
+	if !cr.UseTLS() {
+		return api, nil
+	}
+	if v := cr.Spec.ExtraArgs["mtls"]; strings.ToLower(v) == "true" {
+		return nil, fmt.Errorf("vmalert API requires client certificate, rules health cannot be fetched")
+	}
+	rootCAs, err := x509.SystemCertPool()
+	if err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+	if certFile := cr.Spec.ExtraArgs["tlsCertFile"]; certFile != "" {
+		cert, err := resolveFlagValue(ac, cr, "file://"+certFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load webserver certificate: %w", err)
+		}
+		if cert != "" && !rootCAs.AppendCertsFromPEM([]byte(cert)) {
+			return nil, fmt.Errorf("cannot parse webserver certificate from file=%q", certFile)
+		}
+	}
+	// pods are requested by IP, while certificate is usually issued for service name
+	u, err := url.Parse(cr.AsURL())
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse vmalert url: %w", err)
+	}
+	api.c = &http.Client{
+		Timeout: rulesAPITimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs:    rootCAs,
+				ServerName: u.Hostname(),
+			},
+			DisableKeepAlives: true,
+		},
+	}
+	return api, nil
+}
+
+// resolveFlagValue returns content of file referenced by flag value with file:// prefix,
+// if file is mounted from spec.secrets. Files from other sources cannot be read by operator
+// and empty value is returned for them
+func resolveFlagValue(ac *build.AssetsCache, cr *vmv1beta1.VMAlert, value string) (string, error) {
+	filePath, ok := strings.CutPrefix(value, "file://")
+	if !ok {
+		return value, nil
+	}
+	rel, ok := strings.CutPrefix(path.Clean(filePath), vmv1beta1.SecretsDir+"/")
+	if !ok {
+		return "", nil
+	}
+	name, key, ok := strings.Cut(rel, "/")
+	if !ok || !slices.Contains(cr.Spec.Secrets, name) {
+		return "", nil
+	}
+	return ac.LoadKeyFromSecret(cr.Namespace, &corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	})
+}
+
+// apiGroup is a rule group representation at vmalert /api/v1/rules response
+type apiGroup struct {
+	Name           string    `json:"name"`
+	File           string    `json:"file"`
+	Interval       float64   `json:"interval"`
+	LastEvaluation time.Time `json:"lastEvaluation"`
+	Rules          []apiRule `json:"rules"`
+}
+
+type apiRule struct {
+	Name           string     `json:"name"`
+	LastError      string     `json:"lastError"`
+	EvaluationTime float64    `json:"evaluationTime"`
+	Alerts         []apiAlert `json:"alerts"`
+}
+
+type apiAlert struct {
+	State string `json:"state"`
+}
+
+type apiRulesResponse struct {
+	Data struct {
+		Groups []apiGroup `json:"groups"`
+	} `json:"data"`
+}
+
+// UpdateRulesHealth fetches state of rule groups from each ready vmalert pod
+// and reports aggregated evaluation health of groups at status of originating VMRules.
+//
+// rules maps config map keys to VMRules selected by VMAlert.
+// Groups status of VMRules, which are no longer selected, is removed
+func UpdateRulesHealth(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert, rules map[string]types.NamespacedName) error {
+	if cr.IsUnmanaged() || !config.MustGetBaseConfig().EnableVMAlertRulesHealth {
+		return nil
+	}
+	api, err := newRulesAPI(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	var pods corev1.PodList
+	opts := &client.ListOptions{
+		Namespace:     cr.Namespace,
+		LabelSelector: labels.SelectorFromSet(cr.SelectorLabels()),
+	}
+	if err := rclient.List(ctx, &pods, opts); err != nil {
+		return fmt.Errorf("cannot list vmalert pods: %w", err)
+	}
+	slices.SortFunc(pods.Items, func(a, b corev1.Pod) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	// each named datasource is evaluated by a separate container
+	ports := []string{cr.Spec.Port}
+	for i := range cr.Spec.Datasources {
+		ports = append(ports, datasourcePort(cr, i))
+	}
+	var errs []error
+	var podGroups [][]apiGroup
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !pod.DeletionTimestamp.IsZero() || pod.Status.PodIP == "" || !reconcile.PodIsReady(pod, 0) {
+			continue
+		}
+		for _, port := range ports {
+			u := vmv1beta1.BuildLocalURL("", pod.Status.PodIP, port, rulesAPIPath, cr.Spec.ExtraArgs)
+			groups, err := api.fetchRuleGroups(ctx, u)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("cannot fetch rules from pod=%s port=%s: %w", pod.Name, port, err))
+				continue
+			}
+			podGroups = append(podGroups, groups)
+		}
+	}
+	if len(podGroups) == 0 {
+		return errors.Join(errs...)
+	}
+	owner := fmt.Sprintf("%s/%s", cr.Namespace, cr.Name)
+	statuses := buildRuleGroupsStatus(owner, podGroups, time.Now())
+	desired := make(map[types.NamespacedName][]vmv1beta1.VMRuleGroupStatus, len(rules))
+	for key, nsn := range rules {
+		desired[nsn] = statuses[key]
+	}
+	// VMRules with status of this VMAlert, which are no longer selected by it
+	if err := k8stools.ListObjectsByNamespace(ctx, rclient, config.MustGetBaseConfig().GetWatchNamespaces(), func(list *vmv1beta1.VMRuleList) {
+		for _, item := range list.Items {
+			nsn := types.NamespacedName{Namespace: item.Namespace, Name: item.Name}
+			if _, ok := desired[nsn]; ok {
+				continue
+			}
+			if slices.ContainsFunc(item.Status.Groups, func(g vmv1beta1.VMRuleGroupStatus) bool { return g.VMAlert == owner }) {
+				desired[nsn] = nil
+			}
+		}
+	}); err != nil {
+		errs = append(errs, fmt.Errorf("cannot list VMRules: %w", err))
+	}
+	for _, nsn := range slices.SortedFunc(maps.Keys(desired), func(a, b types.NamespacedName) int {
+		return strings.Compare(a.String(), b.String())
+	}) {
+		if err := reconcile.VMRuleGroupsStatus(ctx, rclient, nsn, owner, desired[nsn]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (api *rulesAPI) fetchRuleGroups(ctx context.Context, u string) ([]apiGroup, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if api.username != "" {
+		req.SetBasicAuth(api.username, api.password)
+	}
+	resp, err := api.c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("unexpected status code=%d, body=%q", resp.StatusCode, string(body))
+	}
+	var r apiRulesResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, fmt.Errorf("cannot parse response: %w", err)
+	}
+	return r.Data.Groups, nil
+}
+
+// buildRuleGroupsStatus aggregates groups state reported by vmalert pods
+// and returns groups status by config map keys of VMRules.
+// Groups loaded from other files are ignored
+func buildRuleGroupsStatus(owner string, podGroups [][]apiGroup, now time.Time) map[string][]vmv1beta1.VMRuleGroupStatus {
+	type groupState struct {
+		status   vmv1beta1.VMRuleGroupStatus
+		duration time.Duration
+	}
+	states := make(map[string]map[string]*groupState)
+	for _, groups := range podGroups {
+		for _, g := range groups {
+			dir, key := path.Split(g.File)
+			if path.Dir(path.Clean(dir)) != vmAlertConfigDir {
+				continue
+			}
+			byName, ok := states[key]
+			if !ok {
+				byName = make(map[string]*groupState)
+				states[key] = byName
+			}
+			st, ok := byName[g.Name]
+			if !ok {
+				st = &groupState{status: vmv1beta1.VMRuleGroupStatus{Name: g.Name, VMAlert: owner}}
+				byName[g.Name] = st
+			}
+			if g.LastEvaluation.IsZero() {
+				// group wasn't evaluated yet
+				continue
+			}
+			if st.status.LastEvaluation == nil || g.LastEvaluation.After(st.status.LastEvaluation.Time) {
+				st.status.LastEvaluation = &metav1.Time{Time: g.LastEvaluation}
+			}
+			var duration time.Duration
+			var firing int32
+			for _, r := range g.Rules {
+				duration += time.Duration(r.EvaluationTime * float64(time.Second))
+				if r.LastError != "" && st.status.LastError == "" {
+					st.status.LastError = fmt.Sprintf("rule %q: %s", r.Name, r.LastError)
+				}
+				for _, a := range r.Alerts {
+					if a.State == "firing" {
+						firing++
+					}
+				}
+			}
+			st.duration = max(st.duration, duration)
+			st.status.FiringAlerts = max(st.status.FiringAlerts, firing)
+			if g.Interval > 0 {
+				intervals := now.Sub(g.LastEvaluation).Seconds() / g.Interval
+				misses := int32(max(math.Floor(intervals)-1, 0))
+				st.status.EvaluationMisses = max(st.status.EvaluationMisses, misses)
+			}
+		}
+	}
+	result := make(map[string][]vmv1beta1.VMRuleGroupStatus, len(states))
+	for key, byName := range states {
+		for _, name := range slices.Sorted(maps.Keys(byName)) {
+			st := byName[name]
+			if st.status.LastEvaluation != nil {
+				st.status.LastDuration = st.duration.Round(time.Millisecond).String()
+			}
+			result[key] = append(result[key], st.status)
+		}
+	}
+	return result
+}
@@ -0,0 +1,222 @@
+package vmalert
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestBuildRuleGroupsStatus(t *testing.T) {
+	type opts struct {
+		podGroups [][]apiGroup
+		want      map[string][]vmv1beta1.VMRuleGroupStatus
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	f := func(o opts) {
+		t.Helper()
+		got := buildRuleGroupsStatus("default/main", o.podGroups, now)
+		assert.Equal(t, o.want, got)
+	}
+
+	// no groups
+	f(opts{
+		want: map[string][]vmv1beta1.VMRuleGroupStatus{},
+	})
+
+	// skip groups from files not managed by operator
+	f(opts{
+		podGroups: [][]apiGroup{{
+			{Name: "extra", File: "/etc/vmalert/extra/rules.yaml", Interval: 30, LastEvaluation: now},
+		}},
+		want: map[string][]vmv1beta1.VMRuleGroupStatus{},
+	})
+
+	// not evaluated group
+	f(opts{
+		podGroups: [][]apiGroup{{
+			{Name: "group", File: "/etc/vmalert/config/vm-main-rulefiles-0/default-rule.yaml", Interval: 30},
+		}},
+		want: map[string][]vmv1beta1.VMRuleGroupStatus{
+			"default-rule.yaml": {
+				{Name: "group", VMAlert: "default/main"},
+			},
+		},
+	})
+
+	// aggregate groups from multiple pods
+	f(opts{
+		podGroups: [][]apiGroup{
+			{
+				{
+					Name:           "group",
+					File:           "/etc/vmalert/config/vm-main-rulefiles-0/default-rule.yaml",
+					Interval:       30,
+					LastEvaluation: now.Add(-10 * time.Second),
+					Rules: []apiRule{
+						{Name: "first", EvaluationTime: 0.1, Alerts: []apiAlert{{State: "firing"}, {State: "pending"}}},
+						{Name: "second", EvaluationTime: 0.2, LastError: "bad request"},
+					},
+				},
+				{
+					Name:           "other",
+					File:           "/etc/vmalert/config/vm-main-rulefiles-0/monitoring-other-rule.yaml",
+					Interval:       10,
+					LastEvaluation: now.Add(-35 * time.Second),
+				},
+			},
+			{
+				{
+					Name:           "group",
+					File:           "/etc/vmalert/config/vm-main-rulefiles-0/default-rule.yaml",
+					Interval:       30,
+					LastEvaluation: now.Add(-5 * time.Second),
+					Rules: []apiRule{
+						{Name: "first", EvaluationTime: 0.5, Alerts: []apiAlert{{State: "firing"}, {State: "firing"}}},
+						{Name: "second", EvaluationTime: 0.1},
+					},
+				},
+			},
+		},
+		want: map[string][]vmv1beta1.VMRuleGroupStatus{
+			"default-rule.yaml": {
+				{
+					Name:           "group",
+					VMAlert:        "default/main",
+					LastEvaluation: &metav1.Time{Time: now.Add(-5 * time.Second)},
+					LastError:      `rule "second": bad request`,
+					LastDuration:   "600ms",
+					FiringAlerts:   2,
+				},
+			},
+			"monitoring-other-rule.yaml": {
+				{
+					Name:             "other",
+					VMAlert:          "default/main",
+					LastEvaluation:   &metav1.Time{Time: now.Add(-35 * time.Second)},
+					LastDuration:     "0s",
+					EvaluationMisses: 2,
+				},
+			},
+		},
+	})
+}
+
+func TestUpdateRulesHealth(t *testing.T) {
+	type opts struct {
+		rules             map[string]types.NamespacedName
+		predefinedObjects []runtime.Object
+		want              map[string][]vmv1beta1.VMRuleGroupStatus
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":{"groups":[
+{"name":"group","file":"/etc/vmalert/config/vm-main-rulefiles-0/kube-system-node-rules.yaml"}
+]}}`))
+	}))
+	defer srv.Close()
+	srvURL, err := url.Parse(srv.URL)
+	assert.NoError(t, err)
+
+	cr := &vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "main",
+			Namespace: "default",
+		},
+		Spec: vmv1beta1.VMAlertSpec{
+			SelectAllByDefault: true,
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+				Port: srvURL.Port(),
+			},
+			CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+				ExtraArgs: map[string]string{
+					"httpAuth.username": "user",
+					"httpAuth.password": "pass",
+				},
+			},
+		},
+	}
+	f := func(o opts) {
+		t.Helper()
+		cfg := config.MustGetBaseConfig()
+		defaultCfg := *cfg
+		cfg.EnableVMAlertRulesHealth = true
+		defer func() {
+			*config.MustGetBaseConfig() = defaultCfg
+		}()
+		ctx := context.Background()
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "vmalert-main-0",
+				Namespace: cr.Namespace,
+				Labels:    cr.SelectorLabels(),
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				PodIP: srvURL.Hostname(),
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodReady, Status: "True"},
+				},
+			},
+		}
+		fclient := k8stools.GetTestClientWithObjects(append(o.predefinedObjects, pod))
+		assert.NoError(t, UpdateRulesHealth(ctx, fclient, cr, o.rules))
+		got := make(map[string][]vmv1beta1.VMRuleGroupStatus)
+		var rules vmv1beta1.VMRuleList
+		assert.NoError(t, fclient.List(ctx, &rules))
+		for _, r := range rules.Items {
+			got[r.Namespace+"/"+r.Name] = r.Status.Groups
+		}
+		assert.Equal(t, o.want, got)
+	}
+
+	// report health of selected rule with dashes at namespace and name, remove status of not selected rule
+	f(opts{
+		rules: map[string]types.NamespacedName{
+			"kube-system-node-rules.yaml": {Namespace: "kube-system", Name: "node-rules"},
+		},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "node-rules",
+					Namespace: "kube-system",
+				},
+			},
+			&vmv1beta1.VMRule{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "system-node-rules",
+					Namespace: "kube",
+				},
+				Status: vmv1beta1.VMRuleStatus{
+					Groups: []vmv1beta1.VMRuleGroupStatus{
+						{Name: "group", VMAlert: "default/main"},
+						{Name: "group", VMAlert: "default/other"},
+					},
+				},
+			},
+		},
+		want: map[string][]vmv1beta1.VMRuleGroupStatus{
+			"kube-system/node-rules": {
+				{Name: "group", VMAlert: "default/main"},
+			},
+			"kube/system-node-rules": {
+				{Name: "group", VMAlert: "default/other"},
+			},
+		},
+	})
+}
@@ -27,7 +27,7 @@ func TestSelectRules(t *testing.T) {
 		fclient := k8stools.GetTestClientWithObjects(o.predefinedObjects)
 		_, got, err := selectRules(ctx, fclient, o.cr)
 		assert.NoError(t, err)
-		for ruleName, content := range got {
+		for ruleName, content := range got[""] {
 			assert.Equal(t, o.want[ruleName], content)
 		}
 	}
@@ -230,7 +230,7 @@ func TestCreateOrUpdateRuleConfigMaps(t *testing.T) {
 	f := func(o opts) {
 		t.Helper()
 		fclient := k8stools.GetTestClientWithObjects(o.predefinedObjects)
-		got, err := CreateOrUpdateRuleConfigMaps(context.TODO(), fclient, o.cr, nil)
+		got, _, err := CreateOrUpdateRuleConfigMaps(context.TODO(), fclient, o.cr, nil)
 		assert.NoError(t, err)
 		assert.Equal(t, o.want, got)
 	}
@@ -256,6 +256,124 @@ func TestCreateOrUpdateRuleConfigMaps(t *testing.T) {
 		},
 		want: []string{"vm-base-vmalert-rulefiles-0"},
 	})
+
+	// rules-gen-with-datasources
+	f(opts{
+		cr: &vmv1beta1.VMAlert{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "base-vmalert",
+			},
+			Spec: vmv1beta1.VMAlertSpec{
+				SelectAllByDefault: true,
+				Datasources: []vmv1beta1.VMAlertNamedDatasource{
+					{Name: "logs", VMAlertDatasourceSpec: vmv1beta1.VMAlertDatasourceSpec{URL: "http://vlsingle"}},
+				},
+			},
+		},
+		want: []string{"vm-base-vmalert-rulefiles-0", "vm-base-vmalert-rulefiles-logs-0"},
+	})
+}
+
+func TestSelectRulesDatasources(t *testing.T) {
+	type opts struct {
+		groups      []vmv1beta1.RuleGroup
+		want        map[string]map[string]string
+		wantWarning string
+	}
+	cr := &vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMAlertSpec{
+			SelectAllByDefault: true,
+			Datasource: vmv1beta1.VMAlertDatasourceSpec{
+				URL: "http://vmsingle",
+			},
+			Datasources: []vmv1beta1.VMAlertNamedDatasource{
+				{Name: "logs", VMAlertDatasourceSpec: vmv1beta1.VMAlertDatasourceSpec{Ref: &vmv1beta1.DatasourceRef{Kind: "VLSingle", Name: "logs"}}},
+				{Name: "traces", VMAlertDatasourceSpec: vmv1beta1.VMAlertDatasourceSpec{Ref: &vmv1beta1.DatasourceRef{Kind: "VTSingle", Name: "traces"}}},
+			},
+		},
+	}
+	f := func(o opts) {
+		t.Helper()
+		ctx := context.Background()
+		fclient := k8stools.GetTestClientWithObjects([]runtime.Object{
+			&vmv1beta1.VMRule{
+				ObjectMeta: metav1.ObjectMeta{Name: "rule", Namespace: "default"},
+				Spec:       vmv1beta1.VMRuleSpec{Groups: o.groups},
+			},
+		})
+		pos, got, err := selectRules(ctx, fclient, cr)
+		assert.NoError(t, err)
+		assert.Equal(t, o.want, got)
+		rules := pos.rules.All()
+		if assert.Len(t, rules, 1) {
+			assert.Equal(t, o.wantWarning, rules[0].Status.CurrentSyncWarning)
+		}
+	}
+
+	// groups without datasource
+	f(opts{
+		groups: []vmv1beta1.RuleGroup{
+			{Name: "metrics", Rules: []vmv1beta1.Rule{{Alert: "up", Expr: "up == 0"}}},
+		},
+		want: map[string]map[string]string{
+			"": {"default-rule.yaml": `groups:
+- name: metrics
+  rules:
+  - alert: up
+    expr: up == 0
+`},
+		},
+	})
+
+	// select datasources by name and by type
+	f(opts{
+		groups: []vmv1beta1.RuleGroup{
+			{Name: "metrics", Rules: []vmv1beta1.Rule{{Alert: "up", Expr: "up == 0"}}},
+			{Name: "logs", Type: "vlogs", Rules: []vmv1beta1.Rule{{Alert: "errors", Expr: "error | stats count() as errors"}}},
+			{Name: "traces", Type: "vlogs", Datasource: "traces", Rules: []vmv1beta1.Rule{{Alert: "spans", Expr: "* | stats count() as spans"}}},
+		},
+		want: map[string]map[string]string{
+			"": {"default-rule.yaml": `groups:
+- name: metrics
+  rules:
+  - alert: up
+    expr: up == 0
+`},
+			"logs": {"default-rule.yaml": `groups:
+- name: logs
+  rules:
+  - alert: errors
+    expr: error | stats count() as errors
+  type: vlogs
+`},
+			"traces": {"default-rule.yaml": `groups:
+- name: traces
+  rules:
+  - alert: spans
+    expr: '* | stats count() as spans'
+  type: vlogs
+`},
+		},
+	})
+
+	// skip group with missing datasource
+	f(opts{
+		groups: []vmv1beta1.RuleGroup{
+			{Name: "metrics", Rules: []vmv1beta1.Rule{{Alert: "up", Expr: "up == 0"}}},
+			{Name: "missing", Datasource: "missing", Rules: []vmv1beta1.Rule{{Alert: "up", Expr: "up == 0"}}},
+		},
+		want: map[string]map[string]string{
+			"": {"default-rule.yaml": `groups:
+- name: metrics
+  rules:
+  - alert: up
+    expr: up == 0
+`},
+		},
+		wantWarning: `skipped groups with datasources missing at vmalert: group="missing" datasource="missing"`,
+	})
 }
 
 func Test_deduplicateRules(t *testing.T) {
@@ -367,3 +485,42 @@ func Test_deduplicateRules(t *testing.T) {
 		},
 	})
 }
+
+func TestSelectRulesGroupType(t *testing.T) {
+	cr := &vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+		Spec: vmv1beta1.VMAlertSpec{
+			SelectAllByDefault: true,
+			Datasource: vmv1beta1.VMAlertDatasourceSpec{
+				Ref: &vmv1beta1.DatasourceRef{Kind: "VLSingle", Name: "logs"},
+			},
+		},
+	}
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{
+		&vmv1beta1.VMRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "rule", Namespace: "default"},
+			Spec: vmv1beta1.VMRuleSpec{Groups: []vmv1beta1.RuleGroup{
+				{Name: "logs", Rules: []vmv1beta1.Rule{{Alert: "errors", Expr: "error | stats count() as errors"}}},
+				{Name: "graphite", Type: "graphite", Rules: []vmv1beta1.Rule{{Alert: "up", Expr: "up"}}},
+			}},
+		},
+	})
+	_, got, err := selectRules(ctx, fclient, cr)
+	assert.NoError(t, err)
+	// only groups without explicit type inherit type of datasource
+	assert.Equal(t, map[string]map[string]string{
+		"": {"default-rule.yaml": `groups:
+- name: logs
+  rules:
+  - alert: errors
+    expr: error | stats count() as errors
+  type: vlogs
+- name: graphite
+  rules:
+  - alert: up
+    expr: up
+  type: graphite
+`},
+	}, got)
+}
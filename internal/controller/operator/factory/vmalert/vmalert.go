@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
@@ -35,6 +37,10 @@ const (
 	tlsAssetsDir            = "/etc/vmalert-tls/certs"
 )
 
+// listen port of config-reloader container for the first named datasource,
+// the next datasources use the following ports
+const datasourceReloaderPort = 8436
+
 func buildScrape(cr *vmv1beta1.VMAlert, svc *corev1.Service) *vmv1beta1.VMServiceScrape {
 	if cr == nil || svc == nil || ptr.Deref(cr.Spec.DisableSelfServiceScrape, false) {
 		return nil
@@ -94,6 +100,11 @@ func getAssetsCache(ctx context.Context, rclient client.Client, cr *vmv1beta1.VM
 
 // CreateOrUpdate creates vmalert deployment for given CRD
 func CreateOrUpdate(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.Client, cmNames []string) error {
+	if !build.MustSkipRuntimeValidation() {
+		if err := cr.Validate(); err != nil {
+			return build.NewValidationError(err)
+		}
+	}
 	var prevCR *vmv1beta1.VMAlert
 	if cr.Status.LastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
@@ -101,6 +112,11 @@ func CreateOrUpdate(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.C
 		if err := discoverNotifiersIfNeeded(ctx, rclient, prevCR); err != nil {
 			return fmt.Errorf("cannot discover notifiers for prev spec: %w", err)
 		}
+		// previously referenced objects could be already renamed or deleted
+		// prev spec is only used to remove stale metadata, so it's safe to ignore the error
+		if resolvedCR, err := resolveRefsIfNeeded(ctx, rclient, prevCR); err == nil {
+			prevCR = resolvedCR
+		}
 		if err := deleteOrphaned(ctx, rclient, cr); err != nil {
 			return fmt.Errorf("cannot delete objects from previous state: %w", err)
 		}
@@ -118,6 +134,10 @@ func CreateOrUpdate(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.C
 	if err := discoverNotifiersIfNeeded(ctx, rclient, cr); err != nil {
 		return fmt.Errorf("cannot discover notifiers for new spec: %w", err)
 	}
+	cr, err := resolveRefsIfNeeded(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
 
 	ac := getAssetsCache(ctx, rclient, cr)
 
@@ -137,7 +157,6 @@ func CreateOrUpdate(ctx context.Context, cr *vmv1beta1.VMAlert, rclient client.C
 
 	var prevDeploy *appsv1.Deployment
 	if prevCR != nil {
-		var err error
 		prevDeploy, err = newDeploy(prevCR, cmNames, ac)
 		if err != nil {
 			return fmt.Errorf("cannot generate prev deploy spec: %w", err)
@@ -180,7 +199,8 @@ func newDeploy(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, ac *build.Ass
 }
 
 func newPodSpec(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, ac *build.AssetsCache) (*appsv1.DeploymentSpec, error) {
-	args, err := buildArgs(cr, ruleConfigMapNames, ac)
+	defaultConfigMapNames, datasourceConfigMapNames := splitRuleConfigMaps(cr, ruleConfigMapNames)
+	args, err := buildArgs(cr, defaultConfigMapNames, ac)
 	if err != nil {
 		return nil, err
 	}
@@ -263,7 +283,9 @@ func newPodSpec(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, ac *build.As
 		crMounts = append(crMounts, vm)
 	}
 
-	for _, name := range ruleConfigMapNames {
+	commonMounts := slices.Clone(volumeMounts)
+	commonCRMounts := slices.Clone(crMounts)
+	for _, name := range defaultConfigMapNames {
 		m := corev1.VolumeMount{
 			Name:      name,
 			MountPath: path.Join(vmAlertConfigDir, name),
@@ -302,9 +324,20 @@ func newPodSpec(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, ac *build.As
 	build.AddConfigReloadAuthKeyToApp(&vmalertContainer, cr.Spec.ExtraArgs, &cr.Spec.CommonConfigReloaderParams)
 	vmalertContainers = append(vmalertContainers, vmalertContainer)
 
+	for i, ds := range cr.Spec.Datasources {
+		c, err := newDatasourceContainer(cr, i, datasourceConfigMapNames[ds.Name], commonMounts, envs, ac)
+		if err != nil {
+			return nil, fmt.Errorf("cannot build container for spec.datasources[%d]: %w", i, err)
+		}
+		vmalertContainers = append(vmalertContainers, c)
+	}
+
 	if !cr.IsUnmanaged() {
 		crc := build.ConfigReloaderContainer(false, cr, crMounts, nil)
 		vmalertContainers = append(vmalertContainers, crc)
+		for i, ds := range cr.Spec.Datasources {
+			vmalertContainers = append(vmalertContainers, newDatasourceReloaderContainer(cr, i, datasourceConfigMapNames[ds.Name], commonCRMounts))
+		}
 	}
 
 	useStrictSecurity := ptr.Deref(cr.Spec.UseStrictSecurity, false)
@@ -355,6 +388,76 @@ func newPodSpec(cr *vmv1beta1.VMAlert, ruleConfigMapNames []string, ac *build.As
 	return spec, nil
 }
 
+// newDatasourceContainer returns vmalert container, which evaluates rule groups of the named datasource.
+// It shares notifiers, remote storages and other settings with the main container
+func newDatasourceContainer(cr *vmv1beta1.VMAlert, idx int, ruleConfigMapNames []string, commonMounts []corev1.VolumeMount, envs []corev1.EnvVar, ac *build.AssetsCache) (corev1.Container, error) {
+	ds := cr.Spec.Datasources[idx]
+	dsCR := cr.DeepCopy()
+	dsCR.Spec.Datasource = ds.VMAlertDatasourceSpec
+	dsCR.Spec.Port = datasourcePort(cr, idx)
+	dsCR.Spec.RulePath = nil
+	// custom probes are defined for the main container port
+	dsCR.Spec.EmbeddedProbes = nil
+	if dsCR.Spec.ExtraArgs == nil {
+		dsCR.Spec.ExtraArgs = make(map[string]string)
+	}
+	dsCR.Spec.ExtraArgs["rule.defaultRuleType"] = ds.RuleType()
+	args, err := buildArgs(dsCR, ruleConfigMapNames, ac)
+	if err != nil {
+		return corev1.Container{}, err
+	}
+	volumeMounts := slices.Clone(commonMounts)
+	for _, name := range ruleConfigMapNames {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: path.Join(vmAlertConfigDir, name),
+		})
+	}
+	sort.Slice(volumeMounts, func(i, j int) bool {
+		return volumeMounts[i].Name < volumeMounts[j].Name
+	})
+	container := corev1.Container{
+		Args:                     args,
+		Name:                     "vmalert-" + ds.Name,
+		Image:                    fmt.Sprintf("%s:%s", cr.Spec.Image.Repository, cr.Spec.Image.Tag),
+		ImagePullPolicy:          cr.Spec.Image.PullPolicy,
+		Ports:                    []corev1.ContainerPort{{Protocol: "TCP", ContainerPort: intstr.Parse(dsCR.Spec.Port).IntVal}},
+		VolumeMounts:             volumeMounts,
+		Resources:                ds.Resources,
+		Env:                      envs,
+		EnvFrom:                  cr.Spec.ExtraEnvsFrom,
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+	container = build.Probe(container, dsCR)
+	build.AddConfigReloadAuthKeyToApp(&container, cr.Spec.ExtraArgs, &cr.Spec.CommonConfigReloaderParams)
+	return container, nil
+}
+
+// newDatasourceReloaderContainer returns config-reloader container,
+// which triggers config reload of vmalert container for spec.datasources[idx] on rule files change
+func newDatasourceReloaderContainer(cr *vmv1beta1.VMAlert, idx int, ruleConfigMapNames []string, commonMounts []corev1.VolumeMount) corev1.Container {
+	ds := cr.Spec.Datasources[idx]
+	dsCR := cr.DeepCopy()
+	dsCR.Spec.Port = datasourcePort(cr, idx)
+	mounts := slices.Clone(commonMounts)
+	for _, name := range ruleConfigMapNames {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: path.Join(vmAlertConfigDir, name),
+		})
+	}
+	c := build.ConfigReloaderContainer(false, dsCR, mounts, nil)
+	c.Name = "config-reloader-" + ds.Name
+	build.SetConfigReloaderListenPort(&c, fmt.Sprintf("reloader-%d", idx+1), int32(datasourceReloaderPort+idx))
+	return c
+}
+
+// datasourcePort returns listen port of vmalert container for spec.datasources[idx]
+func datasourcePort(cr *vmv1beta1.VMAlert, idx int) string {
+	port := intstr.Parse(cr.Spec.Port)
+	return strconv.Itoa(port.IntValue() + idx + 1)
+}
+
 func buildHeadersArg(flagName string, src []string, headers []string) []string {
 	if len(headers) == 0 {
 		return src
@@ -373,7 +476,7 @@ func buildAuthArgs(args []string, namespace, flagPrefix string, cfg vmv1beta1.HT
 	if cfg.BasicAuth != nil {
 		if len(cfg.BasicAuth.PasswordFile) > 0 {
 			args = append(args, fmt.Sprintf("-%s.basicAuth.passwordFile=%s", flagPrefix, cfg.BasicAuth.PasswordFile))
-		} else {
+		} else if len(cfg.BasicAuth.Password.Name) > 0 {
 			file, err := ac.LoadPathFromSecret(build.SecretConfigResourceKind, namespace, &cfg.BasicAuth.Password)
 			if err != nil {
 				return nil, err
@@ -702,7 +805,7 @@ func discoverNotifiersIfNeeded(ctx context.Context, rclient client.Client, cr *v
 		if err != nil {
 			return fmt.Errorf("cannot convert notifier selector as ListOptions: %w", err)
 		}
-		if err := k8stools.ListObjectsByNamespace(ctx, rclient, cfg.WatchNamespaces, func(l *vmv1beta1.VMAlertmanagerList) {
+		if err := k8stools.ListObjectsByNamespace(ctx, rclient, cfg.GetWatchNamespaces(), func(l *vmv1beta1.VMAlertmanagerList) {
 			for _, item := range l.Items {
 				if !item.DeletionTimestamp.IsZero() || (n.Selector.Namespace != nil && !n.Selector.Namespace.IsMatch(&item)) {
 					continue
@@ -724,6 +827,68 @@ func discoverNotifiersIfNeeded(ctx context.Context, rclient client.Client, cr *v
 	return nil
 }
 
+// resolveRefsIfNeeded returns copy of the given VMAlert with urls and auth settings of datasource,
+// remote storages and notifiers resolved from referenced objects
+//
+// resolved settings must not be persisted at the object spec, so the object itself is not modified
+func resolveRefsIfNeeded(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert) (*vmv1beta1.VMAlert, error) {
+	cr = cr.DeepCopy()
+	resolve := func(ref *vmv1beta1.DatasourceRef, access build.DatasourceAccess, url *string, auth *vmv1beta1.HTTPAuth) error {
+		if ref == nil {
+			return nil
+		}
+		// VMUser credentials are mounted from its secret, there is no need to load them
+		withoutUser := *ref
+		withoutUser.User = ""
+		ds, err := build.ResolveDatasourceRef(ctx, rclient, nil, cr.Namespace, &withoutUser, access, ref.TenantID)
+		if err != nil {
+			return err
+		}
+		*url = ds.URL
+		auth.Headers = slices.Concat(auth.Headers, ds.Headers)
+		// explicitly defined TLS settings have priority
+		if auth.TLSConfig == nil {
+			auth.TLSConfig = ds.TLSConfig
+		}
+		if ref.User != "" {
+			userAuth, err := build.VMUserHTTPAuth(ctx, rclient, types.NamespacedName{Name: ref.User, Namespace: cr.Namespace})
+			if err != nil {
+				return err
+			}
+			auth.BasicAuth = userAuth.BasicAuth
+			auth.BearerAuth = userAuth.BearerAuth
+		}
+		return nil
+	}
+	ds := &cr.Spec.Datasource
+	if err := resolve(ds.Ref, build.DatasourceRead, &ds.URL, &ds.HTTPAuth); err != nil {
+		return nil, fmt.Errorf("cannot resolve spec.datasource.ref: %w", err)
+	}
+	for i := range cr.Spec.Datasources {
+		nds := &cr.Spec.Datasources[i]
+		if err := resolve(nds.Ref, build.DatasourceRead, &nds.URL, &nds.HTTPAuth); err != nil {
+			return nil, fmt.Errorf("cannot resolve spec.datasources[%d].ref: %w", i, err)
+		}
+	}
+	if rw := cr.Spec.RemoteWrite; rw != nil {
+		if err := resolve(rw.Ref, build.DatasourceWrite, &rw.URL, &rw.HTTPAuth); err != nil {
+			return nil, fmt.Errorf("cannot resolve spec.remoteWrite.ref: %w", err)
+		}
+	}
+	if rr := cr.Spec.RemoteRead; rr != nil {
+		if err := resolve(rr.Ref, build.DatasourceRead, &rr.URL, &rr.HTTPAuth); err != nil {
+			return nil, fmt.Errorf("cannot resolve spec.remoteRead.ref: %w", err)
+		}
+	}
+	for i := range cr.Spec.Notifiers {
+		nt := &cr.Spec.Notifiers[i]
+		if err := resolve(nt.Ref, build.DatasourceWrite, &nt.URL, &nt.HTTPAuth); err != nil {
+			return nil, fmt.Errorf("cannot resolve spec.notifiers[%d].ref: %w", i, err)
+		}
+	}
+	return cr, nil
+}
+
 func deleteOrphaned(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert) error {
 	svcName := cr.PrefixedName()
 	keepServices := sets.New(svcName)
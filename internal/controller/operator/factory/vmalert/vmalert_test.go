@@ -9,11 +9,14 @@ import (
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
@@ -658,7 +661,12 @@ func Test_buildVMAlertArgs(t *testing.T) {
 		fclient := k8stools.GetTestClientWithObjects(o.predefinedObjects)
 		ac := getAssetsCache(ctx, fclient, o.cr)
 		assert.NoError(t, discoverNotifiersIfNeeded(ctx, fclient, o.cr))
-		got, err := buildArgs(o.cr, o.ruleConfigMapNames, ac)
+		origin := o.cr.DeepCopy()
+		cr, err := resolveRefsIfNeeded(ctx, fclient, o.cr)
+		assert.NoError(t, err)
+		// resolved refs must not modify the original object
+		assert.Equal(t, origin, o.cr)
+		got, err := buildArgs(cr, o.ruleConfigMapNames, ac)
 		assert.NoError(t, err)
 		assert.Equal(t, o.want, got)
 	}
@@ -715,6 +723,70 @@ func Test_buildVMAlertArgs(t *testing.T) {
 		want:               []string{"--datasource.headers=x-org-id:one^^x-org-tenant:5", "-datasource.tlsCAFile=/path/to/sa", "-datasource.tlsInsecureSkipVerify=true", "-datasource.tlsKeyFile=/path/to/key", "-datasource.url=http://vmsingle-url", "-httpListenAddr=:", "-notifier.url=http://test", "-rule=\"/etc/vmalert/config/first-rule-cm.yaml/*.yaml\""},
 	})
 
+	// with refs
+	f(opts{
+		cr: &vmv1beta1.VMAlert{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "base",
+			},
+			Spec: vmv1beta1.VMAlertSpec{
+				Datasource: vmv1beta1.VMAlertDatasourceSpec{
+					Ref: &vmv1beta1.DatasourceRef{Kind: "VLCluster", Name: "logs", Namespace: "logging", TenantID: "1"},
+				},
+				RemoteWrite: &vmv1beta1.VMAlertRemoteWriteSpec{
+					Ref: &vmv1beta1.DatasourceRef{Kind: "VMCluster", Name: "main", TenantID: "1:2"},
+				},
+				RemoteRead: &vmv1beta1.VMAlertRemoteReadSpec{
+					Ref: &vmv1beta1.DatasourceRef{Kind: "VMAuth", Name: "main", User: "vmalert"},
+				},
+				Notifier: &vmv1beta1.VMAlertNotifierSpec{
+					Ref: &vmv1beta1.DatasourceRef{Kind: "VMAuth", Name: "main"},
+				},
+			},
+		},
+		predefinedObjects: []runtime.Object{
+			&vmv1.VLCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "logs", Namespace: "logging"},
+				Spec: vmv1.VLClusterSpec{
+					VLSelect: &vmv1.VLSelect{},
+				},
+			},
+			&vmv1beta1.VMCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+				Spec: vmv1beta1.VMClusterSpec{
+					VMInsert: &vmv1beta1.VMInsert{},
+				},
+			},
+			&vmv1beta1.VMAuth{
+				ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "default"},
+			},
+			&vmv1beta1.VMUser{
+				ObjectMeta: metav1.ObjectMeta{Name: "vmalert", Namespace: "default"},
+				Spec: vmv1beta1.VMUserSpec{
+					GeneratePassword: true,
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "vmuser-vmalert", Namespace: "default"},
+				Data: map[string][]byte{
+					"username": []byte("vmalert"),
+					"password": []byte("generated"),
+				},
+			},
+		},
+		want: []string{
+			"--datasource.headers=AccountID:1^^ProjectID:0",
+			"-datasource.url=http://vlselect-logs.logging.svc:9471",
+			"-httpListenAddr=:",
+			"-notifier.url=http://vmauth-main.default.svc:8427",
+			"-remoteRead.basicAuth.passwordFile=/etc/vmalert/remote_secrets/default_vmuser-vmalert_password",
+			"-remoteRead.basicAuth.username=vmalert",
+			"-remoteRead.url=http://vmauth-main.default.svc:8427",
+			"-remoteWrite.url=http://vminsert-main.default.svc:8480/insert/1:2/prometheus",
+		},
+	})
+
 	// with static and selector notifiers
 	f(opts{
 		cr: &vmv1beta1.VMAlert{
@@ -795,3 +867,81 @@ func Test_buildVMAlertArgs(t *testing.T) {
 		},
 	})
 }
+
+func TestNewPodSpecDatasources(t *testing.T) {
+	ctx := context.Background()
+	cr := &vmv1beta1.VMAlert{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "base",
+		},
+		Spec: vmv1beta1.VMAlertSpec{
+			Datasource: vmv1beta1.VMAlertDatasourceSpec{
+				URL: "http://vmsingle",
+			},
+			Datasources: []vmv1beta1.VMAlertNamedDatasource{
+				{
+					Name:                  "logs",
+					VMAlertDatasourceSpec: vmv1beta1.VMAlertDatasourceSpec{URL: "http://vlsingle", HTTPAuth: vmv1beta1.HTTPAuth{Headers: []string{"AccountID:1"}}},
+					Type:                  "vlogs",
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("100Mi")},
+					},
+				},
+			},
+			Notifier: &vmv1beta1.VMAlertNotifierSpec{
+				URL: "http://alertmanager",
+			},
+			RulePath:           []string{"/etc/rules/*.yaml"},
+			SelectAllByDefault: true,
+			CommonDefaultableParams: vmv1beta1.CommonDefaultableParams{
+				Port: "8080",
+				Resources: corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+			},
+		},
+	}
+	fclient := k8stools.GetTestClientWithObjects(nil)
+	ac := getAssetsCache(ctx, fclient, cr)
+	assert.NoError(t, discoverNotifiersIfNeeded(ctx, fclient, cr))
+	spec, err := newPodSpec(cr, []string{"vm-base-rulefiles-0", "vm-base-rulefiles-logs-0"}, ac)
+	if !assert.NoError(t, err) {
+		return
+	}
+	containers := spec.Template.Spec.Containers
+	if !assert.Len(t, containers, 4) {
+		return
+	}
+	assert.Equal(t, "vmalert", containers[0].Name)
+	assert.Equal(t, []string{
+		"-datasource.url=http://vmsingle",
+		"-httpListenAddr=:8080",
+		"-notifier.url=http://alertmanager",
+		`-rule="/etc/rules/*.yaml"`,
+		`-rule="/etc/vmalert/config/vm-base-rulefiles-0/*.yaml"`,
+	}, containers[0].Args)
+	assert.Equal(t, "vmalert-logs", containers[1].Name)
+	assert.Equal(t, []string{
+		"--datasource.headers=AccountID:1",
+		"-datasource.url=http://vlsingle",
+		"-httpListenAddr=:8081",
+		"-notifier.url=http://alertmanager",
+		"-rule.defaultRuleType=vlogs",
+		`-rule="/etc/vmalert/config/vm-base-rulefiles-logs-0/*.yaml"`,
+	}, containers[1].Args)
+	assert.Equal(t, int32(8081), containers[1].Ports[0].ContainerPort)
+	assert.Equal(t, resource.MustParse("100Mi"), containers[1].Resources.Limits[corev1.ResourceMemory])
+	assert.Equal(t, "config-reloader", containers[2].Name)
+	assert.Contains(t, containers[2].Args, "--watched-dir=/etc/vmalert/config/vm-base-rulefiles-0")
+	assert.Equal(t, "config-reloader-logs", containers[3].Name)
+	assert.Equal(t, []string{
+		"--http.listenAddr=:8436",
+		"--reload-url=http://127.0.0.1:8081/-/reload",
+		"--watched-dir=/etc/vmalert/config/vm-base-rulefiles-logs-0",
+		"--webhook-method=POST",
+	}, containers[3].Args)
+	assert.Equal(t, int32(8436), containers[3].Ports[0].ContainerPort)
+	assert.Equal(t, intstr.FromInt32(8436), containers[3].ReadinessProbe.HTTPGet.Port)
+	assert.Equal(t, intstr.FromInt(8435), containers[2].ReadinessProbe.HTTPGet.Port)
+}
@@ -22,6 +22,11 @@ const templatesDir = "/etc/vm/templates"
 
 // CreateOrUpdateAlertManager creates alertmanager and builds config for it
 func CreateOrUpdateAlertManager(ctx context.Context, cr *vmv1beta1.VMAlertmanager, rclient client.Client) error {
+	if !build.MustSkipRuntimeValidation() {
+		if err := cr.Validate(); err != nil {
+			return build.NewValidationError(err)
+		}
+	}
 	var prevCR *vmv1beta1.VMAlertmanager
 	if cr.Status.LastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
@@ -57,15 +62,18 @@ func CreateOrUpdateAlertManager(ctx context.Context, cr *vmv1beta1.VMAlertmanage
 			return err
 		}
 	}
+	peers, err := buildClusterPeers(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
 	var prevSts *appsv1.StatefulSet
 	if prevCR != nil {
-		var err error
-		prevSts, err = newStsForAlertManager(prevCR)
+		prevSts, err = newStsForAlertManager(withPeersGossipConfig(prevCR, peers), peers)
 		if err != nil {
 			return fmt.Errorf("cannot generate prev alertmanager sts, name: %s,err: %w", cr.Name, err)
 		}
 	}
-	newSts, err := newStsForAlertManager(cr)
+	newSts, err := newStsForAlertManager(withPeersGossipConfig(cr, peers), peers)
 	if err != nil {
 		return fmt.Errorf("cannot generate alertmanager sts, name: %s,err: %w", cr.Name, err)
 	}
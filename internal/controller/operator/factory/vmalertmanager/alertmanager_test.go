@@ -234,6 +234,168 @@ func TestCreateOrUpdateAlertManager(t *testing.T) {
 			}, "unexpected cluster peer arguments found")
 		},
 	})
+
+	// single replica alertmanager with referenced peers
+	f(opts{
+		cr: &vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-am",
+				Namespace: "monitoring",
+			},
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(1)),
+				},
+				PeerRefs: []vmv1beta1.VMAlertmanagerPeerRef{
+					{Name: "eu", Namespace: "region-eu"},
+					{Name: "missing"},
+					{ServiceName: "us-alertmanager-gossip", Namespace: "region-us", Port: ptr.To(int32(19094))},
+				},
+			},
+		},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMAlertmanager{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "eu",
+					Namespace: "region-eu",
+				},
+				Spec: vmv1beta1.VMAlertmanagerSpec{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ReplicaCount: ptr.To(int32(2)),
+					},
+				},
+			},
+		},
+		validate: func(set *appsv1.StatefulSet) {
+			vmaContainer := set.Spec.Template.Spec.Containers[0]
+			var clusterPeers []string
+			for _, arg := range vmaContainer.Args {
+				if strings.HasPrefix(arg, "--cluster.peer=") {
+					clusterPeers = append(clusterPeers, arg)
+				}
+			}
+			assert.ElementsMatch(t, clusterPeers, []string{
+				"--cluster.peer=vmalertmanager-test-am-0.vmalertmanager-test-am.monitoring:9094",
+				"--cluster.peer=vmalertmanager-eu-0.vmalertmanager-eu.region-eu:9094",
+				"--cluster.peer=vmalertmanager-eu-1.vmalertmanager-eu.region-eu:9094",
+				"--cluster.peer=us-alertmanager-gossip.region-us:19094",
+			}, "unexpected cluster peer arguments found")
+			assert.Contains(t, vmaContainer.Args, "--cluster.listen-address=[$(POD_IP)]:9094")
+		},
+	})
+
+	// referenced peer with different gossip TLS mode
+	f(opts{
+		cr: &vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-am",
+				Namespace: "monitoring",
+			},
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(1)),
+				},
+				PeerRefs: []vmv1beta1.VMAlertmanagerPeerRef{
+					{Name: "eu", Namespace: "region-eu"},
+				},
+			},
+		},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMAlertmanager{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "eu",
+					Namespace: "region-eu",
+				},
+				Spec: vmv1beta1.VMAlertmanagerSpec{
+					GossipConfig: &vmv1beta1.VMAlertmanagerGossipConfig{
+						TLSClientConfig: &vmv1beta1.TLSClientConfig{
+							Certs: vmv1beta1.Certs{
+								CertFile: "/etc/tls/cert.pem",
+								KeyFile:  "/etc/tls/key.pem",
+							},
+						},
+					},
+				},
+			},
+		},
+		wantErr: true,
+	})
+
+	// gossip TLS settings propagated from peer at the same namespace
+	f(opts{
+		cr: &vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-am",
+				Namespace: "monitoring",
+			},
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(1)),
+				},
+				PeerRefs: []vmv1beta1.VMAlertmanagerPeerRef{
+					{Name: "tls"},
+				},
+			},
+		},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMAlertmanager{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tls",
+					Namespace: "monitoring",
+				},
+				Spec: vmv1beta1.VMAlertmanagerSpec{
+					CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+						ReplicaCount: ptr.To(int32(1)),
+					},
+					GossipConfig: &vmv1beta1.VMAlertmanagerGossipConfig{
+						TLSClientConfig: &vmv1beta1.TLSClientConfig{
+							Certs: vmv1beta1.Certs{
+								CertFile: "/etc/tls/cert.pem",
+								KeyFile:  "/etc/tls/key.pem",
+							},
+						},
+					},
+				},
+			},
+		},
+		validate: func(set *appsv1.StatefulSet) {
+			vmaContainer := set.Spec.Template.Spec.Containers[0]
+			assert.Contains(t, vmaContainer.Args, "--cluster.tls-config=/etc/alertmanager/tls_assets/gossip_config.yaml")
+			assert.Contains(t, vmaContainer.Args, "--cluster.listen-address=[$(POD_IP)]:9094")
+		},
+	})
+
+	// single replica alertmanager referenced by other alertmanager
+	f(opts{
+		cr: &vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-am",
+				Namespace: "monitoring",
+			},
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
+					ReplicaCount: ptr.To(int32(1)),
+				},
+			},
+		},
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMAlertmanager{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "eu",
+					Namespace: "region-eu",
+				},
+				Spec: vmv1beta1.VMAlertmanagerSpec{
+					PeerRefs: []vmv1beta1.VMAlertmanagerPeerRef{
+						{Name: "test-am", Namespace: "monitoring"},
+					},
+				},
+			},
+		},
+		validate: func(set *appsv1.StatefulSet) {
+			vmaContainer := set.Spec.Template.Spec.Containers[0]
+			assert.Contains(t, vmaContainer.Args, "--cluster.listen-address=[$(POD_IP)]:9094")
+		},
+	})
 }
 
 func Test_createDefaultAMConfig(t *testing.T) {
@@ -15,6 +15,11 @@ import (
 
 type parsedObjects struct {
 	configs *build.ChildObjects[*vmv1beta1.VMAlertmanagerConfig]
+	// receivers holds shared receivers referenced by configs
+	receivers map[string]*sharedReceiver
+	// namespaceLabels holds labels of configs namespaces,
+	// it's set only if some of receivers has allowedNamespaceSelector
+	namespaceLabels map[string]map[string]string
 }
 
 func (pos *parsedObjects) buildConfig(cr *vmv1beta1.VMAlertmanager, baseCfg []byte, ac *build.AssetsCache) ([]byte, error) {
@@ -65,6 +70,7 @@ func (pos *parsedObjects) buildConfig(cr *vmv1beta1.VMAlertmanager, baseCfg []by
 	}
 	var subRoutes []yaml.MapSlice
 	var timeIntervals []yaml.MapSlice
+	renderedSharedReceivers := make(map[string]struct{})
 	pos.configs.ForEachCollectSkipInvalid(func(cfg *vmv1beta1.VMAlertmanagerConfig) error {
 		if !build.MustSkipRuntimeValidation() {
 			if err := cfg.Validate(); err != nil {
@@ -81,18 +87,26 @@ func (pos *parsedObjects) buildConfig(cr *vmv1beta1.VMAlertmanager, baseCfg []by
 				receiverCfgs = append(receiverCfgs, receiverCfg)
 			}
 		}
+		sharedReceiverCfgs, err := pos.buildSharedReceivers(cr, cfg, renderedSharedReceivers, &globalConfigOpts, ac)
+		if err != nil {
+			return err
+		}
 		mtis, err := buildGlobalTimeIntervals(cfg)
 		if err != nil {
 			return err
 		}
 		if cfg.Spec.Route != nil {
-			route, err := buildRoute(cfg, cfg.Spec.Route, true, cr)
+			route, err := buildRoute(cfg, cfg.Spec.Route, true, cr, pos.sharedReceiverNames(cfg))
 			if err != nil {
 				return err
 			}
 			subRoutes = append(subRoutes, route)
 		}
+		for _, rcv := range sharedReceiverCfgs {
+			renderedSharedReceivers[rcv[0].Value.(string)] = struct{}{}
+		}
 		baseYAMLCfg.Receivers = append(baseYAMLCfg.Receivers, receiverCfgs...)
+		baseYAMLCfg.Receivers = append(baseYAMLCfg.Receivers, sharedReceiverCfgs...)
 		for _, rule := range cfg.Spec.InhibitRules {
 			baseYAMLCfg.InhibitRules = append(baseYAMLCfg.InhibitRules, buildInhibitRule(cfg.Namespace, rule, !cr.Spec.DisableNamespaceMatcher))
 		}
@@ -164,44 +178,53 @@ func buildGlobalTimeIntervals(cr *vmv1beta1.VMAlertmanagerConfig) ([]yaml.MapSli
 		if len(mti.TimeIntervals) == 0 {
 			continue
 		}
-		var temp []yaml.MapSlice
-		var tiItem yaml.MapSlice
-		toYaml := func(key string, src []string) {
-			if len(src) > 0 {
-				tiItem = append(tiItem, yaml.MapItem{Key: key, Value: src})
-			}
+		temp := buildTimeIntervals(mti.TimeIntervals)
+		if len(temp) > 0 {
+			r = append(r, yaml.MapSlice{{Key: "name", Value: buildCRPrefixedName(cr, mti.Name)}, {Key: "time_intervals", Value: temp}})
 		}
-		for _, ti := range mti.TimeIntervals {
-			tiItem = yaml.MapSlice{}
-			toYaml("days_of_month", ti.DaysOfMonth)
-			toYaml("weekdays", ti.Weekdays)
-			toYaml("months", ti.Months)
-			toYaml("years", ti.Years)
-			if len(ti.Location) > 0 {
-				tiItem = append(tiItem, yaml.MapItem{Key: "location", Value: ti.Location})
-			}
+	}
+	return r, nil
+}
 
-			var trss []yaml.MapSlice
-			for _, trs := range ti.Times {
-				if trs.EndTime != "" && trs.StartTime != "" {
-					trss = append(trss, yaml.MapSlice{{Key: "start_time", Value: trs.StartTime}, {Key: "end_time", Value: trs.EndTime}})
-				}
-			}
-			if len(trss) > 0 {
-				tiItem = append(tiItem, yaml.MapItem{Key: "times", Value: trss})
-			}
-			if len(tiItem) > 0 {
-				temp = append(temp, tiItem)
+// buildTimeIntervals converts time intervals into alertmanager format
+func buildTimeIntervals(tis []vmv1beta1.TimeInterval) []yaml.MapSlice {
+	var r []yaml.MapSlice
+	var tiItem yaml.MapSlice
+	toYaml := func(key string, src []string) {
+		if len(src) > 0 {
+			tiItem = append(tiItem, yaml.MapItem{Key: key, Value: src})
+		}
+	}
+	for _, ti := range tis {
+		tiItem = yaml.MapSlice{}
+		toYaml("days_of_month", ti.DaysOfMonth)
+		toYaml("weekdays", ti.Weekdays)
+		toYaml("months", ti.Months)
+		toYaml("years", ti.Years)
+		if len(ti.Location) > 0 {
+			tiItem = append(tiItem, yaml.MapItem{Key: "location", Value: ti.Location})
+		}
+
+		var trss []yaml.MapSlice
+		for _, trs := range ti.Times {
+			if trs.EndTime != "" && trs.StartTime != "" {
+				trss = append(trss, yaml.MapSlice{{Key: "start_time", Value: trs.StartTime}, {Key: "end_time", Value: trs.EndTime}})
 			}
 		}
-		if len(temp) > 0 {
-			r = append(r, yaml.MapSlice{{Key: "name", Value: buildCRPrefixedName(cr, mti.Name)}, {Key: "time_intervals", Value: temp}})
+		if len(trss) > 0 {
+			tiItem = append(tiItem, yaml.MapItem{Key: "times", Value: trss})
+		}
+		if len(tiItem) > 0 {
+			r = append(r, tiItem)
 		}
 	}
-	return r, nil
+	return r
 }
 
-func buildRoute(cr *vmv1beta1.VMAlertmanagerConfig, cfgRoute *vmv1beta1.Route, topLevel bool, alertmanagerCR *vmv1beta1.VMAlertmanager) (yaml.MapSlice, error) {
+// buildRoute builds route for the given VMAlertmanagerConfig
+//
+// sharedReceivers maps names of spec.receiverRefs to the names of shared receivers at alertmanager config
+func buildRoute(cr *vmv1beta1.VMAlertmanagerConfig, cfgRoute *vmv1beta1.Route, topLevel bool, alertmanagerCR *vmv1beta1.VMAlertmanager, sharedReceivers map[string]string) (yaml.MapSlice, error) {
 	var r yaml.MapSlice
 	matchers := cfgRoute.Matchers
 	// enforce continue when route is first-level and vmalertmanager disableRouteContinueEnforce filed is not set,
@@ -227,7 +250,7 @@ func buildRoute(cr *vmv1beta1.VMAlertmanagerConfig, cfgRoute *vmv1beta1.Route, t
 	for _, nestedRoute := range cfgRoute.Routes {
 		// namespace matcher not needed for nested routes
 		tmpRoute := vmv1beta1.Route(*nestedRoute)
-		route, err := buildRoute(cr, &tmpRoute, false, alertmanagerCR)
+		route, err := buildRoute(cr, &tmpRoute, false, alertmanagerCR, sharedReceivers)
 		if err != nil {
 			return r, err
 		}
@@ -265,7 +288,11 @@ func buildRoute(cr *vmv1beta1.VMAlertmanagerConfig, cfgRoute *vmv1beta1.Route, t
 	toYamlString("group_wait", cfgRoute.GroupWait)
 	toYamlString("repeat_interval", cfgRoute.RepeatInterval)
 	if len(cfgRoute.Receiver) > 0 {
-		r = append(r, yaml.MapItem{Key: "receiver", Value: buildCRPrefixedName(cr, cfgRoute.Receiver)})
+		receiver, ok := sharedReceivers[cfgRoute.Receiver]
+		if !ok {
+			receiver = buildCRPrefixedName(cr, cfgRoute.Receiver)
+		}
+		r = append(r, yaml.MapItem{Key: "receiver", Value: receiver})
 	}
 	r = append(r, yaml.MapItem{Key: "continue", Value: continueSetting})
 	return r, nil
@@ -0,0 +1,155 @@
+package vmalertmanager
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+const defaultGossipPort = 9094
+
+// clusterPeerDomain returns in-cluster domain of governing service for the given name and namespace
+func clusterPeerDomain(cr *vmv1beta1.VMAlertmanager, name, namespace string) string {
+	// domain consists of service name and namespace
+	domain := fmt.Sprintf("%s.%s", name, namespace)
+	if cr.Spec.ClusterDomainName != "" {
+		domain = fmt.Sprintf("%s.svc.%s.", domain, cr.Spec.ClusterDomainName)
+	}
+	return domain
+}
+
+// buildReplicaPeers returns cluster peer addresses for each replica of the given VMAlertmanager
+func buildReplicaPeers(cr, peer *vmv1beta1.VMAlertmanager) []string {
+	domain := clusterPeerDomain(cr, peer.PrefixedName(), peer.Namespace)
+	replicas := ptr.Deref(peer.Spec.ReplicaCount, 0)
+	peers := make([]string, 0, replicas)
+	for i := int32(0); i < replicas; i++ {
+		peers = append(peers, fmt.Sprintf("%s-%d.%s:%d", peer.PrefixedName(), i, domain, defaultGossipPort))
+	}
+	return peers
+}
+
+// clusterPeers defines cluster membership of VMAlertmanager
+type clusterPeers struct {
+	// addrs defines addresses of peers referenced at spec.peerRefs
+	addrs []string
+	// referenced is true, if other VMAlertmanager references the current object at spec.peerRefs
+	referenced bool
+	// gossipConfig defines gossip TLS settings propagated from peers,
+	// it's set only if the current object has no own spec.gossipConfig
+	gossipConfig *vmv1beta1.VMAlertmanagerGossipConfig
+}
+
+// hasPeers returns true if alertmanager must listen for gossip from other VMAlertmanagers
+func (cp *clusterPeers) hasPeers() bool {
+	return cp != nil && (len(cp.addrs) > 0 || cp.referenced)
+}
+
+// IsPeerReferenced checks if the given VMAlertmanager references object with the given name and namespace at spec.peerRefs
+func IsPeerReferenced(cr *vmv1beta1.VMAlertmanager, name, namespace string) bool {
+	for _, ref := range cr.Spec.PeerRefs {
+		if ref.ServiceName != "" {
+			continue
+		}
+		refNamespace := ref.Namespace
+		if refNamespace == "" {
+			refNamespace = cr.Namespace
+		}
+		if ref.Name == name && refNamespace == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// propagateGossipConfig checks that the given peer is able to exchange gossip messages with the current object
+// and propagates gossip TLS settings of the peer, if the current object has no own settings
+//
+// gossip TLS settings could reference secrets and configmaps, so they're propagated only within the same namespace
+func (cp *clusterPeers) propagateGossipConfig(cr, peer *vmv1beta1.VMAlertmanager) error {
+	hasOwn, peerHas := cr.Spec.GossipConfig != nil, peer.Spec.GossipConfig != nil
+	if hasOwn == peerHas {
+		return nil
+	}
+	if peer.Namespace != cr.Namespace {
+		return fmt.Errorf("VMAlertmanager=%s/%s peered with the current object must have the same gossipConfig TLS mode, peer has gossipConfig=%t, current object has gossipConfig=%t",
+			peer.Namespace, peer.Name, peerHas, hasOwn)
+	}
+	if peerHas && cp.gossipConfig == nil {
+		cp.gossipConfig = peer.Spec.GossipConfig.DeepCopy()
+	}
+	return nil
+}
+
+// buildClusterPeers returns cluster peers for objects referenced by spec.peerRefs
+// and for objects, which reference the current object.
+//
+// missing VMAlertmanager objects are skipped, since peers could be removed independently
+func buildClusterPeers(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanager) (*clusterPeers, error) {
+	cfg := config.MustGetBaseConfig()
+	var cp clusterPeers
+	for _, ref := range cr.Spec.PeerRefs {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = cr.Namespace
+		}
+		if ref.ServiceName != "" {
+			cp.addrs = append(cp.addrs, fmt.Sprintf("%s:%d", clusterPeerDomain(cr, ref.ServiceName, namespace), ptr.Deref(ref.Port, defaultGossipPort)))
+			continue
+		}
+		if !cfg.IsWatchedNamespace(namespace) {
+			return nil, fmt.Errorf("VMAlertmanager=%s/%s referenced at spec.peerRefs belongs to not watched namespace, use serviceName reference instead", namespace, ref.Name)
+		}
+		var peer vmv1beta1.VMAlertmanager
+		if err := rclient.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, &peer); err != nil {
+			if k8serrors.IsNotFound(err) {
+				logger.WithContext(ctx).Info(fmt.Sprintf("skipping missing VMAlertmanager=%s/%s referenced at spec.peerRefs", namespace, ref.Name))
+				continue
+			}
+			return nil, fmt.Errorf("cannot get VMAlertmanager=%s/%s referenced at spec.peerRefs: %w", namespace, ref.Name, err)
+		}
+		// gossip messages cannot be exchanged between peers with different TLS settings
+		if err := cp.propagateGossipConfig(cr, &peer); err != nil {
+			return nil, err
+		}
+		cp.addrs = append(cp.addrs, buildReplicaPeers(cr, &peer)...)
+	}
+	var referrers []*vmv1beta1.VMAlertmanager
+	if err := k8stools.ListObjectsByNamespace(ctx, rclient, cfg.GetWatchNamespaces(), func(dst *vmv1beta1.VMAlertmanagerList) {
+		for i := range dst.Items {
+			item := &dst.Items[i]
+			if item.DeletionTimestamp.IsZero() && IsPeerReferenced(item, cr.Name, cr.Namespace) {
+				referrers = append(referrers, item)
+			}
+		}
+	}); err != nil {
+		return nil, fmt.Errorf("cannot list VMAlertmanagers referencing the current object at spec.peerRefs: %w", err)
+	}
+	for _, peer := range referrers {
+		if err := cp.propagateGossipConfig(cr, peer); err != nil {
+			return nil, err
+		}
+		cp.referenced = true
+	}
+	return &cp, nil
+}
+
+// withPeersGossipConfig returns copy of the given VMAlertmanager with gossip TLS settings propagated from peers
+// or the object itself, if there are no settings to propagate
+func withPeersGossipConfig(cr *vmv1beta1.VMAlertmanager, cp *clusterPeers) *vmv1beta1.VMAlertmanager {
+	if cp == nil || cp.gossipConfig == nil {
+		return cr
+	}
+	cr = cr.DeepCopy()
+	cr.Spec.GossipConfig = cp.gossipConfig
+	return cr
+}
@@ -0,0 +1,243 @@
+package vmalertmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+)
+
+// sharedReceiver holds VMAlertmanagerReceiver or VMAlertmanagerClusterReceiver referenced by VMAlertmanagerConfig
+type sharedReceiver struct {
+	kind string
+	// namespace of VMAlertmanagerReceiver, empty for VMAlertmanagerClusterReceiver
+	namespace string
+	name      string
+	spec      *vmv1beta1.VMAlertmanagerReceiverSpec
+}
+
+// receiverName returns name of receiver at alertmanager configuration
+//
+// it uses slash as separator, which is not allowed at kubernetes object names,
+// in order to prevent conflicts with receivers of VMAlertmanagerConfig objects
+func (sr *sharedReceiver) receiverName() string {
+	if sr.kind == vmv1beta1.VMAlertmanagerClusterReceiverKind {
+		return fmt.Sprintf("vmalertmanagerclusterreceiver/%s/%s", sr.name, sr.spec.Receiver.Name)
+	}
+	return fmt.Sprintf("vmalertmanagerreceiver/%s/%s/%s", sr.namespace, sr.name, sr.spec.Receiver.Name)
+}
+
+// isNamespaceAllowed checks if VMAlertmanagerConfig from the given namespace could reference receiver
+//
+// nsLabels is nil if namespace labels are unknown, in this case allowedNamespaceSelector is ignored
+func (sr *sharedReceiver) isNamespaceAllowed(namespace string, nsLabels map[string]string) (bool, error) {
+	if sr.namespace == namespace {
+		return true, nil
+	}
+	if slices.Contains(sr.spec.AllowedNamespaces, "*") || slices.Contains(sr.spec.AllowedNamespaces, namespace) {
+		return true, nil
+	}
+	if sr.spec.AllowedNamespaceSelector == nil || nsLabels == nil {
+		return false, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(sr.spec.AllowedNamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("cannot parse allowedNamespaceSelector: %w", err)
+	}
+	return selector.Matches(labels.Set(nsLabels)), nil
+}
+
+func receiverRefKey(cfg *vmv1beta1.VMAlertmanagerConfig, ref *vmv1beta1.VMAlertmanagerReceiverRef) string {
+	if ref.Kind == vmv1beta1.VMAlertmanagerClusterReceiverKind {
+		return fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+	}
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = cfg.Namespace
+	}
+	return fmt.Sprintf("%s/%s/%s", vmv1beta1.VMAlertmanagerReceiverKind, namespace, ref.Name)
+}
+
+// IsReceiverReferenced checks if the given config references shared receiver with the given kind, namespace and name.
+// namespace must be empty for VMAlertmanagerClusterReceiver
+func IsReceiverReferenced(cfg *vmv1beta1.VMAlertmanagerConfig, kind, namespace, name string) bool {
+	want := fmt.Sprintf("%s/%s", kind, name)
+	if kind != vmv1beta1.VMAlertmanagerClusterReceiverKind {
+		want = fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+	}
+	for i := range cfg.Spec.ReceiverRefs {
+		if receiverRefKey(cfg, &cfg.Spec.ReceiverRefs[i]) == want {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchSharedReceivers fetches receivers referenced by the given configs
+// and labels of namespaces, which must be matched against allowedNamespaceSelector
+//
+// missing receivers are skipped, config referencing it is marked as broken during config build
+func fetchSharedReceivers(ctx context.Context, rclient client.Client, configs []*vmv1beta1.VMAlertmanagerConfig) (map[string]*sharedReceiver, map[string]map[string]string, error) {
+	receivers := make(map[string]*sharedReceiver)
+	var hasNamespaceSelector bool
+	for _, cfg := range configs {
+		for i := range cfg.Spec.ReceiverRefs {
+			ref := &cfg.Spec.ReceiverRefs[i]
+			key := receiverRefKey(cfg, ref)
+			if _, ok := receivers[key]; ok {
+				continue
+			}
+			var sr *sharedReceiver
+			switch ref.Kind {
+			case vmv1beta1.VMAlertmanagerClusterReceiverKind:
+				// cluster-scoped objects cannot be accessed without cluster-wide access
+				if !config.IsClusterWideAccessAllowed() {
+					continue
+				}
+				var obj vmv1beta1.VMAlertmanagerClusterReceiver
+				if err := rclient.Get(ctx, types.NamespacedName{Name: ref.Name}, &obj); err != nil {
+					if k8serrors.IsNotFound(err) {
+						continue
+					}
+					return nil, nil, fmt.Errorf("cannot get VMAlertmanagerClusterReceiver=%s: %w", ref.Name, err)
+				}
+				sr = &sharedReceiver{kind: ref.Kind, name: obj.Name, spec: &obj.Spec}
+			default:
+				nsn := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+				if nsn.Namespace == "" {
+					nsn.Namespace = cfg.Namespace
+				}
+				var obj vmv1beta1.VMAlertmanagerReceiver
+				if err := rclient.Get(ctx, nsn, &obj); err != nil {
+					if k8serrors.IsNotFound(err) {
+						continue
+					}
+					return nil, nil, fmt.Errorf("cannot get VMAlertmanagerReceiver=%s: %w", nsn.String(), err)
+				}
+				sr = &sharedReceiver{kind: vmv1beta1.VMAlertmanagerReceiverKind, namespace: obj.Namespace, name: obj.Name, spec: &obj.Spec}
+			}
+			if sr.spec.AllowedNamespaceSelector != nil {
+				hasNamespaceSelector = true
+			}
+			receivers[key] = sr
+		}
+	}
+	if !hasNamespaceSelector || !config.IsClusterWideAccessAllowed() {
+		return receivers, nil, nil
+	}
+	namespaceLabels := make(map[string]map[string]string)
+	for _, cfg := range configs {
+		if len(cfg.Spec.ReceiverRefs) == 0 {
+			continue
+		}
+		if _, ok := namespaceLabels[cfg.Namespace]; ok {
+			continue
+		}
+		var ns corev1.Namespace
+		if err := rclient.Get(ctx, types.NamespacedName{Name: cfg.Namespace}, &ns); err != nil {
+			return nil, nil, fmt.Errorf("cannot get namespace=%s: %w", cfg.Namespace, err)
+		}
+		nsLabels := ns.Labels
+		if nsLabels == nil {
+			nsLabels = map[string]string{}
+		}
+		namespaceLabels[cfg.Namespace] = nsLabels
+	}
+	return receivers, namespaceLabels, nil
+}
+
+// sharedReceiverNames returns names of shared receivers at alertmanager configuration by receiver ref names
+func (pos *parsedObjects) sharedReceiverNames(cfg *vmv1beta1.VMAlertmanagerConfig) map[string]string {
+	if len(cfg.Spec.ReceiverRefs) == 0 {
+		return nil
+	}
+	names := make(map[string]string, len(cfg.Spec.ReceiverRefs))
+	for i := range cfg.Spec.ReceiverRefs {
+		ref := &cfg.Spec.ReceiverRefs[i]
+		if sr, ok := pos.receivers[receiverRefKey(cfg, ref)]; ok {
+			names[ref.Name] = sr.receiverName()
+		}
+	}
+	return names
+}
+
+// buildSharedReceivers checks access to receivers referenced by the given config and builds configuration for them
+//
+// receivers already present at rendered are skipped, since shared receiver must be rendered only once
+func (pos *parsedObjects) buildSharedReceivers(
+	cr *vmv1beta1.VMAlertmanager,
+	cfg *vmv1beta1.VMAlertmanagerConfig,
+	rendered map[string]struct{},
+	globalCfg *globalAlertmanagerConfig,
+	ac *build.AssetsCache,
+) ([]yaml.MapSlice, error) {
+	var receiverCfgs []yaml.MapSlice
+	built := make(map[string]struct{})
+	for i := range cfg.Spec.ReceiverRefs {
+		ref := &cfg.Spec.ReceiverRefs[i]
+		kind := ref.Kind
+		if kind == "" {
+			kind = vmv1beta1.VMAlertmanagerReceiverKind
+		}
+		if kind == vmv1beta1.VMAlertmanagerClusterReceiverKind && !config.IsClusterWideAccessAllowed() {
+			return nil, fmt.Errorf("receiverRefs=%s cannot reference %s, operator has no cluster-wide access", ref.Name, kind)
+		}
+		sr, ok := pos.receivers[receiverRefKey(cfg, ref)]
+		if !ok {
+			return nil, fmt.Errorf("receiverRefs=%s references missing %s", ref.Name, kind)
+		}
+		nsLabels := pos.namespaceLabels[cfg.Namespace]
+		allowed, err := sr.isNamespaceAllowed(cfg.Namespace, nsLabels)
+		if err != nil {
+			return nil, fmt.Errorf("receiverRefs=%s: %w", ref.Name, err)
+		}
+		if !allowed {
+			return nil, fmt.Errorf("receiverRefs=%s: namespace=%s is not allowed to reference %s", ref.Name, cfg.Namespace, kind)
+		}
+		name := sr.receiverName()
+		if _, ok := rendered[name]; ok {
+			continue
+		}
+		if _, ok := built[name]; ok {
+			continue
+		}
+		built[name] = struct{}{}
+		if !build.MustSkipRuntimeValidation() {
+			if err := sr.spec.Validate(); err != nil {
+				return nil, fmt.Errorf("receiverRefs=%s references invalid %s: %w", ref.Name, kind, err)
+			}
+		}
+		// cluster receiver secrets are loaded from VMAlertmanager namespace
+		secretsNamespace := sr.namespace
+		if secretsNamespace == "" {
+			secretsNamespace = cr.Namespace
+		}
+		cb := &configBuilder{
+			cache:        ac,
+			namespace:    secretsNamespace,
+			globalConfig: globalCfg,
+			result: yaml.MapSlice{
+				{
+					Key:   "name",
+					Value: name,
+				},
+			},
+		}
+		if err := cb.buildCfg(sr.spec.Receiver); err != nil {
+			return nil, fmt.Errorf("cannot build receiverRefs=%s: %w", ref.Name, err)
+		}
+		receiverCfgs = append(receiverCfgs, cb.result)
+	}
+	return receiverCfgs, nil
+}
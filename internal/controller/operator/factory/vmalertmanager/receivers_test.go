@@ -0,0 +1,197 @@
+package vmalertmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestBuildSharedReceivers(t *testing.T) {
+	type opts struct {
+		predefinedObjects []runtime.Object
+		want              string
+		wantBroken        map[string]string
+	}
+	f := func(o opts) {
+		t.Helper()
+		cr := &vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "monitoring",
+			},
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				ConfigNamespaceSelector: &metav1.LabelSelector{},
+			},
+		}
+		testClient := k8stools.GetTestClientWithObjects(o.predefinedObjects)
+		ctx := context.TODO()
+		ac := getAssetsCache(ctx, testClient, cr)
+		pos, data, err := buildAlertmanagerConfigWithCRDs(ctx, testClient, cr, nil, ac)
+		assert.NoError(t, err)
+		broken := make(map[string]string)
+		for _, cfg := range pos.configs.Broken() {
+			broken[cfg.Namespace+"/"+cfg.Name] = cfg.Status.CurrentSyncError
+		}
+		if len(o.wantBroken) == 0 {
+			assert.Empty(t, broken)
+		} else {
+			assert.Equal(t, o.wantBroken, broken)
+		}
+		assert.Equal(t, o.want, string(data))
+	}
+	webhook := func(name string) vmv1beta1.Receiver {
+		return vmv1beta1.Receiver{
+			Name: name,
+			WebhookConfigs: []vmv1beta1.WebhookConfig{{
+				URL: ptr.To("http://" + name + ":8080"),
+			}},
+		}
+	}
+	amConfig := func(namespace string, ref vmv1beta1.VMAlertmanagerReceiverRef) *vmv1beta1.VMAlertmanagerConfig {
+		return &vmv1beta1.VMAlertmanagerConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team",
+				Namespace: namespace,
+			},
+			Spec: vmv1beta1.VMAlertmanagerConfigSpec{
+				ReceiverRefs: []vmv1beta1.VMAlertmanagerReceiverRef{ref},
+				Route: &vmv1beta1.Route{
+					Receiver: ref.Name,
+				},
+			},
+		}
+	}
+
+	// cluster receiver referenced by multiple configs is rendered once
+	f(opts{
+		predefinedObjects: []runtime.Object{
+			&vmv1beta1.VMAlertmanagerClusterReceiver{
+				ObjectMeta: metav1.ObjectMeta{Name: "platform"},
+				Spec: vmv1beta1.VMAlertmanagerReceiverSpec{
+					Receiver:          webhook("oncall"),
+					AllowedNamespaces: []string{"*"},
+				},
+			},
+			amConfig("team-a", vmv1beta1.VMAlertmanagerReceiverRef{Name: "platform", Kind: vmv1beta1.VMAlertmanagerClusterReceiverKind}),
+			amConfig("team-b", vmv1beta1.VMAlertmanagerReceiverRef{Name: "platform", Kind: vmv1beta1.VMAlertmanagerClusterReceiverKind}),
+		},
+		want: `route:
+  receiver: blackhole
+  routes:
+  - matchers:
+    - namespace = "team-a"
+    receiver: vmalertmanagerclusterreceiver/platform/oncall
+    continue: true
+  - matchers:
+    - namespace = "team-b"
+    receiver: vmalertmanagerclusterreceiver/platform/oncall
+    continue: true
+receivers:
+- name: blackhole
+- name: vmalertmanagerclusterreceiver/platform/oncall
+  webhook_configs:
+  - url: http://oncall:8080
+templates: []
+`,
+	})
+
+	// namespaced receiver allowed by namespace list and selector
+	f(opts{
+		predefinedObjects: []runtime.Object{
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+			},
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"alerting": "shared"}},
+			},
+			&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-c"},
+			},
+			&vmv1beta1.VMAlertmanagerReceiver{
+				ObjectMeta: metav1.ObjectMeta{Name: "pager", Namespace: "shared"},
+				Spec: vmv1beta1.VMAlertmanagerReceiverSpec{
+					Receiver:          webhook("pager"),
+					AllowedNamespaces: []string{"team-a"},
+					AllowedNamespaceSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"alerting": "shared"},
+					},
+				},
+			},
+			amConfig("team-a", vmv1beta1.VMAlertmanagerReceiverRef{Name: "pager", Namespace: "shared"}),
+			amConfig("team-b", vmv1beta1.VMAlertmanagerReceiverRef{Name: "pager", Namespace: "shared"}),
+			amConfig("team-c", vmv1beta1.VMAlertmanagerReceiverRef{Name: "pager", Namespace: "shared"}),
+		},
+		wantBroken: map[string]string{
+			"team-c/team": "receiverRefs=pager: namespace=team-c is not allowed to reference VMAlertmanagerReceiver",
+		},
+		want: `route:
+  receiver: blackhole
+  routes:
+  - matchers:
+    - namespace = "team-a"
+    receiver: vmalertmanagerreceiver/shared/pager/pager
+    continue: true
+  - matchers:
+    - namespace = "team-b"
+    receiver: vmalertmanagerreceiver/shared/pager/pager
+    continue: true
+receivers:
+- name: blackhole
+- name: vmalertmanagerreceiver/shared/pager/pager
+  webhook_configs:
+  - url: http://pager:8080
+templates: []
+`,
+	})
+
+	// missing receiver
+	f(opts{
+		predefinedObjects: []runtime.Object{
+			amConfig("team-a", vmv1beta1.VMAlertmanagerReceiverRef{Name: "missing"}),
+		},
+		wantBroken: map[string]string{
+			"team-a/team": "receiverRefs=missing references missing VMAlertmanagerReceiver",
+		},
+		want: `route:
+  receiver: blackhole
+receivers:
+- name: blackhole
+templates: []
+`,
+	})
+}
+
+func TestIsReceiverReferenced(t *testing.T) {
+	cfg := &vmv1beta1.VMAlertmanagerConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "team",
+			Namespace: "default",
+		},
+		Spec: vmv1beta1.VMAlertmanagerConfigSpec{
+			ReceiverRefs: []vmv1beta1.VMAlertmanagerReceiverRef{
+				{Name: "local"},
+				{Name: "remote", Namespace: "monitoring", Kind: vmv1beta1.VMAlertmanagerReceiverKind},
+				{Name: "global", Kind: vmv1beta1.VMAlertmanagerClusterReceiverKind},
+			},
+		},
+	}
+	f := func(kind, namespace, name string, want bool) {
+		t.Helper()
+		assert.Equal(t, want, IsReceiverReferenced(cfg, kind, namespace, name))
+	}
+	f(vmv1beta1.VMAlertmanagerReceiverKind, "default", "local", true)
+	f(vmv1beta1.VMAlertmanagerReceiverKind, "monitoring", "local", false)
+	f(vmv1beta1.VMAlertmanagerReceiverKind, "monitoring", "remote", true)
+	f(vmv1beta1.VMAlertmanagerReceiverKind, "default", "remote", false)
+	f(vmv1beta1.VMAlertmanagerClusterReceiverKind, "", "global", true)
+	f(vmv1beta1.VMAlertmanagerClusterReceiverKind, "", "local", false)
+	f(vmv1beta1.VMAlertmanagerReceiverKind, "default", "global", false)
+}
@@ -0,0 +1,182 @@
+package vmalertmanager
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	amcfg "github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v2"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// evaluateConfigTests evaluates spec.tests of valid configs against merged alertmanager configuration
+// and stores results at status.tests of each config.
+// Evaluation errors are reported as failed tests and do not break config build
+func (pos *parsedObjects) evaluateConfigTests(cr *vmv1beta1.VMAlertmanager, data []byte) {
+	var withTests []*vmv1beta1.VMAlertmanagerConfig
+	for _, cfg := range pos.configs.All() {
+		if cfg.Status.CurrentSyncError != "" || len(cfg.Spec.Tests) == 0 {
+			continue
+		}
+		withTests = append(withTests, cfg)
+	}
+	if len(withTests) == 0 {
+		return
+	}
+	var amCfg amcfg.Config
+	var evalErr error
+	if err := yaml.Unmarshal(data, &amCfg); err != nil {
+		evalErr = fmt.Errorf("cannot parse merged config for tests evaluation: %w", err)
+	} else if amCfg.Route == nil {
+		evalErr = fmt.Errorf("merged config has no root route")
+	}
+	for _, cfg := range withTests {
+		if evalErr != nil {
+			cfg.Status.Tests = failedTests(cfg, evalErr)
+			continue
+		}
+		cfg.Status.Tests = evaluateTests(cr, cfg, &amCfg, pos.sharedReceiverNames(cfg))
+	}
+}
+
+// failedTests marks all tests of the given config as failed with the given error
+func failedTests(cfg *vmv1beta1.VMAlertmanagerConfig, err error) []vmv1beta1.VMAlertmanagerConfigTestResult {
+	results := make([]vmv1beta1.VMAlertmanagerConfigTestResult, 0, len(cfg.Spec.Tests))
+	for _, ct := range cfg.Spec.Tests {
+		results = append(results, vmv1beta1.VMAlertmanagerConfigTestResult{
+			Name:    ct.Name,
+			Message: err.Error(),
+		})
+	}
+	return results
+}
+
+// evaluateTests follows alertmanager routing and inhibition rules:
+// routing tree is traversed depth-first, the first matching child route wins unless it has continue set,
+// receiver is inherited from the parent route.
+// Alert is inhibited if it matches target matchers, source alert matches source matchers
+// and both alerts have the same values for equal labels.
+func evaluateTests(cr *vmv1beta1.VMAlertmanager, cfg *vmv1beta1.VMAlertmanagerConfig, amCfg *amcfg.Config, sharedReceivers map[string]string) []vmv1beta1.VMAlertmanagerConfigTestResult {
+	// receivers of the given config are reported by names from spec
+	ownReceivers := make(map[string]string, len(cfg.Spec.Receivers)+len(sharedReceivers))
+	for _, recv := range cfg.Spec.Receivers {
+		ownReceivers[buildCRPrefixedName(cfg, recv.Name)] = recv.Name
+	}
+	for refName, recv := range sharedReceivers {
+		ownReceivers[recv] = refName
+	}
+	results := make([]vmv1beta1.VMAlertmanagerConfigTestResult, 0, len(cfg.Spec.Tests))
+	for _, ct := range cfg.Spec.Tests {
+		result := vmv1beta1.VMAlertmanagerConfigTestResult{
+			Name: ct.Name,
+		}
+		lset := buildTestLabelSet(cr, cfg, ct.Labels)
+		for _, recv := range matchRoute(amCfg.Route, "", lset) {
+			if name, ok := ownReceivers[recv]; ok {
+				recv = name
+			}
+			result.Receivers = append(result.Receivers, recv)
+		}
+		var failures []string
+		if len(ct.Receivers) > 0 && !slices.Equal(ct.Receivers, result.Receivers) {
+			failures = append(failures, fmt.Sprintf("expected receivers=%q, got=%q", ct.Receivers, result.Receivers))
+		}
+		for i, it := range ct.Inhibitions {
+			source := buildTestLabelSet(cr, cfg, it.SourceLabels)
+			if got := isInhibited(amCfg.InhibitRules, source, lset); got != it.Inhibited {
+				failures = append(failures, fmt.Sprintf("expected inhibitions[%d] inhibited=%v, got=%v", i, it.Inhibited, got))
+			}
+		}
+		result.Passed = len(failures) == 0
+		result.Message = strings.Join(failures, ", ")
+		results = append(results, result)
+	}
+	return results
+}
+
+// buildTestLabelSet adds namespace label to the given labels in the same way as namespace matcher is added to the config routes
+func buildTestLabelSet(cr *vmv1beta1.VMAlertmanager, cfg *vmv1beta1.VMAlertmanagerConfig, src map[string]string) model.LabelSet {
+	lset := make(model.LabelSet, len(src)+1)
+	for k, v := range src {
+		lset[model.LabelName(k)] = model.LabelValue(v)
+	}
+	if !cr.Spec.DisableNamespaceMatcher {
+		nsLabel := model.LabelName("namespace")
+		if cr.Spec.EnforcedNamespaceLabel != "" {
+			nsLabel = model.LabelName(cr.Spec.EnforcedNamespaceLabel)
+		}
+		if _, ok := lset[nsLabel]; !ok {
+			lset[nsLabel] = model.LabelValue(cfg.Namespace)
+		}
+	}
+	return lset
+}
+
+// matchRoute returns receivers of routes matching the given label set
+func matchRoute(r *amcfg.Route, parentReceiver string, lset model.LabelSet) []string {
+	if !matchLabels(r.Match, r.MatchRE, r.Matchers, lset) {
+		return nil
+	}
+	receiver := r.Receiver
+	if receiver == "" {
+		receiver = parentReceiver
+	}
+	var all []string
+	for _, child := range r.Routes {
+		matches := matchRoute(child, receiver, lset)
+		all = append(all, matches...)
+		if matches != nil && !child.Continue {
+			break
+		}
+	}
+	if len(all) == 0 {
+		all = append(all, receiver)
+	}
+	return all
+}
+
+func isInhibited(rules []amcfg.InhibitRule, source, target model.LabelSet) bool {
+	for _, rule := range rules {
+		if !matchLabels(rule.TargetMatch, rule.TargetMatchRE, rule.TargetMatchers, target) {
+			continue
+		}
+		if !matchLabels(rule.SourceMatch, rule.SourceMatchRE, rule.SourceMatchers, source) {
+			continue
+		}
+		// alert matching both sides of the rule cannot be inhibited by the alert, which matches both sides too
+		if matchLabels(rule.SourceMatch, rule.SourceMatchRE, rule.SourceMatchers, target) &&
+			matchLabels(rule.TargetMatch, rule.TargetMatchRE, rule.TargetMatchers, source) {
+			continue
+		}
+		equal := true
+		for _, ln := range rule.Equal {
+			if source[model.LabelName(ln)] != target[model.LabelName(ln)] {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLabels checks label set against deprecated match and match_re matchers and the new-style matchers
+func matchLabels(match map[string]string, matchRE amcfg.MatchRegexps, matchers amcfg.Matchers, lset model.LabelSet) bool {
+	for ln, lv := range match {
+		if string(lset[model.LabelName(ln)]) != lv {
+			return false
+		}
+	}
+	for ln, re := range matchRE {
+		if !re.MatchString(string(lset[model.LabelName(ln)])) {
+			return false
+		}
+	}
+	return labels.Matchers(matchers).Matches(lset)
+}
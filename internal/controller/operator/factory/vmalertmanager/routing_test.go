@@ -0,0 +1,221 @@
+package vmalertmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestEvaluateConfigTests(t *testing.T) {
+	type opts struct {
+		amSpec  vmv1beta1.VMAlertmanagerSpec
+		baseCfg string
+		tests   []vmv1beta1.VMAlertmanagerConfigTest
+		want    []vmv1beta1.VMAlertmanagerConfigTestResult
+	}
+	f := func(o opts) {
+		t.Helper()
+		cr := &vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "monitoring",
+			},
+			Spec: o.amSpec,
+		}
+		cr.Spec.ConfigNamespaceSelector = &metav1.LabelSelector{}
+		webhook := func(name string) vmv1beta1.Receiver {
+			return vmv1beta1.Receiver{
+				Name: name,
+				WebhookConfigs: []vmv1beta1.WebhookConfig{{
+					URL: ptr.To("http://" + name + ":8080"),
+				}},
+			}
+		}
+		amCfg := &vmv1beta1.VMAlertmanagerConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "team",
+				Namespace: "default",
+			},
+			Spec: vmv1beta1.VMAlertmanagerConfigSpec{
+				Receivers: []vmv1beta1.Receiver{webhook("default"), webhook("critical"), webhook("audit")},
+				Route: &vmv1beta1.Route{
+					Receiver: "default",
+					RawRoutes: []apiextensionsv1.JSON{
+						mustRouteToJSON(t, vmv1beta1.SubRoute{
+							Receiver: "audit",
+							Matchers: []string{`audit="true"`},
+							Continue: true,
+						}),
+						mustRouteToJSON(t, vmv1beta1.SubRoute{
+							Receiver: "critical",
+							Matchers: []string{`severity="critical"`},
+						}),
+					},
+				},
+				InhibitRules: []vmv1beta1.InhibitRule{{
+					SourceMatchers: []string{`severity="critical"`},
+					TargetMatchers: []string{`severity="warning"`},
+					Equal:          []string{"alertname"},
+				}},
+				Tests: o.tests,
+			},
+		}
+		testClient := k8stools.GetTestClientWithObjects([]runtime.Object{amCfg})
+		ctx := context.TODO()
+		ac := getAssetsCache(ctx, testClient, cr)
+		pos, _, err := buildAlertmanagerConfigWithCRDs(ctx, testClient, cr, []byte(o.baseCfg), ac)
+		assert.NoError(t, err)
+		assert.Empty(t, pos.configs.Broken())
+		configs := pos.configs.All()
+		assert.Len(t, configs, 1)
+		assert.Equal(t, o.want, configs[0].Status.Tests)
+	}
+
+	// routing with continue
+	f(opts{
+		tests: []vmv1beta1.VMAlertmanagerConfigTest{
+			{
+				Name:      "critical",
+				Labels:    map[string]string{"alertname": "DiskFull", "severity": "critical"},
+				Receivers: []string{"critical"},
+			},
+			{
+				Name:      "audit critical",
+				Labels:    map[string]string{"alertname": "DiskFull", "severity": "critical", "audit": "true"},
+				Receivers: []string{"audit", "critical"},
+			},
+			{
+				Name:      "default",
+				Labels:    map[string]string{"alertname": "DiskFull", "severity": "warning"},
+				Receivers: []string{"default"},
+			},
+			{
+				Name:   "no expectations",
+				Labels: map[string]string{"alertname": "DiskFull"},
+			},
+		},
+		want: []vmv1beta1.VMAlertmanagerConfigTestResult{
+			{Name: "critical", Passed: true, Receivers: []string{"critical"}},
+			{Name: "audit critical", Passed: true, Receivers: []string{"audit", "critical"}},
+			{Name: "default", Passed: true, Receivers: []string{"default"}},
+			{Name: "no expectations", Passed: true, Receivers: []string{"default"}},
+		},
+	})
+
+	// alert from other namespace falls into base config route
+	f(opts{
+		baseCfg: `
+route:
+  receiver: base
+receivers:
+- name: base
+`,
+		tests: []vmv1beta1.VMAlertmanagerConfigTest{
+			{
+				Name:      "other namespace",
+				Labels:    map[string]string{"alertname": "DiskFull", "severity": "critical", "namespace": "other"},
+				Receivers: []string{"critical"},
+			},
+		},
+		want: []vmv1beta1.VMAlertmanagerConfigTestResult{
+			{
+				Name:      "other namespace",
+				Receivers: []string{"base"},
+				Message:   `expected receivers=["critical"], got=["base"]`,
+			},
+		},
+	})
+
+	// enforced namespace label
+	f(opts{
+		amSpec: vmv1beta1.VMAlertmanagerSpec{EnforcedNamespaceLabel: "kubernetes_namespace"},
+		tests: []vmv1beta1.VMAlertmanagerConfigTest{
+			{
+				Name:      "critical",
+				Labels:    map[string]string{"severity": "critical"},
+				Receivers: []string{"critical"},
+			},
+		},
+		want: []vmv1beta1.VMAlertmanagerConfigTestResult{
+			{Name: "critical", Passed: true, Receivers: []string{"critical"}},
+		},
+	})
+
+	// inhibitions
+	f(opts{
+		tests: []vmv1beta1.VMAlertmanagerConfigTest{
+			{
+				Name:   "inhibited warning",
+				Labels: map[string]string{"alertname": "DiskFull", "severity": "warning"},
+				Inhibitions: []vmv1beta1.VMAlertmanagerConfigInhibitionTest{
+					{
+						SourceLabels: map[string]string{"alertname": "DiskFull", "severity": "critical"},
+						Inhibited:    true,
+					},
+					{
+						SourceLabels: map[string]string{"alertname": "NodeDown", "severity": "critical"},
+					},
+					{
+						SourceLabels: map[string]string{"alertname": "DiskFull", "severity": "critical", "namespace": "other"},
+					},
+				},
+			},
+			{
+				Name:   "wrong expectation",
+				Labels: map[string]string{"alertname": "DiskFull", "severity": "critical"},
+				Inhibitions: []vmv1beta1.VMAlertmanagerConfigInhibitionTest{
+					{
+						SourceLabels: map[string]string{"alertname": "DiskFull", "severity": "critical"},
+						Inhibited:    true,
+					},
+				},
+			},
+		},
+		want: []vmv1beta1.VMAlertmanagerConfigTestResult{
+			{Name: "inhibited warning", Passed: true, Receivers: []string{"default"}},
+			{
+				Name:      "wrong expectation",
+				Receivers: []string{"critical"},
+				Message:   "expected inhibitions[0] inhibited=true, got=false",
+			},
+		},
+	})
+}
+
+func TestEvaluateConfigTestsBrokenConfig(t *testing.T) {
+	cr := &vmv1beta1.VMAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "monitoring",
+		},
+	}
+	newCfg := func(name string) *vmv1beta1.VMAlertmanagerConfig {
+		return &vmv1beta1.VMAlertmanagerConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+			Spec: vmv1beta1.VMAlertmanagerConfigSpec{
+				Tests: []vmv1beta1.VMAlertmanagerConfigTest{{Name: "route"}},
+			},
+		}
+	}
+	cfgs := []*vmv1beta1.VMAlertmanagerConfig{newCfg("team-a"), newCfg("team-b")}
+	pos := &parsedObjects{
+		configs: build.NewChildObjects("vmalertmanagerconfig", cfgs, []string{"default/team-a", "default/team-b"}),
+	}
+	pos.evaluateConfigTests(cr, []byte(`global: {}`))
+	want := []vmv1beta1.VMAlertmanagerConfigTestResult{{Name: "route", Message: "cannot parse merged config for tests evaluation: no routes provided"}}
+	for _, cfg := range cfgs {
+		assert.Equal(t, want, cfg.Status.Tests)
+	}
+}
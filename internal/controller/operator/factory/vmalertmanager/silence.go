@@ -0,0 +1,475 @@
+package vmalertmanager
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	amparse "github.com/prometheus/alertmanager/matcher/parse"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+const (
+	silenceDefaultCreatedBy = "vm-operator"
+	// silenceScheduleLookAhead limits search of the next schedule occurrence
+	silenceScheduleLookAhead = 31 * 24 * time.Hour
+	silenceAPITimeout        = 10 * time.Second
+)
+
+var silencesHTTPClient = &http.Client{
+	Timeout: silenceAPITimeout,
+}
+
+// amSilence is a silence representation at alertmanager v2 API
+type amSilence struct {
+	ID        string             `json:"id,omitempty"`
+	Matchers  []amSilenceMatcher `json:"matchers"`
+	StartsAt  time.Time          `json:"startsAt"`
+	EndsAt    time.Time          `json:"endsAt"`
+	CreatedBy string             `json:"createdBy"`
+	Comment   string             `json:"comment"`
+	Status    *amSilenceStatus   `json:"status,omitempty"`
+}
+
+type amSilenceMatcher struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	IsRegex bool   `json:"isRegex"`
+	IsEqual bool   `json:"isEqual"`
+}
+
+type amSilenceStatus struct {
+	State string `json:"state"`
+}
+
+// SyncSilence creates or updates silence for the given VMSilence at VMAlertmanager
+// via alertmanager v2 API exposed by VMAlertmanager service.
+//
+// It returns silence state and the time of next silence transition,
+// when silence must be synced again. Zero time means there is no transition.
+func SyncSilence(ctx context.Context, rclient client.Client, am *vmv1beta1.VMAlertmanager, cr *vmv1beta1.VMSilence, prevState *vmv1beta1.VMSilenceState) (*vmv1beta1.VMSilenceState, time.Time, error) {
+	api, err := newSilencesAPI(ctx, rclient, am)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	return syncSilence(ctx, api, am, cr, prevState, time.Now())
+}
+
+// ExpireSilence expires silence with given id at VMAlertmanager
+func ExpireSilence(ctx context.Context, rclient client.Client, am *vmv1beta1.VMAlertmanager, id string) error {
+	api, err := newSilencesAPI(ctx, rclient, am)
+	if err != nil {
+		return err
+	}
+	return api.expire(ctx, id)
+}
+
+// newSilencesAPI returns client for alertmanager API with spec.silencesAPIClient settings of VMAlertmanager.
+// Webserver certificate is verified with system CAs, unless CA bundle is defined at spec.silencesAPIClient.tlsConfig
+func newSilencesAPI(ctx context.Context, rclient client.Client, am *vmv1beta1.VMAlertmanager) (*silencesAPI, error) {
+	api := &silencesAPI{c: silencesHTTPClient, baseURL: silencesAPIURL(am)}
+	clientCfg := am.Spec.SilencesAPIClient
+	if clientCfg == nil {
+		clientCfg = &vmv1beta1.VMAlertmanagerAPIClient{}
+	}
+	if webCfg := am.Spec.WebConfig; webCfg != nil {
+		// basic_auth_users contains only password hashes, operator cannot authorize at alertmanager with it
+		if len(webCfg.BasicAuthUsers) > 0 && clientCfg.BasicAuth == nil {
+			return nil, fmt.Errorf("alertmanager API is protected with webConfig.basic_auth_users, spec.silencesAPIClient.basicAuth must be defined to sync silences")
+		}
+		if webCfg.TLSServerConfig != nil && webCfg.TLSServerConfig.ClientAuthType == "RequireAndVerifyClientCert" &&
+			(clientCfg.TLSConfig == nil || clientCfg.TLSConfig.KeySecret == nil) {
+			return nil, fmt.Errorf("alertmanager API requires client certificate, spec.silencesAPIClient.tlsConfig.cert and keySecret must be defined to sync silences")
+		}
+	}
+	ac := build.NewAssetsCache(ctx, rclient, nil)
+	if clientCfg.BasicAuth != nil {
+		creds, err := ac.BuildBasicAuthCreds(am.Namespace, clientCfg.BasicAuth)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load silencesAPIClient.basicAuth: %w", err)
+		}
+		api.username = creds.Username
+		api.password = creds.Password
+	}
+	if authCfg := clientCfg.Authorization; authCfg != nil && authCfg.Credentials != nil {
+		credentials, err := ac.LoadKeyFromSecret(am.Namespace, authCfg.Credentials)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load silencesAPIClient.authorization: %w", err)
+		}
+		authType := authCfg.Type
+		if authType == "" {
+			authType = "Bearer"
+		}
+		api.authorization = authType + " " + credentials
+	}
+	if clientCfg.TLSConfig == nil {
+		return api, nil
+	}
+	tlsCfg, err := buildSilencesTLSConfig(ac, am.Namespace, clientCfg.TLSConfig)
+	if err != nil {
+		return nil, fmt.Errorf("cannot build silencesAPIClient.tlsConfig: %w", err)
+	}
+	api.c = &http.Client{
+		Timeout: silenceAPITimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   tlsCfg,
+			DisableKeepAlives: true,
+		},
+	}
+	return api, nil
+}
+
+// buildSilencesTLSConfig loads CA bundle and client certificate defined at TLSConfig.
+// File paths aren't supported, since they refer to files at alertmanager pods
+func buildSilencesTLSConfig(ac *build.AssetsCache, ns string, cfg *vmv1beta1.TLSConfig) (*tls.Config, error) {
+	tlsCfg := &tls.Config{
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CA.PrefixedName() != "" {
+		ca, err := ac.LoadKeyFromSecretOrConfigMap(ns, &cfg.CA)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load ca: %w", err)
+		}
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM([]byte(ca)) {
+			return nil, fmt.Errorf("cannot parse ca from %s", cfg.CA.PrefixedName())
+		}
+		tlsCfg.RootCAs = rootCAs
+	}
+	if cfg.Cert.PrefixedName() != "" && cfg.KeySecret != nil {
+		cert, err := ac.LoadKeyFromSecretOrConfigMap(ns, &cfg.Cert)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load cert: %w", err)
+		}
+		key, err := ac.LoadKeyFromSecret(ns, cfg.KeySecret)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load keySecret: %w", err)
+		}
+		pair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse client certificate: %w", err)
+		}
+		tlsCfg.Certificates = []tls.Certificate{pair}
+	}
+	return tlsCfg, nil
+}
+
+func silencesAPIURL(am *vmv1beta1.VMAlertmanager) string {
+	return am.AsURL() + path.Join("/", am.Spec.RoutePrefix, "/api/v2")
+}
+
+func syncSilence(ctx context.Context, api *silencesAPI, am *vmv1beta1.VMAlertmanager, cr *vmv1beta1.VMSilence, prevState *vmv1beta1.VMSilenceState, now time.Time) (*vmv1beta1.VMSilenceState, time.Time, error) {
+	desired, err := buildSilence(am, cr, now)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	st := &vmv1beta1.VMSilenceState{
+		Alertmanager: fmt.Sprintf("%s/%s", am.Namespace, am.Name),
+	}
+	var existing *amSilence
+	if prevState != nil && prevState.ID != "" {
+		existing, err = api.get(ctx, prevState.ID)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+	if existing != nil && existing.Status != nil && existing.Status.State == vmv1beta1.SilenceStateExpired {
+		existing = nil
+	}
+
+	// silence doesn't have upcoming occurrence
+	if desired == nil {
+		if existing != nil {
+			if err := api.expire(ctx, existing.ID); err != nil {
+				return nil, time.Time{}, err
+			}
+		}
+		if prevState != nil {
+			st.ID = prevState.ID
+		}
+		st.State = vmv1beta1.SilenceStateExpired
+		return st, time.Time{}, nil
+	}
+
+	switch {
+	case existing == nil:
+		logger.WithContext(ctx).Info("creating silence at alertmanager")
+		id, err := api.post(ctx, desired)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		st.ID = id
+	case !isSilenceUpToDate(existing, desired, now):
+		logger.WithContext(ctx).Info("updating silence at alertmanager", "id", existing.ID)
+		// alertmanager updates silence in-place if possible,
+		// otherwise it expires silence and creates a new one with different id
+		desired.ID = existing.ID
+		id, err := api.post(ctx, desired)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		st.ID = id
+	default:
+		st.ID = existing.ID
+		desired.StartsAt = existing.StartsAt
+	}
+	st.StartsAt = &metav1.Time{Time: desired.StartsAt}
+	st.EndsAt = &metav1.Time{Time: desired.EndsAt}
+	nextTransition := desired.EndsAt
+	if desired.StartsAt.After(now) {
+		st.State = vmv1beta1.SilenceStatePending
+		nextTransition = desired.StartsAt
+	} else {
+		st.State = vmv1beta1.SilenceStateActive
+	}
+	return st, nextTransition, nil
+}
+
+// buildSilence returns desired silence for the given time
+// nil result means that silence is already expired and has no upcoming occurrences
+func buildSilence(am *vmv1beta1.VMAlertmanager, cr *vmv1beta1.VMSilence, now time.Time) (*amSilence, error) {
+	s := &amSilence{
+		Comment:   cr.Spec.Comment,
+		CreatedBy: cr.Spec.CreatedBy,
+	}
+	if s.CreatedBy == "" {
+		s.CreatedBy = silenceDefaultCreatedBy
+	}
+	for idx, m := range cr.Spec.Matchers {
+		parsed, err := amparse.Matcher(m)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse matcher=%q idx=%d: %w", m, idx, err)
+		}
+		s.Matchers = append(s.Matchers, amSilenceMatcher{
+			Name:    parsed.Name,
+			Value:   parsed.Value,
+			IsRegex: parsed.Type == labels.MatchRegexp || parsed.Type == labels.MatchNotRegexp,
+			IsEqual: parsed.Type == labels.MatchEqual || parsed.Type == labels.MatchRegexp,
+		})
+	}
+	if !am.Spec.DisableNamespaceMatcher {
+		nsLabel := am.Spec.EnforcedNamespaceLabel
+		if nsLabel == "" {
+			nsLabel = "namespace"
+		}
+		s.Matchers = append(s.Matchers, amSilenceMatcher{
+			Name:    nsLabel,
+			Value:   cr.Namespace,
+			IsEqual: true,
+		})
+	}
+
+	if len(cr.Spec.Schedule) > 0 {
+		start, end, ok, err := nextScheduleOccurrence(cr.Spec.Schedule, now)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, nil
+		}
+		s.StartsAt = start
+		s.EndsAt = end
+		return s, nil
+	}
+
+	s.StartsAt = cr.CreationTimestamp.Time
+	if cr.Spec.StartsAt != nil {
+		s.StartsAt = cr.Spec.StartsAt.Time
+	}
+	if cr.Spec.EndsAt == nil {
+		return nil, fmt.Errorf("endsAt or schedule must be defined")
+	}
+	s.EndsAt = cr.Spec.EndsAt.Time
+	if !s.EndsAt.After(now) {
+		return nil, nil
+	}
+	return s, nil
+}
+
+// nextScheduleOccurrence returns start and end of the current or the next schedule occurrence
+// occurrence end is limited by silenceScheduleLookAhead
+func nextScheduleOccurrence(schedule []vmv1beta1.TimeInterval, now time.Time) (time.Time, time.Time, bool, error) {
+	data, err := yaml.Marshal(buildTimeIntervals(schedule))
+	if err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("cannot marshal schedule: %w", err)
+	}
+	var intervals []timeinterval.TimeInterval
+	if err := yaml.Unmarshal(data, &intervals); err != nil {
+		return time.Time{}, time.Time{}, false, fmt.Errorf("cannot parse schedule: %w", err)
+	}
+	contains := func(t time.Time) bool {
+		for _, ti := range intervals {
+			if ti.ContainsTime(t) {
+				return true
+			}
+		}
+		return false
+	}
+	start := now.Truncate(time.Minute)
+	limit := start.Add(silenceScheduleLookAhead)
+	if contains(start) {
+		// active occurrence could be started at any time in past, there is no need to search for it
+		start = now
+	} else {
+		for !contains(start) {
+			start = start.Add(time.Minute)
+			if !start.Before(limit) {
+				return time.Time{}, time.Time{}, false, nil
+			}
+		}
+	}
+	end := start.Truncate(time.Minute)
+	for contains(end) && end.Before(limit) {
+		end = end.Add(time.Minute)
+	}
+	return start, end, true, nil
+}
+
+// isSilenceUpToDate checks if existing silence at alertmanager matches desired one
+// start time is ignored for active silences, since alertmanager overwrites it with creation time
+func isSilenceUpToDate(existing, desired *amSilence, now time.Time) bool {
+	if existing.Comment != desired.Comment || existing.CreatedBy != desired.CreatedBy {
+		return false
+	}
+	if !existing.EndsAt.Equal(desired.EndsAt) {
+		return false
+	}
+	if desired.StartsAt.After(now) {
+		if !existing.StartsAt.Equal(desired.StartsAt) {
+			return false
+		}
+	} else if existing.StartsAt.After(now) {
+		return false
+	}
+	return slices.Equal(sortedSilenceMatchers(existing.Matchers), sortedSilenceMatchers(desired.Matchers))
+}
+
+func sortedSilenceMatchers(src []amSilenceMatcher) []amSilenceMatcher {
+	dst := slices.Clone(src)
+	slices.SortFunc(dst, func(a, b amSilenceMatcher) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(fmt.Sprintf("%s%t%t", a.Value, a.IsRegex, a.IsEqual), fmt.Sprintf("%s%t%t", b.Value, b.IsRegex, b.IsEqual))
+	})
+	return dst
+}
+
+// silencesAPI implements a subset of alertmanager v2 silences API
+type silencesAPI struct {
+	c             *http.Client
+	baseURL       string
+	username      string
+	password      string
+	authorization string
+}
+
+// get returns silence by id or nil if silence doesn't exist
+func (api *silencesAPI) get(ctx context.Context, id string) (*amSilence, error) {
+	resp, err := api.do(ctx, http.MethodGet, "/silence/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	data, err := readSilenceResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	var s amSilence
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("cannot parse silence=%s response: %w", id, err)
+	}
+	return &s, nil
+}
+
+// post creates or updates silence and returns its id
+func (api *silencesAPI) post(ctx context.Context, s *amSilence) (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("cannot marshal silence: %w", err)
+	}
+	resp, err := api.do(ctx, http.MethodPost, "/silences", data)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	data, err = readSilenceResponse(resp)
+	if err != nil {
+		return "", err
+	}
+	var r struct {
+		SilenceID string `json:"silenceID"`
+	}
+	if err := json.Unmarshal(data, &r); err != nil {
+		return "", fmt.Errorf("cannot parse silence create response: %w", err)
+	}
+	return r.SilenceID, nil
+}
+
+// expire expires silence by id, it's no-op for missing silence
+func (api *silencesAPI) expire(ctx context.Context, id string) error {
+	resp, err := api.do(ctx, http.MethodDelete, "/silence/"+url.PathEscape(id), nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	_, err = readSilenceResponse(resp)
+	return err
+}
+
+func (api *silencesAPI) do(ctx context.Context, method, p string, body []byte) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, api.baseURL+p, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("cannot build request to alertmanager: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	switch {
+	case api.username != "":
+		req.SetBasicAuth(api.username, api.password)
+	case api.authorization != "":
+		req.Header.Set("Authorization", api.authorization)
+	}
+	resp, err := api.c.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot execute request %s %s: %w", method, req.URL, err)
+	}
+	return resp, nil
+}
+
+func readSilenceResponse(resp *http.Response) ([]byte, error) {
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read alertmanager response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status=%d, body=%q from alertmanager at %s", resp.StatusCode, string(data), resp.Request.URL)
+	}
+	return data, nil
+}
@@ -0,0 +1,365 @@
+package vmalertmanager
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+// fakeSilencesServer imitates alertmanager v2 silences API
+type fakeSilencesServer struct {
+	mu       sync.Mutex
+	silences map[string]*amSilence
+	posts    int
+	nextID   int
+}
+
+func (fs *fakeSilencesServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/api/v2/silences":
+		var s amSilence
+		if err := json.NewDecoder(r.Body).Decode(&s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fs.posts++
+		if s.ID == "" {
+			fs.nextID++
+			s.ID = fmt.Sprintf("id-%d", fs.nextID)
+		}
+		s.Status = &amSilenceStatus{State: vmv1beta1.SilenceStateActive}
+		fs.silences[s.ID] = &s
+		fmt.Fprintf(w, `{"silenceID":%q}`, s.ID)
+	case strings.HasPrefix(r.URL.Path, "/api/v2/silence/"):
+		id := strings.TrimPrefix(r.URL.Path, "/api/v2/silence/")
+		s, ok := fs.silences[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Method == http.MethodDelete {
+			s.Status.State = vmv1beta1.SilenceStateExpired
+			return
+		}
+		_ = json.NewEncoder(w).Encode(s)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestSyncSilence(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC)
+	am := &vmv1beta1.VMAlertmanager{
+		ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+	}
+	cr := &vmv1beta1.VMSilence{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "maintenance",
+			Namespace:         "default",
+			CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)},
+		},
+		Spec: vmv1beta1.VMSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`},
+			EndsAt:   &metav1.Time{Time: now.Add(time.Hour)},
+			Comment:  "maintenance",
+		},
+	}
+	fs := &fakeSilencesServer{silences: map[string]*amSilence{}}
+	ts := httptest.NewServer(fs)
+	defer ts.Close()
+	api := &silencesAPI{c: ts.Client(), baseURL: ts.URL + "/api/v2"}
+	ctx := context.Background()
+
+	// create silence
+	st, next, err := syncSilence(ctx, api, am, cr, nil, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1", st.ID)
+	assert.Equal(t, "monitoring/main", st.Alertmanager)
+	assert.Equal(t, vmv1beta1.SilenceStateActive, st.State)
+	assert.Equal(t, now.Add(time.Hour), next)
+	assert.Equal(t, []amSilenceMatcher{
+		{Name: "alertname", Value: "Watchdog", IsEqual: true},
+		{Name: "namespace", Value: "default", IsEqual: true},
+	}, fs.silences["id-1"].Matchers)
+	assert.Equal(t, silenceDefaultCreatedBy, fs.silences["id-1"].CreatedBy)
+
+	// silence is up to date
+	st, _, err = syncSilence(ctx, api, am, cr, st, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1", st.ID)
+	assert.Equal(t, 1, fs.posts)
+
+	// silence was changed
+	cr.Spec.Comment = "extended maintenance"
+	st, _, err = syncSilence(ctx, api, am, cr, st, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "id-1", st.ID)
+	assert.Equal(t, 2, fs.posts)
+	assert.Equal(t, "extended maintenance", fs.silences["id-1"].Comment)
+
+	// silence was lost by alertmanager
+	delete(fs.silences, "id-1")
+	st, _, err = syncSilence(ctx, api, am, cr, st, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "id-2", st.ID)
+
+	// silence ends
+	st, next, err = syncSilence(ctx, api, am, cr, st, now.Add(2*time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, "id-2", st.ID)
+	assert.Equal(t, vmv1beta1.SilenceStateExpired, st.State)
+	assert.True(t, next.IsZero())
+	assert.Equal(t, vmv1beta1.SilenceStateExpired, fs.silences["id-2"].Status.State)
+}
+
+func TestNewSilencesAPI(t *testing.T) {
+	fs := &fakeSilencesServer{silences: map[string]*amSilence{}}
+	var gotAuth string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		fs.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "am-client", Namespace: "monitoring"},
+		Data: map[string][]byte{
+			"ca":       caPEM,
+			"username": []byte("user"),
+			"password": []byte("pass"),
+			"token":    []byte("secret-token"),
+		},
+	}
+	secretKey := func(key string) *corev1.SecretKeySelector {
+		return &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "am-client"},
+			Key:                  key,
+		}
+	}
+	ctx := context.Background()
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{caSecret})
+	tlsWebCfg := &vmv1beta1.VMAlertmanagerWebConfig{
+		TLSServerConfig: &vmv1beta1.TLSServerConfig{
+			Certs: vmv1beta1.Certs{
+				CertFile: "/etc/tls/cert",
+				KeyFile:  "/etc/tls/key",
+			},
+		},
+	}
+	newAM := func(webCfg *vmv1beta1.VMAlertmanagerWebConfig, clientCfg *vmv1beta1.VMAlertmanagerAPIClient) *vmv1beta1.VMAlertmanager {
+		return &vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+			Spec: vmv1beta1.VMAlertmanagerSpec{
+				WebConfig:         webCfg,
+				SilencesAPIClient: clientCfg,
+			},
+		}
+	}
+	get := func(am *vmv1beta1.VMAlertmanager) error {
+		t.Helper()
+		api, err := newSilencesAPI(ctx, fclient, am)
+		assert.NoError(t, err)
+		api.baseURL = ts.URL + "/api/v2"
+		_, err = api.get(ctx, "missing")
+		return err
+	}
+
+	// webserver certificate isn't trusted by system CAs
+	assert.Error(t, get(newAM(tlsWebCfg, nil)))
+
+	// webserver certificate is verified with CA bundle
+	am := newAM(tlsWebCfg, &vmv1beta1.VMAlertmanagerAPIClient{
+		TLSConfig: &vmv1beta1.TLSConfig{
+			CA: vmv1beta1.SecretOrConfigMap{Secret: secretKey("ca")},
+		},
+	})
+	assert.NoError(t, get(am))
+	api, err := newSilencesAPI(ctx, fclient, am)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(api.baseURL, "https://"))
+
+	// certificate verification is disabled
+	assert.NoError(t, get(newAM(tlsWebCfg, &vmv1beta1.VMAlertmanagerAPIClient{
+		TLSConfig: &vmv1beta1.TLSConfig{InsecureSkipVerify: true},
+	})))
+
+	// basic auth credentials must be provided
+	basicAuthWebCfg := &vmv1beta1.VMAlertmanagerWebConfig{
+		BasicAuthUsers: map[string]string{"user": "$2y$10$hash"},
+	}
+	_, err = newSilencesAPI(ctx, fclient, newAM(basicAuthWebCfg, nil))
+	assert.Error(t, err)
+	assert.NoError(t, get(newAM(basicAuthWebCfg, &vmv1beta1.VMAlertmanagerAPIClient{
+		TLSConfig: &vmv1beta1.TLSConfig{InsecureSkipVerify: true},
+		BasicAuth: &vmv1beta1.BasicAuth{
+			Username: *secretKey("username"),
+			Password: *secretKey("password"),
+		},
+	})))
+	assert.Equal(t, "Basic dXNlcjpwYXNz", gotAuth)
+
+	// authorization header
+	assert.NoError(t, get(newAM(nil, &vmv1beta1.VMAlertmanagerAPIClient{
+		TLSConfig: &vmv1beta1.TLSConfig{InsecureSkipVerify: true},
+		Authorization: &vmv1beta1.Authorization{
+			Credentials: secretKey("token"),
+		},
+	})))
+	assert.Equal(t, "Bearer secret-token", gotAuth)
+
+	// client certificate must be provided
+	_, err = newSilencesAPI(ctx, fclient, newAM(&vmv1beta1.VMAlertmanagerWebConfig{
+		TLSServerConfig: &vmv1beta1.TLSServerConfig{
+			ClientAuthType: "RequireAndVerifyClientCert",
+			Certs:          tlsWebCfg.TLSServerConfig.Certs,
+		},
+	}, nil))
+	assert.Error(t, err)
+}
+
+func TestBuildSilence(t *testing.T) {
+	now := time.Date(2026, 3, 2, 10, 30, 0, 0, time.UTC) // Monday
+	type opts struct {
+		amSpec       vmv1beta1.VMAlertmanagerSpec
+		spec         vmv1beta1.VMSilenceSpec
+		wantMatchers []amSilenceMatcher
+		wantStart    time.Time
+		wantEnd      time.Time
+		wantNil      bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		am := &vmv1beta1.VMAlertmanager{
+			ObjectMeta: metav1.ObjectMeta{Name: "main", Namespace: "monitoring"},
+			Spec:       o.amSpec,
+		}
+		cr := &vmv1beta1.VMSilence{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "maintenance",
+				Namespace:         "default",
+				CreationTimestamp: metav1.Time{Time: now.Add(-time.Hour)},
+			},
+			Spec: o.spec,
+		}
+		got, err := buildSilence(am, cr, now)
+		assert.NoError(t, err)
+		if o.wantNil {
+			assert.Nil(t, got)
+			return
+		}
+		assert.NotNil(t, got)
+		if o.wantMatchers != nil {
+			assert.Equal(t, o.wantMatchers, got.Matchers)
+		}
+		assert.Equal(t, o.wantStart, got.StartsAt)
+		assert.Equal(t, o.wantEnd, got.EndsAt)
+	}
+
+	// enforced namespace label
+	f(opts{
+		amSpec: vmv1beta1.VMAlertmanagerSpec{EnforcedNamespaceLabel: "kubernetes_namespace"},
+		spec: vmv1beta1.VMSilenceSpec{
+			Matchers: []string{`severity!="critical"`, `job=~"node.*"`, `instance!~"db-.+"`},
+			EndsAt:   &metav1.Time{Time: now.Add(time.Hour)},
+		},
+		wantMatchers: []amSilenceMatcher{
+			{Name: "severity", Value: "critical"},
+			{Name: "job", Value: "node.*", IsRegex: true, IsEqual: true},
+			{Name: "instance", Value: "db-.+", IsRegex: true},
+			{Name: "kubernetes_namespace", Value: "default", IsEqual: true},
+		},
+		wantStart: now.Add(-time.Hour),
+		wantEnd:   now.Add(time.Hour),
+	})
+
+	// disabled namespace matcher
+	f(opts{
+		amSpec: vmv1beta1.VMAlertmanagerSpec{DisableNamespaceMatcher: true},
+		spec: vmv1beta1.VMSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`},
+			StartsAt: &metav1.Time{Time: now.Add(time.Hour)},
+			EndsAt:   &metav1.Time{Time: now.Add(2 * time.Hour)},
+		},
+		wantMatchers: []amSilenceMatcher{
+			{Name: "alertname", Value: "Watchdog", IsEqual: true},
+		},
+		wantStart: now.Add(time.Hour),
+		wantEnd:   now.Add(2 * time.Hour),
+	})
+
+	// already expired
+	f(opts{
+		spec: vmv1beta1.VMSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`},
+			EndsAt:   &metav1.Time{Time: now.Add(-time.Minute)},
+		},
+		wantNil: true,
+	})
+
+	// active schedule occurrence
+	f(opts{
+		spec: vmv1beta1.VMSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`},
+			Schedule: []vmv1beta1.TimeInterval{{
+				Times:    []vmv1beta1.TimeRange{{StartTime: "10:00", EndTime: "12:00"}},
+				Weekdays: []string{"monday:friday"},
+			}},
+		},
+		wantStart: now,
+		wantEnd:   time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC),
+	})
+
+	// next schedule occurrence
+	f(opts{
+		spec: vmv1beta1.VMSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`},
+			Schedule: []vmv1beta1.TimeInterval{{
+				Times:    []vmv1beta1.TimeRange{{StartTime: "01:00", EndTime: "03:00"}},
+				Weekdays: []string{"saturday"},
+			}},
+		},
+		wantStart: time.Date(2026, 3, 7, 1, 0, 0, 0, time.UTC),
+		wantEnd:   time.Date(2026, 3, 7, 3, 0, 0, 0, time.UTC),
+	})
+
+	// schedule in other location
+	f(opts{
+		spec: vmv1beta1.VMSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`},
+			Schedule: []vmv1beta1.TimeInterval{{
+				Times:    []vmv1beta1.TimeRange{{StartTime: "01:00", EndTime: "03:00"}},
+				Location: "Europe/Berlin",
+			}},
+		},
+		wantStart: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+		wantEnd:   time.Date(2026, 3, 3, 2, 0, 0, 0, time.UTC),
+	})
+
+	// no occurrences
+	f(opts{
+		spec: vmv1beta1.VMSilenceSpec{
+			Matchers: []string{`alertname="Watchdog"`},
+			Schedule: []vmv1beta1.TimeInterval{{
+				Years: []string{"2020"},
+			}},
+		},
+		wantNil: true,
+	})
+}
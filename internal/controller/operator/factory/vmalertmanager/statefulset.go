@@ -49,12 +49,12 @@ receivers:
 `
 )
 
-func newStsForAlertManager(cr *vmv1beta1.VMAlertmanager) (*appsv1.StatefulSet, error) {
+func newStsForAlertManager(cr *vmv1beta1.VMAlertmanager, peers *clusterPeers) (*appsv1.StatefulSet, error) {
 	if cr.Spec.Retention == "" {
 		cr.Spec.Retention = defaultRetention
 	}
 
-	spec, err := makeStatefulSetSpec(cr)
+	spec, err := makeStatefulSetSpec(cr, peers)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +153,8 @@ func createOrUpdateAlertManagerService(ctx context.Context, rclient client.Clien
 	return nil
 }
 
-func makeStatefulSetSpec(cr *vmv1beta1.VMAlertmanager) (*appsv1.StatefulSetSpec, error) {
+// makeStatefulSetSpec builds statefulset spec, peers defines cluster peers of other VMAlertmanagers
+func makeStatefulSetSpec(cr *vmv1beta1.VMAlertmanager, peers *clusterPeers) (*appsv1.StatefulSetSpec, error) {
 
 	cfg := config.MustGetBaseConfig()
 	image := fmt.Sprintf("%s:%s", cr.Spec.Image.Repository, cr.Spec.Image.Tag)
@@ -172,7 +173,8 @@ func makeStatefulSetSpec(cr *vmv1beta1.VMAlertmanager) (*appsv1.StatefulSetSpec,
 		amArgs = append(amArgs, fmt.Sprintf("--cluster.tls-config=%s/%s", tlsAssetsDir, gossipConfigKey))
 	}
 
-	if ptr.Deref(cr.Spec.ReplicaCount, 0) == 1 {
+	// single replica still must listen for gossip, if it has peers or it's referenced as a peer
+	if ptr.Deref(cr.Spec.ReplicaCount, 0) == 1 && !peers.hasPeers() {
 		amArgs = append(amArgs, "--cluster.listen-address=")
 	} else {
 		amArgs = append(amArgs, "--cluster.listen-address=[$(POD_IP)]:9094")
@@ -211,14 +213,16 @@ func makeStatefulSetSpec(cr *vmv1beta1.VMAlertmanager) (*appsv1.StatefulSetSpec,
 		amArgs = append(amArgs, fmt.Sprintf("--cluster.advertise-address=%s", cr.Spec.ClusterAdvertiseAddress))
 	}
 
-	// clusterPeerDomain consists of service name and namespace
-	clusterPeerDomain := fmt.Sprintf("%s.%s", cr.PrefixedName(), cr.Namespace)
-	if cr.Spec.ClusterDomainName != "" {
-		clusterPeerDomain = fmt.Sprintf("%s.svc.%s.", clusterPeerDomain, cr.Spec.ClusterDomainName)
+	for _, peer := range buildReplicaPeers(cr, cr) {
+		amArgs = append(amArgs, fmt.Sprintf("--cluster.peer=%s", peer))
 	}
 
-	for i := int32(0); i < ptr.Deref(cr.Spec.ReplicaCount, 0); i++ {
-		amArgs = append(amArgs, fmt.Sprintf("--cluster.peer=%s-%d.%s:9094", cr.PrefixedName(), i, clusterPeerDomain))
+	var peerAddrs []string
+	if peers != nil {
+		peerAddrs = peers.addrs
+	}
+	for _, peer := range peerAddrs {
+		amArgs = append(amArgs, fmt.Sprintf("--cluster.peer=%s", peer))
 	}
 
 	for _, peer := range cr.Spec.AdditionalPeers {
@@ -506,7 +510,12 @@ func CreateOrUpdateConfig(ctx context.Context, rclient client.Client, cr *vmv1be
 		return fmt.Errorf("cannot build webserver config: %w", err)
 	}
 
-	gossipCfg, err := buildGossipConfigYAML(cr, ac)
+	peers, err := buildClusterPeers(ctx, rclient, cr)
+	if err != nil {
+		return err
+	}
+	gossipCR := withPeersGossipConfig(cr, peers)
+	gossipCfg, err := buildGossipConfigYAML(gossipCR, ac)
 	if err != nil {
 		return fmt.Errorf("cannot build gossip config: %w", err)
 	}
@@ -545,7 +554,7 @@ func CreateOrUpdateConfig(ctx context.Context, rclient client.Client, cr *vmv1be
 	if cr.Spec.WebConfig != nil {
 		newAMSecretConfig.Data[webserverConfigKey] = webCfg
 	}
-	if cr.Spec.GossipConfig != nil {
+	if gossipCR.Spec.GossipConfig != nil {
 		newAMSecretConfig.Data[gossipConfigKey] = gossipCfg
 	}
 
@@ -561,15 +570,19 @@ func CreateOrUpdateConfig(ctx context.Context, rclient client.Client, cr *vmv1be
 
 	parent := fmt.Sprintf("%s.%s.vmalertmanager", cr.Name, cr.Namespace)
 
+	configs := pos.configs.All()
 	if childCR != nil {
 		// fast path update only single object
 		if o := pos.configs.Get(childCR); o != nil {
-			return reconcile.StatusForChildObjects(ctx, rclient, parent, []*vmv1beta1.VMAlertmanagerConfig{o})
+			configs = []*vmv1beta1.VMAlertmanagerConfig{o}
 		}
 	}
-	if err := reconcile.StatusForChildObjects(ctx, rclient, parent, pos.configs.All()); err != nil {
+	if err := reconcile.StatusForChildObjects(ctx, rclient, parent, configs); err != nil {
 		return fmt.Errorf("failed to update vmalertmanagerConfigs statuses: %w", err)
 	}
+	if err := reconcile.VMAlertmanagerConfigTestsStatus(ctx, rclient, configs); err != nil {
+		return fmt.Errorf("failed to update vmalertmanagerConfigs tests statuses: %w", err)
+	}
 	return nil
 }
 
@@ -623,11 +636,20 @@ func buildAlertmanagerConfigWithCRDs(ctx context.Context, rclient client.Client,
 			return nil, nil, fmt.Errorf("cannot select alertmanager configs: %w", err)
 		}
 	}
-	pos := &parsedObjects{configs: build.NewChildObjects("vmalertmanagerconfig", configs, nsn)}
+	receivers, namespaceLabels, err := fetchSharedReceivers(ctx, rclient, configs)
+	if err != nil {
+		return nil, nil, err
+	}
+	pos := &parsedObjects{
+		configs:         build.NewChildObjects("vmalertmanagerconfig", configs, nsn),
+		receivers:       receivers,
+		namespaceLabels: namespaceLabels,
+	}
 	data, err := pos.buildConfig(cr, originConfig, ac)
 	if err != nil {
 		return nil, nil, err
 	}
+	pos.evaluateConfigTests(cr, data)
 	pos.configs.UpdateMetrics(ctx)
 	return pos, data, nil
 }
@@ -0,0 +1,153 @@
+package vmanomaly
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+const (
+	anomalyScoreVar       = "anomaly_score"
+	defaultAlertThreshold = "1.0"
+	defaultMetricName     = "$VAR"
+	defaultForLabel       = "$QUERY_KEY"
+)
+
+var invalidRuleNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// buildAlertingRules returns VMRule per each model with alert on anomaly score of each model query
+func buildAlertingRules(cr *vmv1.VMAnomaly, modelQueries map[string][]string) ([]*vmv1beta1.VMRule, error) {
+	alerting := cr.Spec.Alerting
+	if alerting == nil {
+		return nil, nil
+	}
+	threshold := alerting.Threshold
+	if threshold == "" {
+		threshold = defaultAlertThreshold
+	}
+	metricName := defaultMetricName
+	forLabel := defaultForLabel
+	var extraLabels map[string]string
+	if cr.Spec.Writer != nil {
+		mf := cr.Spec.Writer.MetricFormat
+		if mf.Name != "" {
+			metricName = mf.Name
+		}
+		if mf.For != "" {
+			forLabel = mf.For
+		}
+		extraLabels = mf.ExtraLabels
+	}
+	ruleLabels := labels.Merge(alerting.RuleLabels, cr.FinalLabels())
+	names := make(map[string]string, len(modelQueries))
+	var rules []*vmv1beta1.VMRule
+	for _, model := range slices.Sorted(maps.Keys(modelQueries)) {
+		name := alertingRuleName(cr, model)
+		if prevModel, ok := names[name]; ok {
+			return nil, fmt.Errorf("models %q and %q have the same alerting VMRule name=%q, rename one of them", prevModel, model, name)
+		}
+		names[name] = model
+		group := vmv1beta1.RuleGroup{
+			Name:     model,
+			Interval: alerting.Interval,
+		}
+		for _, query := range modelQueries[model] {
+			placeholders := strings.NewReplacer("$VAR", anomalyScoreVar, "$QUERY_KEY", query)
+			selector := map[string]string{
+				"for":         placeholders.Replace(forLabel),
+				"model_alias": model,
+			}
+			for k, v := range extraLabels {
+				selector[k] = placeholders.Replace(v)
+			}
+			annotations := labels.Merge(map[string]string{
+				"description": fmt.Sprintf("Anomaly score of query %q computed by model %q is above %s", query, model, threshold),
+			}, alerting.Annotations)
+			group.Rules = append(group.Rules, vmv1beta1.Rule{
+				Alert:       "AnomalyDetected",
+				Expr:        fmt.Sprintf("%s > %s", metricSelector(placeholders.Replace(metricName), selector), threshold),
+				For:         alerting.For,
+				Labels:      alerting.Labels,
+				Annotations: annotations,
+			})
+		}
+		rules = append(rules, &vmv1beta1.VMRule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       cr.Namespace,
+				Labels:          ruleLabels,
+				Annotations:     cr.FinalAnnotations(),
+				OwnerReferences: []metav1.OwnerReference{cr.AsOwner()},
+			},
+			Spec: vmv1beta1.VMRuleSpec{
+				Groups: []vmv1beta1.RuleGroup{group},
+			},
+		})
+	}
+	return rules, nil
+}
+
+// alertingRuleName returns a valid kubernetes object name for the given model
+func alertingRuleName(cr *vmv1.VMAnomaly, model string) string {
+	suffix := strings.Trim(invalidRuleNameChars.ReplaceAllString(strings.ToLower(model), "-"), "-")
+	return fmt.Sprintf("%s-%s", cr.PrefixedName(), suffix)
+}
+
+func metricSelector(name string, lbls map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString(`{__name__=`)
+	sb.WriteString(strconv.Quote(name))
+	for _, k := range slices.Sorted(maps.Keys(lbls)) {
+		sb.WriteString(",")
+		sb.WriteString(k)
+		sb.WriteString("=")
+		sb.WriteString(strconv.Quote(lbls[k]))
+	}
+	sb.WriteString("}")
+	return sb.String()
+}
+
+// createOrUpdateAlertingRules reconciles VMRules generated from alerting section
+// and removes VMRules of deleted models
+func createOrUpdateAlertingRules(ctx context.Context, rclient client.Client, cr, prevCR *vmv1.VMAnomaly, modelQueries map[string][]string) error {
+	keepRules := sets.New[string]()
+	if cr.Spec.Alerting != nil {
+		rules, err := buildAlertingRules(cr, modelQueries)
+		if err != nil {
+			return err
+		}
+		prevRules := make(map[string]*vmv1beta1.VMRule)
+		if prevCR != nil && prevCR.Spec.Alerting != nil {
+			// previous rules are only used to remove stale labels and annotations
+			prev, _ := buildAlertingRules(prevCR, modelQueries)
+			for _, r := range prev {
+				prevRules[r.Name] = r
+			}
+		}
+		owner := cr.AsOwner()
+		for _, r := range rules {
+			if err := reconcile.VMRule(ctx, rclient, r, prevRules[r.Name], &owner); err != nil {
+				return fmt.Errorf("cannot reconcile alerting VMRule=%s: %w", r.Name, err)
+			}
+			keepRules.Insert(r.Name)
+		}
+	}
+	if err := finalize.RemoveOrphanedVMRules(ctx, rclient, cr, keepRules, true); err != nil {
+		return fmt.Errorf("cannot remove orphaned alerting VMRules: %w", err)
+	}
+	return nil
+}
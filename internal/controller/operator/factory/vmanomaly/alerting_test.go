@@ -0,0 +1,192 @@
+package vmanomaly
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmanomaly/config"
+)
+
+func TestBuildAlertingRules(t *testing.T) {
+	type opts struct {
+		spec    vmv1.VMAnomalySpec
+		config  string
+		want    string
+		wantErr bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		cr := &vmv1.VMAnomaly{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "monitoring",
+			},
+			Spec: o.spec,
+		}
+		modelQueries, err := config.ModelQueries([]byte(o.config))
+		assert.NoError(t, err)
+		rules, err := buildAlertingRules(cr, modelQueries)
+		if o.wantErr {
+			assert.Error(t, err)
+			return
+		}
+		assert.NoError(t, err)
+		var got []any
+		for _, r := range rules {
+			got = append(got, map[string]any{
+				"name":   r.Name,
+				"labels": r.Labels,
+				"spec":   r.Spec,
+			})
+		}
+		data, err := yaml.Marshal(got)
+		assert.NoError(t, err)
+		assert.Equal(t, o.want, string(data))
+	}
+
+	cfg := `
+reader:
+  class: vm
+  datasource_url: http://read.endpoint
+  queries:
+    cpu:
+      expr: rate(cpu_seconds_total)
+    mem:
+      expr: mem_bytes
+models:
+  zscore_cpu:
+    class: zscore
+    queries: [cpu]
+  mad:
+    class: mad
+`
+
+	// default metric format
+	f(opts{
+		spec: vmv1.VMAnomalySpec{
+			Writer: &vmv1.VMAnomalyWritersSpec{},
+			Alerting: &vmv1.VMAnomalyAlertingSpec{
+				For:        "5m",
+				Labels:     map[string]string{"severity": "warning"},
+				RuleLabels: map[string]string{"team": "infra"},
+			},
+		},
+		config: cfg,
+		want: `- labels:
+    app.kubernetes.io/component: monitoring
+    app.kubernetes.io/instance: test
+    app.kubernetes.io/name: vmanomaly
+    managed-by: vm-operator
+    team: infra
+  name: vmanomaly-test-mad
+  spec:
+    groups:
+    - name: mad
+      rules:
+      - alert: AnomalyDetected
+        expr: '{__name__="anomaly_score",for="cpu",model_alias="mad"} > 1.0'
+        for: 5m
+        labels:
+          severity: warning
+        annotations:
+          description: Anomaly score of query "cpu" computed by model "mad" is above
+            1.0
+      - alert: AnomalyDetected
+        expr: '{__name__="anomaly_score",for="mem",model_alias="mad"} > 1.0'
+        for: 5m
+        labels:
+          severity: warning
+        annotations:
+          description: Anomaly score of query "mem" computed by model "mad" is above
+            1.0
+- labels:
+    app.kubernetes.io/component: monitoring
+    app.kubernetes.io/instance: test
+    app.kubernetes.io/name: vmanomaly
+    managed-by: vm-operator
+    team: infra
+  name: vmanomaly-test-zscore-cpu
+  spec:
+    groups:
+    - name: zscore_cpu
+      rules:
+      - alert: AnomalyDetected
+        expr: '{__name__="anomaly_score",for="cpu",model_alias="zscore_cpu"} > 1.0'
+        for: 5m
+        labels:
+          severity: warning
+        annotations:
+          description: Anomaly score of query "cpu" computed by model "zscore_cpu"
+            is above 1.0
+`,
+	})
+
+	// custom metric format
+	f(opts{
+		spec: vmv1.VMAnomalySpec{
+			Writer: &vmv1.VMAnomalyWritersSpec{
+				MetricFormat: vmv1.VMAnomalyVMWriterMetricFormatSpec{
+					Name:        "vmanomaly_$VAR",
+					For:         "q_$QUERY_KEY",
+					ExtraLabels: map[string]string{"job": "anomaly"},
+				},
+			},
+			Alerting: &vmv1.VMAnomalyAlertingSpec{
+				Threshold:   "2.5",
+				Interval:    "1m",
+				Annotations: map[string]string{"summary": "anomaly detected"},
+			},
+		},
+		config: `
+reader:
+  queries:
+    cpu:
+      expr: rate(cpu_seconds_total)
+models:
+  zscore:
+    class: zscore
+`,
+		want: `- labels:
+    app.kubernetes.io/component: monitoring
+    app.kubernetes.io/instance: test
+    app.kubernetes.io/name: vmanomaly
+    managed-by: vm-operator
+  name: vmanomaly-test-zscore
+  spec:
+    groups:
+    - name: zscore
+      interval: 1m
+      rules:
+      - alert: AnomalyDetected
+        expr: '{__name__="vmanomaly_anomaly_score",for="q_cpu",job="anomaly",model_alias="zscore"}
+          > 2.5'
+        annotations:
+          description: Anomaly score of query "cpu" computed by model "zscore" is
+            above 2.5
+          summary: anomaly detected
+`,
+	})
+
+	// models with clashing rule names
+	f(opts{
+		spec: vmv1.VMAnomalySpec{
+			Alerting: &vmv1.VMAnomalyAlertingSpec{},
+		},
+		config: `
+reader:
+  queries:
+    cpu:
+      expr: rate(cpu_seconds_total)
+models:
+  zscore_cpu:
+    class: zscore
+  zscore-cpu:
+    class: zscore
+`,
+		wantErr: true,
+	})
+}
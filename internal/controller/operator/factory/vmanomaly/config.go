@@ -18,10 +18,20 @@ import (
 
 // createOrUpdateConfig reconcile configuration for vmanomaly and returns configuration consistent hash
 func createOrUpdateConfig(ctx context.Context, rclient client.Client, cr, prevCR *vmv1.VMAnomaly, ac *build.AssetsCache) (string, error) {
-	data, err := config.Load(cr, ac)
+	pos, err := selectObjects(ctx, rclient, cr)
 	if err != nil {
 		return "", err
 	}
+	if err := resolveRefs(ctx, rclient, cr, ac, pos); err != nil {
+		return "", err
+	}
+	data, err := config.Load(cr, pos, ac)
+	if err != nil {
+		return "", err
+	}
+	if err := updateObjectsStatus(ctx, rclient, cr, pos); err != nil {
+		return "", err
+	}
 	newSecretConfig := &corev1.Secret{
 		ObjectMeta: build.ResourceMeta(build.SecretConfigResourceKind, cr),
 		Data: map[string][]byte{
@@ -49,6 +59,14 @@ func createOrUpdateConfig(ctx context.Context, rclient client.Client, cr, prevCR
 		return "", err
 	}
 
+	modelQueries, err := config.ModelQueries(data)
+	if err != nil {
+		return "", err
+	}
+	if err := createOrUpdateAlertingRules(ctx, rclient, cr, prevCR, modelQueries); err != nil {
+		return "", err
+	}
+
 	hash := sha256.New()
 	hash.Write(data)
 	hashBytes := hash.Sum(nil)
@@ -2,11 +2,14 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/VictoriaMetrics/VictoriaMetrics/lib/timeutil"
 	"gopkg.in/yaml.v2"
+	"k8s.io/utils/ptr"
 
 	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
@@ -56,7 +59,7 @@ type settings struct {
 	RestoreState      bool    `yaml:"restore_state,omitempty"`
 }
 
-func (c *config) override(cr *vmv1.VMAnomaly, ac *build.AssetsCache) error {
+func (c *config) override(cr *vmv1.VMAnomaly, pos *ParsedObjects, ac *build.AssetsCache) error {
 	crCanonicalName := strings.Join([]string{cr.Namespace, cr.Name}, "/")
 	if cr.Spec.Server != nil {
 		srv := cr.Spec.Server
@@ -107,7 +110,7 @@ func (c *config) override(cr *vmv1.VMAnomaly, ac *build.AssetsCache) error {
 		return fmt.Errorf("reader is required for anomaly name=%q", crCanonicalName)
 	}
 	if c.Reader == nil || len(c.Reader.Queries) == 0 {
-		return fmt.Errorf("reader.queries must be provided via configRawYaml or configSecret, name=%q", crCanonicalName)
+		return fmt.Errorf("reader.queries must be provided via configRawYaml, configSecret or VMAnomalyQuery objects, name=%q", crCanonicalName)
 	}
 	if cr.Spec.Writer == nil {
 		return fmt.Errorf("writer is required for anomaly name=%q", crCanonicalName)
@@ -124,6 +127,10 @@ func (c *config) override(cr *vmv1.VMAnomaly, ac *build.AssetsCache) error {
 	if err = r.ClientConfig.override(cr, &cr.Spec.Reader.VMAnomalyHTTPClientSpec, ac); err != nil {
 		return fmt.Errorf("failed to update HTTP client for anomaly reader, name=%q: %w", crCanonicalName, err)
 	}
+	if pos != nil && pos.Reader != nil {
+		r.DatasourceURL = pos.Reader.URL
+		r.ClientConfig.withDatasource(pos.Reader)
+	}
 	r.Class = "vm"
 
 	r.Queries = c.Reader.Queries
@@ -141,6 +148,10 @@ func (c *config) override(cr *vmv1.VMAnomaly, ac *build.AssetsCache) error {
 	if err = w.ClientConfig.override(cr, &cr.Spec.Writer.VMAnomalyHTTPClientSpec, ac); err != nil {
 		return fmt.Errorf("failed to update HTTP client for anomaly writer, name=%q: %w", crCanonicalName, err)
 	}
+	if pos != nil && pos.Writer != nil {
+		w.DatasourceURL = pos.Writer.URL
+		w.ClientConfig.withDatasource(pos.Writer)
+	}
 	if w.MetricFormat != nil && len(w.MetricFormat.ExtraLabels) > 0 {
 		w.MetricFormat.Labels = w.MetricFormat.ExtraLabels
 		w.MetricFormat.ExtraLabels = nil
@@ -285,7 +296,7 @@ type clientConfig struct {
 	Password        string    `yaml:"password,omitempty"`
 	BearerToken     string    `yaml:"bearer_token,omitempty"`
 	BearerTokenFile string    `yaml:"bearer_token_file,omitempty"`
-	VerifyTLS       bool      `yaml:"verify_tls,omitempty"`
+	VerifyTLS       *bool     `yaml:"verify_tls,omitempty"`
 	TLSCertFile     string    `yaml:"tls_cert_file,omitempty"`
 	TLSKeyFile      string    `yaml:"tls_key_file,omitempty"`
 }
@@ -298,7 +309,7 @@ func (c *clientConfig) override(cr *vmv1.VMAnomaly, cfg *vmv1.VMAnomalyHTTPClien
 		}
 		c.TLSCertFile = creds.CertFile
 		c.TLSKeyFile = creds.KeyFile
-		c.VerifyTLS = !cfg.TLSConfig.InsecureSkipVerify
+		c.VerifyTLS = ptr.To(!cfg.TLSConfig.InsecureSkipVerify)
 	}
 	if cfg.BasicAuth != nil {
 		creds, err := ac.BuildBasicAuthCreds(cr.Namespace, cfg.BasicAuth)
@@ -319,8 +330,27 @@ func (c *clientConfig) override(cr *vmv1.VMAnomaly, cfg *vmv1.VMAnomalyHTTPClien
 	return nil
 }
 
-// Load returns vmanomaly config merged with provided secrets
-func Load(cr *vmv1.VMAnomaly, ac *build.AssetsCache) ([]byte, error) {
+// withDatasource applies tenant, credentials and TLS settings of datasource resolved from ref
+//
+// explicitly defined TLS settings have priority over datasource settings
+func (c *clientConfig) withDatasource(ds *build.Datasource) {
+	c.TenantID = ds.TenantID
+	if ds.TLSConfig != nil && c.VerifyTLS == nil {
+		c.VerifyTLS = ptr.To(!ds.TLSConfig.InsecureSkipVerify)
+	}
+	if ds.BearerToken != "" {
+		c.BearerToken = ds.BearerToken
+	}
+	if ds.Username != "" {
+		c.User = ds.Username
+		c.Password = ds.Password
+	}
+}
+
+// Load returns vmanomaly config merged with provided secrets and selected objects
+//
+// Invalid selected objects are marked as broken at pos and skipped
+func Load(cr *vmv1.VMAnomaly, pos *ParsedObjects, ac *build.AssetsCache) ([]byte, error) {
 	var data []byte
 	switch {
 	case cr.Spec.ConfigSecret != nil:
@@ -331,15 +361,18 @@ func Load(cr *vmv1.VMAnomaly, ac *build.AssetsCache) ([]byte, error) {
 		data = []byte(secret)
 	case cr.Spec.ConfigRawYaml != "":
 		data = []byte(cr.Spec.ConfigRawYaml)
+	case !pos.isEmpty():
+		// configuration is built from selected objects only
 	default:
-		return nil, fmt.Errorf(`either "configRawYaml" or "configSecret" are required`)
+		return nil, fmt.Errorf(`either "configRawYaml", "configSecret" or selected VMAnomalyModel objects are required`)
 	}
 	c := &config{}
 	err := yaml.UnmarshalStrict(data, c)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal anomaly configuration, name=%q: %w", cr.Name, err)
 	}
-	if err = c.override(cr, ac); err != nil {
+	c.addObjects(pos)
+	if err = c.override(cr, pos, ac); err != nil {
 		return nil, fmt.Errorf("failed to update secret values with values from anomaly instance, name=%q: %w", cr.Name, err)
 	}
 	if err = c.validate(); err != nil {
@@ -351,3 +384,26 @@ func Load(cr *vmv1.VMAnomaly, ac *build.AssetsCache) ([]byte, error) {
 	}
 	return data, nil
 }
+
+// ModelQueries returns names of queries used by each model of the given vmanomaly configuration
+//
+// Models without explicitly defined queries use all reader queries
+func ModelQueries(data []byte) (map[string][]string, error) {
+	c := &config{}
+	if err := yaml.UnmarshalStrict(data, c); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal anomaly configuration: %w", err)
+	}
+	var readerQueries []string
+	if c.Reader != nil {
+		readerQueries = slices.Sorted(maps.Keys(c.Reader.Queries))
+	}
+	output := make(map[string][]string, len(c.Models))
+	for name, m := range c.Models {
+		queries := m.queries()
+		if len(queries) == 0 {
+			queries = readerQueries
+		}
+		output[name] = queries
+	}
+	return output, nil
+}
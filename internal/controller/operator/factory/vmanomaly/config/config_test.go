@@ -39,7 +39,7 @@ func TestLoad(t *testing.T) {
 			},
 		}
 		ac := build.NewAssetsCache(ctx, fclient, cfg)
-		loaded, err := Load(o.cr, ac)
+		loaded, err := Load(o.cr, nil, ac)
 		if o.wantErr {
 			assert.Error(t, err)
 			return
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+)
+
+// ParsedObjects contains VMAnomalyModel, VMAnomalyScheduler and VMAnomalyQuery objects selected by VMAnomaly
+// and datasources resolved from reader and writer refs
+type ParsedObjects struct {
+	Models     *build.ChildObjects[*vmv1.VMAnomalyModel]
+	Schedulers *build.ChildObjects[*vmv1.VMAnomalyScheduler]
+	Queries    *build.ChildObjects[*vmv1.VMAnomalyQuery]
+	Reader     *build.Datasource
+	Writer     *build.Datasource
+}
+
+func (pos *ParsedObjects) isEmpty() bool {
+	return pos == nil || len(pos.Models.All())+len(pos.Schedulers.All())+len(pos.Queries.All()) == 0
+}
+
+// addObjects validates selected objects with typed configuration structs
+// and adds valid objects into configuration.
+//
+// Objects are added with <namespace>-<name> keys,
+// references of VMAnomalyModel are resolved within the model namespace
+func (c *config) addObjects(pos *ParsedObjects) {
+	if pos == nil {
+		return
+	}
+	queryKeys := make(map[string]string)
+	pos.Queries.ForEachCollectSkipInvalid(func(o *vmv1.VMAnomalyQuery) error {
+		if !build.MustSkipRuntimeValidation() {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+		}
+		key := o.AsKey(false)
+		if c.Reader != nil {
+			if _, ok := c.Reader.Queries[key]; ok {
+				return fmt.Errorf("reader.queries already contains query=%q", key)
+			}
+		}
+		data, err := yaml.Marshal(o.Spec)
+		if err != nil {
+			return fmt.Errorf("cannot marshal query: %w", err)
+		}
+		var q readerQuery
+		if err := yaml.UnmarshalStrict(data, &q); err != nil {
+			return fmt.Errorf("cannot parse query: %w", err)
+		}
+		if err := validateDataRange(q.DataRange); err != nil {
+			return err
+		}
+		if c.Reader == nil {
+			c.Reader = &reader{}
+		}
+		if c.Reader.Queries == nil {
+			c.Reader.Queries = make(map[string]readerQuery)
+		}
+		c.Reader.Queries[key] = q
+		queryKeys[o.Namespace+"/"+o.Name] = key
+		return nil
+	})
+	schedulerKeys := make(map[string]string)
+	pos.Schedulers.ForEachCollectSkipInvalid(func(o *vmv1.VMAnomalyScheduler) error {
+		if !build.MustSkipRuntimeValidation() {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+		}
+		key := o.AsKey(false)
+		if _, ok := c.Schedulers[key]; ok {
+			return fmt.Errorf("schedulers already contains scheduler=%q", key)
+		}
+		var s scheduler
+		if err := unmarshalWithParams(&s, o.Spec.Class, o.Spec.Params); err != nil {
+			return err
+		}
+		if err := s.validate(); err != nil {
+			return err
+		}
+		if c.Schedulers == nil {
+			c.Schedulers = make(map[string]*scheduler)
+		}
+		c.Schedulers[key] = &s
+		schedulerKeys[o.Namespace+"/"+o.Name] = key
+		return nil
+	})
+	pos.Models.ForEachCollectSkipInvalid(func(o *vmv1.VMAnomalyModel) error {
+		if !build.MustSkipRuntimeValidation() {
+			if err := o.Validate(); err != nil {
+				return err
+			}
+		}
+		key := o.AsKey(false)
+		if _, ok := c.Models[key]; ok {
+			return fmt.Errorf("models already contains model=%q", key)
+		}
+		queries, err := resolveRefs("VMAnomalyQuery", o.Namespace, o.Spec.Queries, queryKeys)
+		if err != nil {
+			return err
+		}
+		schedulers, err := resolveRefs("VMAnomalyScheduler", o.Namespace, o.Spec.Schedulers, schedulerKeys)
+		if err != nil {
+			return err
+		}
+		var refs yaml.MapSlice
+		if len(queries) > 0 {
+			refs = append(refs, yaml.MapItem{Key: "queries", Value: queries})
+		}
+		if len(schedulers) > 0 {
+			refs = append(refs, yaml.MapItem{Key: "schedulers", Value: schedulers})
+		}
+		var m model
+		if err := unmarshalWithParams(&m, o.Spec.Class, o.Spec.Params, refs...); err != nil {
+			return err
+		}
+		if err := m.validate(); err != nil {
+			return err
+		}
+		if c.Models == nil {
+			c.Models = make(map[string]*model)
+		}
+		c.Models[key] = &m
+		return nil
+	})
+}
+
+// resolveRefs converts names of objects at the given namespace into configuration keys
+func resolveRefs(kind, namespace string, refs []string, keys map[string]string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	resolved := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		key, ok := keys[namespace+"/"+ref]
+		if !ok {
+			return nil, fmt.Errorf("references missing or invalid %s=%s/%s", kind, namespace, ref)
+		}
+		resolved = append(resolved, key)
+	}
+	return resolved, nil
+}
+
+// unmarshalWithParams unmarshals class, params and extra items into dst with typed validation of params
+func unmarshalWithParams(dst yaml.Unmarshaler, class string, params map[string]apiextensionsv1.JSON, extra ...yaml.MapItem) error {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	values := yaml.MapSlice{{Key: "class", Value: class}}
+	for _, name := range names {
+		var v any
+		if err := json.Unmarshal(params[name].Raw, &v); err != nil {
+			return fmt.Errorf("cannot parse params.%s: %w", name, err)
+		}
+		values = append(values, yaml.MapItem{Key: name, Value: v})
+	}
+	values = append(values, extra...)
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("cannot marshal params: %w", err)
+	}
+	if err := yaml.UnmarshalStrict(data, dst); err != nil {
+		return fmt.Errorf("cannot parse params: %w", err)
+	}
+	return nil
+}
@@ -36,22 +36,8 @@ func (r *reader) validate() error {
 	if r.SamplingPeriod == nil {
 		return fmt.Errorf(`"sampling_period" is required`)
 	}
-	if len(r.DataRange) > 0 {
-		if len(r.DataRange) == 2 {
-			v1, err := strconv.ParseFloat(r.DataRange[0], 64)
-			if err != nil {
-				return fmt.Errorf(`cannot parse first value in "data_range": %w`, err)
-			}
-			v2, err := strconv.ParseFloat(r.DataRange[1], 64)
-			if err != nil {
-				return fmt.Errorf(`cannot parse second value in "data_range": %w`, err)
-			}
-			if v1 > v2 {
-				return fmt.Errorf(`first value in "data_range" should be smaller than second`)
-			}
-		} else {
-			return fmt.Errorf(`only two values are expected in "data_range", got %d`, len(r.DataRange))
-		}
+	if err := validateDataRange(r.DataRange); err != nil {
+		return err
 	}
 	return nil
 }
@@ -64,3 +50,25 @@ type readerQuery struct {
 	TZ                time.Location `yaml:"tz,omitempty"`
 	TenantID          string        `yaml:"tenant_id,omitempty"`
 }
+
+// validateDataRange checks if data_range contains exactly two ordered float values
+func validateDataRange(dataRange []string) error {
+	if len(dataRange) == 0 {
+		return nil
+	}
+	if len(dataRange) != 2 {
+		return fmt.Errorf(`only two values are expected in "data_range", got %d`, len(dataRange))
+	}
+	v1, err := strconv.ParseFloat(dataRange[0], 64)
+	if err != nil {
+		return fmt.Errorf(`cannot parse first value in "data_range": %w`, err)
+	}
+	v2, err := strconv.ParseFloat(dataRange[1], 64)
+	if err != nil {
+		return fmt.Errorf(`cannot parse second value in "data_range": %w`, err)
+	}
+	if v1 > v2 {
+		return fmt.Errorf(`first value in "data_range" should be smaller than second`)
+	}
+	return nil
+}
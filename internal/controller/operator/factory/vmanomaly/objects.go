@@ -0,0 +1,118 @@
+package vmanomaly
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmanomaly/config"
+)
+
+// selectObjects returns VMAnomalyModel, VMAnomalyScheduler and VMAnomalyQuery objects matched by VMAnomaly selectors
+func selectObjects(ctx context.Context, rclient client.Client, cr *vmv1.VMAnomaly) (*config.ParsedObjects, error) {
+	opts := &k8stools.SelectorOpts{
+		ObjectSelector:    cr.Spec.ModelSelector,
+		NamespaceSelector: cr.Spec.ModelNamespaceSelector,
+		DefaultNamespace:  cr.Namespace,
+	}
+	models, modelsNsn, err := selectChildObjects(ctx, rclient, opts, func(l *vmv1.VMAnomalyModelList) []vmv1.VMAnomalyModel { return l.Items })
+	if err != nil {
+		return nil, fmt.Errorf("cannot select VMAnomalyModel objects: %w", err)
+	}
+
+	opts = &k8stools.SelectorOpts{
+		ObjectSelector:    cr.Spec.SchedulerSelector,
+		NamespaceSelector: cr.Spec.SchedulerNamespaceSelector,
+		DefaultNamespace:  cr.Namespace,
+	}
+	schedulers, schedulersNsn, err := selectChildObjects(ctx, rclient, opts, func(l *vmv1.VMAnomalySchedulerList) []vmv1.VMAnomalyScheduler { return l.Items })
+	if err != nil {
+		return nil, fmt.Errorf("cannot select VMAnomalyScheduler objects: %w", err)
+	}
+
+	opts = &k8stools.SelectorOpts{
+		ObjectSelector:    cr.Spec.QuerySelector,
+		NamespaceSelector: cr.Spec.QueryNamespaceSelector,
+		DefaultNamespace:  cr.Namespace,
+	}
+	queries, queriesNsn, err := selectChildObjects(ctx, rclient, opts, func(l *vmv1.VMAnomalyQueryList) []vmv1.VMAnomalyQuery { return l.Items })
+	if err != nil {
+		return nil, fmt.Errorf("cannot select VMAnomalyQuery objects: %w", err)
+	}
+
+	return &config.ParsedObjects{
+		Models:     build.NewChildObjects("vmanomalymodel", models, modelsNsn),
+		Schedulers: build.NewChildObjects("vmanomalyscheduler", schedulers, schedulersNsn),
+		Queries:    build.NewChildObjects("vmanomalyquery", queries, queriesNsn),
+	}, nil
+}
+
+// selectChildObjects returns not deleted objects matched by the given selectors and their namespace/name keys.
+// items returns items of the listed objects
+func selectChildObjects[T any, PT interface {
+	*T
+	client.Object
+	DeepCopy() *T
+}, L any, PL interface {
+	*L
+	client.ObjectList
+}](ctx context.Context, rclient client.Client, opts *k8stools.SelectorOpts, items func(PL) []T) ([]PT, []string, error) {
+	var objects []PT
+	var nsn []string
+	if err := k8stools.VisitSelected(ctx, rclient, opts, func(list PL) {
+		listItems := items(list)
+		for i := range listItems {
+			item := PT(&listItems[i])
+			if !item.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+			objects = append(objects, item.DeepCopy())
+			nsn = append(nsn, fmt.Sprintf("%s/%s", item.GetNamespace(), item.GetName()))
+		}
+	}); err != nil {
+		return nil, nil, err
+	}
+	return objects, nsn, nil
+}
+
+// updateObjectsStatus updates metrics and statuses of selected objects
+func updateObjectsStatus(ctx context.Context, rclient client.Client, cr *vmv1.VMAnomaly, pos *config.ParsedObjects) error {
+	pos.Models.UpdateMetrics(ctx)
+	pos.Schedulers.UpdateMetrics(ctx)
+	pos.Queries.UpdateMetrics(ctx)
+	parentObject := fmt.Sprintf("%s.%s.vmanomaly", cr.Name, cr.Namespace)
+	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, pos.Queries.All()); err != nil {
+		return fmt.Errorf("cannot update status for VMAnomalyQuery objects: %w", err)
+	}
+	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, pos.Schedulers.All()); err != nil {
+		return fmt.Errorf("cannot update status for VMAnomalyScheduler objects: %w", err)
+	}
+	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, pos.Models.All()); err != nil {
+		return fmt.Errorf("cannot update status for VMAnomalyModel objects: %w", err)
+	}
+	return nil
+}
+
+// resolveRefs resolves reader and writer refs into datasources
+func resolveRefs(ctx context.Context, rclient client.Client, cr *vmv1.VMAnomaly, ac *build.AssetsCache, pos *config.ParsedObjects) error {
+	if r := cr.Spec.Reader; r != nil && r.Ref != nil {
+		ds, err := build.ResolveDatasourceRef(ctx, rclient, ac, cr.Namespace, r.Ref, build.DatasourceRead, r.TenantID)
+		if err != nil {
+			return fmt.Errorf("cannot resolve spec.reader.ref: %w", err)
+		}
+		pos.Reader = ds
+	}
+	if w := cr.Spec.Writer; w != nil && w.Ref != nil {
+		ds, err := build.ResolveDatasourceRef(ctx, rclient, ac, cr.Namespace, w.Ref, build.DatasourceWrite, w.TenantID)
+		if err != nil {
+			return fmt.Errorf("cannot resolve spec.writer.ref: %w", err)
+		}
+		pos.Writer = ds
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+package vmanomaly
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmanomaly/config"
+)
+
+func TestLoadConfigWithObjects(t *testing.T) {
+	type opts struct {
+		predefinedObjects []runtime.Object
+		want              string
+		wantBroken        map[string]string
+		wantErr           bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		cr := &vmv1.VMAnomaly{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "monitoring",
+			},
+			Spec: vmv1.VMAnomalySpec{
+				License: &vmv1beta1.License{
+					Key: ptr.To("test"),
+				},
+				ModelSelector:              &metav1.LabelSelector{},
+				ModelNamespaceSelector:     &metav1.LabelSelector{},
+				SchedulerSelector:          &metav1.LabelSelector{},
+				SchedulerNamespaceSelector: &metav1.LabelSelector{},
+				QuerySelector:              &metav1.LabelSelector{},
+				QueryNamespaceSelector:     &metav1.LabelSelector{},
+				Reader: &vmv1.VMAnomalyReadersSpec{
+					DatasourceURL:  "http://read.endpoint",
+					SamplingPeriod: "1m",
+				},
+				Writer: &vmv1.VMAnomalyWritersSpec{
+					DatasourceURL: "http://write.endpoint",
+				},
+			},
+		}
+		fclient := k8stools.GetTestClientWithObjects(o.predefinedObjects)
+		build.AddDefaults(fclient.Scheme())
+		fclient.Scheme().Default(cr)
+		ctx := context.TODO()
+		ac := build.NewAssetsCache(ctx, fclient, map[build.ResourceKind]*build.ResourceCfg{
+			build.TLSAssetsResourceKind: {
+				MountDir:   tlsAssetsDir,
+				SecretName: build.ResourceName(build.TLSAssetsResourceKind, cr),
+			},
+		})
+		pos, err := selectObjects(ctx, fclient, cr)
+		assert.NoError(t, err)
+		data, err := config.Load(cr, pos, ac)
+		broken := make(map[string]string)
+		for _, o := range pos.Models.Broken() {
+			broken["model/"+o.Namespace+"/"+o.Name] = o.Status.CurrentSyncError
+		}
+		for _, o := range pos.Schedulers.Broken() {
+			broken["scheduler/"+o.Namespace+"/"+o.Name] = o.Status.CurrentSyncError
+		}
+		for _, o := range pos.Queries.Broken() {
+			broken["query/"+o.Namespace+"/"+o.Name] = o.Status.CurrentSyncError
+		}
+		if len(o.wantBroken) == 0 {
+			assert.Empty(t, broken)
+		} else {
+			assert.Equal(t, o.wantBroken, broken)
+		}
+		if o.wantErr {
+			assert.Error(t, err)
+			return
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, o.want, string(data))
+	}
+	params := func(kv ...string) map[string]apiextensionsv1.JSON {
+		m := make(map[string]apiextensionsv1.JSON)
+		for i := 0; i < len(kv); i += 2 {
+			m[kv[i]] = apiextensionsv1.JSON{Raw: []byte(kv[i+1])}
+		}
+		return m
+	}
+	query := func(namespace, name, expr string) *vmv1.VMAnomalyQuery {
+		return &vmv1.VMAnomalyQuery{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec:       vmv1.VMAnomalyQuerySpec{Expr: expr},
+		}
+	}
+	periodic := func(namespace, name string) *vmv1.VMAnomalyScheduler {
+		return &vmv1.VMAnomalyScheduler{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: vmv1.VMAnomalySchedulerSpec{
+				Class:  "periodic",
+				Params: params("fit_every", `"1h"`, "fit_window", `"2d"`, "infer_every", `"1m"`),
+			},
+		}
+	}
+
+	// objects from multiple namespaces
+	f(opts{
+		predefinedObjects: []runtime.Object{
+			query("team-a", "cpu", "rate(cpu_seconds_total)"),
+			query("team-b", "mem", "mem_bytes"),
+			periodic("team-a", "hourly"),
+			periodic("team-b", "hourly"),
+			&vmv1.VMAnomalyModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "zscore", Namespace: "team-a"},
+				Spec: vmv1.VMAnomalyModelSpec{
+					Class:      "zscore",
+					Queries:    []string{"cpu"},
+					Schedulers: []string{"hourly"},
+					Params:     params("z_threshold", "2.5"),
+				},
+			},
+			&vmv1.VMAnomalyModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "mad", Namespace: "team-b"},
+				Spec: vmv1.VMAnomalyModelSpec{
+					Class:      "mad",
+					Queries:    []string{"mem"},
+					Schedulers: []string{"hourly"},
+				},
+			},
+		},
+		want: `models:
+  team-a-zscore:
+    class: zscore
+    queries:
+    - team-a-cpu
+    schedulers:
+    - team-a-hourly
+    z_threshold: 2.5
+  team-b-mad:
+    class: mad
+    queries:
+    - team-b-mem
+    schedulers:
+    - team-b-hourly
+schedulers:
+  team-a-hourly:
+    class: periodic
+    fit_every: 1h
+    fit_window: 2d
+    infer_every: 1m
+  team-b-hourly:
+    class: periodic
+    fit_every: 1h
+    fit_window: 2d
+    infer_every: 1m
+reader:
+  class: vm
+  datasource_url: http://read.endpoint
+  sampling_period: 1m
+  queries:
+    team-a-cpu:
+      expr: rate(cpu_seconds_total)
+    team-b-mem:
+      expr: mem_bytes
+writer:
+  class: vm
+  datasource_url: http://write.endpoint
+monitoring:
+  pull:
+    port: "8080"
+`,
+	})
+
+	// invalid objects are skipped
+	f(opts{
+		predefinedObjects: []runtime.Object{
+			query("team-a", "cpu", "rate(cpu_seconds_total)"),
+			periodic("team-a", "hourly"),
+			&vmv1.VMAnomalyScheduler{
+				ObjectMeta: metav1.ObjectMeta{Name: "once", Namespace: "team-a"},
+				Spec: vmv1.VMAnomalySchedulerSpec{
+					Class: "oneoff",
+				},
+			},
+			&vmv1.VMAnomalyModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "zscore", Namespace: "team-a"},
+				Spec: vmv1.VMAnomalyModelSpec{
+					Class:      "zscore",
+					Queries:    []string{"cpu"},
+					Schedulers: []string{"hourly"},
+				},
+			},
+			&vmv1.VMAnomalyModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "unknown-param", Namespace: "team-a"},
+				Spec: vmv1.VMAnomalyModelSpec{
+					Class:   "zscore",
+					Queries: []string{"cpu"},
+					Params:  params("unknown", "1"),
+				},
+			},
+			&vmv1.VMAnomalyModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "missing-query", Namespace: "team-b"},
+				Spec: vmv1.VMAnomalyModelSpec{
+					Class:   "zscore",
+					Queries: []string{"cpu"},
+				},
+			},
+			&vmv1.VMAnomalyModel{
+				ObjectMeta: metav1.ObjectMeta{Name: "bad-decay", Namespace: "team-a"},
+				Spec: vmv1.VMAnomalyModelSpec{
+					Class:   "zscore_online",
+					Queries: []string{"cpu"},
+					Params:  params("decay", "2"),
+				},
+			},
+		},
+		wantBroken: map[string]string{
+			"scheduler/team-a/once":      `either "infer_start_iso" or "infer_start_s" should be set`,
+			"model/team-a/unknown-param": "cannot parse params: yaml: unmarshal errors:\n  line 2: field unknown not found in type config.zScoreModel",
+			"model/team-b/missing-query": "references missing or invalid VMAnomalyQuery=team-b/cpu",
+			"model/team-a/bad-decay":     "decay must be in range [0, 1], got 2.000000",
+		},
+		want: `models:
+  team-a-zscore:
+    class: zscore
+    queries:
+    - team-a-cpu
+    schedulers:
+    - team-a-hourly
+schedulers:
+  team-a-hourly:
+    class: periodic
+    fit_every: 1h
+    fit_window: 2d
+    infer_every: 1m
+reader:
+  class: vm
+  datasource_url: http://read.endpoint
+  sampling_period: 1m
+  queries:
+    team-a-cpu:
+      expr: rate(cpu_seconds_total)
+writer:
+  class: vm
+  datasource_url: http://write.endpoint
+monitoring:
+  pull:
+    port: "8080"
+`,
+	})
+
+	// no selected objects and raw configuration
+	f(opts{
+		wantErr: true,
+	})
+}
@@ -34,6 +34,11 @@ func buildScrape(cr *vmv1.VMAnomaly) *vmv1beta1.VMPodScrape {
 
 // CreateOrUpdate creates vmanomalyand and builds config for it
 func CreateOrUpdate(ctx context.Context, cr *vmv1.VMAnomaly, rclient client.Client) error {
+	if !build.MustSkipRuntimeValidation() {
+		if err := cr.Validate(); err != nil {
+			return build.NewValidationError(err)
+		}
+	}
 	var prevCR *vmv1.VMAnomaly
 	if cr.Status.LastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
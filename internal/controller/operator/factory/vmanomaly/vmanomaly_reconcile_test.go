@@ -88,6 +88,9 @@ func Test_CreateOrUpdate_Actions(t *testing.T) {
 		cr: &vmv1.VMAnomaly{
 			ObjectMeta: objectMeta,
 			Spec: vmv1.VMAnomalySpec{
+				License: &vmv1beta1.License{
+					Key: ptr.To("test"),
+				},
 				ConfigRawYaml: `
 models:
   M1:
@@ -137,6 +140,9 @@ schedulers:
 		cr: &vmv1.VMAnomaly{
 			ObjectMeta: objectMeta,
 			Spec: vmv1.VMAnomalySpec{
+				License: &vmv1beta1.License{
+					Key: ptr.To("test"),
+				},
 				CommonApplicationDeploymentParams: vmv1beta1.CommonApplicationDeploymentParams{
 					ReplicaCount: ptr.To(int32(1)),
 				},
@@ -0,0 +1,250 @@
+package vmauth
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// routingURLPrefix holds url_prefix or default_url value,
+// which could be defined either as a string or a list of strings
+type routingURLPrefix []string
+
+// UnmarshalYAML implements yaml.Unmarshaler interface
+func (up *routingURLPrefix) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err == nil {
+		*up = []string{s}
+		return nil
+	}
+	var ss []string
+	if err := unmarshal(&ss); err != nil {
+		return err
+	}
+	*up = ss
+	return nil
+}
+
+// routingRegex holds src_paths and src_hosts entry,
+// vmauth matches the whole value against regex
+type routingRegex struct {
+	re *regexp.Regexp
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface
+func (r *routingRegex) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	re, err := newRoutingRegex(s)
+	if err != nil {
+		return err
+	}
+	*r = *re
+	return nil
+}
+
+func newRoutingRegex(s string) (*routingRegex, error) {
+	re, err := regexp.Compile("^(?:" + s + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("cannot build regexp from %q: %w", s, err)
+	}
+	return &routingRegex{re: re}, nil
+}
+
+func (r *routingRegex) match(s string) bool {
+	return r.re.MatchString(s)
+}
+
+// routingQueryArg holds src_query_args entry in `name=value` format.
+// vmauth compares value for exact match, value prefixed with `~` is matched as regex.
+// Entry without `=` never matches
+type routingQueryArg struct {
+	name  string
+	value *routingRegex
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface
+func (qa *routingQueryArg) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	name, expr, ok := strings.Cut(s, "=")
+	if !ok {
+		return nil
+	}
+	if v, ok := strings.CutPrefix(expr, "~"); ok {
+		expr = v
+	} else {
+		expr = regexp.QuoteMeta(expr)
+	}
+	re, err := newRoutingRegex(expr)
+	if err != nil {
+		return fmt.Errorf("cannot unmarshal regex for %q query arg: %w", name, err)
+	}
+	qa.name = name
+	qa.value = re
+	return nil
+}
+
+// routingHeader holds src_headers entry in `Name: value` format,
+// vmauth compares header value for exact match
+type routingHeader struct {
+	name  string
+	value string
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler interface
+func (h *routingHeader) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	name, value, ok := strings.Cut(s, ":")
+	if !ok {
+		return fmt.Errorf("missing separator char ':' between Name and Value in the header %q; expected format - 'Name: Value'", s)
+	}
+	h.name = strings.TrimSpace(name)
+	h.value = strings.TrimSpace(value)
+	return nil
+}
+
+type routingURLMap struct {
+	SrcPaths     []*routingRegex    `yaml:"src_paths,omitempty"`
+	SrcHosts     []*routingRegex    `yaml:"src_hosts,omitempty"`
+	SrcQueryArgs []*routingQueryArg `yaml:"src_query_args,omitempty"`
+	SrcHeaders   []*routingHeader   `yaml:"src_headers,omitempty"`
+	URLPrefix    routingURLPrefix   `yaml:"url_prefix,omitempty"`
+}
+
+type routingUserConfig struct {
+	URLMaps    []routingURLMap  `yaml:"url_map,omitempty"`
+	URLPrefix  routingURLPrefix `yaml:"url_prefix,omitempty"`
+	DefaultURL routingURLPrefix `yaml:"default_url,omitempty"`
+}
+
+// evaluateUserTests routes spec.tests requests of given user with user config rendered for the given VMAuth
+// it follows vmauth routing rules:
+// url_map entries are checked in order and the first matching entry wins,
+// url_prefix is used if none of url_map entries match,
+// default_url is used if url_prefix is missing.
+func evaluateUserTests(user *vmv1beta1.VMUser, vmauth string, userCfg yaml.MapSlice) ([]vmv1beta1.VMUserRoutingTestResult, error) {
+	if len(user.Spec.Tests) == 0 {
+		return nil, nil
+	}
+	data, err := yaml.Marshal(userCfg)
+	if err != nil {
+		return nil, fmt.Errorf("cannot serialize user config: %w", err)
+	}
+	var cfg routingUserConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("cannot parse user config: %w", err)
+	}
+	results := make([]vmv1beta1.VMUserRoutingTestResult, 0, len(user.Spec.Tests))
+	for _, rt := range user.Spec.Tests {
+		result, err := cfg.route(&rt)
+		if err != nil {
+			result = vmv1beta1.VMUserRoutingTestResult{Error: err.Error()}
+		}
+		result.Name = rt.Name
+		result.VMAuth = vmauth
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (cfg *routingUserConfig) route(rt *vmv1beta1.VMUserRoutingTest) (vmv1beta1.VMUserRoutingTestResult, error) {
+	var result vmv1beta1.VMUserRoutingTestResult
+	u, err := url.ParseRequestURI(rt.Path)
+	if err != nil {
+		return result, fmt.Errorf("cannot parse path=%q: %w", rt.Path, err)
+	}
+	headers := make(http.Header, len(rt.Headers))
+	for k, v := range rt.Headers {
+		headers.Set(k, v)
+	}
+	// vmauth matches src_hosts against Host header as is, including port
+	path := normalizePath(u.Path)
+	query := u.Query()
+	for idx, um := range cfg.URLMaps {
+		if !um.match(rt.Host, path, query, headers) {
+			continue
+		}
+		result.Route = fmt.Sprintf("url_map[%d]", idx)
+		result.Backends = um.URLPrefix
+		return result, nil
+	}
+	switch {
+	case len(cfg.URLPrefix) > 0:
+		result.Route = "url_prefix"
+		result.Backends = cfg.URLPrefix
+	case len(cfg.DefaultURL) > 0:
+		result.Route = "default_url"
+		result.Backends = cfg.DefaultURL
+	default:
+		return result, fmt.Errorf("missing route for request host=%q path=%q", rt.Host, rt.Path)
+	}
+	return result, nil
+}
+
+// normalizePath cleans request path the same way as vmauth does before routing
+func normalizePath(p string) string {
+	cleaned := path.Clean(p)
+	if cleaned == "." {
+		cleaned = "/"
+	}
+	if !strings.HasSuffix(cleaned, "/") && strings.HasSuffix(p, "/") {
+		cleaned += "/"
+	}
+	if !strings.HasPrefix(cleaned, "/") {
+		cleaned = "/" + cleaned
+	}
+	if cleaned == "/" {
+		return ""
+	}
+	return cleaned
+}
+
+func (um *routingURLMap) match(host, path string, query url.Values, headers http.Header) bool {
+	return matchAnyRegex(um.SrcHosts, host) &&
+		matchAnyRegex(um.SrcPaths, path) &&
+		matchAnyQueryArg(um.SrcQueryArgs, query) &&
+		matchAnyHeader(um.SrcHeaders, headers)
+}
+
+func matchAnyRegex(rs []*routingRegex, s string) bool {
+	if len(rs) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(rs, func(r *routingRegex) bool {
+		return r.match(s)
+	})
+}
+
+func matchAnyQueryArg(qas []*routingQueryArg, query url.Values) bool {
+	if len(qas) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(qas, func(qa *routingQueryArg) bool {
+		return qa.value != nil && slices.ContainsFunc(query[qa.name], qa.value.match)
+	})
+}
+
+func matchAnyHeader(hs []*routingHeader, headers http.Header) bool {
+	if len(hs) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(hs, func(h *routingHeader) bool {
+		return slices.Contains(headers.Values(h.name), h.value)
+	})
+}
@@ -0,0 +1,250 @@
+package vmauth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func Test_evaluateUserTests(t *testing.T) {
+	type opts struct {
+		targetRefs  []vmv1beta1.TargetRef
+		defaultURLs []string
+		tests       []vmv1beta1.VMUserRoutingTest
+		want        []vmv1beta1.VMUserRoutingTestResult
+	}
+	f := func(o opts) {
+		t.Helper()
+		cr := &vmv1beta1.VMAuth{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-auth",
+				Namespace: "default",
+			},
+		}
+		user := &vmv1beta1.VMUser{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "user",
+				Namespace: "default",
+			},
+			Spec: vmv1beta1.VMUserSpec{
+				BearerToken: ptr.To("token"),
+				TargetRefs:  o.targetRefs,
+				VMUserConfigOptions: vmv1beta1.VMUserConfigOptions{
+					DefaultURLs: o.defaultURLs,
+				},
+				Tests: o.tests,
+			},
+		}
+		ctx := context.TODO()
+		fclient := k8stools.GetTestClientWithObjects(nil)
+		ac := getAssetsCache(ctx, fclient, cr)
+		userCfg, err := genUserCfg(user, nil, cr, ac)
+		assert.NoError(t, err)
+		got, err := evaluateUserTests(user, "default/test-auth", userCfg)
+		assert.NoError(t, err)
+		for i := range o.want {
+			o.want[i].VMAuth = "default/test-auth"
+		}
+		assert.Equal(t, o.want, got)
+	}
+
+	// no tests
+	f(opts{
+		targetRefs: []vmv1beta1.TargetRef{
+			{Static: &vmv1beta1.StaticRef{URL: "http://vmsingle:8428"}},
+		},
+	})
+
+	// default route
+	f(opts{
+		targetRefs: []vmv1beta1.TargetRef{
+			{Static: &vmv1beta1.StaticRef{URL: "http://vmsingle:8428"}},
+		},
+		tests: []vmv1beta1.VMUserRoutingTest{
+			{Name: "query", Path: "/api/v1/query?query=up"},
+		},
+		want: []vmv1beta1.VMUserRoutingTestResult{
+			{Name: "query", Route: "url_prefix", Backends: []string{"http://vmsingle:8428"}},
+		},
+	})
+
+	// url_map with hosts, headers and query args
+	f(opts{
+		targetRefs: []vmv1beta1.TargetRef{
+			{
+				Static: &vmv1beta1.StaticRef{URL: "http://vminsert:8480"},
+				Paths:  []string{"/api/v1/write"},
+				Hosts:  []string{"insert\\..+"},
+			},
+			{
+				Static: &vmv1beta1.StaticRef{URLs: []string{"http://vmselect-0:8481", "http://vmselect-1:8481"}},
+				Paths:  []string{"/api/v1/query.*"},
+				URLMapCommon: vmv1beta1.URLMapCommon{
+					SrcHeaders: []string{"X-Tenant: team-a"},
+				},
+			},
+			{
+				Static: &vmv1beta1.StaticRef{URL: "http://vmselect-debug:8481"},
+				Paths:  []string{"/api/v1/query.*"},
+				URLMapCommon: vmv1beta1.URLMapCommon{
+					SrcQueryArgs: []string{"debug=~(1|true)"},
+				},
+			},
+		},
+		defaultURLs: []string{"http://default:8080"},
+		tests: []vmv1beta1.VMUserRoutingTest{
+			{Name: "write", Host: "insert.example.com:443", Path: "/api/v1/write"},
+			{Name: "write-wrong-host", Host: "select.example.com", Path: "/api/v1/write"},
+			{Name: "tenant", Path: "/api/v1/query_range", Headers: map[string]string{"x-tenant": "team-a"}},
+			{Name: "tenant-not-exact", Path: "/api/v1/query_range", Headers: map[string]string{"x-tenant": "team-ab"}},
+			{Name: "debug", Path: "/api/v1/query?debug=true"},
+			{Name: "partial-path", Path: "/prefix/api/v1/query?debug=true"},
+		},
+		want: []vmv1beta1.VMUserRoutingTestResult{
+			{Name: "write", Route: "url_map[0]", Backends: []string{"http://vminsert:8480"}},
+			{Name: "write-wrong-host", Route: "default_url", Backends: []string{"http://default:8080"}},
+			{Name: "tenant", Route: "url_map[1]", Backends: []string{"http://vmselect-0:8481", "http://vmselect-1:8481"}},
+			{Name: "tenant-not-exact", Route: "default_url", Backends: []string{"http://default:8080"}},
+			{Name: "debug", Route: "url_map[2]", Backends: []string{"http://vmselect-debug:8481"}},
+			{Name: "partial-path", Route: "default_url", Backends: []string{"http://default:8080"}},
+		},
+	})
+
+	// missing route
+	f(opts{
+		targetRefs: []vmv1beta1.TargetRef{
+			{
+				Static: &vmv1beta1.StaticRef{URL: "http://vminsert:8480"},
+				Paths:  []string{"/api/v1/write"},
+			},
+		},
+		tests: []vmv1beta1.VMUserRoutingTest{
+			{Name: "query", Path: "/api/v1/query"},
+		},
+		want: []vmv1beta1.VMUserRoutingTestResult{
+			{Name: "query", Error: `missing route for request host="" path="/api/v1/query"`},
+		},
+	})
+}
+
+// Test_routeParity checks routing against cases of upstream vmauth target_url_test.go
+func Test_routeParity(t *testing.T) {
+	f := func(cfgData string, rt vmv1beta1.VMUserRoutingTest, wantRoute string) {
+		t.Helper()
+		var cfg routingUserConfig
+		assert.NoError(t, yaml.Unmarshal([]byte(cfgData), &cfg))
+		got, err := cfg.route(&rt)
+		if wantRoute == "" {
+			assert.Error(t, err)
+			return
+		}
+		assert.NoError(t, err)
+		assert.Equal(t, wantRoute, got.Route)
+	}
+
+	// multiple url_map entries, the first matching entry wins
+	cfg := `
+url_map:
+- src_hosts: ["host42"]
+  src_paths: ["/vmsingle/api/v1/query"]
+  src_query_args: ["db=foo"]
+  url_prefix: http://vmselect/0/prometheus
+- src_paths: ["/api/v1/write"]
+  url_prefix: http://vminsert/0/prometheus
+- src_paths: ["/metrics", "/api/v1/write"]
+  url_prefix: http://metrics-server
+url_prefix: http://default-server
+`
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "host42", Path: "/vmsingle/api/v1/query?query=up&db=foo"}, "url_map[0]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "host123", Path: "/vmsingle/api/v1/query?query=up&db=foo"}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "host42", Path: "/vmsingle/api/v1/query?query=up"}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "foo-host", Path: "/api/v1/write"}, "url_map[1]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "foo-host", Path: "/foo/bar/api/v1/query_range"}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "foo-host", Path: "/metrics"}, "url_map[2]")
+
+	// regex is anchored at both sides
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "host420", Path: "/vmsingle/api/v1/query?db=foo"}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/write/extra"}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/prefix/api/v1/write"}, "url_prefix")
+
+	// path is normalized before matching
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/./api/v1/../v1/write"}, "url_map[1]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/write/"}, "url_prefix")
+
+	// regexp paths and hosts
+	cfg = `
+url_map:
+- src_paths: ["/api/v1/query(_range)?", "/api/v1/label/[^/]+/values"]
+  url_prefix: http://vmselect/0/prometheus
+- src_paths: ["/api/v1/write"]
+  url_prefix: http://vminsert/0/prometheus
+- src_hosts: ["vmui\\..+"]
+  url_prefix: http://vmui.host:1234/vmui/
+url_prefix: http://default-server
+`
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/query?query=up"}, "url_map[0]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/query_range?query=up"}, "url_map[0]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/label/foo/values"}, "url_map[0]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/label/foo/bar/values"}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/write"}, "url_map[1]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/foo/bar"}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "vmui.foobar.com", Path: "/a/b?c=d"}, "url_map[2]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "vmui", Path: "/a/b?c=d"}, "url_prefix")
+
+	// host is matched with port
+	cfg = `
+url_map:
+- src_hosts: ["vmui\\.example\\.com"]
+  url_prefix: http://vmui
+url_prefix: http://default-server
+`
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "vmui.example.com", Path: "/"}, "url_map[0]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Host: "vmui.example.com:8427", Path: "/"}, "url_prefix")
+
+	// query args are matched exactly unless value is prefixed with ~
+	cfg = `
+url_map:
+- src_paths: ["/api/v1/query"]
+  src_query_args: ['query=~.*{.*env="dev".*}*.']
+  url_prefix: http://vmselect/0/prometheus
+- src_paths: ["/api/v1/query"]
+  src_query_args: ['query=~.*{.*env="prod".*}.*']
+  url_prefix: http://vmselect/1/prometheus
+- src_query_args: ["db=foo.*", "missing-separator"]
+  url_prefix: http://vmselect/2/prometheus
+url_prefix: http://default-server
+`
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: `/api/v1/query?query=up{env="prod"}`}, "url_map[1]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: `/api/v1/query?query=up{foo="bar",env="dev",pod!=""}`}, "url_map[0]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: `/api/v1/query?query=up{foo="bar"}`}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/export?db=foo.*"}, "url_map[2]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/export?db=foobar"}, "url_prefix")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/export?missing-separator="}, "url_prefix")
+
+	// headers are matched exactly
+	cfg = `
+url_map:
+- src_headers: ["X-Tenant: team-a", "X-Env:prod"]
+  url_prefix: http://vmselect
+default_url: http://default-server
+`
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/", Headers: map[string]string{"x-tenant": "team-a"}}, "url_map[0]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/", Headers: map[string]string{"X-Env": "prod"}}, "url_map[0]")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/", Headers: map[string]string{"X-Tenant": "team-.*"}}, "default_url")
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/", Headers: map[string]string{"X-Tenant": "team-ab"}}, "default_url")
+
+	// missing route
+	cfg = `
+url_map:
+- src_paths: ["/api/v1/write"]
+  url_prefix: http://vminsert
+`
+	f(cfg, vmv1beta1.VMUserRoutingTest{Path: "/api/v1/query"}, "")
+}
@@ -42,6 +42,11 @@ const (
 
 // CreateOrUpdate - handles VMAuth deployment reconciliation.
 func CreateOrUpdate(ctx context.Context, cr *vmv1beta1.VMAuth, rclient client.Client) error {
+	if !build.MustSkipRuntimeValidation() {
+		if err := cr.Validate(); err != nil {
+			return build.NewValidationError(err)
+		}
+	}
 
 	var prevCR *vmv1beta1.VMAuth
 	if cr.Status.LastAppliedSpec != nil {
@@ -428,14 +433,18 @@ func CreateOrUpdateConfig(ctx context.Context, rclient client.Client, cr *vmv1be
 	pos.users.UpdateMetrics(ctx)
 
 	parentObject := fmt.Sprintf("%s.%s.vmauth", cr.GetName(), cr.GetNamespace())
+	users := pos.users.All()
 	if childObject != nil {
 		if u := pos.users.Get(childObject); u != nil {
-			return reconcile.StatusForChildObjects(ctx, rclient, parentObject, []*vmv1beta1.VMUser{u})
+			users = []*vmv1beta1.VMUser{u}
 		}
 	}
-	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, pos.users.All()); err != nil {
+	if err := reconcile.StatusForChildObjects(ctx, rclient, parentObject, users); err != nil {
 		return fmt.Errorf("cannot update statuses for vmusers: %w", err)
 	}
+	if err := reconcile.VMUserTestsStatus(ctx, rclient, cr, users); err != nil {
+		return fmt.Errorf("cannot update tests statuses for vmusers: %w", err)
+	}
 	return nil
 }
 
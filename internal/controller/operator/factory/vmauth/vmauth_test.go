@@ -145,6 +145,7 @@ func TestCreateOrUpdate(t *testing.T) {
 						LocalObjectReference: corev1.LocalObjectReference{
 							Name: "external-cfg",
 						},
+						Key: "config.yaml",
 					},
 				},
 			},
@@ -354,6 +354,10 @@ func (pos *parsedObjects) generateVMAuthConfig(cr *vmv1beta1.VMAuth, crdURLCache
 		if err != nil {
 			return err
 		}
+		user.Status.Tests, err = evaluateUserTests(user, cr.Namespace+"/"+cr.Name, userCfg)
+		if err != nil {
+			return fmt.Errorf("cannot evaluate spec.tests: %w", err)
+		}
 		cfgUsers = append(cfgUsers, userCfg)
 		return nil
 	})
@@ -525,10 +529,31 @@ func addUserConfigOptionToYaml(dst yaml.MapSlice, opt vmv1beta1.VMUserConfigOpti
 		},
 		)
 	}
-	if opt.MaxConcurrentRequests != nil {
+	maxConcurrentRequests := opt.MaxConcurrentRequests
+	if opt.RateLimit != nil {
+		if cr.SupportsUserRateLimits() {
+			dst = append(dst, yaml.MapItem{
+				Key:   "rate_limit",
+				Value: opt.RateLimit,
+			})
+		} else {
+			// older vmauth versions support only concurrency limits per user
+			// fallback to max_concurrent_requests, which has the same meaning as -maxConcurrentPerUserRequests flag
+			if len(opt.RateLimit.Paths) > 0 {
+				return nil, fmt.Errorf("rate_limit.paths is not supported by vmauth version=%q", cr.Spec.Image.Tag)
+			}
+			if maxConcurrentRequests == nil {
+				maxConcurrentRequests = opt.RateLimit.Burst
+				if maxConcurrentRequests == nil {
+					maxConcurrentRequests = opt.RateLimit.RequestsPerSecond
+				}
+			}
+		}
+	}
+	if maxConcurrentRequests != nil {
 		dst = append(dst, yaml.MapItem{
 			Key:   "max_concurrent_requests",
-			Value: *opt.MaxConcurrentRequests,
+			Value: *maxConcurrentRequests,
 		},
 		)
 	}
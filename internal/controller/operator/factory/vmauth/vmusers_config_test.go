@@ -735,6 +735,139 @@ bearer_token: secret-token
 	})
 }
 
+func Test_genUserCfgRateLimit(t *testing.T) {
+	type opts struct {
+		version string
+		user    *vmv1beta1.VMUser
+		want    string
+		wantErr bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		cr := &vmv1beta1.VMAuth{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-auth",
+				Namespace: "default",
+			},
+		}
+		cr.Spec.Image.Tag = o.version
+		ctx := context.TODO()
+		fclient := k8stools.GetTestClientWithObjects(nil)
+		ac := getAssetsCache(ctx, fclient, cr)
+		got, err := genUserCfg(o.user, nil, cr, ac)
+		if o.wantErr {
+			assert.Error(t, err)
+			return
+		}
+		assert.NoError(t, err)
+		szd, err := yaml.Marshal(got)
+		assert.NoError(t, err)
+		assert.Equal(t, o.want, string(szd))
+	}
+	newUser := func(rl *vmv1beta1.VMUserRateLimit) *vmv1beta1.VMUser {
+		return &vmv1beta1.VMUser{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "user",
+				Namespace: "default",
+			},
+			Spec: vmv1beta1.VMUserSpec{
+				BearerToken: ptr.To("token"),
+				TargetRefs: []vmv1beta1.TargetRef{
+					{
+						Static: &vmv1beta1.StaticRef{URL: "http://vmselect"},
+					},
+				},
+				VMUserConfigOptions: vmv1beta1.VMUserConfigOptions{
+					RateLimit: rl,
+				},
+			},
+		}
+	}
+
+	// supported version
+	f(opts{
+		version: "v1.137.0",
+		user: newUser(&vmv1beta1.VMUserRateLimit{
+			RequestsPerSecond: ptr.To(10),
+			Burst:             ptr.To(20),
+			Paths: []vmv1beta1.VMUserPathRateLimit{
+				{
+					SrcPaths:          []string{"/api/v1/query_range"},
+					RequestsPerSecond: ptr.To(1),
+				},
+			},
+		}),
+		want: `url_prefix:
+- http://vmselect
+rate_limit:
+  requests_per_second: 10
+  burst: 20
+  paths:
+  - src_paths:
+    - /api/v1/query_range
+    requests_per_second: 1
+bearer_token: token
+`,
+	})
+
+	// fallback to max_concurrent_requests for older version
+	f(opts{
+		version: "v1.136.0-enterprise",
+		user: newUser(&vmv1beta1.VMUserRateLimit{
+			RequestsPerSecond: ptr.To(10),
+			Burst:             ptr.To(20),
+		}),
+		want: `url_prefix:
+- http://vmselect
+max_concurrent_requests: 20
+bearer_token: token
+`,
+	})
+
+	// explicit max_concurrent_requests has priority over fallback
+	u := newUser(&vmv1beta1.VMUserRateLimit{
+		RequestsPerSecond: ptr.To(10),
+	})
+	u.Spec.MaxConcurrentRequests = ptr.To(5)
+	f(opts{
+		version: "v1.120.0",
+		user:    u,
+		want: `url_prefix:
+- http://vmselect
+max_concurrent_requests: 5
+bearer_token: token
+`,
+	})
+
+	// unknown version is treated as supported
+	f(opts{
+		version: "latest",
+		user: newUser(&vmv1beta1.VMUserRateLimit{
+			RequestsPerSecond: ptr.To(10),
+		}),
+		want: `url_prefix:
+- http://vmselect
+rate_limit:
+  requests_per_second: 10
+bearer_token: token
+`,
+	})
+
+	// per-path limits cannot be applied to older versions
+	f(opts{
+		version: "v1.136.0",
+		user: newUser(&vmv1beta1.VMUserRateLimit{
+			Paths: []vmv1beta1.VMUserPathRateLimit{
+				{
+					SrcPaths: []string{"/api/v1/query_range"},
+					Burst:    ptr.To(1),
+				},
+			},
+		}),
+		wantErr: true,
+	})
+}
+
 func Test_genPassword(t *testing.T) {
 	f := func() {
 		t.Helper()
@@ -37,7 +37,7 @@ import (
 func CreateOrUpdate(ctx context.Context, cr *vmv1beta1.VMCluster, rclient client.Client) error {
 	if !build.MustSkipRuntimeValidation() {
 		if err := cr.Validate(); err != nil {
-			return err
+			return build.NewValidationError(err)
 		}
 	}
 	cfg := config.MustGetBaseConfig()
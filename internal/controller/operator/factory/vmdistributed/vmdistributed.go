@@ -30,7 +30,7 @@ func CreateOrUpdate(ctx context.Context, cr *vmv1alpha1.VMDistributed, rclient c
 
 	if !build.MustSkipRuntimeValidation() {
 		if err := cr.Validate(); err != nil {
-			return err
+			return build.NewValidationError(err)
 		}
 	}
 	nsn := types.NamespacedName{
@@ -21,7 +21,7 @@ import (
 func CreateOrUpdate(ctx context.Context, rclient client.Client, cr *vmv1.VTCluster) error {
 	if !build.MustSkipRuntimeValidation() {
 		if err := cr.Validate(); err != nil {
-			return err
+			return build.NewValidationError(err)
 		}
 	}
 	owner := cr.AsOwner()
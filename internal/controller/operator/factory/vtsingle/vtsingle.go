@@ -61,6 +61,11 @@ func newPVC(r *vmv1.VTSingle) *corev1.PersistentVolumeClaim {
 
 // CreateOrUpdate performs an update for vtsingle resource
 func CreateOrUpdate(ctx context.Context, rclient client.Client, cr *vmv1.VTSingle) error {
+	if !build.MustSkipRuntimeValidation() {
+		if err := cr.Validate(); err != nil {
+			return build.NewValidationError(err)
+		}
+	}
 	var prevCR *vmv1.VTSingle
 	if cr.Status.LastAppliedSpec != nil {
 		prevCR = cr.DeepCopy()
@@ -0,0 +1,140 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+// reconcileWithMaintenanceWindows calls cb with context, which defers disruptive changes of child objects,
+// if none of maintenance windows is active. Operator default windows are used if windows are empty.
+// Deferred changes are published at status.pendingChanges of the given object.
+//
+// It returns duration until the start of the next window if any changes were deferred
+func reconcileWithMaintenanceWindows(ctx context.Context, rclient client.Client, obj client.Object, windows []vmv1beta1.MaintenanceWindow, prevPending *vmv1beta1.PendingChanges, cb func(ctx context.Context) error) (time.Duration, error) {
+	now := time.Now()
+	active, nextWindow, err := activeMaintenanceWindow(windows, now)
+	if err != nil {
+		return 0, err
+	}
+	if active {
+		if prevPending != nil {
+			// desired state of child objects matches the previous reconcile,
+			// so deferred changes must not be reported as manual changes
+			ctx = reconcile.WithoutDriftTracker(ctx)
+		}
+		if err := cb(ctx); err != nil {
+			return 0, err
+		}
+		if prevPending == nil {
+			return 0, nil
+		}
+		if err := reconcile.UpdateObjectPendingChanges(ctx, rclient, obj, nil); err != nil {
+			return 0, err
+		}
+		events.Normal(ctx, events.ReasonDeferredChangesApplied, fmt.Sprintf("%d deferred changes of child objects were applied within maintenance window", len(prevPending.Changes)))
+		return 0, nil
+	}
+
+	deferCtx := reconcile.WithMaintenanceDeferral(ctx)
+	if err := cb(deferCtx); err != nil {
+		return 0, err
+	}
+	changes := reconcile.DeferredChanges(deferCtx)
+	if len(changes) == 0 {
+		// deferred changes were reverted at custom resource
+		if prevPending != nil {
+			if err := reconcile.UpdateObjectPendingChanges(ctx, rclient, obj, nil); err != nil {
+				return 0, err
+			}
+		}
+		return 0, nil
+	}
+	var recreateCount int
+	for _, ch := range changes {
+		if ch.Action == vmv1beta1.PlannedActionRecreate {
+			recreateCount++
+		}
+	}
+	next := metav1.NewTime(nextWindow)
+	pending := &vmv1beta1.PendingChanges{
+		ObservedGeneration: obj.GetGeneration(),
+		Summary:            fmt.Sprintf("%d to update, %d to recreate", len(changes)-recreateCount, recreateCount),
+		NextWindow:         &next,
+		Changes:            changes,
+	}
+	if prevPending == nil || !equality.Semantic.DeepEqual(prevPending.Changes, pending.Changes) ||
+		prevPending.ObservedGeneration != pending.ObservedGeneration || !prevPending.NextWindow.Equal(pending.NextWindow) {
+		if err := reconcile.UpdateObjectPendingChanges(ctx, rclient, obj, pending); err != nil {
+			return 0, err
+		}
+	}
+	if prevPending == nil || !equality.Semantic.DeepEqual(prevPending.Changes, pending.Changes) {
+		events.Normal(ctx, events.ReasonChangesDeferred, fmt.Sprintf("disruptive changes of child objects were deferred until the next maintenance window at %s: %s", nextWindow.UTC().Format(time.RFC3339), pending.Summary))
+	}
+	return nextWindow.Sub(now), nil
+}
+
+// planWithMaintenanceWindows calls cb with context, which defers disruptive changes of child objects,
+// if none of maintenance windows is active. So reconcile plan contains only changes applied at the current reconcile.
+// Operator default windows are used if windows are empty.
+//
+// It returns duration until the start of the next window if any changes were deferred
+func planWithMaintenanceWindows(ctx context.Context, windows []vmv1beta1.MaintenanceWindow, cb func(ctx context.Context) error) (time.Duration, error) {
+	now := time.Now()
+	active, nextWindow, err := activeMaintenanceWindow(windows, now)
+	if err != nil {
+		return 0, err
+	}
+	if active {
+		return 0, cb(ctx)
+	}
+	deferCtx := reconcile.WithMaintenanceDeferral(ctx)
+	if err := cb(deferCtx); err != nil {
+		return 0, err
+	}
+	if len(reconcile.DeferredChanges(deferCtx)) == 0 {
+		return 0, nil
+	}
+	return nextWindow.Sub(now), nil
+}
+
+// activeMaintenanceWindow checks if any of windows is active at the given time.
+// Otherwise, it returns the nearest start of the next window.
+// Operator default windows are used if windows are empty, changes are never deferred without windows
+func activeMaintenanceWindow(windows []vmv1beta1.MaintenanceWindow, now time.Time) (bool, time.Time, error) {
+	if len(windows) == 0 {
+		cfg := config.MustGetBaseConfig()
+		if cfg.MaintenanceWindow.Schedule == "" {
+			return true, time.Time{}, nil
+		}
+		windows = []vmv1beta1.MaintenanceWindow{{
+			Schedule: cfg.MaintenanceWindow.Schedule,
+			Duration: cfg.MaintenanceWindow.Duration,
+			Timezone: cfg.MaintenanceWindow.Timezone,
+		}}
+	}
+	active, nextWindow, err := vmv1beta1.ActiveMaintenanceWindow(windows, now)
+	if err != nil {
+		return false, nextWindow, build.NewValidationError(err)
+	}
+	return active, nextWindow, nil
+}
+
+// maintenanceWindowRequeue returns requeue duration, which ensures reconcile at the start of the next maintenance window
+func maintenanceWindowRequeue(requeueAfter, untilNextWindow time.Duration) time.Duration {
+	if untilNextWindow > 0 && (requeueAfter == 0 || untilNextWindow < requeueAfter) {
+		return untilNextWindow
+	}
+	return requeueAfter
+}
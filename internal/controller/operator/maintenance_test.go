@@ -0,0 +1,144 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+func TestReconcileWithMaintenanceWindows(t *testing.T) {
+	getPVC := func(size string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "vmagent-storage",
+				Namespace:   "default",
+				Annotations: map[string]string{vmv1beta1.PVCExpandableLabel: "true"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(size),
+					},
+				},
+			},
+		}
+	}
+	// window starts once a year and is active only for a minute
+	inactive := []vmv1beta1.MaintenanceWindow{{Schedule: "0 0 1 1 *", Duration: "1m"}}
+	// window is always active
+	active := []vmv1beta1.MaintenanceWindow{{Schedule: "* * * * *", Duration: "1m"}}
+
+	ctx := context.Background()
+	cr := &vmv1beta1.VMAgent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "example",
+			Namespace: "default",
+		},
+	}
+	fclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr, getPVC("10Gi")})
+	reconcileStorage := func(ctx context.Context) error {
+		return reconcile.PersistentVolumeClaim(ctx, fclient, getPVC("20Gi"), getPVC("10Gi"), nil)
+	}
+	assertState := func(wantSize string, wantPending bool) {
+		t.Helper()
+		var pvc corev1.PersistentVolumeClaim
+		assert.NoError(t, fclient.Get(ctx, types.NamespacedName{Name: "vmagent-storage", Namespace: "default"}, &pvc))
+		assert.Equal(t, wantSize, pvc.Spec.Resources.Requests.Storage().String())
+		var got vmv1beta1.VMAgent
+		assert.NoError(t, fclient.Get(ctx, types.NamespacedName{Name: cr.Name, Namespace: cr.Namespace}, &got))
+		if !wantPending {
+			assert.Nil(t, got.Status.PendingChanges)
+			return
+		}
+		if assert.NotNil(t, got.Status.PendingChanges) {
+			assert.Equal(t, "1 to update, 0 to recreate", got.Status.PendingChanges.Summary)
+			assert.Equal(t, []vmv1beta1.PlannedChange{{
+				Kind:   "PersistentVolumeClaim",
+				Name:   "vmagent-storage",
+				Action: vmv1beta1.PlannedActionUpdate,
+				Fields: []string{"spec.resources.requests.storage"},
+			}}, got.Status.PendingChanges.Changes)
+			assert.NotNil(t, got.Status.PendingChanges.NextWindow)
+		}
+	}
+
+	// invalid window
+	_, err := reconcileWithMaintenanceWindows(ctx, fclient, cr, []vmv1beta1.MaintenanceWindow{{Schedule: "bad", Duration: "1h"}}, nil, reconcileStorage)
+	assert.Error(t, err)
+
+	// outside of window
+	untilNextWindow, err := reconcileWithMaintenanceWindows(ctx, fclient, cr, inactive, nil, reconcileStorage)
+	assert.NoError(t, err)
+	assert.Greater(t, untilNextWindow, time.Duration(0))
+	assertState("10Gi", true)
+
+	// within window
+	untilNextWindow, err = reconcileWithMaintenanceWindows(ctx, fclient, cr, active, cr.Status.PendingChanges, reconcileStorage)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), untilNextWindow)
+	assertState("20Gi", false)
+}
+
+func TestPlanWithMaintenanceWindows(t *testing.T) {
+	getPVC := func(size string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "vmstorage-db",
+				Namespace:   "default",
+				Annotations: map[string]string{vmv1beta1.PVCExpandableLabel: "true"},
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceStorage: resource.MustParse(size),
+					},
+				},
+			},
+		}
+	}
+	f := func(windows []vmv1beta1.MaintenanceWindow, wantDeferred bool, wantChanges []vmv1beta1.PlannedChange) {
+		t.Helper()
+		ctx := context.Background()
+		pc := reconcile.NewPlanClient(k8stools.GetTestClientWithObjects([]runtime.Object{getPVC("10Gi")}))
+		untilNextWindow, err := planWithMaintenanceWindows(ctx, windows, func(ctx context.Context) error {
+			return reconcile.PersistentVolumeClaim(ctx, pc, getPVC("20Gi"), getPVC("10Gi"), nil)
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, wantDeferred, untilNextWindow > 0)
+		assert.Equal(t, wantChanges, pc.Plan().Changes)
+	}
+
+	// disruptive change is planned within window
+	f([]vmv1beta1.MaintenanceWindow{{Schedule: "* * * * *", Duration: "1m"}}, false, []vmv1beta1.PlannedChange{{
+		Kind:   "PersistentVolumeClaim",
+		Name:   "vmstorage-db",
+		Action: vmv1beta1.PlannedActionUpdate,
+		Fields: []string{"spec.resources.requests[storage]"},
+	}})
+
+	// disruptive change isn't planned outside of window
+	f([]vmv1beta1.MaintenanceWindow{{Schedule: "0 0 1 1 *", Duration: "1m"}}, true, nil)
+}
+
+func TestMaintenanceWindowRequeue(t *testing.T) {
+	f := func(requeueAfter, untilNextWindow, want time.Duration) {
+		t.Helper()
+		assert.Equal(t, want, maintenanceWindowRequeue(requeueAfter, untilNextWindow))
+	}
+	f(time.Minute, 0, time.Minute)
+	f(time.Minute, time.Hour, time.Minute)
+	f(time.Hour, time.Minute, time.Minute)
+	f(0, time.Minute, time.Minute)
+	f(0, 0, 0)
+}
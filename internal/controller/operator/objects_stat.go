@@ -29,6 +29,8 @@ func (oc *objectCollector) deRegister(name, ns, controller string) {
 	oc.mu.Lock()
 	defer oc.mu.Unlock()
 	delete(oc.objectsByController[controller], ns+"/"+name)
+	deleteObjectReconcileMetrics(name, ns, controller)
+	deleteReportedDrifts(name, ns, controller)
 }
 
 func (oc *objectCollector) countByController(controller string) float64 {
@@ -49,7 +51,8 @@ func newCollector() *objectCollector {
 		"vmagent", "vmalert", "vmsingle", "vmcluster", "vmalertmanager", "vmauth", "vlogs", "vlsingle",
 		"vlcluster", "vmalertmanagerconfig", "vmrule", "vmuser", "vmservicescrape", "vmstaticscrape",
 		"vmnodescrape", "vmpodscrape", "vmprobescrape", "vmscrapeconfig", "vmanomaly", "vlagent",
-		"vtsingle", "vtcluster", "vmdistributed",
+		"vtsingle", "vtcluster", "vmdistributed", "vmsilence", "vmalertmanagerreceiver",
+		"vmalertmanagerclusterreceiver", "vmoperatorconfig",
 	}
 	for _, controller := range registeredObjects {
 		oc.objectsByController[controller] = map[string]struct{}{}
@@ -0,0 +1,296 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+const (
+	shardLeaseRetryPeriod  = 2 * time.Second
+	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+var errLeaseHeld = errors.New("lease is held by another replica")
+
+// ShardLeases acquires per-shard Leases for the current operator replica.
+//
+// Each replica renews its own replica Lease, so replicas know how many of them are alive
+// and every replica holds at most ceil(shardsCount/replicas) shards.
+// Shards of stopped or crashed replicas are acquired by other replicas once their Leases expire
+type ShardLeases struct {
+	client        client.Client
+	cache         cache.Cache
+	namespace     string
+	name          string
+	identity      string
+	replicaName   string
+	leaseDuration time.Duration
+	renewDeadline time.Duration
+
+	// renewed holds time of the last successful renew of owned shard Leases
+	renewed map[int]time.Time
+	// observed holds the last observed state of Leases,
+	// expiration is measured with local clock since observed change, so it doesn't depend on clock skew
+	observed map[string]observedLease
+}
+
+type observedLease struct {
+	holder    string
+	renewTime time.Time
+	at        time.Time
+}
+
+var _ manager.LeaderElectionRunnable = (*ShardLeases)(nil)
+
+// NewShardLeases returns runnable, which acquires shard Leases with the given name prefix at the given namespace.
+// In-cluster namespace is used if namespace is empty
+func NewShardLeases(mgr manager.Manager, namespace, name string, leaseDuration, renewDeadline time.Duration) (*ShardLeases, error) {
+	if namespace == "" {
+		data, err := os.ReadFile(inClusterNamespacePath)
+		if err != nil {
+			return nil, fmt.Errorf("cannot detect in-cluster namespace for shard leases, set -leader-elect-namespace flag: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get hostname: %w", err)
+	}
+	// leases are read directly from api server, since cache could be restricted to watched namespaces
+	rclient, err := client.New(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create client for shard leases: %w", err)
+	}
+	return newShardLeases(rclient, mgr.GetCache(), namespace, name, hostname+"_"+uuid.NewString(), leaseDuration, renewDeadline), nil
+}
+
+func newShardLeases(rclient client.Client, c cache.Cache, namespace, name, identity string, leaseDuration, renewDeadline time.Duration) *ShardLeases {
+	_, id, _ := strings.Cut(identity, "_")
+	return &ShardLeases{
+		client:        rclient,
+		cache:         c,
+		namespace:     namespace,
+		name:          name,
+		identity:      identity,
+		replicaName:   fmt.Sprintf("%s-replica-%s", name, id),
+		leaseDuration: leaseDuration,
+		renewDeadline: renewDeadline,
+		renewed:       make(map[int]time.Time),
+		observed:      make(map[string]observedLease),
+	}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable
+func (*ShardLeases) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable
+func (sl *ShardLeases) Start(ctx context.Context) error {
+	if err := watchNamespaceShards(ctx, sl.cache); err != nil {
+		return err
+	}
+	logger.WithContext(ctx).Info("acquiring shard leases", "identity", sl.identity, "namespace", sl.namespace, "shards", *shardsCount)
+	t := time.NewTicker(shardLeaseRetryPeriod)
+	defer t.Stop()
+	for {
+		sl.sync(ctx, time.Now())
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), sl.renewDeadline)
+			defer cancel()
+			sl.releaseAll(releaseCtx)
+			return nil
+		case <-t.C:
+		}
+	}
+}
+
+func (sl *ShardLeases) shardLeaseName(shard int) string {
+	return fmt.Sprintf("%s-shard-%d", sl.name, shard)
+}
+
+// sync renews replica and owned shard Leases, releases excess shards and acquires free ones
+func (sl *ShardLeases) sync(ctx context.Context, now time.Time) {
+	l := logger.WithContext(ctx)
+	defer sl.publish(ctx)
+	var leases coordinationv1.LeaseList
+	if err := sl.client.List(ctx, &leases, client.InNamespace(sl.namespace)); err != nil {
+		l.Error(err, "cannot list shard leases")
+		sl.expireOwned(now)
+		return
+	}
+	byName := make(map[string]*coordinationv1.Lease, len(leases.Items))
+	replicas := 1
+	for i := range leases.Items {
+		lease := &leases.Items[i]
+		byName[lease.Name] = lease
+		if lease.Name != sl.replicaName && strings.HasPrefix(lease.Name, sl.name+"-replica-") && !sl.isExpired(lease, now) {
+			replicas++
+		}
+	}
+	if err := sl.acquire(ctx, byName[sl.replicaName], sl.replicaName, now); err != nil {
+		l.Error(err, "cannot renew replica lease", "lease", sl.replicaName)
+	}
+
+	for _, shard := range slices.Sorted(maps.Keys(sl.renewed)) {
+		name := sl.shardLeaseName(shard)
+		if err := sl.acquire(ctx, byName[name], name, now); err != nil {
+			if errors.Is(err, errLeaseHeld) {
+				delete(sl.renewed, shard)
+			}
+			l.Error(err, "cannot renew shard lease", "lease", name)
+			continue
+		}
+		sl.renewed[shard] = now
+	}
+	sl.expireOwned(now)
+
+	target := (*shardsCount + replicas - 1) / replicas
+	for len(sl.renewed) > target {
+		shard := slices.Max(slices.Collect(maps.Keys(sl.renewed)))
+		sl.release(ctx, shard)
+	}
+	for shard := 0; shard < *shardsCount && len(sl.renewed) < target; shard++ {
+		if _, ok := sl.renewed[shard]; ok {
+			continue
+		}
+		name := sl.shardLeaseName(shard)
+		if err := sl.acquire(ctx, byName[name], name, now); err != nil {
+			if !errors.Is(err, errLeaseHeld) {
+				l.Error(err, "cannot acquire shard lease", "lease", name)
+			}
+			continue
+		}
+		sl.renewed[shard] = now
+	}
+}
+
+// expireOwned drops ownership of shards, which Leases weren't renewed in time
+func (sl *ShardLeases) expireOwned(now time.Time) {
+	for shard, renewed := range sl.renewed {
+		if now.Sub(renewed) > sl.renewDeadline {
+			delete(sl.renewed, shard)
+		}
+	}
+}
+
+// publish updates shards owned by the current replica if they were changed
+func (sl *ShardLeases) publish(ctx context.Context) {
+	shards := make(map[int]struct{}, len(sl.renewed))
+	for shard := range sl.renewed {
+		shards[shard] = struct{}{}
+	}
+	owned.mu.RLock()
+	changed := !maps.Equal(owned.shards, shards)
+	owned.mu.RUnlock()
+	if !changed {
+		return
+	}
+	logger.WithContext(ctx).Info("owned shards changed", "shards", slices.Sorted(maps.Keys(shards)))
+	setOwnedShards(shards)
+}
+
+// isExpired checks if Lease is free or wasn't renewed by its holder for lease duration
+func (sl *ShardLeases) isExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	holder := ptr.Deref(lease.Spec.HolderIdentity, "")
+	if holder == "" {
+		return true
+	}
+	var renewTime time.Time
+	if lease.Spec.RenewTime != nil {
+		renewTime = lease.Spec.RenewTime.Time
+	}
+	o, ok := sl.observed[lease.Name]
+	if !ok || o.holder != holder || !o.renewTime.Equal(renewTime) {
+		o = observedLease{holder: holder, renewTime: renewTime, at: now}
+		sl.observed[lease.Name] = o
+	}
+	duration := sl.leaseDuration
+	if lease.Spec.LeaseDurationSeconds != nil {
+		duration = time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	}
+	return now.Sub(o.at) > duration
+}
+
+// acquire creates, renews or takes over expired Lease with the given name.
+// Conflicting updates by other replicas are rejected by api server
+func (sl *ShardLeases) acquire(ctx context.Context, lease *coordinationv1.Lease, name string, now time.Time) error {
+	renewTime := metav1.NewMicroTime(now)
+	spec := coordinationv1.LeaseSpec{
+		HolderIdentity:       ptr.To(sl.identity),
+		LeaseDurationSeconds: ptr.To(int32(sl.leaseDuration.Seconds())),
+		AcquireTime:          &renewTime,
+		RenewTime:            &renewTime,
+	}
+	if lease == nil {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: sl.namespace,
+			},
+			Spec: spec,
+		}
+		return sl.client.Create(ctx, lease)
+	}
+	lease = lease.DeepCopy()
+	if holder := ptr.Deref(lease.Spec.HolderIdentity, ""); holder != sl.identity {
+		if !sl.isExpired(lease, now) {
+			return fmt.Errorf("%w: %s", errLeaseHeld, holder)
+		}
+		spec.LeaseTransitions = ptr.To(ptr.Deref(lease.Spec.LeaseTransitions, 0) + 1)
+	} else {
+		spec.AcquireTime = lease.Spec.AcquireTime
+		spec.LeaseTransitions = lease.Spec.LeaseTransitions
+	}
+	lease.Spec = spec
+	return sl.client.Update(ctx, lease)
+}
+
+// release stops processing of the shard and frees its Lease, so other replica could acquire it without waiting for expiration
+func (sl *ShardLeases) release(ctx context.Context, shard int) {
+	delete(sl.renewed, shard)
+	sl.publish(ctx)
+	name := sl.shardLeaseName(shard)
+	var lease coordinationv1.Lease
+	if err := sl.client.Get(ctx, types.NamespacedName{Namespace: sl.namespace, Name: name}, &lease); err != nil {
+		logger.WithContext(ctx).Error(err, "cannot get shard lease for release", "lease", name)
+		return
+	}
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != sl.identity {
+		return
+	}
+	lease.Spec.HolderIdentity = nil
+	if err := sl.client.Update(ctx, &lease); err != nil {
+		logger.WithContext(ctx).Error(err, "cannot release shard lease", "lease", name)
+	}
+}
+
+// releaseAll frees all Leases of the current replica on shutdown
+func (sl *ShardLeases) releaseAll(ctx context.Context) {
+	for shard := range sl.renewed {
+		sl.release(ctx, shard)
+	}
+	replica := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Namespace: sl.namespace, Name: sl.replicaName}}
+	if err := sl.client.Delete(ctx, replica); err != nil && !k8serrors.IsNotFound(err) {
+		logger.WithContext(ctx).Error(err, "cannot delete replica lease", "lease", sl.replicaName)
+	}
+}
@@ -0,0 +1,336 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+)
+
+const (
+	shardByHash  = "hash"
+	shardByLabel = "label"
+)
+
+var (
+	shardsCount = ptr.To(1)
+	shardBy     = ptr.To(shardByHash)
+	// namespaceShards caches shards of namespaces seen by the namespaces informer for label based sharding
+	namespaceShards sync.Map
+)
+
+// owned holds shards, which Leases are held by the current operator replica
+var owned = struct {
+	mu      sync.RWMutex
+	shards  map[int]struct{}
+	changed chan struct{}
+	queues  map[*shardQueue]struct{}
+}{
+	shards:  make(map[int]struct{}),
+	changed: make(chan struct{}),
+	queues:  make(map[*shardQueue]struct{}),
+}
+
+// InitSharding validates sharding flags
+func InitSharding(cfg *config.BaseOperatorConf) error {
+	if *shardsCount < 1 {
+		return fmt.Errorf("controller.shardsCount=%d must be greater than 0", *shardsCount)
+	}
+	switch *shardBy {
+	case shardByHash:
+	case shardByLabel:
+		// namespace labels could be watched only with cluster-wide access
+		if len(cfg.WatchNamespaces) > 0 {
+			return fmt.Errorf("controller.shardBy=%s cannot be used with WATCH_NAMESPACE, use controller.shardBy=%s instead", shardByLabel, shardByHash)
+		}
+	default:
+		return fmt.Errorf("unsupported controller.shardBy=%q, supported values: %s, %s", *shardBy, shardByHash, shardByLabel)
+	}
+	return nil
+}
+
+// IsShardingEnabled checks if operator runs with multiple shards
+func IsShardingEnabled() bool {
+	return *shardsCount > 1
+}
+
+// IsPrimaryShard checks if the current replica handles cluster-scoped objects and global components,
+// e.g. holds Lease of the shard with index 0
+func IsPrimaryShard() bool {
+	return !IsShardingEnabled() || ownsShard(0)
+}
+
+// ShardsChanged returns a channel, which is closed on the next change of shards owned by the current replica
+func ShardsChanged() <-chan struct{} {
+	owned.mu.RLock()
+	defer owned.mu.RUnlock()
+	return owned.changed
+}
+
+func ownsShard(idx int) bool {
+	owned.mu.RLock()
+	defer owned.mu.RUnlock()
+	_, ok := owned.shards[idx]
+	return ok
+}
+
+// setOwnedShards updates shards owned by the current replica
+// and returns parked requests of acquired shards back to the queues
+func setOwnedShards(shards map[int]struct{}) {
+	owned.mu.Lock()
+	owned.shards = shards
+	close(owned.changed)
+	owned.changed = make(chan struct{})
+	queues := make([]*shardQueue, 0, len(owned.queues))
+	for q := range owned.queues {
+		queues = append(queues, q)
+	}
+	owned.mu.Unlock()
+	for _, q := range queues {
+		q.unpark(func(string) bool { return true })
+	}
+}
+
+// isOwnedNamespace checks if objects at the given namespace belong to the shard owned by the current replica.
+// resolved is false if shard of the namespace isn't known yet
+func isOwnedNamespace(namespace string) (isOwned, resolved bool) {
+	shard, ok := shardForNamespace(namespace)
+	if !ok {
+		return false, false
+	}
+	return ownsShard(shard), true
+}
+
+// namespaceShard returns index of shard, which owns objects at the given namespace
+func namespaceShard(ns *corev1.Namespace) int {
+	if *shardBy == shardByLabel {
+		if v, ok := ns.Labels[vmv1beta1.ShardLabel]; ok {
+			idx, err := strconv.Atoi(v)
+			if err == nil && idx >= 0 && idx < *shardsCount {
+				return idx
+			}
+			logger.WithContext(context.Background()).Info(fmt.Sprintf("unexpected value=%q of label=%s at namespace=%s, fallback to hash based sharding", v, vmv1beta1.ShardLabel, ns.Name))
+		}
+	}
+	return hashShard(ns.Name)
+}
+
+func hashShard(namespace string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace))
+	return int(h.Sum32() % uint32(*shardsCount))
+}
+
+// shardForNamespace returns index of shard, which owns objects at the given namespace
+// cluster-scoped objects are owned by the primary shard.
+// Label based sharding uses cached namespace shards, ok is false for namespaces not seen yet
+func shardForNamespace(namespace string) (shard int, ok bool) {
+	if namespace == "" {
+		return 0, true
+	}
+	if *shardBy == shardByLabel {
+		v, ok := namespaceShards.Load(namespace)
+		if !ok {
+			return 0, false
+		}
+		return v.(int), true
+	}
+	return hashShard(namespace), true
+}
+
+// watchNamespaceShards tracks shards of namespaces for label based sharding
+// and returns parked requests back to the queues once namespace shard is known or changed
+func watchNamespaceShards(ctx context.Context, c cache.Cache) error {
+	if *shardBy != shardByLabel {
+		return nil
+	}
+	inf, err := c.GetInformer(ctx, &corev1.Namespace{})
+	if err != nil {
+		return fmt.Errorf("cannot get namespaces informer: %w", err)
+	}
+	_, err = inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			if ns, ok := obj.(*corev1.Namespace); ok {
+				setNamespaceShard(ns)
+			}
+		},
+		UpdateFunc: func(_, obj any) {
+			if ns, ok := obj.(*corev1.Namespace); ok {
+				setNamespaceShard(ns)
+			}
+		},
+		DeleteFunc: func(obj any) {
+			if d, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = d.Obj
+			}
+			if ns, ok := obj.(*corev1.Namespace); ok {
+				forgetNamespaceShard(ns.Name)
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot add namespaces handler: %w", err)
+	}
+	return nil
+}
+
+func setNamespaceShard(ns *corev1.Namespace) {
+	shard := namespaceShard(ns)
+	if prev, ok := namespaceShards.Swap(ns.Name, shard); ok && prev.(int) == shard {
+		return
+	}
+	for _, q := range shardQueues() {
+		q.unpark(func(namespace string) bool { return namespace == ns.Name })
+	}
+}
+
+func forgetNamespaceShard(namespace string) {
+	namespaceShards.Delete(namespace)
+	for _, q := range shardQueues() {
+		q.forget(namespace)
+	}
+}
+
+func shardQueues() []*shardQueue {
+	owned.mu.RLock()
+	defer owned.mu.RUnlock()
+	queues := make([]*shardQueue, 0, len(owned.queues))
+	for q := range owned.queues {
+		queues = append(queues, q)
+	}
+	return queues
+}
+
+// shardQueue processes only requests for objects, which belong to shards owned by the current replica.
+// Other requests are parked and added back to the queue once shard is acquired or namespace shard is resolved
+type shardQueue struct {
+	priorityqueue.PriorityQueue[k8sreconcile.Request]
+
+	mu     sync.Mutex
+	parked map[k8sreconcile.Request]struct{}
+}
+
+func newShardQueue(controllerName string, rateLimiter workqueue.TypedRateLimiter[k8sreconcile.Request]) workqueue.TypedRateLimitingInterface[k8sreconcile.Request] {
+	q := &shardQueue{
+		PriorityQueue: priorityqueue.New(controllerName, func(o *priorityqueue.Opts[k8sreconcile.Request]) {
+			o.Log = logger.WithContext(context.Background()).WithValues("controller", controllerName)
+			o.RateLimiter = rateLimiter
+		}),
+		parked: make(map[k8sreconcile.Request]struct{}),
+	}
+	owned.mu.Lock()
+	owned.queues[q] = struct{}{}
+	owned.mu.Unlock()
+	return q
+}
+
+// filterOwned parks requests of not owned shards and returns the rest
+func (q *shardQueue) filterOwned(items []k8sreconcile.Request) []k8sreconcile.Request {
+	result := items[:0:0]
+	for _, item := range items {
+		if isOwned, _ := isOwnedNamespace(item.Namespace); isOwned {
+			result = append(result, item)
+			continue
+		}
+		q.mu.Lock()
+		q.parked[item] = struct{}{}
+		q.mu.Unlock()
+	}
+	return result
+}
+
+// unpark adds parked requests from matching namespaces, which belong to owned shards, back to the queue
+func (q *shardQueue) unpark(matches func(namespace string) bool) {
+	var items []k8sreconcile.Request
+	q.mu.Lock()
+	for item := range q.parked {
+		if !matches(item.Namespace) {
+			continue
+		}
+		if isOwned, _ := isOwnedNamespace(item.Namespace); isOwned {
+			items = append(items, item)
+			delete(q.parked, item)
+		}
+	}
+	q.mu.Unlock()
+	if len(items) > 0 {
+		q.PriorityQueue.AddWithOpts(priorityqueue.AddOpts{}, items...)
+	}
+}
+
+// forget drops parked requests of deleted namespace
+func (q *shardQueue) forget(namespace string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for item := range q.parked {
+		if item.Namespace == namespace {
+			delete(q.parked, item)
+		}
+	}
+}
+
+// Add implements workqueue.TypedInterface
+func (q *shardQueue) Add(item k8sreconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, item)
+}
+
+// AddAfter implements workqueue.TypedDelayingInterface
+func (q *shardQueue) AddAfter(item k8sreconcile.Request, after time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: after}, item)
+}
+
+// AddRateLimited implements workqueue.TypedRateLimitingInterface
+func (q *shardQueue) AddRateLimited(item k8sreconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, item)
+}
+
+// AddWithOpts implements priorityqueue.PriorityQueue
+func (q *shardQueue) AddWithOpts(o priorityqueue.AddOpts, items ...k8sreconcile.Request) {
+	if items = q.filterOwned(items); len(items) > 0 {
+		q.PriorityQueue.AddWithOpts(o, items...)
+	}
+}
+
+// Get implements workqueue.TypedInterface
+func (q *shardQueue) Get() (k8sreconcile.Request, bool) {
+	item, _, shutdown := q.GetWithPriority()
+	return item, shutdown
+}
+
+// GetWithPriority implements priorityqueue.PriorityQueue
+//
+// Requests queued before shard was released are parked instead of processing
+func (q *shardQueue) GetWithPriority() (k8sreconcile.Request, int, bool) {
+	for {
+		item, priority, shutdown := q.PriorityQueue.GetWithPriority()
+		if shutdown {
+			return item, priority, shutdown
+		}
+		if len(q.filterOwned([]k8sreconcile.Request{item})) > 0 {
+			return item, priority, false
+		}
+		q.PriorityQueue.Done(item)
+	}
+}
+
+// ShutDown implements workqueue.TypedInterface
+func (q *shardQueue) ShutDown() {
+	owned.mu.Lock()
+	delete(owned.queues, q)
+	owned.mu.Unlock()
+	q.PriorityQueue.ShutDown()
+}
@@ -0,0 +1,301 @@
+package operator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func setSharding(t *testing.T, count int, by string, shards ...int) {
+	t.Helper()
+	prevCount, prevBy := *shardsCount, *shardBy
+	t.Cleanup(func() {
+		*shardsCount, *shardBy = prevCount, prevBy
+		namespaceShards.Clear()
+		setOwnedShards(make(map[int]struct{}))
+	})
+	*shardsCount, *shardBy = count, by
+	assert.NoError(t, InitSharding(&config.BaseOperatorConf{}))
+	setShards(shards...)
+}
+
+func setShards(shards ...int) {
+	owned := make(map[int]struct{}, len(shards))
+	for _, shard := range shards {
+		owned[shard] = struct{}{}
+	}
+	setOwnedShards(owned)
+}
+
+func TestShardForNamespace(t *testing.T) {
+	type opts struct {
+		namespace string
+		shardBy   string
+		labels    map[string]string
+		want      int
+	}
+	f := func(o opts) {
+		t.Helper()
+		setSharding(t, 3, o.shardBy)
+		namespaceShards.Clear()
+		if o.namespace != "" && o.shardBy == shardByLabel {
+			// shard of not seen namespace is unknown
+			_, ok := shardForNamespace(o.namespace)
+			assert.False(t, ok)
+			setNamespaceShard(&corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:   o.namespace,
+					Labels: o.labels,
+				},
+			})
+		}
+		got, ok := shardForNamespace(o.namespace)
+		assert.True(t, ok)
+		assert.Equal(t, o.want, got)
+	}
+
+	// cluster-scoped object
+	f(opts{
+		shardBy: shardByHash,
+		want:    0,
+	})
+	f(opts{
+		shardBy: shardByLabel,
+		want:    0,
+	})
+
+	// hash based
+	f(opts{
+		namespace: "team-b",
+		shardBy:   shardByHash,
+		want:      2,
+	})
+	f(opts{
+		namespace: "team-c",
+		shardBy:   shardByHash,
+		want:      0,
+	})
+
+	// label is ignored for hash based sharding
+	f(opts{
+		namespace: "team-c",
+		shardBy:   shardByHash,
+		labels:    map[string]string{vmv1beta1.ShardLabel: "1"},
+		want:      0,
+	})
+
+	// label based
+	f(opts{
+		namespace: "team-c",
+		shardBy:   shardByLabel,
+		labels:    map[string]string{vmv1beta1.ShardLabel: "1"},
+		want:      1,
+	})
+
+	// fallback to hash for missing label
+	f(opts{
+		namespace: "team-c",
+		shardBy:   shardByLabel,
+		want:      0,
+	})
+
+	// fallback to hash for out of range label
+	f(opts{
+		namespace: "team-c",
+		shardBy:   shardByLabel,
+		labels:    map[string]string{vmv1beta1.ShardLabel: "5"},
+		want:      0,
+	})
+}
+
+func TestInitSharding(t *testing.T) {
+	f := func(count int, by string, watchNamespaces []string, wantErr bool) {
+		t.Helper()
+		prevCount, prevBy := *shardsCount, *shardBy
+		defer func() {
+			*shardsCount, *shardBy = prevCount, prevBy
+		}()
+		*shardsCount, *shardBy = count, by
+		err := InitSharding(&config.BaseOperatorConf{WatchNamespaces: watchNamespaces})
+		if wantErr {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+
+	f(1, shardByHash, nil, false)
+	f(3, shardByLabel, nil, false)
+	f(3, shardByHash, []string{"team-a"}, false)
+	f(3, shardByLabel, []string{"team-a"}, true)
+	f(0, shardByHash, nil, true)
+	f(3, "unknown", nil, true)
+}
+
+func TestShardQueue(t *testing.T) {
+	setSharding(t, 3, shardByHash, 2)
+	assert.False(t, IsPrimaryShard())
+
+	q := newShardQueue("test", workqueue.DefaultTypedControllerRateLimiter[k8sreconcile.Request]())
+	defer q.ShutDown()
+	owned := k8sreconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-b", Name: "owned"}}
+	parked := k8sreconcile.Request{NamespacedName: types.NamespacedName{Namespace: "team-c", Name: "parked"}}
+	clusterScoped := k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: "cluster-scoped"}}
+	q.Add(parked)
+	q.AddRateLimited(clusterScoped)
+	q.Add(owned)
+	assert.Equal(t, 1, q.Len())
+	got, _ := q.Get()
+	assert.Equal(t, owned, got)
+	q.Done(got)
+
+	// parked requests are returned back once shard is acquired
+	changed := ShardsChanged()
+	setShards(0, 2)
+	assert.True(t, IsPrimaryShard())
+	select {
+	case <-changed:
+	default:
+		t.Fatal("shards change must be broadcasted")
+	}
+	assert.Equal(t, 2, q.Len())
+	var unparked []k8sreconcile.Request
+	for range 2 {
+		got, _ = q.Get()
+		unparked = append(unparked, got)
+		q.Done(got)
+	}
+	assert.ElementsMatch(t, []k8sreconcile.Request{parked, clusterScoped}, unparked)
+
+	// requests queued before shard was released are not processed
+	q.Add(owned)
+	setShards(0)
+	q.Add(parked)
+	got, _ = q.Get()
+	assert.Equal(t, parked, got)
+	q.Done(got)
+	assert.Equal(t, 0, q.Len())
+	setShards(0, 2)
+	assert.Equal(t, 1, q.Len())
+	got, _ = q.Get()
+	assert.Equal(t, owned, got)
+}
+
+func TestShardQueueByLabel(t *testing.T) {
+	setSharding(t, 3, shardByLabel, 1)
+
+	q := newShardQueue("test", workqueue.DefaultTypedControllerRateLimiter[k8sreconcile.Request]())
+	defer q.ShutDown()
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "team-c",
+			Labels: map[string]string{vmv1beta1.ShardLabel: "1"},
+		},
+	}
+	req := k8sreconcile.Request{NamespacedName: types.NamespacedName{Namespace: ns.Name, Name: "vmagent"}}
+
+	// request of not seen namespace is requeued once namespace shard is known
+	q.Add(req)
+	assert.Equal(t, 0, q.Len())
+	setNamespaceShard(ns)
+	assert.Equal(t, 1, q.Len())
+	got, _ := q.Get()
+	assert.Equal(t, req, got)
+	q.Done(got)
+
+	// namespace moved to another shard
+	ns.Labels[vmv1beta1.ShardLabel] = "2"
+	setNamespaceShard(ns)
+	q.Add(req)
+	assert.Equal(t, 0, q.Len())
+
+	// parked requests of deleted namespace are dropped
+	forgetNamespaceShard(ns.Name)
+	ns.Labels[vmv1beta1.ShardLabel] = "1"
+	setNamespaceShard(ns)
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestShardLeases(t *testing.T) {
+	setSharding(t, 3, shardByHash)
+	ctx := context.Background()
+	rclient := k8stools.GetTestClientWithObjectsAndInterceptors(nil, interceptor.Funcs{})
+	newReplica := func(identity string) *ShardLeases {
+		return newShardLeases(rclient, nil, "default", "operator", identity, 15*time.Second, 10*time.Second)
+	}
+	holders := func() map[string]string {
+		var leases coordinationv1.LeaseList
+		assert.NoError(t, rclient.List(ctx, &leases, client.InNamespace("default")))
+		result := make(map[string]string)
+		for _, l := range leases.Items {
+			result[l.Name] = ptr.Deref(l.Spec.HolderIdentity, "")
+		}
+		return result
+	}
+	now := time.Now()
+
+	// single replica acquires all shards
+	a := newReplica("host-a_a")
+	a.sync(ctx, now)
+	assert.Equal(t, map[string]string{
+		"operator-replica-a": "host-a_a",
+		"operator-shard-0":   "host-a_a",
+		"operator-shard-1":   "host-a_a",
+		"operator-shard-2":   "host-a_a",
+	}, holders())
+	assert.True(t, IsPrimaryShard())
+
+	// new replica waits for released shards
+	b := newReplica("host-b_b")
+	b.sync(ctx, now)
+	assert.Len(t, b.renewed, 0)
+
+	// shards are balanced between replicas
+	now = now.Add(2 * time.Second)
+	a.sync(ctx, now)
+	assert.Len(t, a.renewed, 2)
+	b.sync(ctx, now)
+	assert.Equal(t, map[string]string{
+		"operator-replica-a": "host-a_a",
+		"operator-replica-b": "host-b_b",
+		"operator-shard-0":   "host-a_a",
+		"operator-shard-1":   "host-a_a",
+		"operator-shard-2":   "host-b_b",
+	}, holders())
+
+	// shards of stopped replica are acquired after lease expiration
+	for range 10 {
+		now = now.Add(2 * time.Second)
+		b.sync(ctx, now)
+	}
+	assert.Len(t, b.renewed, 3)
+	assert.Equal(t, "host-b_b", holders()["operator-shard-0"])
+
+	// stopped replica drops not renewed shards
+	a.sync(ctx, now)
+	assert.Len(t, a.renewed, 0)
+
+	// shards are released on shutdown
+	b.releaseAll(ctx)
+	assert.Equal(t, map[string]string{
+		"operator-replica-a": "host-a_a",
+		"operator-shard-0":   "",
+		"operator-shard-1":   "",
+		"operator-shard-2":   "",
+	}, holders())
+}
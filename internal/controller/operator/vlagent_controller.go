@@ -90,7 +90,7 @@ func (r *VLAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 	r.Client.Scheme().Default(instance)
 
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
 		if err := vlagent.CreateOrUpdate(ctx, instance, r); err != nil {
 			return result, err
 		}
@@ -98,7 +98,7 @@ func (r *VLAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	})
 
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	}
 
 	return
@@ -113,6 +113,7 @@ func (r *VLAgentReconciler) Scheme() *runtime.Scheme {
 func (r *VLAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1.VLAgent{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1.VLAgentList { return &vmv1.VLAgentList{} })).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.ServiceAccount{}).
@@ -83,7 +83,7 @@ func (r *VLClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 	r.Client.Scheme().Default(instance)
 
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
 		if err := vlcluster.CreateOrUpdate(ctx, r, instance); err != nil {
 			return result, fmt.Errorf("failed create or update vlcluster: %w", err)
 		}
@@ -91,7 +91,7 @@ func (r *VLClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	})
 
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	}
 
 	return
@@ -101,6 +101,7 @@ func (r *VLClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 func (r *VLClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1.VLCluster{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1.VLClusterList { return &vmv1.VLClusterList{} })).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
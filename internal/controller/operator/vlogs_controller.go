@@ -92,6 +92,7 @@ func (r *VLogsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (resu
 func (r *VLogsReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VLogs{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1beta1.VLogsList { return &vmv1beta1.VLogsList{} })).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ServiceAccount{}).
 		WithOptions(getDefaultOptions()).
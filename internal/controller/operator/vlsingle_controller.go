@@ -84,7 +84,7 @@ func (r *VLSingleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 	}
 	r.Client.Scheme().Default(instance)
 
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
 		if err := vlsingle.CreateOrUpdate(ctx, r, instance); err != nil {
 			return result, fmt.Errorf("failed create or update vlsingle: %w", err)
 		}
@@ -92,7 +92,7 @@ func (r *VLSingleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 	})
 
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	}
 
 	return
@@ -102,6 +102,7 @@ func (r *VLSingleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (r
 func (r *VLSingleReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1.VLSingle{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1.VLSingleList { return &vmv1.VLSingleList{} })).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		WithOptions(getDefaultOptions()).
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -115,15 +116,21 @@ func (r *VMAgentReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 	r.Client.Scheme().Default(instance)
 
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
-		if err := vmagent.CreateOrUpdate(ctx, instance, r); err != nil {
+	trackedInstance := instance.DeepCopy()
+	var untilNextWindow time.Duration
+	result, err = reconcileAndTrackStatus(ctx, r.Client, trackedInstance, func(ctx context.Context) (ctrl.Result, error) {
+		var err error
+		untilNextWindow, err = reconcileWithMaintenanceWindows(ctx, r.Client, trackedInstance, instance.Spec.MaintenanceWindows, instance.Status.PendingChanges, func(ctx context.Context) error {
+			return vmagent.CreateOrUpdate(ctx, instance, r)
+		})
+		if err != nil {
 			return result, err
 		}
 		return result, nil
 	})
 
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = maintenanceWindowRequeue(config.MustGetBaseConfig().ResyncAfterDuration(), untilNextWindow)
 	}
 
 	return
@@ -138,6 +145,7 @@ func (r *VMAgentReconciler) Scheme() *runtime.Scheme {
 func (r *VMAgentReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMAgent{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1beta1.VMAgentList { return &vmv1beta1.VMAgentList{} })).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.ServiceAccount{}).
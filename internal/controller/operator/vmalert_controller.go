@@ -18,19 +18,27 @@ package operator
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/limiter"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmalert"
@@ -66,6 +74,7 @@ func (r *VMAlertReconciler) Scheme() *runtime.Scheme {
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalerts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalerts/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalerts/finalizers,verbs=*
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsingles;vmclusters;vlsingles;vlclusters;vtsingles;vtclusters;vmauths;vmusers,verbs=get;list;watch
 func (r *VMAlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	l := r.Log.WithValues("vmalert", req.Name, "namespace", req.Namespace)
 	ctx = logger.AddToContext(ctx, l)
@@ -101,19 +110,24 @@ func (r *VMAlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 	}
 	r.Client.Scheme().Default(instance)
 
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
-		maps, err := vmalert.CreateOrUpdateRuleConfigMaps(ctx, r, instance, nil)
+	var ruleKeys map[string]types.NamespacedName
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
+		maps, keys, err := vmalert.CreateOrUpdateRuleConfigMaps(ctx, r, instance, nil)
 		if err != nil {
 			return result, err
 		}
 		if err := vmalert.CreateOrUpdate(ctx, instance, r, maps); err != nil {
 			return result, err
 		}
+		ruleKeys = keys
 		return result, nil
 	})
 
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
+		if err := vmalert.UpdateRulesHealth(ctx, r.Client, instance, ruleKeys); err != nil {
+			logger.WithContext(ctx).Error(err, "cannot update health of rule groups at VMRule status")
+		}
 	}
 
 	return
@@ -123,12 +137,78 @@ func (r *VMAlertReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 func (r *VMAlertReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMAlert{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1beta1.VMAlertList { return &vmv1beta1.VMAlertList{} })).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.ServiceAccount{}).
+		Watches(&vmv1beta1.VMSingle{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1beta1.VMCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1.VLSingle{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1.VLCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1.VTSingle{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1.VTCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1beta1.VMAuth{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1beta1.VMUser{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		WithOptions(getDefaultOptions()).
 		Complete(r)
 }
 
+// requestsForReferencedObject returns requests for VMAlert objects, which datasources, remote storages or notifiers
+// refer to the given object
+func (r *VMAlertReconciler) requestsForReferencedObject(ctx context.Context, obj client.Object) []k8sreconcile.Request {
+	var objects vmv1beta1.VMAlertList
+	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.GetWatchNamespaces(), func(dst *vmv1beta1.VMAlertList) {
+		objects.Items = append(objects.Items, dst.Items...)
+	}); err != nil {
+		r.Log.Error(err, "cannot list vmalerts for referenced object")
+		return nil
+	}
+	var requests []k8sreconcile.Request
+	for i := range objects.Items {
+		item := &objects.Items[i]
+		if !item.DeletionTimestamp.IsZero() || item.Spec.ParsingError != "" {
+			continue
+		}
+		refs := []*vmv1beta1.DatasourceRef{item.Spec.Datasource.Ref}
+		for j := range item.Spec.Datasources {
+			refs = append(refs, item.Spec.Datasources[j].Ref)
+		}
+		if item.Spec.RemoteWrite != nil {
+			refs = append(refs, item.Spec.RemoteWrite.Ref)
+		}
+		if item.Spec.RemoteRead != nil {
+			refs = append(refs, item.Spec.RemoteRead.Ref)
+		}
+		if item.Spec.Notifier != nil {
+			refs = append(refs, item.Spec.Notifier.Ref)
+		}
+		for j := range item.Spec.Notifiers {
+			refs = append(refs, item.Spec.Notifiers[j].Ref)
+		}
+		if slices.ContainsFunc(refs, func(ref *vmv1beta1.DatasourceRef) bool {
+			return isDatasourceRefMatches(ref, obj, item.Namespace)
+		}) {
+			requests = append(requests, k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+		}
+	}
+	return requests
+}
+
 // IsDisabled returns true if controller should be disabled
 func (*VMAlertReconciler) IsDisabled(_ *config.BaseOperatorConf, _ sets.Set[string]) bool {
 	return false
@@ -24,13 +24,19 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/limiter"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmalertmanager"
@@ -102,7 +108,7 @@ func (r *VMAlertmanagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	}
 	r.Client.Scheme().Default(instance)
 
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
 		if err := vmalertmanager.CreateOrUpdateConfig(ctx, r.Client, instance, nil); err != nil {
 			return result, err
 		}
@@ -113,7 +119,7 @@ func (r *VMAlertmanagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	})
 
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	}
 
 	return
@@ -123,12 +129,53 @@ func (r *VMAlertmanagerReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 func (r *VMAlertmanagerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMAlertmanager{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1beta1.VMAlertmanagerList { return &vmv1beta1.VMAlertmanagerList{} })).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.ServiceAccount{}).
+		Watches(&vmv1beta1.VMAlertmanager{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForPeerRefs),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		WithOptions(getDefaultOptions()).
 		Complete(r)
 }
 
+// requestsForPeerRefs returns requests for VMAlertmanager objects, which reference the given one at spec.peerRefs,
+// and for objects referenced by the given one.
+// It allows to update cluster peers on replicas count changes and to enable gossip listener at referenced peers
+func (r *VMAlertmanagerReconciler) requestsForPeerRefs(ctx context.Context, obj client.Object) []k8sreconcile.Request {
+	var requests []k8sreconcile.Request
+	if am, ok := obj.(*vmv1beta1.VMAlertmanager); ok {
+		for _, ref := range am.Spec.PeerRefs {
+			if ref.ServiceName != "" {
+				continue
+			}
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = am.Namespace
+			}
+			// objects outside of watched namespaces cannot be reconciled
+			if !r.BaseConf.IsWatchedNamespace(namespace) {
+				continue
+			}
+			requests = append(requests, k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: ref.Name, Namespace: namespace}})
+		}
+	}
+	var objects vmv1beta1.VMAlertmanagerList
+	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.GetWatchNamespaces(), func(dst *vmv1beta1.VMAlertmanagerList) {
+		objects.Items = append(objects.Items, dst.Items...)
+	}); err != nil {
+		r.Log.Error(err, "cannot list vmalertmanagers for peerRefs")
+		return requests
+	}
+	for i := range objects.Items {
+		item := &objects.Items[i]
+		if vmalertmanager.IsPeerReferenced(item, obj.GetName(), obj.GetNamespace()) {
+			requests = append(requests, k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+		}
+	}
+	return requests
+}
+
 // IsDisabled returns true if controller should be disabled
 func (*VMAlertmanagerReconciler) IsDisabled(_ *config.BaseOperatorConf, _ sets.Set[string]) bool {
 	return false
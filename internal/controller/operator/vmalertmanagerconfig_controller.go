@@ -78,7 +78,7 @@ func (r *VMAlertmanagerConfigReconciler) Reconcile(ctx context.Context, req ctrl
 	alertmanagerSync.Lock()
 	defer alertmanagerSync.Unlock()
 	var objects vmv1beta1.VMAlertmanagerList
-	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.WatchNamespaces, func(dst *vmv1beta1.VMAlertmanagerList) {
+	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.GetWatchNamespaces(), func(dst *vmv1beta1.VMAlertmanagerList) {
 		objects.Items = append(objects.Items, dst.Items...)
 	}); err != nil {
 		return result, fmt.Errorf("cannot list vmalertmanagers for vmalertmanagerconfig: %w", err)
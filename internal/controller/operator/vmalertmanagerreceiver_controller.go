@@ -0,0 +1,204 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmalertmanager"
+)
+
+// VMAlertmanagerReceiverReconciler reconciles a VMAlertmanagerReceiver object
+type VMAlertmanagerReceiverReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VMAlertmanagerReceiverReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VMAlertmanagerReceiver")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMAlertmanagerReceiverReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile implements interface
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalertmanagerreceivers,verbs=get;list;watch
+func (r *VMAlertmanagerReceiverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, resultErr error) {
+	l := r.Log.WithValues("vmalertmanagerreceiver", req.Name, "namespace", req.Namespace)
+	var instance vmv1beta1.VMAlertmanagerReceiver
+	defer func() {
+		result, resultErr = handleReconcileErrWithoutStatus(ctx, r.Client, &instance, result, resultErr)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return result, &getError{err, "vmalertmanagerreceiver", req}
+		}
+		// deleted receiver must be removed from alertmanager configs
+		deregisterObjectByCollector(req.Name, req.Namespace, "vmalertmanagerreceiver")
+	} else {
+		RegisterObjectStat(&instance, "vmalertmanagerreceiver")
+	}
+	return result, syncAlertmanagersWithSharedReceiver(ctx, r.Client, l, r.BaseConf, vmv1beta1.VMAlertmanagerReceiverKind, req.Namespace, req.Name)
+}
+
+// SetupWithManager configures reconcile
+func (r *VMAlertmanagerReceiverReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMAlertmanagerReceiver{}).
+		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
+
+// IsDisabled returns true if controller should be disabled
+func (*VMAlertmanagerReceiverReconciler) IsDisabled(_ *config.BaseOperatorConf, disabledControllers sets.Set[string]) bool {
+	return disabledControllers.Has("VMAlertmanager")
+}
+
+// VMAlertmanagerClusterReceiverReconciler reconciles a VMAlertmanagerClusterReceiver object
+type VMAlertmanagerClusterReceiverReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VMAlertmanagerClusterReceiverReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VMAlertmanagerClusterReceiver")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMAlertmanagerClusterReceiverReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile implements interface
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmalertmanagerclusterreceivers,verbs=get;list;watch
+func (r *VMAlertmanagerClusterReceiverReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, resultErr error) {
+	l := r.Log.WithValues("vmalertmanagerclusterreceiver", req.Name)
+	var instance vmv1beta1.VMAlertmanagerClusterReceiver
+	defer func() {
+		result, resultErr = handleReconcileErrWithoutStatus(ctx, r.Client, &instance, result, resultErr)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return result, &getError{err, "vmalertmanagerclusterreceiver", req}
+		}
+		// deleted receiver must be removed from alertmanager configs
+		deregisterObjectByCollector(req.Name, req.Namespace, "vmalertmanagerclusterreceiver")
+	} else {
+		RegisterObjectStat(&instance, "vmalertmanagerclusterreceiver")
+	}
+	return result, syncAlertmanagersWithSharedReceiver(ctx, r.Client, l, r.BaseConf, vmv1beta1.VMAlertmanagerClusterReceiverKind, "", req.Name)
+}
+
+// SetupWithManager configures reconcile
+func (r *VMAlertmanagerClusterReceiverReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMAlertmanagerClusterReceiver{}).
+		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
+
+// IsDisabled returns true if controller should be disabled
+//
+// cluster-scoped objects cannot be watched without cluster-wide access
+func (*VMAlertmanagerClusterReceiverReconciler) IsDisabled(cfg *config.BaseOperatorConf, disabledControllers sets.Set[string]) bool {
+	return disabledControllers.Has("VMAlertmanager") || len(cfg.WatchNamespaces) > 0 || cfg.WatchNamespaceSelector != ""
+}
+
+// syncAlertmanagersWithSharedReceiver rebuilds configuration of VMAlertmanagers,
+// which select VMAlertmanagerConfigs referencing the given shared receiver
+func syncAlertmanagersWithSharedReceiver(ctx context.Context, rclient client.Client, l logr.Logger, cfg *config.BaseOperatorConf, kind, namespace, name string) error {
+	if alertmanagerReconcileLimit.MustThrottleReconcile() {
+		return nil
+	}
+	alertmanagerSync.Lock()
+	defer alertmanagerSync.Unlock()
+	var configs []*vmv1beta1.VMAlertmanagerConfig
+	if err := k8stools.ListObjectsByNamespace(ctx, rclient, cfg.GetWatchNamespaces(), func(dst *vmv1beta1.VMAlertmanagerConfigList) {
+		for i := range dst.Items {
+			item := &dst.Items[i]
+			if vmalertmanager.IsReceiverReferenced(item, kind, namespace, name) {
+				configs = append(configs, item)
+			}
+		}
+	}); err != nil {
+		return fmt.Errorf("cannot list vmalertmanagerconfigs for shared receiver: %w", err)
+	}
+	if len(configs) == 0 {
+		return nil
+	}
+	var objects vmv1beta1.VMAlertmanagerList
+	if err := k8stools.ListObjectsByNamespace(ctx, rclient, cfg.GetWatchNamespaces(), func(dst *vmv1beta1.VMAlertmanagerList) {
+		objects.Items = append(objects.Items, dst.Items...)
+	}); err != nil {
+		return fmt.Errorf("cannot list vmalertmanagers for shared receiver: %w", err)
+	}
+	for i := range objects.Items {
+		item := &objects.Items[i]
+		if !item.DeletionTimestamp.IsZero() || item.Spec.ParsingError != "" || item.IsUnmanaged() {
+			continue
+		}
+		l := l.WithValues("vmalertmanager", item.Name, "parent_namespace", item.Namespace)
+		ctx := logger.AddToContext(ctx, l)
+		match, err := isAlertmanagerSelectsAnyConfig(ctx, rclient, item, configs)
+		if err != nil {
+			l.Error(err, "cannot match alertmanager against selector, probably bug")
+			continue
+		}
+		if !match {
+			continue
+		}
+		if err := vmalertmanager.CreateOrUpdateConfig(ctx, rclient, item, nil); err != nil {
+			l.Error(err, "cannot update vmalertmanager config with shared receiver")
+			continue
+		}
+	}
+	return nil
+}
+
+func isAlertmanagerSelectsAnyConfig(ctx context.Context, rclient client.Client, am *vmv1beta1.VMAlertmanager, configs []*vmv1beta1.VMAlertmanagerConfig) (bool, error) {
+	for _, amCfg := range configs {
+		opts := &k8stools.SelectorOpts{
+			SelectAll:         am.Spec.SelectAllByDefault,
+			NamespaceSelector: am.Spec.ConfigNamespaceSelector,
+			ObjectSelector:    am.Spec.ConfigSelector,
+			DefaultNamespace:  amCfg.Namespace,
+		}
+		match, err := isSelectorsMatchesTargetCRD(ctx, rclient, amCfg, am, opts)
+		if err != nil {
+			return false, err
+		}
+		if match {
+			return true, nil
+		}
+	}
+	return false, nil
+}
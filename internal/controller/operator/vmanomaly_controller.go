@@ -18,18 +18,27 @@ package operator
 
 import (
 	"context"
+	"fmt"
+	"slices"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmanomaly"
 )
@@ -54,6 +63,10 @@ func (r *VMAnomalyReconciler) Init(rclient client.Client, l logr.Logger, sc *run
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmanomalies,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmanomalies/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmanomalies/finalizers,verbs=*
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmanomalymodels;vmanomalyschedulers;vmanomalyqueries,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmanomalymodels/status;vmanomalyschedulers/status;vmanomalyqueries/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmrules,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsingles;vmclusters;vmauths;vmusers,verbs=get;list;watch
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=*
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=*
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;create,update;list
@@ -90,7 +103,7 @@ func (r *VMAnomalyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	}
 	r.Client.Scheme().Default(instance)
 
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
 		if err := vmanomaly.CreateOrUpdate(ctx, instance, r); err != nil {
 			return result, err
 		}
@@ -98,7 +111,7 @@ func (r *VMAnomalyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	})
 
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	}
 
 	return
@@ -108,12 +121,109 @@ func (r *VMAnomalyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 func (r *VMAnomalyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1.VMAnomaly{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1.VMAnomalyList { return &vmv1.VMAnomalyList{} })).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.ServiceAccount{}).
+		Watches(&vmv1.VMAnomalyModel{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForSelectedObject),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
+		Watches(&vmv1.VMAnomalyScheduler{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForSelectedObject),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
+		Watches(&vmv1.VMAnomalyQuery{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForSelectedObject),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{}))).
+		Watches(&vmv1beta1.VMSingle{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1beta1.VMCluster{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1beta1.VMAuth{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&vmv1beta1.VMUser{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForReferencedObject),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		WithOptions(getDefaultOptions()).
 		Complete(r)
 }
 
+// requestsForSelectedObject returns requests for VMAnomaly objects, which select the given VMAnomalyModel, VMAnomalyScheduler or VMAnomalyQuery.
+// Configuration of the matched VMAnomaly must be rebuilt and rolled out to all shards
+func (r *VMAnomalyReconciler) requestsForSelectedObject(ctx context.Context, obj client.Object) []k8sreconcile.Request {
+	var objects vmv1.VMAnomalyList
+	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.GetWatchNamespaces(), func(dst *vmv1.VMAnomalyList) {
+		objects.Items = append(objects.Items, dst.Items...)
+	}); err != nil {
+		r.Log.Error(err, "cannot list vmanomalies for selected object")
+		return nil
+	}
+	var requests []k8sreconcile.Request
+	for i := range objects.Items {
+		item := &objects.Items[i]
+		if !item.DeletionTimestamp.IsZero() || item.Spec.ParsingError != "" {
+			continue
+		}
+		opts := &k8stools.SelectorOpts{DefaultNamespace: item.Namespace}
+		switch obj.(type) {
+		case *vmv1.VMAnomalyModel:
+			opts.ObjectSelector = item.Spec.ModelSelector
+			opts.NamespaceSelector = item.Spec.ModelNamespaceSelector
+		case *vmv1.VMAnomalyScheduler:
+			opts.ObjectSelector = item.Spec.SchedulerSelector
+			opts.NamespaceSelector = item.Spec.SchedulerNamespaceSelector
+		case *vmv1.VMAnomalyQuery:
+			opts.ObjectSelector = item.Spec.QuerySelector
+			opts.NamespaceSelector = item.Spec.QueryNamespaceSelector
+		default:
+			r.Log.Error(fmt.Errorf("unexpected object type %T", obj), "BUG: cannot match vmanomaly and selected object")
+			return nil
+		}
+		match, err := isSelectorsMatchesTargetCRD(ctx, r.Client, obj, item, opts)
+		if err != nil {
+			r.Log.Error(err, "cannot match vmanomaly and selected object")
+			continue
+		}
+		if match {
+			requests = append(requests, k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+		}
+	}
+	return requests
+}
+
+// requestsForReferencedObject returns requests for VMAnomaly objects, which reader or writer refers to the given
+// VMSingle, VMCluster, VMAuth or VMUser
+func (r *VMAnomalyReconciler) requestsForReferencedObject(ctx context.Context, obj client.Object) []k8sreconcile.Request {
+	var objects vmv1.VMAnomalyList
+	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.GetWatchNamespaces(), func(dst *vmv1.VMAnomalyList) {
+		objects.Items = append(objects.Items, dst.Items...)
+	}); err != nil {
+		r.Log.Error(err, "cannot list vmanomalies for referenced object")
+		return nil
+	}
+	var requests []k8sreconcile.Request
+	for i := range objects.Items {
+		item := &objects.Items[i]
+		if !item.DeletionTimestamp.IsZero() || item.Spec.ParsingError != "" {
+			continue
+		}
+		var refs []*vmv1beta1.DatasourceRef
+		if item.Spec.Reader != nil {
+			refs = append(refs, item.Spec.Reader.Ref)
+		}
+		if item.Spec.Writer != nil {
+			refs = append(refs, item.Spec.Writer.Ref)
+		}
+		if slices.ContainsFunc(refs, func(ref *vmv1beta1.DatasourceRef) bool {
+			return isDatasourceRefMatches(ref, obj, item.Namespace)
+		}) {
+			requests = append(requests, k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+		}
+	}
+	return requests
+}
+
 // IsDisabled returns true if controller should be disabled
 func (*VMAnomalyReconciler) IsDisabled(_ *config.BaseOperatorConf, _ sets.Set[string]) bool {
 	return false
@@ -3,6 +3,7 @@ package operator
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	appsv1 "k8s.io/api/apps/v1"
@@ -15,6 +16,7 @@ import (
 	"github.com/VictoriaMetrics/operator/internal/config"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
 	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmcluster"
 )
 
@@ -71,17 +73,43 @@ func (r *VMClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 	if err := finalize.AddFinalizer(ctx, r.Client, instance); err != nil {
 		return result, err
 	}
+	if instance.Spec.ReconcileMode != vmv1beta1.ReconcileModePlan && instance.Status.Plan != nil {
+		if err := reconcile.UpdateObjectPlan(ctx, r.Client, instance, nil); err != nil {
+			return result, err
+		}
+	}
 	r.Client.Scheme().Default(instance)
 
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
-		if err := vmcluster.CreateOrUpdate(ctx, instance, r.Client); err != nil {
+	if instance.Spec.ReconcileMode == vmv1beta1.ReconcileModePlan {
+		var untilNextWindow time.Duration
+		err = reconcilePlan(ctx, r.Client, instance, instance.Status.Plan, func(rclient client.Client) error {
+			var err error
+			untilNextWindow, err = planWithMaintenanceWindows(ctx, instance.Spec.MaintenanceWindows, func(ctx context.Context) error {
+				return vmcluster.CreateOrUpdate(ctx, instance, rclient)
+			})
+			return err
+		})
+		if err == nil {
+			result.RequeueAfter = maintenanceWindowRequeue(config.MustGetBaseConfig().ResyncAfterDuration(), untilNextWindow)
+		}
+		return
+	}
+
+	trackedInstance := instance.DeepCopy()
+	var untilNextWindow time.Duration
+	result, err = reconcileAndTrackStatus(ctx, r.Client, trackedInstance, func(ctx context.Context) (ctrl.Result, error) {
+		var err error
+		untilNextWindow, err = reconcileWithMaintenanceWindows(ctx, r.Client, trackedInstance, instance.Spec.MaintenanceWindows, instance.Status.PendingChanges, func(ctx context.Context) error {
+			return vmcluster.CreateOrUpdate(ctx, instance, r.Client)
+		})
+		if err != nil {
 			return result, fmt.Errorf("failed create or update vmcluster: %w", err)
 		}
 		return result, nil
 	})
 
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = maintenanceWindowRequeue(config.MustGetBaseConfig().ResyncAfterDuration(), untilNextWindow)
 	}
 
 	return
@@ -91,6 +119,7 @@ func (r *VMClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reques
 func (r *VMClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&vmv1beta1.VMCluster{}).
+		WatchesRawSource(newConfigReloadSource(mgr.GetClient(), func() *vmv1beta1.VMClusterList { return &vmv1beta1.VMClusterList{} })).
 		Owns(&appsv1.Deployment{}).
 		Owns(&appsv1.StatefulSet{}).
 		WithOptions(getDefaultOptions()).
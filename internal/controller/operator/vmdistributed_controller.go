@@ -87,7 +87,7 @@ func (r *VMDistributedReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return result, err
 	}
 	r.Client.Scheme().Default(instance)
-	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func() (ctrl.Result, error) {
+	result, err = reconcileAndTrackStatus(ctx, r.Client, instance.DeepCopy(), func(ctx context.Context) (ctrl.Result, error) {
 		if err := vmdistributed.CreateOrUpdate(ctx, instance, r); err != nil {
 			return result, fmt.Errorf("VMDistributed %s update failed: %w", instance.Name, err)
 		}
@@ -95,7 +95,7 @@ func (r *VMDistributedReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		return result, nil
 	})
 	if err == nil {
-		result.RequeueAfter = r.BaseConf.ResyncAfterDuration()
+		result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
 	}
 	return
 }
@@ -74,11 +74,11 @@ func (r *VMNodeScrapeReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return result, &parsingError{instance.Spec.ParsingError, "vmnodescrape"}
 	}
 
-	if err = collectVMAgentScrapes(l, ctx, r.Client, r.BaseConf.WatchNamespaces, instance); err != nil {
+	if err = collectVMAgentScrapes(l, ctx, r.Client, r.BaseConf.GetWatchNamespaces(), instance); err != nil {
 		return
 	}
 
-	if err = collectVMSingleScrapes(l, ctx, r.Client, r.BaseConf.WatchNamespaces, instance); err != nil {
+	if err = collectVMSingleScrapes(l, ctx, r.Client, r.BaseConf.GetWatchNamespaces(), instance); err != nil {
 		return
 	}
 
@@ -0,0 +1,159 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+// VMOperatorConfigReconciler reconciles a VMOperatorConfig object
+// and reloads operator configuration
+type VMOperatorConfigReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+
+	// applied holds overrides of the last successfully applied VMOperatorConfig
+	applied map[string]string
+	// elected is closed once the current replica is elected as leader,
+	// only leader updates status
+	elected <-chan struct{}
+}
+
+// Init implements crdController interface
+func (r *VMOperatorConfigReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VMOperatorConfig")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMOperatorConfigReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile implements interface
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmoperatorconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmoperatorconfigs/status,verbs=get;update;patch
+func (r *VMOperatorConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, resultErr error) {
+	l := r.Log.WithValues("vmoperatorconfig", req.Name)
+	var instance vmv1beta1.VMOperatorConfig
+	defer func() {
+		result, resultErr = handleReconcileErrWithoutStatus(ctx, r.Client, &instance, result, resultErr)
+	}()
+	if req.Name != vmv1beta1.VMOperatorConfigName {
+		l.Info(fmt.Sprintf("skipping object, only %q name is supported", vmv1beta1.VMOperatorConfigName))
+		return
+	}
+
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return result, &getError{err, "vmoperatorconfig", req}
+		}
+		deregisterObjectByCollector(req.Name, req.Namespace, "vmoperatorconfig")
+		// configuration object was deleted, fallback to env variables
+		if err := config.Reload(nil); err != nil {
+			return result, fmt.Errorf("cannot restore operator configuration from env variables: %w", err)
+		}
+		r.applied = nil
+		l.Info("operator configuration restored from env variables")
+		return
+	}
+	RegisterObjectStat(&instance, "vmoperatorconfig")
+
+	overrides, syncErr := operatorConfigOverrides(&instance)
+	if syncErr == nil {
+		syncErr = config.Reload(overrides)
+	}
+	if syncErr != nil {
+		// previously applied configuration is kept
+		l.Error(syncErr, "cannot apply operator configuration")
+	} else {
+		r.applied = overrides
+		l.Info("operator configuration reloaded")
+	}
+
+	// all operator replicas reload configuration, but only leader of primary shard reports status
+	if !IsPrimaryShard() || !r.isLeader() {
+		return
+	}
+	effectiveConfig, err := config.EffectiveVariables(r.applied)
+	if err != nil {
+		return result, fmt.Errorf("cannot get effective operator configuration: %w", err)
+	}
+	return result, reconcile.VMOperatorConfigStatus(ctx, r.Client, &instance, effectiveConfig, syncErr)
+}
+
+// InitOperatorConfig applies VMOperatorConfig before controllers start,
+// so objects are not reconciled with configuration from env variables
+func InitOperatorConfig(ctx context.Context, reader client.Reader) error {
+	var instance vmv1beta1.VMOperatorConfig
+	if err := reader.Get(ctx, types.NamespacedName{Name: vmv1beta1.VMOperatorConfigName}, &instance); err != nil {
+		if k8serrors.IsNotFound(err) || apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get VMOperatorConfig=%s: %w", vmv1beta1.VMOperatorConfigName, err)
+	}
+	overrides, err := operatorConfigOverrides(&instance)
+	if err != nil {
+		return err
+	}
+	return config.Reload(overrides)
+}
+
+// operatorConfigOverrides validates VMOperatorConfig and returns its overrides of env variables
+func operatorConfigOverrides(cr *vmv1beta1.VMOperatorConfig) (map[string]string, error) {
+	if err := cr.Validate(); err != nil {
+		return nil, err
+	}
+	return config.OverridesFromSpec(&cr.Spec)
+}
+
+func (r *VMOperatorConfigReconciler) isLeader() bool {
+	if r.elected == nil {
+		return true
+	}
+	select {
+	case <-r.elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// SetupWithManager configures reconcile
+func (r *VMOperatorConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	opts := getDefaultOptions()
+	// configuration must be reloaded by all shards and replicas
+	opts.NewQueue = nil
+	opts.NeedLeaderElection = ptr.To(false)
+	r.elected = mgr.Elected()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMOperatorConfig{}).
+		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
+		WithOptions(opts).
+		Complete(r)
+}
+
+// IsDisabled returns true if controller should be disabled
+//
+// cluster-scoped objects cannot be watched without cluster-wide access
+func (*VMOperatorConfigReconciler) IsDisabled(cfg *config.BaseOperatorConf, _ sets.Set[string]) bool {
+	return len(cfg.WatchNamespaces) > 0 || cfg.WatchNamespaceSelector != ""
+}
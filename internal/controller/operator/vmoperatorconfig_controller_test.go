@@ -0,0 +1,229 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+)
+
+func TestVMOperatorConfigReconcile(t *testing.T) {
+	defer func() {
+		assert.NoError(t, config.Reload(nil))
+	}()
+	ctx := context.Background()
+	defaultVersion := config.MustGetBaseConfig().VMSingle.Version
+	cr := &vmv1beta1.VMOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: vmv1beta1.VMOperatorConfigName},
+		Spec: vmv1beta1.VMOperatorConfigSpec{
+			MetricsVersion: ptr.To("v1.100.0"),
+		},
+	}
+	rclient := k8stools.GetTestClientWithObjects([]runtime.Object{cr})
+	r := &VMOperatorConfigReconciler{
+		Client: rclient,
+		Log:    logr.Discard(),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: vmv1beta1.VMOperatorConfigName}}
+	reconcileAndGet := func() *vmv1beta1.VMOperatorConfig {
+		t.Helper()
+		_, err := r.Reconcile(ctx, req)
+		assert.NoError(t, err)
+		var got vmv1beta1.VMOperatorConfig
+		assert.NoError(t, rclient.Get(ctx, req.NamespacedName, &got))
+		return &got
+	}
+	update := func(spec vmv1beta1.VMOperatorConfigSpec) {
+		t.Helper()
+		var got vmv1beta1.VMOperatorConfig
+		assert.NoError(t, rclient.Get(ctx, req.NamespacedName, &got))
+		got.Spec = spec
+		assert.NoError(t, rclient.Update(ctx, &got))
+	}
+
+	// overrides are applied and subscribers are notified
+	reloaded := config.Reloaded()
+	got := reconcileAndGet()
+	assert.Equal(t, vmv1beta1.UpdateStatusOperational, got.Status.UpdateStatus)
+	assert.Equal(t, "v1.100.0", got.Status.EffectiveConfig["VM_VMSINGLEDEFAULT_VERSION"])
+	assert.Equal(t, "v1.100.0", config.MustGetBaseConfig().VMSingle.Version)
+	select {
+	case <-reloaded:
+	default:
+		t.Fatalf("expected notification about configuration reload")
+	}
+
+	// invalid configuration isn't applied
+	update(vmv1beta1.VMOperatorConfigSpec{
+		MetricsVersion: ptr.To("v1.110.0"),
+		VMSingle: &vmv1beta1.OperatorConfigApp{
+			Resource: &vmv1beta1.OperatorConfigResource{
+				Limit: &vmv1beta1.OperatorConfigResourceValues{Mem: ptr.To("bad")},
+			},
+		},
+	})
+	got = reconcileAndGet()
+	assert.Equal(t, vmv1beta1.UpdateStatusFailed, got.Status.UpdateStatus)
+	assert.Contains(t, got.Status.Reason, "cannot parse resource limit memory")
+	assert.Equal(t, "v1.100.0", got.Status.EffectiveConfig["VM_VMSINGLEDEFAULT_VERSION"])
+	assert.Equal(t, "v1.100.0", config.MustGetBaseConfig().VMSingle.Version)
+
+	// prometheus converter could be enabled at runtime
+	update(vmv1beta1.VMOperatorConfigSpec{
+		EnabledPrometheusConverter: &vmv1beta1.OperatorConfigPrometheusConverter{
+			Prometheus:     ptr.To(true),
+			PrometheusRule: ptr.To(false),
+		},
+	})
+	got = reconcileAndGet()
+	assert.Equal(t, vmv1beta1.UpdateStatusOperational, got.Status.UpdateStatus)
+	assert.True(t, config.MustGetBaseConfig().EnabledPrometheusConverter.Prometheus)
+	assert.False(t, config.MustGetBaseConfig().EnabledPrometheusConverter.PrometheusRule)
+	assert.Equal(t, defaultVersion, config.MustGetBaseConfig().VMSingle.Version)
+
+	// only leader updates status
+	r.elected = make(chan struct{})
+	update(vmv1beta1.VMOperatorConfigSpec{
+		EnableStrictSecurity: ptr.To(true),
+	})
+	got = reconcileAndGet()
+	assert.True(t, config.MustGetBaseConfig().EnableStrictSecurity)
+	assert.Equal(t, "false", got.Status.EffectiveConfig["VM_ENABLESTRICTSECURITY"])
+	r.elected = nil
+
+	// configuration is restored from env variables after object deletion
+	assert.NoError(t, rclient.Delete(ctx, got))
+	_, err := r.Reconcile(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultVersion, config.MustGetBaseConfig().VMSingle.Version)
+	assert.False(t, config.MustGetBaseConfig().EnableStrictSecurity)
+}
+
+func TestOperatorConfigOverrides(t *testing.T) {
+	f := func(spec vmv1beta1.VMOperatorConfigSpec, want map[string]string) {
+		t.Helper()
+		cr := &vmv1beta1.VMOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: vmv1beta1.VMOperatorConfigName},
+			Spec:       spec,
+		}
+		got, err := operatorConfigOverrides(cr)
+		assert.NoError(t, err)
+		assert.Equal(t, want, got)
+		_, err = config.ParseWithOverrides(got)
+		assert.NoError(t, err)
+	}
+
+	// empty spec
+	f(vmv1beta1.VMOperatorConfigSpec{}, map[string]string{})
+
+	// nested fields
+	f(vmv1beta1.VMOperatorConfigSpec{
+		TracesVersion: ptr.To("v0.8.0"),
+		VMAgent: &vmv1beta1.OperatorConfigApp{
+			UseDefaultResources: ptr.To(false),
+			Resource: &vmv1beta1.OperatorConfigResource{
+				Limit: &vmv1beta1.OperatorConfigResourceValues{
+					CPU:              ptr.To("1"),
+					EphemeralStorage: ptr.To("1Gi"),
+				},
+			},
+		},
+		VMAlertmanager: &vmv1beta1.OperatorConfigApp{
+			Image:   ptr.To("quay.io/prometheus/alertmanager"),
+			Version: ptr.To("v0.30.0"),
+		},
+		VMCluster: &vmv1beta1.OperatorConfigVMCluster{
+			Storage: &vmv1beta1.OperatorConfigVMStorage{
+				OperatorConfigComponent: vmv1beta1.OperatorConfigComponent{Port: ptr.To("8483")},
+				VMInsertPort:            ptr.To("8402"),
+			},
+		},
+		VTCluster: &vmv1beta1.OperatorConfigCluster{
+			Select: &vmv1beta1.OperatorConfigComponent{Image: ptr.To("victoriametrics/victoria-traces-select")},
+		},
+		FilterPrometheusConverterLabelPrefixes: []string{"app", "team"},
+		ForceResyncInterval:                    &metav1.Duration{Duration: 30 * time.Second},
+		MaintenanceWindow: &vmv1beta1.MaintenanceWindow{
+			Schedule: "0 2 * * 6",
+			Duration: "2h",
+		},
+	}, map[string]string{
+		"VM_TRACES_VERSION":                                  "v0.8.0",
+		"VM_VMAGENTDEFAULT_USEDEFAULTRESOURCES":              "false",
+		"VM_VMAGENTDEFAULT_RESOURCE_LIMIT_CPU":               "1",
+		"VM_VMAGENTDEFAULT_RESOURCE_LIMIT_EPHEMERAL_STORAGE": "1Gi",
+		"VM_VMALERTMANAGER_ALERTMANAGERDEFAULTBASEIMAGE":     "quay.io/prometheus/alertmanager",
+		"VM_VMALERTMANAGER_ALERTMANAGERVERSION":              "v0.30.0",
+		"VM_VMCLUSTERDEFAULT_VMSTORAGEDEFAULT_PORT":          "8483",
+		"VM_VMCLUSTERDEFAULT_VMSTORAGEDEFAULT_VMINSERTPORT":  "8402",
+		"VM_VTCLUSTERDEFAULT_SELECT_IMAGE":                   "victoriametrics/victoria-traces-select",
+		"VM_FILTERPROMETHEUSCONVERTERLABELPREFIXES":          "app,team",
+		"VM_FORCERESYNCINTERVAL":                             "30s",
+		"VM_MAINTENANCEWINDOW_SCHEDULE":                      "0 2 * * 6",
+		"VM_MAINTENANCEWINDOW_DURATION":                      "2h",
+	})
+}
+
+// TestOperatorConfigOverridesAllFields checks, that all fields of VMOperatorConfig spec
+// are mapped to known variables of operator configuration and all runtime variables could be overridden
+func TestOperatorConfigOverridesAllFields(t *testing.T) {
+	var fill func(v reflect.Value)
+	fill = func(v reflect.Value) {
+		switch v.Kind() {
+		case reflect.Pointer:
+			v.Set(reflect.New(v.Type().Elem()))
+			fill(v.Elem())
+		case reflect.Struct:
+			if v.Type() == reflect.TypeFor[metav1.Duration]() {
+				v.Set(reflect.ValueOf(metav1.Duration{Duration: time.Minute}))
+				return
+			}
+			for i := range v.NumField() {
+				fill(v.Field(i))
+			}
+		case reflect.Slice:
+			v.Set(reflect.ValueOf([]string{"value"}))
+		case reflect.String:
+			v.SetString("value")
+		}
+	}
+	var spec vmv1beta1.VMOperatorConfigSpec
+	fill(reflect.ValueOf(&spec).Elem())
+	overrides, err := config.OverridesFromSpec(&spec)
+	assert.NoError(t, err)
+	known, err := config.EffectiveVariables(nil)
+	assert.NoError(t, err)
+	for key := range overrides {
+		assert.Contains(t, known, key)
+	}
+	var missing []string
+	for key := range known {
+		if _, ok := overrides[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	// startup only, deprecated and api discovery variables cannot be overridden
+	assert.ElementsMatch(t, []string{
+		"WATCH_NAMESPACE",
+		"WATCH_NAMESPACE_SELECTOR",
+		"VM_APPREADYTIMEOUT",
+		"VM_PODWAITREADYTIMEOUT",
+		"VM_PODWAITREADYINTERVALCHECK",
+		"VM_CUSTOMCONFIGRELOADERIMAGE",
+		"VM_PSPAUTOCREATEENABLED",
+		"VM_GATEWAY_API_ENABLED",
+		"VM_VPA_API_ENABLED",
+	}, missing)
+}
@@ -2,6 +2,7 @@ package operator
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"sync"
@@ -56,6 +57,10 @@ const (
 	IgnoreConversionLabel = "operator.victoriametrics.com/ignore-prometheus-updates"
 	// IgnoreConversion - disables updates from prometheus api
 	IgnoreConversion = "enabled"
+
+	// ConvertedFromAnnotation holds kind of prometheus object, which VMObject was converted from.
+	// It prevents VMAgent converted from Prometheus from being overwritten by PrometheusAgent with the same name
+	ConvertedFromAnnotation = "operator.victoriametrics.com/converted-from"
 )
 
 // ConverterController - watches for prometheus objects
@@ -70,15 +75,18 @@ type ConverterController struct {
 	amConfigInf     cache.SharedInformer
 	probeInf        cache.SharedIndexInformer
 	scrapeConfigInf cache.SharedIndexInformer
-	baseConf        *config.BaseOperatorConf
+	promInf         cache.SharedIndexInformer
+	promAgentInf    cache.SharedIndexInformer
+	amInf           cache.SharedIndexInformer
+	reports         conversionReports
+	informers       []*converterInformer
 }
 
 // NewConverterController builder for vmprometheusconverter service
 func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientset, rclient client.WithWatch, resyncPeriod time.Duration, baseConf *config.BaseOperatorConf) (*ConverterController, error) {
 	c := &ConverterController{
-		ctx:      ctx,
-		rclient:  rclient,
-		baseConf: baseConf,
+		ctx:     ctx,
+		rclient: rclient,
 		sd: &sharedAPIDiscoverer{
 			baseClient:       baseClient,
 			kindReadyByGroup: map[string]map[string]chan struct{}{},
@@ -90,7 +98,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
 				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 					var objects promv1.PrometheusRuleList
-					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.WatchNamespaces, func(dst *promv1.PrometheusRuleList) {
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1.PrometheusRuleList) {
 						objects.Items = append(objects.Items, dst.Items...)
 					}); err != nil {
 						return nil, fmt.Errorf("cannot list prometheus_rules: %w", err)
@@ -98,19 +106,26 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 					return &objects, nil
 				},
 				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-					return k8stools.NewObjectWatcherForNamespaces[promv1.PrometheusRuleList](ctx, rclient, "prometheus_rules", baseConf.WatchNamespaces)
+					return k8stools.NewObjectWatcherForNamespaces[promv1.PrometheusRuleList](ctx, rclient, "prometheus_rules", baseConf.GetWatchNamespaces())
 				},
 			}, rclient),
 			&promv1.PrometheusRule{},
 			resyncPeriod,
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
 		)
-		if _, err := c.ruleInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.CreatePrometheusRule,
-			UpdateFunc: c.UpdatePrometheusRule,
-		}); err != nil {
-			return nil, fmt.Errorf("cannot add prometheus_rule handler: %w", err)
-		}
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1.SchemeGroupVersion.String(),
+			kind:     promv1.PrometheusRuleKind,
+			informer: c.ruleInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.PrometheusRule
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreatePrometheusRule,
+				UpdateFunc: c.UpdatePrometheusRule,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
 	}
 
 	if !build.IsControllerDisabled("VMPodScrape") || !scrapeControllersDisabled {
@@ -118,7 +133,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
 				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 					var objects promv1.PodMonitorList
-					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.WatchNamespaces, func(dst *promv1.PodMonitorList) {
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1.PodMonitorList) {
 						objects.Items = append(objects.Items, dst.Items...)
 					}); err != nil {
 						return nil, fmt.Errorf("cannot list pod_monitors: %w", err)
@@ -126,19 +141,26 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 					return &objects, nil
 				},
 				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-					return k8stools.NewObjectWatcherForNamespaces[promv1.PodMonitorList](ctx, rclient, "pod_monitors", baseConf.WatchNamespaces)
+					return k8stools.NewObjectWatcherForNamespaces[promv1.PodMonitorList](ctx, rclient, "pod_monitors", baseConf.GetWatchNamespaces())
 				},
 			}, rclient),
 			&promv1.PodMonitor{},
 			resyncPeriod,
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
 		)
-		if _, err := c.podInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.CreatePodMonitor,
-			UpdateFunc: c.UpdatePodMonitor,
-		}); err != nil {
-			return nil, fmt.Errorf("cannot add pod_monitor handler: %w", err)
-		}
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1.SchemeGroupVersion.String(),
+			kind:     promv1.PodMonitorsKind,
+			informer: c.podInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.PodMonitor
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreatePodMonitor,
+				UpdateFunc: c.UpdatePodMonitor,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
 	}
 
 	if !build.IsControllerDisabled("VMServiceScrape") || !scrapeControllersDisabled {
@@ -146,7 +168,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
 				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 					var objects promv1.ServiceMonitorList
-					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.WatchNamespaces, func(dst *promv1.ServiceMonitorList) {
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1.ServiceMonitorList) {
 						objects.Items = append(objects.Items, dst.Items...)
 					}); err != nil {
 						return nil, fmt.Errorf("cannot list service_monitors: %w", err)
@@ -154,19 +176,26 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 					return &objects, nil
 				},
 				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-					return k8stools.NewObjectWatcherForNamespaces[promv1.ServiceMonitorList](ctx, rclient, "service_monitors", baseConf.WatchNamespaces)
+					return k8stools.NewObjectWatcherForNamespaces[promv1.ServiceMonitorList](ctx, rclient, "service_monitors", baseConf.GetWatchNamespaces())
 				},
 			}, rclient),
 			&promv1.ServiceMonitor{},
 			resyncPeriod,
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
 		)
-		if _, err := c.serviceInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.CreateServiceMonitor,
-			UpdateFunc: c.UpdateServiceMonitor,
-		}); err != nil {
-			return nil, fmt.Errorf("cannot add service_monitor handler: %w", err)
-		}
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1.SchemeGroupVersion.String(),
+			kind:     promv1.ServiceMonitorsKind,
+			informer: c.serviceInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.ServiceScrape
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreateServiceMonitor,
+				UpdateFunc: c.UpdateServiceMonitor,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
 	}
 
 	if !build.IsControllerDisabled("VMAlertmanagerConfig") || !build.IsControllerDisabled("VMAlertmanager") {
@@ -174,7 +203,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
 				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 					var objects promv1alpha1.AlertmanagerConfigList
-					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.WatchNamespaces, func(dst *promv1alpha1.AlertmanagerConfigList) {
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1alpha1.AlertmanagerConfigList) {
 						objects.Items = append(objects.Items, dst.Items...)
 					}); err != nil {
 						return nil, fmt.Errorf("cannot list alertmanager_configs: %w", err)
@@ -182,19 +211,26 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 					return &objects, nil
 				},
 				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-					return k8stools.NewObjectWatcherForNamespaces[promv1alpha1.AlertmanagerConfigList](ctx, rclient, "alertmanager_configs", baseConf.WatchNamespaces)
+					return k8stools.NewObjectWatcherForNamespaces[promv1alpha1.AlertmanagerConfigList](ctx, rclient, "alertmanager_configs", baseConf.GetWatchNamespaces())
 				},
 			}, rclient),
 			&promv1alpha1.AlertmanagerConfig{},
 			resyncPeriod,
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
 		)
-		if _, err := amConfigInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.CreateAlertmanagerConfig,
-			UpdateFunc: c.UpdateAlertmanagerConfig,
-		}); err != nil {
-			return nil, fmt.Errorf("cannot add alertmanager_config handler: %w", err)
-		}
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1alpha1.SchemeGroupVersion.String(),
+			kind:     promv1alpha1.AlertmanagerConfigKind,
+			informer: amConfigInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.AlertmanagerConfig
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreateAlertmanagerConfig,
+				UpdateFunc: c.UpdateAlertmanagerConfig,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
 		c.amConfigInf = amConfigInf
 	}
 
@@ -203,7 +239,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
 				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 					var objects promv1.ProbeList
-					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.WatchNamespaces, func(dst *promv1.ProbeList) {
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1.ProbeList) {
 						objects.Items = append(objects.Items, dst.Items...)
 					}); err != nil {
 						return nil, fmt.Errorf("cannot list probes: %w", err)
@@ -211,19 +247,26 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 					return &objects, nil
 				},
 				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-					return k8stools.NewObjectWatcherForNamespaces[promv1.ProbeList](ctx, rclient, "probes", baseConf.WatchNamespaces)
+					return k8stools.NewObjectWatcherForNamespaces[promv1.ProbeList](ctx, rclient, "probes", baseConf.GetWatchNamespaces())
 				},
 			}, rclient),
 			&promv1.Probe{},
 			resyncPeriod,
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
 		)
-		if _, err := c.probeInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.CreateProbe,
-			UpdateFunc: c.UpdateProbe,
-		}); err != nil {
-			return nil, fmt.Errorf("cannot add probe handler: %w", err)
-		}
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1.SchemeGroupVersion.String(),
+			kind:     promv1.ProbesKind,
+			informer: c.probeInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.Probe
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreateProbe,
+				UpdateFunc: c.UpdateProbe,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
 	}
 
 	if !build.IsControllerDisabled("VMScrapeConfig") || !scrapeControllersDisabled {
@@ -231,7 +274,7 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
 				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
 					var objects promv1alpha1.ScrapeConfigList
-					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.WatchNamespaces, func(dst *promv1alpha1.ScrapeConfigList) {
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1alpha1.ScrapeConfigList) {
 						objects.Items = append(objects.Items, dst.Items...)
 					}); err != nil {
 						return nil, fmt.Errorf("cannot list scrapeConfig: %w", err)
@@ -239,19 +282,131 @@ func NewConverterController(ctx context.Context, baseClient *kubernetes.Clientse
 					return &objects, nil
 				},
 				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-					return k8stools.NewObjectWatcherForNamespaces[promv1alpha1.ScrapeConfigList](ctx, rclient, "scrape_configs", baseConf.WatchNamespaces)
+					return k8stools.NewObjectWatcherForNamespaces[promv1alpha1.ScrapeConfigList](ctx, rclient, "scrape_configs", baseConf.GetWatchNamespaces())
 				},
 			}, rclient),
 			&promv1alpha1.ScrapeConfig{},
 			resyncPeriod,
 			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
 		)
-		if _, err := c.scrapeConfigInf.AddEventHandler(cache.ResourceEventHandlerFuncs{
-			AddFunc:    c.CreateScrapeConfig,
-			UpdateFunc: c.UpdateScrapeConfig,
-		}); err != nil {
-			return nil, fmt.Errorf("cannot add scrapeConfig handler: %w", err)
-		}
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1alpha1.SchemeGroupVersion.String(),
+			kind:     promv1alpha1.ScrapeConfigsKind,
+			informer: c.scrapeConfigInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.ScrapeConfig
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreateScrapeConfig,
+				UpdateFunc: c.UpdateScrapeConfig,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
+	}
+
+	if !build.IsControllerDisabled("VMAgent") && !build.IsControllerDisabled("VMSingle") {
+		c.promInf = cache.NewSharedIndexInformer(
+			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					var objects promv1.PrometheusList
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1.PrometheusList) {
+						objects.Items = append(objects.Items, dst.Items...)
+					}); err != nil {
+						return nil, fmt.Errorf("cannot list prometheuses: %w", err)
+					}
+					return &objects, nil
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return k8stools.NewObjectWatcherForNamespaces[promv1.PrometheusList](ctx, rclient, "prometheuses", baseConf.GetWatchNamespaces())
+				},
+			}, rclient),
+			&promv1.Prometheus{},
+			resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		)
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1.SchemeGroupVersion.String(),
+			kind:     promv1.PrometheusesKind,
+			informer: c.promInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.Prometheus
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreatePrometheus,
+				UpdateFunc: c.UpdatePrometheus,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
+	}
+
+	if !build.IsControllerDisabled("VMAgent") {
+		c.promAgentInf = cache.NewSharedIndexInformer(
+			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					var objects promv1alpha1.PrometheusAgentList
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1alpha1.PrometheusAgentList) {
+						objects.Items = append(objects.Items, dst.Items...)
+					}); err != nil {
+						return nil, fmt.Errorf("cannot list prometheus_agents: %w", err)
+					}
+					return &objects, nil
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return k8stools.NewObjectWatcherForNamespaces[promv1alpha1.PrometheusAgentList](ctx, rclient, "prometheus_agents", baseConf.GetWatchNamespaces())
+				},
+			}, rclient),
+			&promv1alpha1.PrometheusAgent{},
+			resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		)
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1alpha1.SchemeGroupVersion.String(),
+			kind:     promv1alpha1.PrometheusAgentsKind,
+			informer: c.promAgentInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.PrometheusAgent
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreatePrometheusAgent,
+				UpdateFunc: c.UpdatePrometheusAgent,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
+	}
+
+	if !build.IsControllerDisabled("VMAlertmanager") {
+		c.amInf = cache.NewSharedIndexInformer(
+			cache.ToListWatcherWithWatchListSemantics(&cache.ListWatch{
+				ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+					var objects promv1.AlertmanagerList
+					if err := k8stools.ListObjectsByNamespace(ctx, rclient, baseConf.GetWatchNamespaces(), func(dst *promv1.AlertmanagerList) {
+						objects.Items = append(objects.Items, dst.Items...)
+					}); err != nil {
+						return nil, fmt.Errorf("cannot list alertmanagers: %w", err)
+					}
+					return &objects, nil
+				},
+				WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+					return k8stools.NewObjectWatcherForNamespaces[promv1.AlertmanagerList](ctx, rclient, "alertmanagers", baseConf.GetWatchNamespaces())
+				},
+			}, rclient),
+			&promv1.Alertmanager{},
+			resyncPeriod,
+			cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc},
+		)
+		c.informers = append(c.informers, &converterInformer{
+			group:    promv1.SchemeGroupVersion.String(),
+			kind:     promv1.AlertmanagersKind,
+			informer: c.amInf,
+			isEnabled: func(cfg *config.BaseOperatorConf) bool {
+				return cfg.EnabledPrometheusConverter.Alertmanager
+			},
+			handler: cache.ResourceEventHandlerFuncs{
+				AddFunc:    c.CreateAlertmanager,
+				UpdateFunc: c.UpdateAlertmanager,
+				DeleteFunc: c.forgetConversion,
+			},
+		})
 	}
 	return c, nil
 }
@@ -267,56 +422,81 @@ func (c *ConverterController) Start(ctx context.Context) error {
 	var errG errgroup.Group
 	converterLogger.Info("starting prometheus converter")
 	c.Run(ctx, &errG)
-	go func() {
-		converterLogger.Info("waiting for prometheus converter to stop")
-		err := errG.Wait()
-		if err != nil {
-			converterLogger.Error(err, "error occurred at prometheus converter")
-		}
-	}()
+	converterLogger.Info("waiting for prometheus converter to stop")
+	if err := errG.Wait(); err != nil {
+		converterLogger.Error(err, "error occurred at prometheus converter")
+	}
 	return nil
 }
 
-// Run - starts vmprometheusconverter with background discovery process for each prometheus api object
+// Run - starts vmprometheusconverter with background discovery process for each enabled prometheus api object
+// and enables or disables conversion on operator configuration reload or shards change until ctx is done
 func (c *ConverterController) Run(ctx context.Context, group *errgroup.Group) {
-	if c.baseConf.EnabledPrometheusConverter.ServiceScrape {
-		group.Go(func() error {
-			return c.runInformerWithDiscovery(ctx, promv1.SchemeGroupVersion.String(), promv1.ServiceMonitorsKind, c.serviceInf.Run)
-		})
-	}
-	if c.baseConf.EnabledPrometheusConverter.PodMonitor {
-		group.Go(func() error {
-			return c.runInformerWithDiscovery(ctx, promv1.SchemeGroupVersion.String(), promv1.PodMonitorsKind, c.podInf.Run)
-		})
-	}
-	if c.baseConf.EnabledPrometheusConverter.PrometheusRule {
-		group.Go(func() error {
-			return c.runInformerWithDiscovery(ctx, promv1.SchemeGroupVersion.String(), promv1.PrometheusRuleKind, c.ruleInf.Run)
-		})
-	}
-	if c.baseConf.EnabledPrometheusConverter.Probe {
-		group.Go(func() error {
-			return c.runInformerWithDiscovery(ctx, promv1.SchemeGroupVersion.String(), promv1.ProbesKind, c.probeInf.Run)
-		})
+	for {
+		reloaded := config.Reloaded()
+		shardsChanged := ShardsChanged()
+		cfg := config.MustGetBaseConfig()
+		for _, ci := range c.informers {
+			if err := c.syncInformer(ctx, group, ci, cfg); err != nil {
+				converterLogger.Error(err, "cannot sync prometheus converter", "kind", ci.kind)
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-reloaded:
+		case <-shardsChanged:
+		}
 	}
+}
 
-	if c.baseConf.EnabledPrometheusConverter.AlertmanagerConfig {
-		group.Go(func() error {
-			return c.runInformerWithDiscovery(ctx, promv1alpha1.SchemeGroupVersion.String(), promv1alpha1.AlertmanagerConfigKind, c.amConfigInf.Run)
-		})
-	}
-	if c.baseConf.EnabledPrometheusConverter.ScrapeConfig {
-		group.Go(func() error {
-			return c.runInformerWithDiscovery(ctx, promv1alpha1.SchemeGroupVersion.String(), promv1alpha1.ScrapeConfigsKind, c.scrapeConfigInf.Run)
-		})
+// converterInformer holds informer of prometheus objects of the given kind.
+// Informer is started once conversion of the kind is enabled and keeps running after disable,
+// since informer cannot be restarted. Conversion handler is registered only while conversion is enabled
+type converterInformer struct {
+	group        string
+	kind         string
+	informer     cache.SharedInformer
+	isEnabled    func(cfg *config.BaseOperatorConf) bool
+	handler      cache.ResourceEventHandler
+	started      bool
+	registration cache.ResourceEventHandlerRegistration
+}
+
+// syncInformer registers or removes conversion handler according to VM_ENABLEDPROMETHEUSCONVERTER_ variables.
+// Handler registered at running informer receives add events for all existing objects, so they're converted again.
+// Converter creates objects at all namespaces, so with sharding it runs only at the replica, which owns the primary shard
+func (c *ConverterController) syncInformer(ctx context.Context, group *errgroup.Group, ci *converterInformer, cfg *config.BaseOperatorConf) error {
+	enabled := ci.isEnabled(cfg) && IsPrimaryShard()
+	switch {
+	case enabled && ci.registration == nil:
+		registration, err := ci.informer.AddEventHandler(ci.handler)
+		if err != nil {
+			return fmt.Errorf("cannot add %s handler: %w", ci.kind, err)
+		}
+		ci.registration = registration
+		if !ci.started {
+			ci.started = true
+			group.Go(func() error {
+				return c.runInformerWithDiscovery(ctx, ci.group, ci.kind, ci.informer.Run)
+			})
+		}
+		converterLogger.Info("enabled conversion", "kind", ci.kind)
+	case !enabled && ci.registration != nil:
+		if err := ci.informer.RemoveEventHandler(ci.registration); err != nil {
+			return fmt.Errorf("cannot remove %s handler: %w", ci.kind, err)
+		}
+		ci.registration = nil
+		converterLogger.Info("disabled conversion", "kind", ci.kind)
 	}
+	return nil
 }
 
 // CreatePrometheusRule converts prometheus rule to vmrule
 func (c *ConverterController) CreatePrometheusRule(rule any) {
 	promRule := rule.(*promv1.PrometheusRule)
 	l := converterLogger.WithValues("vmrule", promRule.Name, "namespace", promRule.Namespace)
-	cr := converter.ConvertPromRule(promRule, c.baseConf)
+	cr := converter.ConvertPromRule(promRule, config.MustGetBaseConfig())
 
 	err := c.rclient.Create(context.Background(), cr)
 	if err != nil {
@@ -324,25 +504,29 @@ func (c *ConverterController) CreatePrometheusRule(rule any) {
 			c.UpdatePrometheusRule(nil, promRule)
 			return
 		}
+		c.reportConversion(promRule, cr, nil, err)
 		l.Error(err, "cannot create VMRule from PrometheusRule")
 		return
 	}
+	c.reportConversion(promRule, cr, nil, nil)
 }
 
 // UpdatePrometheusRule updates vmrule
 func (c *ConverterController) UpdatePrometheusRule(_old, new any) {
 	promRuleNew := new.(*promv1.PrometheusRule)
 	l := converterLogger.WithValues("vmrule", promRuleNew.Name, "namespace", promRuleNew.Namespace)
-	vmRule := converter.ConvertPromRule(promRuleNew, c.baseConf)
+	vmRule := converter.ConvertPromRule(promRuleNew, config.MustGetBaseConfig())
 	ctx := context.Background()
 	existingVMRule := &vmv1beta1.VMRule{}
 	err := c.rclient.Get(ctx, types.NamespacedName{Name: vmRule.Name, Namespace: vmRule.Namespace}, existingVMRule)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmRule); err == nil {
+				c.reportConversion(promRuleNew, vmRule, nil, nil)
 				return
 			}
 		}
+		c.reportConversion(promRuleNew, vmRule, nil, err)
 		l.Error(err, "cannot get existing VMRule")
 		return
 	}
@@ -358,12 +542,14 @@ func (c *ConverterController) UpdatePrometheusRule(_old, new any) {
 		isMetaEqual(vmRule, existingVMRule) {
 		return
 	}
+	logConvertedObjectOverwrite(l, metaMergeStrategy, vmRule.Spec, existingVMRule.Spec, vmRule, existingVMRule)
 	existingVMRule.Annotations = vmRule.Annotations
 	existingVMRule.Labels = vmRule.Labels
 	existingVMRule.OwnerReferences = vmRule.OwnerReferences
 	existingVMRule.Spec = vmRule.Spec
 
 	err = c.rclient.Update(ctx, existingVMRule)
+	c.reportConversion(promRuleNew, vmRule, nil, err)
 	if err != nil {
 		l.Error(err, "cannot update VMRule")
 		return
@@ -375,32 +561,36 @@ func (c *ConverterController) CreateServiceMonitor(service any) {
 	serviceMon := service.(*promv1.ServiceMonitor)
 
 	l := converterLogger.WithValues("vmservicescrape", serviceMon.Name, "namespace", serviceMon.Namespace)
-	vmServiceScrape := converter.ConvertServiceMonitor(serviceMon, c.baseConf)
+	vmServiceScrape, dropped := converter.ConvertServiceMonitor(serviceMon, config.MustGetBaseConfig())
 	err := c.rclient.Create(context.Background(), vmServiceScrape)
 	if err != nil {
 		if k8serrors.IsAlreadyExists(err) {
 			c.UpdateServiceMonitor(nil, serviceMon)
 			return
 		}
+		c.reportConversion(serviceMon, vmServiceScrape, dropped, err)
 		l.Error(err, "cannot create VMServiceScrape")
 		return
 	}
+	c.reportConversion(serviceMon, vmServiceScrape, dropped, nil)
 }
 
 // UpdateServiceMonitor updates VMServiceMonitor
 func (c *ConverterController) UpdateServiceMonitor(_, new any) {
 	serviceMonNew := new.(*promv1.ServiceMonitor)
 	l := converterLogger.WithValues("vmservicescrape", serviceMonNew.Name, "namespace", serviceMonNew.Namespace)
-	vmServiceScrape := converter.ConvertServiceMonitor(serviceMonNew, c.baseConf)
+	vmServiceScrape, dropped := converter.ConvertServiceMonitor(serviceMonNew, config.MustGetBaseConfig())
 	existingVMServiceScrape := &vmv1beta1.VMServiceScrape{}
 	ctx := context.Background()
 	err := c.rclient.Get(ctx, types.NamespacedName{Name: vmServiceScrape.Name, Namespace: vmServiceScrape.Namespace}, existingVMServiceScrape)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmServiceScrape); err == nil {
+				c.reportConversion(serviceMonNew, vmServiceScrape, dropped, nil)
 				return
 			}
 		}
+		c.reportConversion(serviceMonNew, vmServiceScrape, dropped, err)
 		l.Error(err, "cannot get existing VMServiceScrape")
 		return
 	}
@@ -410,6 +600,11 @@ func (c *ConverterController) UpdateServiceMonitor(_, new any) {
 		return
 	}
 
+	if err := normalizeConvertedSpec(&vmServiceScrape.Spec); err != nil {
+		c.reportConversion(serviceMonNew, vmServiceScrape, dropped, err)
+		l.Error(err, "cannot normalize converted VMServiceScrape")
+		return
+	}
 	metaMergeStrategy := getMetaMergeStrategy(existingVMServiceScrape.Annotations)
 	vmServiceScrape.Annotations = mergeLabelsWithStrategy(existingVMServiceScrape.Annotations, vmServiceScrape.Annotations, metaMergeStrategy)
 	vmServiceScrape.Labels = mergeLabelsWithStrategy(existingVMServiceScrape.Labels, vmServiceScrape.Labels, metaMergeStrategy)
@@ -417,12 +612,14 @@ func (c *ConverterController) UpdateServiceMonitor(_, new any) {
 		isMetaEqual(vmServiceScrape, existingVMServiceScrape) {
 		return
 	}
+	logConvertedObjectOverwrite(l, metaMergeStrategy, vmServiceScrape.Spec, existingVMServiceScrape.Spec, vmServiceScrape, existingVMServiceScrape)
 	existingVMServiceScrape.Annotations = vmServiceScrape.Annotations
 	existingVMServiceScrape.Labels = vmServiceScrape.Labels
 	existingVMServiceScrape.Spec = vmServiceScrape.Spec
 	existingVMServiceScrape.OwnerReferences = vmServiceScrape.OwnerReferences
 
 	err = c.rclient.Update(ctx, existingVMServiceScrape)
+	c.reportConversion(serviceMonNew, vmServiceScrape, dropped, err)
 	if err != nil {
 		l.Error(err, "cannot update VMServiceScrape")
 		return
@@ -433,32 +630,36 @@ func (c *ConverterController) UpdateServiceMonitor(_, new any) {
 func (c *ConverterController) CreatePodMonitor(pod any) {
 	podMonitor := pod.(*promv1.PodMonitor)
 	l := converterLogger.WithValues("vmpodscrape", podMonitor.Name, "namespace", podMonitor.Namespace)
-	podScrape := converter.ConvertPodMonitor(podMonitor, c.baseConf)
+	podScrape, dropped := converter.ConvertPodMonitor(podMonitor, config.MustGetBaseConfig())
 	err := c.rclient.Create(c.ctx, podScrape)
 	if err != nil {
 		if k8serrors.IsAlreadyExists(err) {
 			c.UpdatePodMonitor(nil, podMonitor)
 			return
 		}
+		c.reportConversion(podMonitor, podScrape, dropped, err)
 		l.Error(err, "cannot create VMPodScrape")
 		return
 	}
+	c.reportConversion(podMonitor, podScrape, dropped, nil)
 }
 
 // UpdatePodMonitor updates VMPodScrape
 func (c *ConverterController) UpdatePodMonitor(_, new any) {
 	podMonitorNew := new.(*promv1.PodMonitor)
 	l := converterLogger.WithValues("vmpodscrape", podMonitorNew.Name, "namespace", podMonitorNew.Namespace)
-	podScrape := converter.ConvertPodMonitor(podMonitorNew, c.baseConf)
+	podScrape, dropped := converter.ConvertPodMonitor(podMonitorNew, config.MustGetBaseConfig())
 	ctx := context.Background()
 	existingVMPodScrape := &vmv1beta1.VMPodScrape{}
 	err := c.rclient.Get(ctx, types.NamespacedName{Name: podScrape.Name, Namespace: podScrape.Namespace}, existingVMPodScrape)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, podScrape); err == nil {
+				c.reportConversion(podMonitorNew, podScrape, dropped, nil)
 				return
 			}
 		}
+		c.reportConversion(podMonitorNew, podScrape, dropped, err)
 		l.Error(err, "cannot get existing VMPodScrape")
 		return
 	}
@@ -467,6 +668,11 @@ func (c *ConverterController) UpdatePodMonitor(_, new any) {
 		return
 	}
 
+	if err := normalizeConvertedSpec(&podScrape.Spec); err != nil {
+		c.reportConversion(podMonitorNew, podScrape, dropped, err)
+		l.Error(err, "cannot normalize converted VMPodScrape")
+		return
+	}
 	mergeStrategy := getMetaMergeStrategy(existingVMPodScrape.Annotations)
 	podScrape.Annotations = mergeLabelsWithStrategy(existingVMPodScrape.Annotations, podScrape.Annotations, mergeStrategy)
 	podScrape.Labels = mergeLabelsWithStrategy(existingVMPodScrape.Labels, podScrape.Labels, mergeStrategy)
@@ -474,12 +680,14 @@ func (c *ConverterController) UpdatePodMonitor(_, new any) {
 		isMetaEqual(podScrape, existingVMPodScrape) {
 		return
 	}
+	logConvertedObjectOverwrite(l, mergeStrategy, podScrape.Spec, existingVMPodScrape.Spec, podScrape, existingVMPodScrape)
 	existingVMPodScrape.Annotations = podScrape.Annotations
 	existingVMPodScrape.Labels = podScrape.Labels
 	existingVMPodScrape.Spec = podScrape.Spec
 	existingVMPodScrape.OwnerReferences = podScrape.OwnerReferences
 
 	err = c.rclient.Update(ctx, existingVMPodScrape)
+	c.reportConversion(podMonitorNew, podScrape, dropped, err)
 	if err != nil {
 		l.Error(err, "cannot update VMPodScrape")
 		return
@@ -492,7 +700,7 @@ func (c *ConverterController) CreateAlertmanagerConfig(new any) {
 	var err error
 	switch promAMc := new.(type) {
 	case *promv1alpha1.AlertmanagerConfig:
-		vmAMc, err = converterv1alpha1.ConvertAlertmanagerConfig(promAMc, c.baseConf)
+		vmAMc, err = converterv1alpha1.ConvertAlertmanagerConfig(promAMc, config.MustGetBaseConfig())
 	default:
 		err = fmt.Errorf("BUG: scrape config of type %T is not supported", promAMc)
 	}
@@ -506,9 +714,11 @@ func (c *ConverterController) CreateAlertmanagerConfig(new any) {
 			c.UpdateAlertmanagerConfig(nil, new)
 			return
 		}
+		c.reportConversion(new.(client.Object), vmAMc, nil, err)
 		l.Error(err, "cannot create VMAlertmanagerConfig")
 		return
 	}
+	c.reportConversion(new.(client.Object), vmAMc, nil, nil)
 }
 
 // UpdateAlertmanagerConfig updates VMAlertmanagerConfig
@@ -517,7 +727,7 @@ func (c *ConverterController) UpdateAlertmanagerConfig(_, new any) {
 	var err error
 	switch promAMc := new.(type) {
 	case *promv1alpha1.AlertmanagerConfig:
-		vmAMc, err = converterv1alpha1.ConvertAlertmanagerConfig(promAMc, c.baseConf)
+		vmAMc, err = converterv1alpha1.ConvertAlertmanagerConfig(promAMc, config.MustGetBaseConfig())
 	default:
 		err = fmt.Errorf("BUG: alertmanager config of type %T is not supported", new)
 	}
@@ -531,9 +741,11 @@ func (c *ConverterController) UpdateAlertmanagerConfig(_, new any) {
 	if err := c.rclient.Get(ctx, types.NamespacedName{Name: vmAMc.Name, Namespace: vmAMc.Namespace}, existAlertmanagerConfig); err != nil {
 		if k8serrors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmAMc); err == nil {
+				c.reportConversion(new.(client.Object), vmAMc, nil, nil)
 				return
 			}
 		}
+		c.reportConversion(new.(client.Object), vmAMc, nil, err)
 		l.Error(err, "cannot get existing VMAlertmanagerConfig")
 		return
 	}
@@ -551,12 +763,14 @@ func (c *ConverterController) UpdateAlertmanagerConfig(_, new any) {
 		return
 	}
 
+	logConvertedObjectOverwrite(l, metaMergeStrategy, vmAMc.Spec, existAlertmanagerConfig.Spec, vmAMc, existAlertmanagerConfig)
 	existAlertmanagerConfig.Annotations = vmAMc.Annotations
 	existAlertmanagerConfig.Labels = vmAMc.Labels
 	existAlertmanagerConfig.OwnerReferences = vmAMc.OwnerReferences
 	existAlertmanagerConfig.Spec = vmAMc.Spec
 
 	err = c.rclient.Update(ctx, existAlertmanagerConfig)
+	c.reportConversion(new.(client.Object), vmAMc, nil, err)
 	if err != nil {
 		l.Error(err, "cannot update exist VMAlertmanagerConfig")
 		return
@@ -602,32 +816,36 @@ func getMetaMergeStrategy(vmMeta map[string]string) string {
 func (c *ConverterController) CreateProbe(obj any) {
 	probe := obj.(*promv1.Probe)
 	l := converterLogger.WithValues("vmprobe", probe.Name, "namespace", probe.Namespace)
-	vmProbe := converter.ConvertProbe(probe, c.baseConf)
+	vmProbe, dropped := converter.ConvertProbe(probe, config.MustGetBaseConfig())
 	err := c.rclient.Create(c.ctx, vmProbe)
 	if err != nil {
 		if k8serrors.IsAlreadyExists(err) {
 			c.UpdateProbe(nil, probe)
 			return
 		}
+		c.reportConversion(probe, vmProbe, dropped, err)
 		l.Error(err, "cannot create VMProbe")
 		return
 	}
+	c.reportConversion(probe, vmProbe, dropped, nil)
 }
 
 // UpdateProbe updates VMProbe
 func (c *ConverterController) UpdateProbe(_, new any) {
 	probeNew := new.(*promv1.Probe)
 	l := converterLogger.WithValues("vmprobe", probeNew.Name, "namespace", probeNew.Namespace)
-	vmProbe := converter.ConvertProbe(probeNew, c.baseConf)
+	vmProbe, dropped := converter.ConvertProbe(probeNew, config.MustGetBaseConfig())
 	ctx := context.Background()
 	existingVMProbe := &vmv1beta1.VMProbe{}
 	err := c.rclient.Get(ctx, types.NamespacedName{Name: vmProbe.Name, Namespace: vmProbe.Namespace}, existingVMProbe)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmProbe); err == nil {
+				c.reportConversion(probeNew, vmProbe, dropped, nil)
 				return
 			}
 		}
+		c.reportConversion(probeNew, vmProbe, dropped, err)
 		l.Error(err, "cannot get existing VMProbe")
 		return
 	}
@@ -636,6 +854,11 @@ func (c *ConverterController) UpdateProbe(_, new any) {
 		return
 	}
 
+	if err := normalizeConvertedSpec(&vmProbe.Spec); err != nil {
+		c.reportConversion(probeNew, vmProbe, dropped, err)
+		l.Error(err, "cannot normalize converted VMProbe")
+		return
+	}
 	mergeStrategy := getMetaMergeStrategy(existingVMProbe.Annotations)
 	vmProbe.Annotations = mergeLabelsWithStrategy(existingVMProbe.Annotations, vmProbe.Annotations, mergeStrategy)
 	vmProbe.Labels = mergeLabelsWithStrategy(existingVMProbe.Labels, vmProbe.Labels, mergeStrategy)
@@ -644,11 +867,13 @@ func (c *ConverterController) UpdateProbe(_, new any) {
 		return
 	}
 
+	logConvertedObjectOverwrite(l, mergeStrategy, vmProbe.Spec, existingVMProbe.Spec, vmProbe, existingVMProbe)
 	existingVMProbe.Labels = vmProbe.Labels
 	existingVMProbe.Annotations = vmProbe.Annotations
 	existingVMProbe.OwnerReferences = vmProbe.OwnerReferences
 	existingVMProbe.Spec = vmProbe.Spec
 	err = c.rclient.Update(ctx, existingVMProbe)
+	c.reportConversion(probeNew, vmProbe, dropped, err)
 	if err != nil {
 		l.Error(err, "cannot update VMProbe")
 		return
@@ -658,10 +883,11 @@ func (c *ConverterController) UpdateProbe(_, new any) {
 // CreateScrapeConfig converts ServiceMonitor to VMScrapeConfig
 func (c *ConverterController) CreateScrapeConfig(scrapeConfig any) {
 	var vmScrapeConfig *vmv1beta1.VMScrapeConfig
+	var dropped []string
 	var err error
 	switch promScrapeConfig := scrapeConfig.(type) {
 	case *promv1alpha1.ScrapeConfig:
-		vmScrapeConfig = converterv1alpha1.ConvertScrapeConfig(promScrapeConfig, c.baseConf)
+		vmScrapeConfig, dropped = converterv1alpha1.ConvertScrapeConfig(promScrapeConfig, config.MustGetBaseConfig())
 	default:
 		err = fmt.Errorf("BUG: scrape config of type %T is not supported", promScrapeConfig)
 		converterLogger.Error(err, "cannot parse promscrapeConfig for create")
@@ -674,18 +900,21 @@ func (c *ConverterController) CreateScrapeConfig(scrapeConfig any) {
 			c.UpdateScrapeConfig(nil, scrapeConfig)
 			return
 		}
+		c.reportConversion(scrapeConfig.(client.Object), vmScrapeConfig, dropped, err)
 		l.Error(err, "cannot create vmScrapeConfig")
 		return
 	}
+	c.reportConversion(scrapeConfig.(client.Object), vmScrapeConfig, dropped, nil)
 }
 
 // UpdateScrapeConfig updates VMScrapeConfig
 func (c *ConverterController) UpdateScrapeConfig(_, newObj any) {
 	var vmScrapeConfig *vmv1beta1.VMScrapeConfig
+	var dropped []string
 	var err error
 	switch promScrapeConfig := newObj.(type) {
 	case *promv1alpha1.ScrapeConfig:
-		vmScrapeConfig = converterv1alpha1.ConvertScrapeConfig(promScrapeConfig, c.baseConf)
+		vmScrapeConfig, dropped = converterv1alpha1.ConvertScrapeConfig(promScrapeConfig, config.MustGetBaseConfig())
 	default:
 		err = fmt.Errorf("BUG: scrape config of type %T is not supported", promScrapeConfig)
 		converterLogger.Error(err, "cannot parse promScrapeConfig for update")
@@ -698,9 +927,11 @@ func (c *ConverterController) UpdateScrapeConfig(_, newObj any) {
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			if err = c.rclient.Create(ctx, vmScrapeConfig); err == nil {
+				c.reportConversion(newObj.(client.Object), vmScrapeConfig, dropped, nil)
 				return
 			}
 		}
+		c.reportConversion(newObj.(client.Object), vmScrapeConfig, dropped, err)
 		l.Error(err, "cannot get existing VMScrapeConfig")
 		return
 	}
@@ -709,6 +940,11 @@ func (c *ConverterController) UpdateScrapeConfig(_, newObj any) {
 		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
 		return
 	}
+	if err := normalizeConvertedSpec(&vmScrapeConfig.Spec); err != nil {
+		c.reportConversion(newObj.(client.Object), vmScrapeConfig, dropped, err)
+		l.Error(err, "cannot normalize converted VMScrapeConfig")
+		return
+	}
 	metaMergeStrategy := getMetaMergeStrategy(existingVMScrapeConfig.Annotations)
 	vmScrapeConfig.Annotations = mergeLabelsWithStrategy(existingVMScrapeConfig.Annotations, vmScrapeConfig.Annotations, metaMergeStrategy)
 	vmScrapeConfig.Labels = mergeLabelsWithStrategy(existingVMScrapeConfig.Labels, vmScrapeConfig.Labels, metaMergeStrategy)
@@ -717,17 +953,243 @@ func (c *ConverterController) UpdateScrapeConfig(_, newObj any) {
 		isMetaEqual(vmScrapeConfig, existingVMScrapeConfig) {
 		return
 	}
+	logConvertedObjectOverwrite(l, metaMergeStrategy, vmScrapeConfig.Spec, existingVMScrapeConfig.Spec, vmScrapeConfig, existingVMScrapeConfig)
 	existingVMScrapeConfig.Labels = vmScrapeConfig.Labels
 	existingVMScrapeConfig.Annotations = vmScrapeConfig.Annotations
 	existingVMScrapeConfig.OwnerReferences = vmScrapeConfig.OwnerReferences
 	existingVMScrapeConfig.Spec = vmScrapeConfig.Spec
 	err = c.rclient.Update(ctx, existingVMScrapeConfig)
+	c.reportConversion(newObj.(client.Object), vmScrapeConfig, dropped, err)
 	if err != nil {
 		l.Error(err, "cannot update VMScrapeConfig")
 		return
 	}
 }
 
+// CreatePrometheus converts Prometheus to VMAgent and VMSingle
+func (c *ConverterController) CreatePrometheus(obj any) {
+	c.UpdatePrometheus(nil, obj)
+}
+
+// UpdatePrometheus updates VMAgent and VMSingle converted from Prometheus
+func (c *ConverterController) UpdatePrometheus(_, new any) {
+	prom := new.(*promv1.Prometheus)
+	vmAgent, vmSingle, dropped := converter.ConvertPrometheus(prom, config.MustGetBaseConfig())
+	ctx := context.Background()
+	if err := c.syncConvertedVMSingle(ctx, prom, vmSingle, dropped); err != nil {
+		converterLogger.Error(err, "cannot sync VMSingle", "vmsingle", vmSingle.Name, "namespace", vmSingle.Namespace)
+	}
+	if err := c.syncConvertedVMAgent(ctx, prom, vmAgent, dropped); err != nil {
+		converterLogger.Error(err, "cannot sync VMAgent", "vmagent", vmAgent.Name, "namespace", vmAgent.Namespace)
+	}
+}
+
+// CreatePrometheusAgent converts PrometheusAgent to VMAgent
+func (c *ConverterController) CreatePrometheusAgent(obj any) {
+	c.UpdatePrometheusAgent(nil, obj)
+}
+
+// UpdatePrometheusAgent updates VMAgent converted from PrometheusAgent
+func (c *ConverterController) UpdatePrometheusAgent(_, new any) {
+	promAgent := new.(*promv1alpha1.PrometheusAgent)
+	vmAgent, dropped := converterv1alpha1.ConvertPrometheusAgent(promAgent, config.MustGetBaseConfig())
+	if err := c.syncConvertedVMAgent(context.Background(), promAgent, vmAgent, dropped); err != nil {
+		converterLogger.Error(err, "cannot sync VMAgent", "vmagent", vmAgent.Name, "namespace", vmAgent.Namespace)
+	}
+}
+
+// CreateAlertmanager converts Alertmanager to VMAlertmanager
+func (c *ConverterController) CreateAlertmanager(obj any) {
+	c.UpdateAlertmanager(nil, obj)
+}
+
+// UpdateAlertmanager updates VMAlertmanager converted from Alertmanager
+func (c *ConverterController) UpdateAlertmanager(_, new any) {
+	am := new.(*promv1.Alertmanager)
+	l := converterLogger.WithValues("vmalertmanager", am.Name, "namespace", am.Namespace)
+	vmAM, dropped := converter.ConvertAlertmanager(am, config.MustGetBaseConfig())
+	ctx := context.Background()
+	existingVMAM := &vmv1beta1.VMAlertmanager{}
+	if err := c.rclient.Get(ctx, types.NamespacedName{Name: vmAM.Name, Namespace: vmAM.Namespace}, existingVMAM); err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = c.rclient.Create(ctx, vmAM)
+			c.reportConversion(am, vmAM, dropped, err)
+			if err != nil {
+				l.Error(err, "cannot create VMAlertmanager")
+			}
+			return
+		}
+		c.reportConversion(am, vmAM, dropped, err)
+		l.Error(err, "cannot get existing VMAlertmanager")
+		return
+	}
+	if existingVMAM.Annotations[IgnoreConversionLabel] == IgnoreConversion {
+		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		return
+	}
+	metaMergeStrategy := getMetaMergeStrategy(existingVMAM.Annotations)
+	vmAM.Annotations = mergeLabelsWithStrategy(existingVMAM.Annotations, vmAM.Annotations, metaMergeStrategy)
+	vmAM.Labels = mergeLabelsWithStrategy(existingVMAM.Labels, vmAM.Labels, metaMergeStrategy)
+	// spec is compared with DeepDerivative, since fields not managed by converter could be set to existing object
+	if equality.Semantic.DeepDerivative(vmAM.Spec, existingVMAM.Spec) &&
+		isMetaEqual(vmAM, existingVMAM) {
+		return
+	}
+	mergedSpec, err := mergeConvertedSpec(&existingVMAM.Spec, &vmAM.Spec)
+	if err != nil {
+		l.Error(err, "cannot merge converted spec")
+		return
+	}
+	logConvertedObjectOverwrite(l, metaMergeStrategy, mergedSpec, existingVMAM.Spec, vmAM, existingVMAM)
+	existingVMAM.Annotations = vmAM.Annotations
+	existingVMAM.Labels = vmAM.Labels
+	existingVMAM.OwnerReferences = vmAM.OwnerReferences
+	existingVMAM.Spec = *mergedSpec
+	err = c.rclient.Update(ctx, existingVMAM)
+	c.reportConversion(am, vmAM, dropped, err)
+	if err != nil {
+		l.Error(err, "cannot update VMAlertmanager")
+		return
+	}
+}
+
+// syncConvertedVMAgent creates or updates VMAgent
+//
+// spec is compared with DeepDerivative, since fields not managed by converter could be set to existing object
+func (c *ConverterController) syncConvertedVMAgent(ctx context.Context, src client.Object, vmAgent *vmv1beta1.VMAgent, dropped []string) error {
+	srcKind := c.objectKind(src)
+	if vmAgent.Annotations == nil {
+		vmAgent.Annotations = make(map[string]string)
+	}
+	vmAgent.Annotations[ConvertedFromAnnotation] = srcKind
+	existingVMAgent := &vmv1beta1.VMAgent{}
+	if err := c.rclient.Get(ctx, types.NamespacedName{Name: vmAgent.Name, Namespace: vmAgent.Namespace}, existingVMAgent); err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = c.rclient.Create(ctx, vmAgent)
+			c.reportConversion(src, vmAgent, dropped, err)
+			return err
+		}
+		c.reportConversion(src, vmAgent, dropped, err)
+		return fmt.Errorf("cannot get existing VMAgent: %w", err)
+	}
+	l := converterLogger.WithValues("vmagent", vmAgent.Name, "namespace", vmAgent.Namespace)
+	if existingVMAgent.Annotations[IgnoreConversionLabel] == IgnoreConversion {
+		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		return nil
+	}
+	if existingKind, ok := existingVMAgent.Annotations[ConvertedFromAnnotation]; ok && existingKind != srcKind {
+		err := fmt.Errorf("VMAgent=%s/%s is already converted from %s with the same name", vmAgent.Namespace, vmAgent.Name, existingKind)
+		c.reportConversion(src, vmAgent, dropped, err)
+		return err
+	}
+	metaMergeStrategy := getMetaMergeStrategy(existingVMAgent.Annotations)
+	vmAgent.Annotations = mergeLabelsWithStrategy(existingVMAgent.Annotations, vmAgent.Annotations, metaMergeStrategy)
+	vmAgent.Labels = mergeLabelsWithStrategy(existingVMAgent.Labels, vmAgent.Labels, metaMergeStrategy)
+	if equality.Semantic.DeepDerivative(vmAgent.Spec, existingVMAgent.Spec) &&
+		isMetaEqual(vmAgent, existingVMAgent) {
+		return nil
+	}
+	mergedSpec, err := mergeConvertedSpec(&existingVMAgent.Spec, &vmAgent.Spec)
+	if err != nil {
+		return fmt.Errorf("cannot merge converted spec: %w", err)
+	}
+	logConvertedObjectOverwrite(l, metaMergeStrategy, mergedSpec, existingVMAgent.Spec, vmAgent, existingVMAgent)
+	existingVMAgent.Annotations = vmAgent.Annotations
+	existingVMAgent.Labels = vmAgent.Labels
+	existingVMAgent.OwnerReferences = vmAgent.OwnerReferences
+	existingVMAgent.Spec = *mergedSpec
+	err = c.rclient.Update(ctx, existingVMAgent)
+	c.reportConversion(src, vmAgent, dropped, err)
+	if err != nil {
+		return fmt.Errorf("cannot update VMAgent: %w", err)
+	}
+	return nil
+}
+
+// syncConvertedVMSingle creates or updates VMSingle
+//
+// spec is compared with DeepDerivative, since fields not managed by converter could be set to existing object
+func (c *ConverterController) syncConvertedVMSingle(ctx context.Context, src client.Object, vmSingle *vmv1beta1.VMSingle, dropped []string) error {
+	existingVMSingle := &vmv1beta1.VMSingle{}
+	if err := c.rclient.Get(ctx, types.NamespacedName{Name: vmSingle.Name, Namespace: vmSingle.Namespace}, existingVMSingle); err != nil {
+		if k8serrors.IsNotFound(err) {
+			err = c.rclient.Create(ctx, vmSingle)
+			c.reportConversion(src, vmSingle, dropped, err)
+			return err
+		}
+		c.reportConversion(src, vmSingle, dropped, err)
+		return fmt.Errorf("cannot get existing VMSingle: %w", err)
+	}
+	l := converterLogger.WithValues("vmsingle", vmSingle.Name, "namespace", vmSingle.Namespace)
+	if existingVMSingle.Annotations[IgnoreConversionLabel] == IgnoreConversion {
+		l.Info("syncing for object was disabled by annotation", "annotation", IgnoreConversionLabel)
+		return nil
+	}
+	metaMergeStrategy := getMetaMergeStrategy(existingVMSingle.Annotations)
+	vmSingle.Annotations = mergeLabelsWithStrategy(existingVMSingle.Annotations, vmSingle.Annotations, metaMergeStrategy)
+	vmSingle.Labels = mergeLabelsWithStrategy(existingVMSingle.Labels, vmSingle.Labels, metaMergeStrategy)
+	// storage cannot be changed after creation
+	if existingVMSingle.Spec.Storage != nil {
+		vmSingle.Spec.Storage = existingVMSingle.Spec.Storage
+	}
+	if equality.Semantic.DeepDerivative(vmSingle.Spec, existingVMSingle.Spec) &&
+		isMetaEqual(vmSingle, existingVMSingle) {
+		return nil
+	}
+	mergedSpec, err := mergeConvertedSpec(&existingVMSingle.Spec, &vmSingle.Spec)
+	if err != nil {
+		return fmt.Errorf("cannot merge converted spec: %w", err)
+	}
+	logConvertedObjectOverwrite(l, metaMergeStrategy, mergedSpec, existingVMSingle.Spec, vmSingle, existingVMSingle)
+	existingVMSingle.Annotations = vmSingle.Annotations
+	existingVMSingle.Labels = vmSingle.Labels
+	existingVMSingle.OwnerReferences = vmSingle.OwnerReferences
+	existingVMSingle.Spec = *mergedSpec
+	err = c.rclient.Update(ctx, existingVMSingle)
+	c.reportConversion(src, vmSingle, dropped, err)
+	if err != nil {
+		return fmt.Errorf("cannot update VMSingle: %w", err)
+	}
+	return nil
+}
+
+// normalizeConvertedSpec applies json round trip to the converted spec
+// VictoriaMetrics types could fill alias fields at unmarshal, e.g. RelabelConfig.UnderScoreSourceLabels,
+// so converted spec must have the same form as an object returned by kubernetes API for comparison
+func normalizeConvertedSpec[T any](spec *T) error {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("cannot marshal spec: %w", err)
+	}
+	var normalized T
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return fmt.Errorf("cannot unmarshal spec: %w", err)
+	}
+	*spec = normalized
+	return nil
+}
+
+// mergeConvertedSpec returns copy of the existing spec with fields set by converter.
+// Fields omitted by converter are kept as is, since they could be set manually
+func mergeConvertedSpec[T any](existing, converted *T) (*T, error) {
+	existingData, err := json.Marshal(existing)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal existing spec: %w", err)
+	}
+	convertedData, err := json.Marshal(converted)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal converted spec: %w", err)
+	}
+	var merged T
+	if err := json.Unmarshal(existingData, &merged); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal existing spec: %w", err)
+	}
+	if err := json.Unmarshal(convertedData, &merged); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal converted spec: %w", err)
+	}
+	return &merged, nil
+}
+
 func isMetaEqual(left, right metav1.Object) bool {
 	return equality.Semantic.DeepEqual(left.GetLabels(), right.GetLabels()) &&
 		equality.Semantic.DeepEqual(left.GetAnnotations(), right.GetAnnotations()) &&
@@ -1,9 +1,22 @@
 package operator
 
 import (
+	"context"
 	"testing"
 
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	promv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
 )
 
 func Test_mergeLabelsWithStrategy(t *testing.T) {
@@ -67,3 +80,169 @@ func Test_mergeLabelsWithStrategy(t *testing.T) {
 		want:          map[string]string{"label1": "value1", "label2": "value4", "missinglabel": "value10"},
 	})
 }
+
+func TestConverterController_reportConversion(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, promv1.AddToScheme(s))
+	assert.NoError(t, vmv1beta1.AddToScheme(s))
+	assert.NoError(t, corev1.AddToScheme(s))
+	rclient := fake.NewClientBuilder().WithScheme(s).Build()
+	c := &ConverterController{
+		ctx:     context.Background(),
+		rclient: rclient,
+	}
+	sm := &promv1.ServiceMonitor{
+		ObjectMeta: metav1.ObjectMeta{Name: "sm", Namespace: "default"},
+		Spec: promv1.ServiceMonitorSpec{
+			Endpoints: []promv1.Endpoint{
+				{
+					Port: "http",
+					RelabelConfigs: []promv1.RelabelConfig{
+						{Action: "drop"},
+						{Action: "drop", SourceLabels: []promv1.LabelName{"job"}},
+					},
+				},
+			},
+		},
+	}
+	seenEvents := make(map[string]bool)
+	// events returns events created since the previous call, order of events isn't guaranteed
+	events := func() []string {
+		var evs corev1.EventList
+		assert.NoError(t, rclient.List(context.Background(), &evs))
+		var got []string
+		for _, ev := range evs.Items {
+			if seenEvents[ev.Name] {
+				continue
+			}
+			seenEvents[ev.Name] = true
+			got = append(got, ev.Type+" "+ev.Reason+" "+ev.Message)
+		}
+		return got
+	}
+
+	// object created
+	c.UpdateServiceMonitor(nil, sm)
+	assert.ElementsMatch(t, []string{
+		"Normal ConversionSucceeded converted into VMServiceScrape=default/sm",
+		"Warning UnsupportedFieldsDropped unsupported fields were dropped: spec.endpoints[0].relabelings[0]",
+	}, events())
+
+	// nothing changed
+	c.UpdateServiceMonitor(nil, sm)
+	assert.Empty(t, events())
+
+	// manual changes overwritten
+	var vmss vmv1beta1.VMServiceScrape
+	assert.NoError(t, rclient.Get(context.Background(), types.NamespacedName{Name: "sm", Namespace: "default"}, &vmss))
+	vmss.Spec.JobLabel = "manual"
+	assert.NoError(t, rclient.Update(context.Background(), &vmss))
+	c.UpdateServiceMonitor(nil, sm)
+	// dropped fields are reported once per generation
+	assert.Equal(t, []string{
+		"Normal ConversionSucceeded converted into VMServiceScrape=default/sm",
+	}, events())
+	assert.NoError(t, rclient.Get(context.Background(), types.NamespacedName{Name: "sm", Namespace: "default"}, &vmss))
+	assert.Empty(t, vmss.Spec.JobLabel)
+}
+
+func TestConverterController_syncConvertedVMAgent(t *testing.T) {
+	s := runtime.NewScheme()
+	assert.NoError(t, promv1.AddToScheme(s))
+	assert.NoError(t, promv1alpha1.AddToScheme(s))
+	assert.NoError(t, vmv1beta1.AddToScheme(s))
+	assert.NoError(t, corev1.AddToScheme(s))
+	rclient := fake.NewClientBuilder().WithScheme(s).Build()
+	c := &ConverterController{
+		ctx:     context.Background(),
+		rclient: rclient,
+	}
+	ctx := context.Background()
+	prom := &promv1.Prometheus{
+		ObjectMeta: metav1.ObjectMeta{Name: "prom", Namespace: "default"},
+		Spec: promv1.PrometheusSpec{
+			CommonPrometheusFields: promv1.CommonPrometheusFields{
+				ScrapeInterval: "30s",
+			},
+		},
+	}
+	c.UpdatePrometheus(nil, prom)
+	var vmagent vmv1beta1.VMAgent
+	nsn := types.NamespacedName{Name: "prom", Namespace: "default"}
+	assert.NoError(t, rclient.Get(ctx, nsn, &vmagent))
+	assert.Equal(t, "Prometheus", vmagent.Annotations[ConvertedFromAnnotation])
+
+	// fields not managed by converter are kept
+	vmagent.Spec.LogLevel = "WARN"
+	vmagent.Spec.ScrapeInterval = "1m"
+	assert.NoError(t, rclient.Update(ctx, &vmagent))
+	c.UpdatePrometheus(nil, prom)
+	assert.NoError(t, rclient.Get(ctx, nsn, &vmagent))
+	assert.Equal(t, "WARN", vmagent.Spec.LogLevel)
+	assert.Equal(t, "30s", vmagent.Spec.ScrapeInterval)
+
+	// PrometheusAgent with the same name doesn't overwrite VMAgent
+	promAgent := &promv1alpha1.PrometheusAgent{
+		ObjectMeta: metav1.ObjectMeta{Name: "prom", Namespace: "default"},
+		Spec: promv1alpha1.PrometheusAgentSpec{
+			CommonPrometheusFields: promv1.CommonPrometheusFields{
+				ScrapeInterval: "10s",
+				Replicas:       ptr.To[int32](2),
+			},
+		},
+	}
+	c.UpdatePrometheusAgent(nil, promAgent)
+	assert.NoError(t, rclient.Get(ctx, nsn, &vmagent))
+	assert.Equal(t, "Prometheus", vmagent.Annotations[ConvertedFromAnnotation])
+	assert.Equal(t, "30s", vmagent.Spec.ScrapeInterval)
+
+	// the same failure is reported once
+	c.UpdatePrometheusAgent(nil, promAgent)
+	var evs corev1.EventList
+	assert.NoError(t, rclient.List(ctx, &evs))
+	var failures int
+	for _, ev := range evs.Items {
+		if ev.Reason == ConversionFailedReason {
+			failures++
+		}
+	}
+	assert.Equal(t, 1, failures)
+}
+
+func TestConverterController_syncInformer(t *testing.T) {
+	c := &ConverterController{}
+	ci := &converterInformer{
+		kind:     promv1.PodMonitorsKind,
+		informer: cache.NewSharedInformer(&cache.ListWatch{}, &promv1.PodMonitor{}, 0),
+		isEnabled: func(cfg *config.BaseOperatorConf) bool {
+			return cfg.EnabledPrometheusConverter.PodMonitor
+		},
+		handler: cache.ResourceEventHandlerFuncs{},
+		// informer is running already
+		started: true,
+	}
+	cfg := &config.BaseOperatorConf{}
+	sync := func(enabled bool) {
+		t.Helper()
+		cfg.EnabledPrometheusConverter.PodMonitor = enabled
+		assert.NoError(t, c.syncInformer(context.Background(), nil, ci, cfg))
+	}
+
+	// enable conversion
+	sync(true)
+	assert.NotNil(t, ci.registration)
+	registration := ci.registration
+
+	// keep handler registered
+	sync(true)
+	assert.Equal(t, registration, ci.registration)
+
+	// disable conversion
+	sync(false)
+	assert.Nil(t, ci.registration)
+
+	// enable conversion again
+	sync(true)
+	assert.NotNil(t, ci.registration)
+	assert.NotEqual(t, registration, ci.registration)
+}
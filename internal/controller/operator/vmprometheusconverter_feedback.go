@@ -0,0 +1,151 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/events"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+)
+
+const (
+	// ConversionSucceededReason is used for events on prometheus objects converted into VM objects
+	ConversionSucceededReason = "ConversionSucceeded"
+	// ConversionFailedReason is used for events on prometheus objects, which cannot be converted or synced
+	ConversionFailedReason = "ConversionFailed"
+	// UnsupportedFieldsDroppedReason is used for events on prometheus objects with fields, which were dropped by conversion
+	UnsupportedFieldsDroppedReason = "UnsupportedFieldsDropped"
+)
+
+var converterConversionsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "operator_prometheus_converter_conversions_total",
+	Help: "Counts number of prometheus objects conversions, which resulted into VM object create or update",
+}, []string{"kind", "result"})
+
+func init() {
+	metrics.Registry.MustRegister(converterConversionsTotal)
+}
+
+// conversionReports holds results of conversion reported for the source objects
+type conversionReports struct {
+	mu      sync.Mutex
+	reports map[string]*conversionReport
+}
+
+// conversionReport holds results reported for the source object generation
+type conversionReport struct {
+	generation int64
+	dropped    bool
+	// failures holds last reported error per kind of VM object
+	failures map[string]string
+}
+
+func conversionReportKey(srcKind string, src client.Object) string {
+	return srcKind + "/" + src.GetNamespace() + "/" + src.GetName()
+}
+
+// mustReport checks if result of conversion wasn't reported for the current generation of the source object
+func (cr *conversionReports) mustReport(key string, generation int64, dstKind string, err error) (mustReportResult, mustReportDropped bool) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	if cr.reports == nil {
+		cr.reports = make(map[string]*conversionReport)
+	}
+	r, ok := cr.reports[key]
+	if !ok || r.generation != generation {
+		r = &conversionReport{generation: generation, failures: make(map[string]string)}
+		cr.reports[key] = r
+	}
+	if err != nil {
+		if prev, ok := r.failures[dstKind]; ok && prev == err.Error() {
+			return false, false
+		}
+		r.failures[dstKind] = err.Error()
+		return true, false
+	}
+	delete(r.failures, dstKind)
+	mustReportDropped = !r.dropped
+	r.dropped = true
+	return true, mustReportDropped
+}
+
+func (cr *conversionReports) forget(key string) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	delete(cr.reports, key)
+}
+
+// reportConversion records result of prometheus object conversion with metric and events attached to the source object
+// dropped contains paths of source object fields, which were dropped by converter
+//
+// it must be called only if VM object was created, updated or sync failed.
+// Failed sync is retried on each informer resync, so the same error and dropped fields
+// are reported only once per generation of the source object
+func (c *ConverterController) reportConversion(src, dst client.Object, dropped []string, err error) {
+	srcKind := c.objectKind(src)
+	dstKind := c.objectKind(dst)
+	mustReportResult, mustReportDropped := c.reports.mustReport(conversionReportKey(srcKind, src), src.GetGeneration(), dstKind, err)
+	if !mustReportResult {
+		return
+	}
+	if err != nil {
+		converterConversionsTotal.WithLabelValues(srcKind, "failed").Inc()
+		c.recordEvent(src, corev1.EventTypeWarning, ConversionFailedReason, fmt.Sprintf("cannot sync %s=%s/%s: %s", dstKind, dst.GetNamespace(), dst.GetName(), err))
+		return
+	}
+	converterConversionsTotal.WithLabelValues(srcKind, "success").Inc()
+	c.recordEvent(src, corev1.EventTypeNormal, ConversionSucceededReason, fmt.Sprintf("converted into %s=%s/%s", dstKind, dst.GetNamespace(), dst.GetName()))
+	if !mustReportDropped {
+		return
+	}
+	if len(dropped) > 0 {
+		c.recordEvent(src, corev1.EventTypeWarning, UnsupportedFieldsDroppedReason, fmt.Sprintf("unsupported fields were dropped: %s", strings.Join(dropped, ", ")))
+	}
+}
+
+// forgetConversion removes reported results of conversion for deleted source object
+func (c *ConverterController) forgetConversion(obj any) {
+	if d, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = d.Obj
+	}
+	src, ok := obj.(client.Object)
+	if !ok {
+		return
+	}
+	c.reports.forget(conversionReportKey(c.objectKind(src), src))
+}
+
+func (c *ConverterController) recordEvent(obj client.Object, eventType, reason, message string) {
+	if err := events.Create(c.ctx, c.rclient, obj, eventType, reason, message); err != nil {
+		converterLogger.Error(err, "cannot create k8s api event", "kind", c.objectKind(obj), "name", obj.GetName(), "namespace", obj.GetNamespace())
+	}
+}
+
+func (c *ConverterController) objectKind(obj client.Object) string {
+	gvk, err := apiutil.GVKForObject(obj, c.rclient.Scheme())
+	if err != nil {
+		return fmt.Sprintf("%T", obj)
+	}
+	return gvk.Kind
+}
+
+// logConvertedObjectOverwrite logs fields of VM object, which are going to be overwritten with converted values
+// it helps to find manual changes of VM object reverted by converter
+func logConvertedObjectOverwrite(l logr.Logger, mergeStrategy string, newSpec, existingSpec any, newObj, existingObj metav1.Object) {
+	l.Info("overwriting object with values converted from prometheus object",
+		"merge_strategy", mergeStrategy,
+		"spec_diff", reconcile.DiffDeep(newSpec, existingSpec),
+		"labels_diff", reconcile.DiffDeep(newObj.GetLabels(), existingObj.GetLabels()),
+		"annotations_diff", reconcile.DiffDeep(newObj.GetAnnotations(), existingObj.GetAnnotations()),
+	)
+}
@@ -82,7 +82,7 @@ func (r *VMRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 	alertSync.Lock()
 	defer alertSync.Unlock()
 	var objects vmv1beta1.VMAlertList
-	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.WatchNamespaces, func(dst *vmv1beta1.VMAlertList) {
+	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.GetWatchNamespaces(), func(dst *vmv1beta1.VMAlertList) {
 		objects.Items = append(objects.Items, dst.Items...)
 	}); err != nil {
 		return result, fmt.Errorf("cannot list vmalerts for vmrule: %w", err)
@@ -115,7 +115,7 @@ func (r *VMRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (res
 			}
 		}
 
-		_, err := vmalert.CreateOrUpdateRuleConfigMaps(ctx, r, item, instance)
+		_, _, err := vmalert.CreateOrUpdateRuleConfigMaps(ctx, r, item, instance)
 		if err != nil {
 			return ctrl.Result{}, fmt.Errorf("cannot update rules configmaps: %w", err)
 		}
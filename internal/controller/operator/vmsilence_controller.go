@@ -0,0 +1,249 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	k8sreconcile "sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/finalize"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/logger"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/reconcile"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmalertmanager"
+)
+
+// VMSilenceReconciler reconciles a VMSilence object
+type VMSilenceReconciler struct {
+	client.Client
+	Log          logr.Logger
+	OriginScheme *runtime.Scheme
+	BaseConf     *config.BaseOperatorConf
+}
+
+// Init implements crdController interface
+func (r *VMSilenceReconciler) Init(rclient client.Client, l logr.Logger, sc *runtime.Scheme, cf *config.BaseOperatorConf) {
+	r.Client = rclient
+	r.Log = l.WithName("controller.VMSilence")
+	r.OriginScheme = sc
+	r.BaseConf = cf
+}
+
+// Scheme implements interface.
+func (r *VMSilenceReconciler) Scheme() *runtime.Scheme {
+	return r.OriginScheme
+}
+
+// Reconcile implements interface
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsilences,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsilences/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=operator.victoriametrics.com,resources=vmsilences/finalizers,verbs=*
+func (r *VMSilenceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, resultErr error) {
+	l := r.Log.WithValues("vmsilence", req.Name, "namespace", req.Namespace)
+	var instance vmv1beta1.VMSilence
+	defer func() {
+		result, resultErr = handleReconcileErrWithoutStatus(ctx, r.Client, &instance, result, resultErr)
+	}()
+
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		return result, &getError{err, "vmsilence", req}
+	}
+
+	RegisterObjectStat(&instance, "vmsilence")
+
+	var objects vmv1beta1.VMAlertmanagerList
+	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.GetWatchNamespaces(), func(dst *vmv1beta1.VMAlertmanagerList) {
+		objects.Items = append(objects.Items, dst.Items...)
+	}); err != nil {
+		return result, fmt.Errorf("cannot list vmalertmanagers for vmsilence: %w", err)
+	}
+	alertmanagers := make(map[string]*vmv1beta1.VMAlertmanager, len(objects.Items))
+	for i := range objects.Items {
+		item := &objects.Items[i]
+		alertmanagers[fmt.Sprintf("%s/%s", item.Namespace, item.Name)] = item
+	}
+	prevStates := make(map[string]*vmv1beta1.VMSilenceState, len(instance.Status.Silences))
+	for i := range instance.Status.Silences {
+		st := &instance.Status.Silences[i]
+		prevStates[st.Alertmanager] = st
+	}
+
+	if !instance.DeletionTimestamp.IsZero() {
+		for key, st := range prevStates {
+			// finalizer must be released if alertmanager no longer exists
+			am, ok := alertmanagers[key]
+			if !ok {
+				continue
+			}
+			if err := r.expireSilence(ctx, am, st); err != nil {
+				return result, err
+			}
+		}
+		if err := finalize.RemoveFinalizer(ctx, r.Client, &instance); err != nil {
+			return result, err
+		}
+		return
+	}
+	if err := instance.Validate(); err != nil {
+		return result, reconcile.VMSilenceStatus(ctx, r.Client, &instance, instance.Status.Silences, err)
+	}
+	if err := finalize.AddFinalizer(ctx, r.Client, &instance); err != nil {
+		return result, err
+	}
+
+	var silences []vmv1beta1.VMSilenceState
+	var syncErrs []string
+	var nextTransition time.Time
+	for i := range objects.Items {
+		item := &objects.Items[i]
+		if !item.DeletionTimestamp.IsZero() || item.Spec.ParsingError != "" {
+			continue
+		}
+		opts := &k8stools.SelectorOpts{
+			SelectAll:         item.Spec.SelectAllByDefault,
+			NamespaceSelector: item.Spec.SilenceNamespaceSelector,
+			ObjectSelector:    item.Spec.SilenceSelector,
+			DefaultNamespace:  instance.Namespace,
+		}
+		match, err := isSelectorsMatchesTargetCRD(ctx, r.Client, &instance, item, opts)
+		if err != nil {
+			return result, fmt.Errorf("cannot match vmalertmanager=%s/%s against silence selector: %w", item.Namespace, item.Name, err)
+		}
+		if !match {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", item.Namespace, item.Name)
+		prevState := prevStates[key]
+		delete(prevStates, key)
+
+		ctx := logger.AddToContext(ctx, l.WithValues("vmalertmanager", item.Name, "parent_namespace", item.Namespace))
+		st, next, err := vmalertmanager.SyncSilence(ctx, r.Client, item, &instance, prevState)
+		if err != nil {
+			syncErrs = append(syncErrs, fmt.Sprintf("vmalertmanager=%s: %s", key, err))
+			if prevState != nil {
+				silences = append(silences, *prevState)
+			}
+			continue
+		}
+		silences = append(silences, *st)
+		if !next.IsZero() && (nextTransition.IsZero() || next.Before(nextTransition)) {
+			nextTransition = next
+		}
+	}
+	// expire silences at alertmanagers, which no longer select VMSilence
+	for key, st := range prevStates {
+		if err := r.expireSilence(ctx, alertmanagers[key], st); err != nil {
+			syncErrs = append(syncErrs, fmt.Sprintf("vmalertmanager=%s: %s", key, err))
+			silences = append(silences, *st)
+		}
+	}
+
+	var syncErr error
+	if len(syncErrs) > 0 {
+		syncErr = errors.New(strings.Join(syncErrs, ", "))
+	}
+	if err := reconcile.VMSilenceStatus(ctx, r.Client, &instance, silences, syncErr); err != nil {
+		return result, err
+	}
+	if syncErr != nil {
+		return result, syncErr
+	}
+
+	// periodic resync recreates silences lost by alertmanager
+	result.RequeueAfter = config.MustGetBaseConfig().ResyncAfterDuration()
+	if !nextTransition.IsZero() {
+		// add a small delay in order to sync silence after transition
+		untilNext := time.Until(nextTransition) + time.Second
+		if result.RequeueAfter == 0 || untilNext < result.RequeueAfter {
+			result.RequeueAfter = untilNext
+		}
+	}
+	return
+}
+
+// expireSilence expires silence, which was previously synced to the given alertmanager
+func (r *VMSilenceReconciler) expireSilence(ctx context.Context, am *vmv1beta1.VMAlertmanager, st *vmv1beta1.VMSilenceState) error {
+	// alertmanager was deleted with all silences
+	if am == nil || !am.DeletionTimestamp.IsZero() {
+		return nil
+	}
+	if st.ID == "" || st.State == vmv1beta1.SilenceStateExpired {
+		return nil
+	}
+	if err := vmalertmanager.ExpireSilence(ctx, r.Client, am, st.ID); err != nil {
+		return fmt.Errorf("cannot expire silence=%s: %w", st.ID, err)
+	}
+	return nil
+}
+
+// SetupWithManager configures reconcile
+func (r *VMSilenceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&vmv1beta1.VMSilence{}).
+		Watches(&vmv1beta1.VMAlertmanager{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForAlertmanager)).
+		WithEventFilter(predicate.TypedGenerationChangedPredicate[client.Object]{}).
+		WithOptions(getDefaultOptions()).
+		Complete(r)
+}
+
+// requestsForAlertmanager returns silences selected by the given VMAlertmanager or previously synced to it.
+// Silences must be synced to the new or updated alertmanager and expired at deselected alertmanager
+func (r *VMSilenceReconciler) requestsForAlertmanager(ctx context.Context, obj client.Object) []k8sreconcile.Request {
+	am, ok := obj.(*vmv1beta1.VMAlertmanager)
+	if !ok {
+		return nil
+	}
+	var objects vmv1beta1.VMSilenceList
+	if err := k8stools.ListObjectsByNamespace(ctx, r.Client, r.BaseConf.GetWatchNamespaces(), func(dst *vmv1beta1.VMSilenceList) {
+		objects.Items = append(objects.Items, dst.Items...)
+	}); err != nil {
+		r.Log.Error(err, "cannot list vmsilences for vmalertmanager")
+		return nil
+	}
+	key := fmt.Sprintf("%s/%s", am.Namespace, am.Name)
+	opts := &k8stools.SelectorOpts{
+		SelectAll:         am.Spec.SelectAllByDefault,
+		NamespaceSelector: am.Spec.SilenceNamespaceSelector,
+		ObjectSelector:    am.Spec.SilenceSelector,
+	}
+	var requests []k8sreconcile.Request
+	for i := range objects.Items {
+		item := &objects.Items[i]
+		match := slices.ContainsFunc(item.Status.Silences, func(st vmv1beta1.VMSilenceState) bool {
+			return st.Alertmanager == key
+		})
+		if !match {
+			opts.DefaultNamespace = item.Namespace
+			var err error
+			match, err = isSelectorsMatchesTargetCRD(ctx, r.Client, item, am, opts)
+			if err != nil {
+				r.Log.Error(err, "cannot match vmsilence against vmalertmanager selector", "vmsilence", item.Name, "namespace", item.Namespace)
+				continue
+			}
+		}
+		if match {
+			requests = append(requests, k8sreconcile.Request{NamespacedName: types.NamespacedName{Name: item.Name, Namespace: item.Namespace}})
+		}
+	}
+	return requests
+}
+
+// IsDisabled returns true if controller should be disabled
+func (*VMSilenceReconciler) IsDisabled(_ *config.BaseOperatorConf, disabledControllers sets.Set[string]) bool {
+	return disabledControllers.Has("VMAlertmanager")
+}
@@ -23,6 +23,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -223,6 +224,9 @@ func RunManager(ctx context.Context) error {
 	config.ConfigAsMetrics(r, baseConfig)
 
 	setupLog.Info("registering Components.")
+	if err := vmcontroller.InitSharding(baseConfig); err != nil {
+		return fmt.Errorf("cannot init operator sharding: %w", err)
+	}
 	var watchNsCacheByName map[string]cache.Config
 	if len(baseConfig.WatchNamespaces) > 0 {
 		setupLog.Info("operator configured with watching for subset of namespaces, cluster wide access is disabled", "namespaces", strings.Join(baseConfig.WatchNamespaces, ","))
@@ -232,6 +236,19 @@ func RunManager(ctx context.Context) error {
 		}
 	}
 
+	var newCache cache.NewCacheFunc
+	switch {
+	case baseConfig.WatchNamespaceSelector != "":
+		setupLog.Info("operator configured with watching for namespaces matching selector, cluster wide access is disabled", "selector", baseConfig.WatchNamespaceSelector)
+		selector, err := labels.Parse(baseConfig.WatchNamespaceSelector)
+		if err != nil {
+			return fmt.Errorf("cannot parse namespace selector: %w", err)
+		}
+		newCache = newNamespaceSelectorCacheFunc(func(ns *corev1.Namespace) bool {
+			return selector.Matches(labels.Set(ns.Labels))
+		})
+	}
+
 	reconcile.InitDeadlines(baseConfig.PodWaitReadyIntervalCheck, baseConfig.AppReadyTimeout, baseConfig.PodWaitReadyTimeout, 5*time.Second)
 	reconcile.SetStatusUpdateTTL(*statusUpdateTTL)
 	config := ctrl.GetConfigOrDie()
@@ -250,7 +267,7 @@ func RunManager(ctx context.Context) error {
 		ReadinessEndpointName:   "/ready",
 		LivenessEndpointName:    "/health",
 		WebhookServer:           webhookServer,
-		LeaderElection:          *leaderElect,
+		LeaderElection:          *leaderElect && !vmcontroller.IsShardingEnabled(),
 		LeaderElectionNamespace: *leaderElectNamespace,
 		LeaderElectionID:        *leaderElectID,
 		LeaseDuration:           leaderElectLeaseDuration,
@@ -258,6 +275,7 @@ func RunManager(ctx context.Context) error {
 		Cache: cache.Options{
 			DefaultNamespaces: watchNsCacheByName,
 		},
+		NewCache: newCache,
 		Client: client.Options{
 			Cache: co,
 		},
@@ -301,6 +319,13 @@ func RunManager(ctx context.Context) error {
 		build.SetSkipRuntimeValidation(true)
 	}
 
+	// VMOperatorConfig is cluster-scoped and could be read only with cluster-wide access
+	if len(baseConfig.WatchNamespaces) == 0 && baseConfig.WatchNamespaceSelector == "" {
+		if err := vmcontroller.InitOperatorConfig(ctx, mgr.GetAPIReader()); err != nil {
+			setupLog.Error(err, "cannot apply VMOperatorConfig, using configuration from env variables")
+		}
+	}
+
 	if err := initControllers(mgr, ctrl.Log, baseConfig); err != nil {
 		return err
 	}
@@ -333,11 +358,20 @@ func RunManager(ctx context.Context) error {
 		setupLog.Error(err, "cannot setup prometheus CRD converter: %w", err)
 		return err
 	}
-
 	if err := mgr.Add(converterController); err != nil {
 		setupLog.Error(err, "cannot add runnable")
 		return err
 	}
+	if vmcontroller.IsShardingEnabled() {
+		shardLeases, err := vmcontroller.NewShardLeases(mgr, *leaderElectNamespace, *leaderElectID, *leaderElectLeaseDuration, *leaderElectRenewDeadline)
+		if err != nil {
+			return fmt.Errorf("cannot setup shard leases: %w", err)
+		}
+		if err := mgr.Add(shardLeases); err != nil {
+			setupLog.Error(err, "cannot add runnable")
+			return err
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctx); err != nil {
@@ -361,6 +395,9 @@ func addWebhooks(mgr ctrl.Manager) error {
 		webhookv1beta1.SetupVMAgentWebhookWithManager,
 		webhookv1beta1.SetupVMAlertWebhookWithManager,
 		webhookv1.SetupVMAnomalyWebhookWithManager,
+		webhookv1.SetupVMAnomalyModelWebhookWithManager,
+		webhookv1.SetupVMAnomalySchedulerWebhookWithManager,
+		webhookv1.SetupVMAnomalyQueryWebhookWithManager,
 		webhookv1beta1.SetupVMSingleWebhookWithManager,
 		webhookv1beta1.SetupVMClusterWebhookWithManager,
 		webhookv1alpha1.SetupVMDistributedWebhookWithManager,
@@ -372,6 +409,10 @@ func addWebhooks(mgr ctrl.Manager) error {
 		webhookv1.SetupVTClusterWebhookWithManager,
 		webhookv1beta1.SetupVMAlertmanagerWebhookWithManager,
 		webhookv1beta1.SetupVMAlertmanagerConfigWebhookWithManager,
+		webhookv1beta1.SetupVMSilenceWebhookWithManager,
+		webhookv1beta1.SetupVMAlertmanagerReceiverWebhookWithManager,
+		webhookv1beta1.SetupVMAlertmanagerClusterReceiverWebhookWithManager,
+		webhookv1beta1.SetupVMOperatorConfigWebhookWithManager,
 		webhookv1beta1.SetupVMAuthWebhookWithManager,
 		webhookv1beta1.SetupVMUserWebhookWithManager,
 		webhookv1beta1.SetupVMRuleWebhookWithManager,
@@ -462,29 +503,33 @@ type crdController interface {
 }
 
 var controllersByName = map[string]crdController{
-	"VMCluster":            &vmcontroller.VMClusterReconciler{},
-	"VMAgent":              &vmcontroller.VMAgentReconciler{},
-	"VMAnomaly":            &vmcontroller.VMAnomalyReconciler{},
-	"VMAuth":               &vmcontroller.VMAuthReconciler{},
-	"VMSingle":             &vmcontroller.VMSingleReconciler{},
-	"VLAgent":              &vmcontroller.VLAgentReconciler{},
-	"VLogs":                &vmcontroller.VLogsReconciler{},
-	"VLSingle":             &vmcontroller.VLSingleReconciler{},
-	"VLCluster":            &vmcontroller.VLClusterReconciler{},
-	"VTSingle":             &vmcontroller.VTSingleReconciler{},
-	"VTCluster":            &vmcontroller.VTClusterReconciler{},
-	"VMAlertmanager":       &vmcontroller.VMAlertmanagerReconciler{},
-	"VMAlert":              &vmcontroller.VMAlertReconciler{},
-	"VMUser":               &vmcontroller.VMUserReconciler{},
-	"VMRule":               &vmcontroller.VMRuleReconciler{},
-	"VMAlertmanagerConfig": &vmcontroller.VMAlertmanagerConfigReconciler{},
-	"VMServiceScrape":      &vmcontroller.VMServiceScrapeReconciler{},
-	"VMPodScrape":          &vmcontroller.VMPodScrapeReconciler{},
-	"VMProbe":              &vmcontroller.VMProbeReconciler{},
-	"VMNodeScrape":         &vmcontroller.VMNodeScrapeReconciler{},
-	"VMStaticScrape":       &vmcontroller.VMStaticScrapeReconciler{},
-	"VMScrapeConfig":       &vmcontroller.VMScrapeConfigReconciler{},
-	"VMDistributed":        &vmcontroller.VMDistributedReconciler{},
+	"VMCluster":                     &vmcontroller.VMClusterReconciler{},
+	"VMAgent":                       &vmcontroller.VMAgentReconciler{},
+	"VMAnomaly":                     &vmcontroller.VMAnomalyReconciler{},
+	"VMAuth":                        &vmcontroller.VMAuthReconciler{},
+	"VMSingle":                      &vmcontroller.VMSingleReconciler{},
+	"VLAgent":                       &vmcontroller.VLAgentReconciler{},
+	"VLogs":                         &vmcontroller.VLogsReconciler{},
+	"VLSingle":                      &vmcontroller.VLSingleReconciler{},
+	"VLCluster":                     &vmcontroller.VLClusterReconciler{},
+	"VTSingle":                      &vmcontroller.VTSingleReconciler{},
+	"VTCluster":                     &vmcontroller.VTClusterReconciler{},
+	"VMAlertmanager":                &vmcontroller.VMAlertmanagerReconciler{},
+	"VMAlert":                       &vmcontroller.VMAlertReconciler{},
+	"VMUser":                        &vmcontroller.VMUserReconciler{},
+	"VMRule":                        &vmcontroller.VMRuleReconciler{},
+	"VMAlertmanagerConfig":          &vmcontroller.VMAlertmanagerConfigReconciler{},
+	"VMSilence":                     &vmcontroller.VMSilenceReconciler{},
+	"VMAlertmanagerReceiver":        &vmcontroller.VMAlertmanagerReceiverReconciler{},
+	"VMAlertmanagerClusterReceiver": &vmcontroller.VMAlertmanagerClusterReceiverReconciler{},
+	"VMServiceScrape":               &vmcontroller.VMServiceScrapeReconciler{},
+	"VMPodScrape":                   &vmcontroller.VMPodScrapeReconciler{},
+	"VMProbe":                       &vmcontroller.VMProbeReconciler{},
+	"VMNodeScrape":                  &vmcontroller.VMNodeScrapeReconciler{},
+	"VMStaticScrape":                &vmcontroller.VMStaticScrapeReconciler{},
+	"VMScrapeConfig":                &vmcontroller.VMScrapeConfigReconciler{},
+	"VMDistributed":                 &vmcontroller.VMDistributedReconciler{},
+	"VMOperatorConfig":              &vmcontroller.VMOperatorConfigReconciler{},
 }
 
 func initControllers(mgr ctrl.Manager, l logr.Logger, bs *config.BaseOperatorConf) error {
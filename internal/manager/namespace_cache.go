@@ -0,0 +1,549 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+// newNamespaceSelectorCacheFunc returns cache builder, which watches objects only at namespaces accepted by matches.
+// It's used for WATCH_NAMESPACE_SELECTOR and operator sharding
+func newNamespaceSelectorCacheFunc(matches func(ns *corev1.Namespace) bool) cache.NewCacheFunc {
+	return func(restConfig *rest.Config, opts cache.Options) (cache.Cache, error) {
+		clusterCache, err := cache.New(restConfig, opts)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create cache for cluster-scoped objects: %w", err)
+		}
+		return &namespaceSelectorCache{
+			scheme:       opts.Scheme,
+			mapper:       opts.Mapper,
+			matches:      matches,
+			clusterCache: clusterCache,
+			newCache: func(namespace string) (cache.Cache, error) {
+				nsOpts := opts
+				nsOpts.DefaultNamespaces = map[string]cache.Config{namespace: {}}
+				return cache.New(restConfig, nsOpts)
+			},
+			namespaces: make(map[string]*namespaceCache),
+			informers:  make(map[schema.GroupVersionKind]*namespaceSelectorInformer),
+			started:    make(chan struct{}),
+		}, nil
+	}
+}
+
+type namespaceCache struct {
+	cache.Cache
+	cancel context.CancelFunc
+}
+
+type fieldIndex struct {
+	obj          client.Object
+	field        string
+	extractValue client.IndexerFunc
+}
+
+// namespaceSelectorCache starts and stops caches for matching namespaces at runtime.
+// Cluster-scoped objects, including namespaces, are served by the cluster cache
+type namespaceSelectorCache struct {
+	scheme       *runtime.Scheme
+	mapper       apimeta.RESTMapper
+	matches      func(ns *corev1.Namespace) bool
+	clusterCache cache.Cache
+	newCache     func(namespace string) (cache.Cache, error)
+
+	mu  sync.RWMutex
+	ctx context.Context
+	// started is closed after namespaces handler registration
+	started    chan struct{}
+	nsHandler  toolscache.ResourceEventHandlerRegistration
+	namespaces map[string]*namespaceCache
+	informers  map[schema.GroupVersionKind]*namespaceSelectorInformer
+	indexes    []fieldIndex
+}
+
+var _ cache.Cache = (*namespaceSelectorCache)(nil)
+
+// Start implements cache.Cache
+func (c *namespaceSelectorCache) Start(ctx context.Context) error {
+	nsInformer, err := c.clusterCache.GetInformer(ctx, &corev1.Namespace{}, cache.BlockUntilSynced(false))
+	if err != nil {
+		return fmt.Errorf("cannot get namespaces informer: %w", err)
+	}
+	c.mu.Lock()
+	c.ctx = ctx
+	c.mu.Unlock()
+	nsHandler, err := nsInformer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			c.syncNamespace(obj)
+		},
+		UpdateFunc: func(_, obj any) {
+			c.syncNamespace(obj)
+		},
+		DeleteFunc: func(obj any) {
+			if d, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+				obj = d.Obj
+			}
+			if ns, ok := obj.(*corev1.Namespace); ok {
+				c.removeNamespace(ns.Name)
+			}
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("cannot add namespaces handler: %w", err)
+	}
+	c.nsHandler = nsHandler
+	close(c.started)
+	defer c.stopNamespaces()
+	return c.clusterCache.Start(ctx)
+}
+
+func (c *namespaceSelectorCache) syncNamespace(obj any) {
+	ns, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+	if c.matches(ns) && ns.DeletionTimestamp == nil {
+		c.addNamespace(ns.Name)
+		return
+	}
+	c.removeNamespace(ns.Name)
+}
+
+func (c *namespaceSelectorCache) addNamespace(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.namespaces[namespace]; ok || c.ctx == nil {
+		return
+	}
+	nsCache, err := c.newCache(namespace)
+	if err != nil {
+		setupLog.Error(err, "cannot create cache", "namespace", namespace)
+		return
+	}
+	for _, idx := range c.indexes {
+		if err := nsCache.IndexField(c.ctx, idx.obj, idx.field, idx.extractValue); err != nil {
+			setupLog.Error(err, "cannot add field index", "namespace", namespace, "field", idx.field)
+			return
+		}
+	}
+	for _, inf := range c.informers {
+		nsInformer, err := nsCache.GetInformer(c.ctx, inf.obj, cache.BlockUntilSynced(false))
+		if err != nil {
+			setupLog.Error(err, "cannot get informer", "namespace", namespace)
+			return
+		}
+		if err := inf.addNamespace(namespace, nsInformer); err != nil {
+			setupLog.Error(err, "cannot register informer handlers", "namespace", namespace)
+			return
+		}
+	}
+	ctx, cancel := context.WithCancel(c.ctx)
+	c.namespaces[namespace] = &namespaceCache{Cache: nsCache, cancel: cancel}
+	go func() {
+		if err := nsCache.Start(ctx); err != nil {
+			setupLog.Error(err, "cannot start cache", "namespace", namespace)
+		}
+	}()
+	setupLog.Info("started watching namespace", "namespace", namespace)
+	config.SetSelectedNamespaces(c.namespaceNames())
+}
+
+func (c *namespaceSelectorCache) removeNamespace(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	nsCache, ok := c.namespaces[namespace]
+	if !ok {
+		return
+	}
+	delete(c.namespaces, namespace)
+	for _, inf := range c.informers {
+		inf.removeNamespace(namespace)
+	}
+	nsCache.cancel()
+	setupLog.Info("stopped watching namespace", "namespace", namespace)
+	config.SetSelectedNamespaces(c.namespaceNames())
+}
+
+func (c *namespaceSelectorCache) stopNamespaces() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, nsCache := range c.namespaces {
+		nsCache.cancel()
+	}
+}
+
+// namespaceNames must be called under lock
+func (c *namespaceSelectorCache) namespaceNames() []string {
+	names := make([]string, 0, len(c.namespaces))
+	for ns := range c.namespaces {
+		names = append(names, ns)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (c *namespaceSelectorCache) namespaceCaches() []cache.Cache {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	caches := make([]cache.Cache, 0, len(c.namespaces))
+	for _, nsCache := range c.namespaces {
+		caches = append(caches, nsCache.Cache)
+	}
+	return caches
+}
+
+// isStopped checks if cache was started and its context is done
+func (c *namespaceSelectorCache) isStopped() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ctx != nil && c.ctx.Err() != nil
+}
+
+// WaitForCacheSync implements cache.Cache
+//
+// It blocks until namespaces handler processed initial list of namespaces
+// and caches for all matching namespaces are synced
+func (c *namespaceSelectorCache) WaitForCacheSync(ctx context.Context) bool {
+	select {
+	case <-c.started:
+	case <-ctx.Done():
+		return false
+	}
+	if !c.clusterCache.WaitForCacheSync(ctx) {
+		return false
+	}
+	if !toolscache.WaitForCacheSync(ctx.Done(), c.nsHandler.HasSynced) {
+		return false
+	}
+	synced := true
+	for _, nsCache := range c.namespaceCaches() {
+		if !nsCache.WaitForCacheSync(ctx) {
+			synced = false
+		}
+	}
+	return synced
+}
+
+// GetInformer implements cache.Cache
+func (c *namespaceSelectorCache) GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	isNamespaced, err := apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+	if err != nil {
+		return nil, err
+	}
+	if !isNamespaced {
+		return c.clusterCache.GetInformer(ctx, obj, opts...)
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return nil, err
+	}
+	return c.getNamespacedInformer(ctx, gvk, obj)
+}
+
+// GetInformerForKind implements cache.Cache
+func (c *namespaceSelectorCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind, opts ...cache.InformerGetOption) (cache.Informer, error) {
+	isNamespaced, err := apiutil.IsGVKNamespaced(gvk, c.mapper)
+	if err != nil {
+		return nil, err
+	}
+	if !isNamespaced {
+		return c.clusterCache.GetInformerForKind(ctx, gvk, opts...)
+	}
+	obj, err := c.scheme.New(gvk)
+	if err != nil {
+		return nil, err
+	}
+	cObj, ok := obj.(client.Object)
+	if !ok {
+		return nil, fmt.Errorf("object of kind=%s doesn't implement client.Object", gvk.Kind)
+	}
+	return c.getNamespacedInformer(ctx, gvk, cObj)
+}
+
+func (c *namespaceSelectorCache) getNamespacedInformer(ctx context.Context, gvk schema.GroupVersionKind, obj client.Object) (cache.Informer, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if inf, ok := c.informers[gvk]; ok {
+		return inf, nil
+	}
+	inf := &namespaceSelectorInformer{
+		obj:       obj.DeepCopyObject().(client.Object),
+		informers: make(map[string]cache.Informer),
+		stopped:   c.isStopped,
+	}
+	for ns, nsCache := range c.namespaces {
+		nsInformer, err := nsCache.GetInformer(ctx, obj, cache.BlockUntilSynced(false))
+		if err != nil {
+			return nil, fmt.Errorf("cannot get informer for namespace=%s: %w", ns, err)
+		}
+		if err := inf.addNamespace(ns, nsInformer); err != nil {
+			return nil, err
+		}
+	}
+	c.informers[gvk] = inf
+	return inf, nil
+}
+
+// RemoveInformer implements cache.Cache
+func (c *namespaceSelectorCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	isNamespaced, err := apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+	if err != nil {
+		return err
+	}
+	if !isNamespaced {
+		return c.clusterCache.RemoveInformer(ctx, obj)
+	}
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.informers, gvk)
+	for _, nsCache := range c.namespaces {
+		if err := nsCache.RemoveInformer(ctx, obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IndexField implements cache.Cache
+func (c *namespaceSelectorCache) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	isNamespaced, err := apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+	if err != nil {
+		return err
+	}
+	if !isNamespaced {
+		return c.clusterCache.IndexField(ctx, obj, field, extractValue)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.indexes = append(c.indexes, fieldIndex{obj: obj, field: field, extractValue: extractValue})
+	for _, nsCache := range c.namespaces {
+		if err := nsCache.IndexField(ctx, obj, field, extractValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get implements client.Reader
+func (c *namespaceSelectorCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	isNamespaced, err := apiutil.IsObjectNamespaced(obj, c.scheme, c.mapper)
+	if err != nil {
+		return err
+	}
+	if !isNamespaced {
+		return c.clusterCache.Get(ctx, key, obj, opts...)
+	}
+	c.mu.RLock()
+	nsCache, ok := c.namespaces[key.Namespace]
+	c.mu.RUnlock()
+	if !ok {
+		// object at not watched namespace is treated as missing
+		// it allows to properly handle requests for namespaces, which no longer match selector
+		gvk, err := apiutil.GVKForObject(obj, c.scheme)
+		if err != nil {
+			return err
+		}
+		return k8serrors.NewNotFound(schema.GroupResource{Group: gvk.Group, Resource: gvk.Kind}, key.Name)
+	}
+	return nsCache.Get(ctx, key, obj, opts...)
+}
+
+// List implements client.Reader
+func (c *namespaceSelectorCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	gvk, err := apiutil.GVKForObject(list, c.scheme)
+	if err != nil {
+		return err
+	}
+	gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	isNamespaced, err := apiutil.IsGVKNamespaced(gvk, c.mapper)
+	if err != nil {
+		return err
+	}
+	if !isNamespaced {
+		return c.clusterCache.List(ctx, list, opts...)
+	}
+	if listOpts.Namespace != corev1.NamespaceAll {
+		c.mu.RLock()
+		nsCache, ok := c.namespaces[listOpts.Namespace]
+		c.mu.RUnlock()
+		if !ok {
+			return apimeta.SetList(list, nil)
+		}
+		return nsCache.List(ctx, list, opts...)
+	}
+
+	var allItems []runtime.Object
+	for _, nsCache := range c.namespaceCaches() {
+		nsList := list.DeepCopyObject().(client.ObjectList)
+		if err := nsCache.List(ctx, nsList, &listOpts); err != nil {
+			return err
+		}
+		items, err := apimeta.ExtractList(nsList)
+		if err != nil {
+			return err
+		}
+		allItems = append(allItems, items...)
+	}
+	return apimeta.SetList(list, allItems)
+}
+
+type namespaceSelectorHandler struct {
+	handler       toolscache.ResourceEventHandler
+	options       toolscache.HandlerOptions
+	registrations map[string]toolscache.ResourceEventHandlerRegistration
+}
+
+// HasSynced implements toolscache.ResourceEventHandlerRegistration
+func (h *namespaceSelectorHandler) HasSynced() bool {
+	for _, r := range h.registrations {
+		if !r.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// namespaceSelectorInformer registers handlers at informers of all watched namespaces,
+// including namespaces added after handler registration
+type namespaceSelectorInformer struct {
+	obj client.Object
+	// stopped checks if parent cache is stopped,
+	// informers of namespaces are stopped and removed at runtime
+	stopped func() bool
+
+	mu        sync.Mutex
+	informers map[string]cache.Informer
+	handlers  []*namespaceSelectorHandler
+	indexers  []toolscache.Indexers
+}
+
+var _ cache.Informer = (*namespaceSelectorInformer)(nil)
+
+func (i *namespaceSelectorInformer) addNamespace(namespace string, inf cache.Informer) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, indexers := range i.indexers {
+		if err := inf.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	for _, h := range i.handlers {
+		r, err := inf.AddEventHandlerWithOptions(h.handler, h.options)
+		if err != nil {
+			return err
+		}
+		h.registrations[namespace] = r
+	}
+	i.informers[namespace] = inf
+	return nil
+}
+
+func (i *namespaceSelectorInformer) removeNamespace(namespace string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.informers, namespace)
+	for _, h := range i.handlers {
+		delete(h.registrations, namespace)
+	}
+}
+
+// AddEventHandler implements cache.Informer
+func (i *namespaceSelectorInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	return i.AddEventHandlerWithOptions(handler, toolscache.HandlerOptions{})
+}
+
+// AddEventHandlerWithResyncPeriod implements cache.Informer
+func (i *namespaceSelectorInformer) AddEventHandlerWithResyncPeriod(handler toolscache.ResourceEventHandler, resyncPeriod time.Duration) (toolscache.ResourceEventHandlerRegistration, error) {
+	return i.AddEventHandlerWithOptions(handler, toolscache.HandlerOptions{ResyncPeriod: &resyncPeriod})
+}
+
+// AddEventHandlerWithOptions implements cache.Informer
+func (i *namespaceSelectorInformer) AddEventHandlerWithOptions(handler toolscache.ResourceEventHandler, options toolscache.HandlerOptions) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	h := &namespaceSelectorHandler{
+		handler:       handler,
+		options:       options,
+		registrations: make(map[string]toolscache.ResourceEventHandlerRegistration, len(i.informers)),
+	}
+	for ns, inf := range i.informers {
+		r, err := inf.AddEventHandlerWithOptions(handler, options)
+		if err != nil {
+			return nil, err
+		}
+		h.registrations[ns] = r
+	}
+	i.handlers = append(i.handlers, h)
+	return h, nil
+}
+
+// RemoveEventHandler implements cache.Informer
+func (i *namespaceSelectorInformer) RemoveEventHandler(handle toolscache.ResourceEventHandlerRegistration) error {
+	h, ok := handle.(*namespaceSelectorHandler)
+	if !ok {
+		return fmt.Errorf("registration is not a registration returned by namespaceSelectorInformer")
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.handlers = slices.DeleteFunc(i.handlers, func(v *namespaceSelectorHandler) bool { return v == h })
+	for ns, r := range h.registrations {
+		if inf, ok := i.informers[ns]; ok {
+			if err := inf.RemoveEventHandler(r); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AddIndexers implements cache.Informer
+func (i *namespaceSelectorInformer) AddIndexers(indexers toolscache.Indexers) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.indexers = append(i.indexers, indexers)
+	for _, inf := range i.informers {
+		if err := inf.AddIndexers(indexers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasSynced implements cache.Informer
+func (i *namespaceSelectorInformer) HasSynced() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for _, inf := range i.informers {
+		if !inf.HasSynced() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsStopped implements cache.Informer
+func (i *namespaceSelectorInformer) IsStopped() bool {
+	return i.stopped()
+}
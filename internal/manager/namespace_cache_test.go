@@ -0,0 +1,161 @@
+package manager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+
+	"github.com/VictoriaMetrics/operator/internal/config"
+)
+
+func TestNamespaceSelectorCache(t *testing.T) {
+	ctx := context.Background()
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, apimeta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, apimeta.RESTScopeRoot)
+	nsCaches := map[string]*informertest.FakeInformers{}
+	c := &namespaceSelectorCache{
+		scheme: clientgoscheme.Scheme,
+		mapper: mapper,
+		matches: func(ns *corev1.Namespace) bool {
+			return labels.SelectorFromSet(labels.Set{"team": "a"}).Matches(labels.Set(ns.Labels))
+		},
+		clusterCache: &informertest.FakeInformers{},
+		newCache: func(namespace string) (cache.Cache, error) {
+			nsCaches[namespace] = &informertest.FakeInformers{}
+			return nsCaches[namespace], nil
+		},
+		ctx:        ctx,
+		namespaces: make(map[string]*namespaceCache),
+		informers:  make(map[schema.GroupVersionKind]*namespaceSelectorInformer),
+		started:    make(chan struct{}),
+	}
+	defer c.stopNamespaces()
+	defer config.SetSelectedNamespaces(nil)
+	cfg := &config.BaseOperatorConf{WatchNamespaceSelector: "team=a"}
+	newNamespace := func(name, team string) *corev1.Namespace {
+		return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"team": team}}}
+	}
+
+	// handler is registered before namespaces are added
+	inf, err := c.GetInformer(ctx, &corev1.Pod{})
+	assert.NoError(t, err)
+	var got []string
+	_, err = inf.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			got = append(got, obj.(*corev1.Pod).Namespace)
+		},
+	})
+	assert.NoError(t, err)
+
+	changed := config.WatchNamespacesChanged()
+	c.syncNamespace(newNamespace("team-a", "a"))
+	c.syncNamespace(newNamespace("team-b", "b"))
+	assert.Equal(t, []string{"team-a"}, cfg.GetWatchNamespaces())
+	assert.NotContains(t, nsCaches, "team-b")
+	select {
+	case <-changed:
+	default:
+		t.Fatalf("expected notification about namespaces change")
+	}
+
+	podInformer, err := nsCaches["team-a"].FakeInformerFor(ctx, &corev1.Pod{})
+	assert.NoError(t, err)
+	podInformer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "team-a"}})
+	assert.Equal(t, []string{"team-a"}, got)
+
+	// namespace no longer matches selector
+	c.syncNamespace(newNamespace("team-a", "b"))
+	assert.Empty(t, cfg.GetWatchNamespaces())
+	var pod corev1.Pod
+	err = c.Get(ctx, types.NamespacedName{Name: "pod", Namespace: "team-a"}, &pod)
+	assert.True(t, k8serrors.IsNotFound(err))
+	var pods corev1.PodList
+	assert.NoError(t, c.List(ctx, &pods))
+	assert.Empty(t, pods.Items)
+
+	// namespace matches selector again
+	c.syncNamespace(newNamespace("team-a", "a"))
+	podInformer, err = nsCaches["team-a"].FakeInformerFor(ctx, &corev1.Pod{})
+	assert.NoError(t, err)
+	podInformer.Add(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "team-a"}})
+	assert.Equal(t, []string{"team-a", "team-a"}, got)
+}
+
+func TestNamespaceSelectorCacheWaitForCacheSync(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mapper := apimeta.NewDefaultRESTMapper(nil)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, apimeta.RESTScopeNamespace)
+	mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Namespace"}, apimeta.RESTScopeRoot)
+	clusterCache := &informertest.FakeInformers{}
+	nsCaches := map[string]*informertest.FakeInformers{}
+	c := &namespaceSelectorCache{
+		scheme: clientgoscheme.Scheme,
+		mapper: mapper,
+		matches: func(ns *corev1.Namespace) bool {
+			return ns.Name == "team-a"
+		},
+		clusterCache: clusterCache,
+		newCache: func(namespace string) (cache.Cache, error) {
+			nsCaches[namespace] = &informertest.FakeInformers{Synced: ptr.To(false)}
+			return nsCaches[namespace], nil
+		},
+		namespaces: make(map[string]*namespaceCache),
+		informers:  make(map[schema.GroupVersionKind]*namespaceSelectorInformer),
+		started:    make(chan struct{}),
+	}
+	defer config.SetSelectedNamespaces(nil)
+	waitForCacheSync := func() bool {
+		t.Helper()
+		waitCtx, waitCancel := context.WithTimeout(ctx, 200*time.Millisecond)
+		defer waitCancel()
+		return c.WaitForCacheSync(waitCtx)
+	}
+
+	// cache is not started yet
+	assert.False(t, waitForCacheSync())
+
+	nsInformer, err := clusterCache.FakeInformerFor(ctx, &corev1.Namespace{})
+	assert.NoError(t, err)
+	nsInformer.Synced = false
+	assert.NoError(t, c.Start(ctx))
+	inf, err := c.GetInformer(ctx, &corev1.Pod{})
+	assert.NoError(t, err)
+	assert.False(t, inf.IsStopped())
+
+	// initial namespaces are not processed yet
+	assert.False(t, waitForCacheSync())
+
+	nsInformer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a"}})
+	nsInformer.Add(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}})
+	nsInformer.SyncedLock.Lock()
+	nsInformer.Synced = true
+	nsInformer.SyncedLock.Unlock()
+
+	// cache of matched namespace is not synced yet
+	assert.False(t, waitForCacheSync())
+
+	nsCaches["team-a"].Synced = ptr.To(true)
+	assert.True(t, waitForCacheSync())
+	assert.NotContains(t, nsCaches, "team-b")
+
+	// informer is stopped only with cache
+	assert.False(t, inf.IsStopped())
+	cancel()
+	assert.True(t, inf.IsStopped())
+}
@@ -0,0 +1,152 @@
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	vmv1alpha1 "github.com/VictoriaMetrics/operator/api/operator/v1alpha1"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+)
+
+// statusObjects are custom resources with status subresource, factories update status of them
+var statusObjects = []client.Object{
+	&vmv1beta1.VMRule{},
+	&vmv1beta1.VMAlert{},
+	&vmv1beta1.VMAuth{},
+	&vmv1beta1.VMUser{},
+	&vmv1beta1.VMCluster{},
+	&vmv1beta1.VMSingle{},
+	&vmv1beta1.VMAgent{},
+	&vmv1beta1.VMAlertmanager{},
+	&vmv1beta1.VMAlertmanagerConfig{},
+	&vmv1beta1.VMAlertmanagerReceiver{},
+	&vmv1beta1.VMAlertmanagerClusterReceiver{},
+	&vmv1beta1.VMSilence{},
+	&vmv1beta1.VMOperatorConfig{},
+	&vmv1beta1.VLogs{},
+	&vmv1beta1.VMServiceScrape{},
+	&vmv1beta1.VMPodScrape{},
+	&vmv1beta1.VMProbe{},
+	&vmv1beta1.VMScrapeConfig{},
+	&vmv1beta1.VMStaticScrape{},
+	&vmv1beta1.VMNodeScrape{},
+	&vmv1alpha1.VMDistributed{},
+	&vmv1.VLSingle{},
+	&vmv1.VLCluster{},
+	&vmv1.VLAgent{},
+	&vmv1.VTSingle{},
+	&vmv1.VTCluster{},
+	&vmv1.VMAnomaly{},
+	&vmv1.VMAnomalyModel{},
+	&vmv1.VMAnomalyScheduler{},
+	&vmv1.VMAnomalyQuery{},
+}
+
+// newClient returns in-memory client with the given objects, which records keys of objects created by factories.
+//
+// There are no kubernetes controllers for rendered objects, so Deployments and StatefulSets
+// are marked as ready right after create or update, otherwise factories wait for rollout until timeout
+func newClient(objects []client.Object, created *[]objectKey) client.Client {
+	record := func(cl client.WithWatch, obj client.Object) error {
+		gvk, err := apiutil.GVKForObject(obj, cl.Scheme())
+		if err != nil {
+			return err
+		}
+		*created = append(*created, objectKey{gvk: gvk, nsn: client.ObjectKeyFromObject(obj)})
+		return nil
+	}
+	fns := interceptor.Funcs{
+		Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			markReady(obj)
+			if err := cl.Create(ctx, obj, opts...); err != nil {
+				return err
+			}
+			return record(cl, obj)
+		},
+		Update: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			markReady(obj)
+			return cl.Update(ctx, obj, opts...)
+		},
+		Apply: func(ctx context.Context, cl client.WithWatch, obj runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			if err := cl.Apply(ctx, obj, opts...); err != nil {
+				return err
+			}
+			applied, err := appliedObject(ctx, cl, obj)
+			if err != nil {
+				return err
+			}
+			if markReady(applied) {
+				if err := cl.Update(ctx, applied); err != nil {
+					return err
+				}
+			}
+			return record(cl, applied)
+		},
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(statusObjects...).
+		WithObjects(objects...).
+		WithInterceptorFuncs(fns).
+		Build()
+}
+
+// appliedObject returns object stored by the given apply configuration
+func appliedObject(ctx context.Context, cl client.Client, obj runtime.ApplyConfiguration) (client.Object, error) {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal apply configuration: %w", err)
+	}
+	var u unstructured.Unstructured
+	if err := u.UnmarshalJSON(data); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal apply configuration: %w", err)
+	}
+	o, err := cl.Scheme().New(u.GroupVersionKind())
+	if err != nil {
+		return nil, fmt.Errorf("cannot create object for kind=%s: %w", u.GroupVersionKind(), err)
+	}
+	applied := o.(client.Object)
+	if err := cl.Get(ctx, client.ObjectKeyFromObject(&u), applied); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
+
+// markReady sets status of Deployment and StatefulSet to ready with all replicas updated.
+// It returns false for other objects
+func markReady(obj client.Object) bool {
+	switch v := obj.(type) {
+	case *appsv1.StatefulSet:
+		v.Status.ObservedGeneration = v.Generation
+		v.Status.Replicas = ptr.Deref(v.Spec.Replicas, 0)
+		v.Status.ReadyReplicas = ptr.Deref(v.Spec.Replicas, 0)
+		v.Status.UpdatedReplicas = ptr.Deref(v.Spec.Replicas, 0)
+		v.Status.CurrentReplicas = ptr.Deref(v.Spec.Replicas, 0)
+		v.Status.UpdateRevision = "v1"
+		v.Status.CurrentRevision = "v1"
+	case *appsv1.Deployment:
+		v.Status.ObservedGeneration = v.Generation
+		v.Status.Conditions = []appsv1.DeploymentCondition{{
+			Type:   appsv1.DeploymentProgressing,
+			Reason: "NewReplicaSetAvailable",
+			Status: "True",
+		}}
+		v.Status.Replicas = ptr.Deref(v.Spec.Replicas, 0)
+		v.Status.UpdatedReplicas = ptr.Deref(v.Spec.Replicas, 0)
+		v.Status.ReadyReplicas = ptr.Deref(v.Spec.Replicas, 0)
+	default:
+		return false
+	}
+	return true
+}
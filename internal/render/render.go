@@ -0,0 +1,368 @@
+// Package render implements offline rendering of kubernetes objects managed by operator.
+//
+// It reads custom resources and referenced objects from manifests, runs the same factories as controllers
+// against in-memory client and prints objects created by them.
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/version"
+	vpav1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+	vmv1alpha1 "github.com/VictoriaMetrics/operator/api/operator/v1alpha1"
+	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/build"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vlagent"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vlcluster"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vlsingle"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmagent"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmalert"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmalertmanager"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmanomaly"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmauth"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmcluster"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vmsingle"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vtcluster"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/vtsingle"
+)
+
+// Command is a name of operator sub-command, which renders objects
+const Command = "render"
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(vmv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(vmv1beta1.AddToScheme(scheme))
+	utilruntime.Must(vmv1.AddToScheme(scheme))
+	utilruntime.Must(gwapiv1.Install(scheme))
+	utilruntime.Must(vpav1.AddToScheme(scheme))
+}
+
+type filesFlag []string
+
+// String implements flag.Value interface
+func (f *filesFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+// Set implements flag.Value interface
+func (f *filesFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// Run parses render command flags, renders objects for custom resources from the given manifests
+// and writes them into w as a YAML stream
+func Run(ctx context.Context, args []string, w io.Writer) error {
+	flags := flag.NewFlagSet(Command, flag.ContinueOnError)
+	var files filesFlag
+	flags.Var(&files, "f", "Path to manifest file or directory with manifests. Could be set multiple times. Use - to read manifests from stdin")
+	kubernetesVersion := flags.String("kubernetesVersion", "1.33", "Version of kubernetes server in major.minor format, which is used for version dependent objects")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("at least one manifest must be provided with -f flag")
+	}
+	if err := setKubernetesVersion(*kubernetesVersion); err != nil {
+		return err
+	}
+	var objects []client.Object
+	for _, f := range files {
+		objs, err := readManifests(f)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, objs...)
+	}
+	rendered, err := Objects(ctx, objects)
+	if err != nil {
+		return err
+	}
+	return Write(w, rendered)
+}
+
+func setKubernetesVersion(v string) error {
+	major, minor, ok := strings.Cut(strings.TrimPrefix(v, "v"), ".")
+	if !ok {
+		return fmt.Errorf("unexpected kubernetesVersion=%q, want major.minor format", v)
+	}
+	return k8stools.SetKubernetesVersionWithDefaults(&version.Info{Major: major, Minor: minor}, 0, 0)
+}
+
+// readManifests reads objects from file, all yaml and json files from directory or stdin
+func readManifests(path string) ([]client.Object, error) {
+	if path == "-" {
+		return decodeManifests(os.Stdin, "stdin")
+	}
+	var objects []client.Object
+	err := filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if p != path {
+			switch filepath.Ext(p) {
+			case ".yaml", ".yml", ".json":
+			default:
+				return nil
+			}
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		objs, err := decodeManifests(f, p)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, objs...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read manifests from %q: %w", path, err)
+	}
+	return objects, nil
+}
+
+// decodeManifests decodes multi-document YAML or JSON stream into typed objects
+func decodeManifests(r io.Reader, source string) ([]client.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDeserializer()
+	reader := yaml.NewYAMLReader(bufio.NewReader(r))
+	var objects []client.Object
+	for {
+		doc, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return objects, nil
+			}
+			return nil, fmt.Errorf("cannot read document from %s: %w", source, err)
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode document from %s: %w", source, err)
+		}
+		cobj, ok := obj.(client.Object)
+		if !ok {
+			return nil, fmt.Errorf("unsupported object=%T at %s", obj, source)
+		}
+		if cobj.GetNamespace() == "" && isNamespaced(cobj) {
+			cobj.SetNamespace("default")
+		}
+		objects = append(objects, cobj)
+	}
+}
+
+func isNamespaced(obj client.Object) bool {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return true
+	}
+	switch gvk.Kind {
+	case "Namespace", "Node", "ClusterRole", "ClusterRoleBinding", "PersistentVolume", "StorageClass", "CustomResourceDefinition":
+		return false
+	}
+	return true
+}
+
+type objectKey struct {
+	gvk schema.GroupVersionKind
+	nsn client.ObjectKey
+}
+
+// Objects runs factories for all supported custom resources from the given objects
+// and returns objects created by them. Given objects are not included into the result
+func Objects(ctx context.Context, objects []client.Object) ([]client.Object, error) {
+	var created []objectKey
+	rclient := newClient(objects, &created)
+	build.AddDefaults(rclient.Scheme())
+
+	for _, obj := range objects {
+		if err := renderObject(ctx, rclient, obj.DeepCopyObject().(client.Object)); err != nil {
+			gvk, _ := apiutil.GVKForObject(obj, scheme)
+			return nil, fmt.Errorf("cannot render %s=%s: %w", gvk.Kind, client.ObjectKeyFromObject(obj), err)
+		}
+	}
+
+	inputs := make(map[objectKey]struct{}, len(objects))
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme)
+		if err != nil {
+			return nil, err
+		}
+		inputs[objectKey{gvk: gvk, nsn: client.ObjectKeyFromObject(obj)}] = struct{}{}
+	}
+	seen := make(map[objectKey]struct{}, len(created))
+	var result []client.Object
+	for _, key := range created {
+		if _, ok := inputs[key]; ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		obj, err := rclient.Scheme().New(key.gvk)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create object for kind=%s: %w", key.gvk, err)
+		}
+		cobj := obj.(client.Object)
+		if err := rclient.Get(ctx, key.nsn, cobj); err != nil {
+			if client.IgnoreNotFound(err) == nil {
+				// object was removed by factory
+				continue
+			}
+			return nil, fmt.Errorf("cannot get %s=%s: %w", key.gvk.Kind, key.nsn, err)
+		}
+		cobj.GetObjectKind().SetGroupVersionKind(key.gvk)
+		result = append(result, cobj)
+	}
+	slices.SortFunc(result, func(a, b client.Object) int {
+		ak, bk := a.GetObjectKind().GroupVersionKind(), b.GetObjectKind().GroupVersionKind()
+		if c := strings.Compare(ak.Kind, bk.Kind); c != 0 {
+			return c
+		}
+		if c := strings.Compare(a.GetNamespace(), b.GetNamespace()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.GetName(), b.GetName())
+	})
+	return result, nil
+}
+
+// renderFunc performs the same factory calls as controller for the given custom resource
+type renderFunc func(ctx context.Context, rclient client.Client, obj client.Object) error
+
+// newRenderFunc returns renderFunc, which checks parsing error and applies defaults to custom resource before render
+func newRenderFunc[T client.Object](parsingError func(T) string, render func(context.Context, client.Client, T) error) renderFunc {
+	return func(ctx context.Context, rclient client.Client, obj client.Object) error {
+		cr := obj.(T)
+		if err := parsingError(cr); err != "" {
+			return errors.New(err)
+		}
+		rclient.Scheme().Default(cr)
+		return render(ctx, rclient, cr)
+	}
+}
+
+// renderFuncs contains renderFunc by kind of custom resource.
+// Objects without own controller, like VMRule or Secret, are only used as input for other objects
+var renderFuncs = map[string]renderFunc{
+	"VMSingle": newRenderFunc(func(cr *vmv1beta1.VMSingle) string { return cr.Spec.ParsingError },
+		func(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMSingle) error {
+			return vmsingle.CreateOrUpdate(ctx, cr, rclient)
+		}),
+	"VMCluster": newRenderFunc(func(cr *vmv1beta1.VMCluster) string { return cr.Spec.ParsingError },
+		func(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMCluster) error {
+			return vmcluster.CreateOrUpdate(ctx, cr, rclient)
+		}),
+	"VMAgent": newRenderFunc(func(cr *vmv1beta1.VMAgent) string { return cr.Spec.ParsingError },
+		func(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAgent) error {
+			return vmagent.CreateOrUpdate(ctx, cr, rclient)
+		}),
+	"VMAlert": newRenderFunc(func(cr *vmv1beta1.VMAlert) string { return cr.Spec.ParsingError },
+		func(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlert) error {
+			maps, _, err := vmalert.CreateOrUpdateRuleConfigMaps(ctx, rclient, cr, nil)
+			if err != nil {
+				return err
+			}
+			return vmalert.CreateOrUpdate(ctx, cr, rclient, maps)
+		}),
+	"VMAlertmanager": newRenderFunc(func(cr *vmv1beta1.VMAlertmanager) string { return cr.Spec.ParsingError },
+		func(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAlertmanager) error {
+			if err := vmalertmanager.CreateOrUpdateConfig(ctx, rclient, cr, nil); err != nil {
+				return err
+			}
+			return vmalertmanager.CreateOrUpdateAlertManager(ctx, cr, rclient)
+		}),
+	"VMAuth": newRenderFunc(func(cr *vmv1beta1.VMAuth) string { return cr.Spec.ParsingError },
+		func(ctx context.Context, rclient client.Client, cr *vmv1beta1.VMAuth) error {
+			return vmauth.CreateOrUpdate(ctx, cr, rclient)
+		}),
+	"VMAnomaly": newRenderFunc(func(cr *vmv1.VMAnomaly) string { return cr.Spec.ParsingError },
+		func(ctx context.Context, rclient client.Client, cr *vmv1.VMAnomaly) error {
+			return vmanomaly.CreateOrUpdate(ctx, cr, rclient)
+		}),
+	"VLSingle":  newRenderFunc(func(cr *vmv1.VLSingle) string { return cr.Spec.ParsingError }, vlsingle.CreateOrUpdate),
+	"VLCluster": newRenderFunc(func(cr *vmv1.VLCluster) string { return cr.Spec.ParsingError }, vlcluster.CreateOrUpdate),
+	"VLAgent": newRenderFunc(func(cr *vmv1.VLAgent) string { return cr.Spec.ParsingError },
+		func(ctx context.Context, rclient client.Client, cr *vmv1.VLAgent) error {
+			return vlagent.CreateOrUpdate(ctx, cr, rclient)
+		}),
+	"VTSingle":  newRenderFunc(func(cr *vmv1.VTSingle) string { return cr.Spec.ParsingError }, vtsingle.CreateOrUpdate),
+	"VTCluster": newRenderFunc(func(cr *vmv1.VTCluster) string { return cr.Spec.ParsingError }, vtcluster.CreateOrUpdate),
+}
+
+// renderObject performs the same factory calls as controller for the given object
+func renderObject(ctx context.Context, rclient client.Client, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, rclient.Scheme())
+	if err != nil {
+		return err
+	}
+	if gvk.Group != vmv1beta1.APIGroup {
+		return nil
+	}
+	render, ok := renderFuncs[gvk.Kind]
+	if !ok {
+		return nil
+	}
+	return render(ctx, rclient, obj)
+}
+
+// Write prints given objects as YAML stream without fields populated by kubernetes API server
+func Write(w io.Writer, objects []client.Object) error {
+	encoder := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{Yaml: true})
+	for i, obj := range objects {
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return fmt.Errorf("cannot convert %s=%s: %w", obj.GetObjectKind().GroupVersionKind().Kind, client.ObjectKeyFromObject(obj), err)
+		}
+		u := &unstructured.Unstructured{Object: content}
+		delete(u.Object, "status")
+		unstructured.RemoveNestedField(u.Object, "metadata", "resourceVersion")
+		unstructured.RemoveNestedField(u.Object, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(u.Object, "metadata", "generation")
+		if i > 0 {
+			if _, err := io.WriteString(w, "---\n"); err != nil {
+				return err
+			}
+		}
+		if err := encoder.Encode(u, w); err != nil {
+			return fmt.Errorf("cannot encode %s=%s: %w", u.GetKind(), client.ObjectKeyFromObject(u), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package render
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestObjects(t *testing.T) {
+	type opts struct {
+		manifests string
+		want      []string
+		wantErr   bool
+	}
+	f := func(o opts) {
+		t.Helper()
+		ctx := context.TODO()
+		objects, err := decodeManifests(strings.NewReader(o.manifests), "test")
+		assert.NoError(t, err)
+		rendered, err := Objects(ctx, objects)
+		if o.wantErr {
+			assert.Error(t, err)
+			return
+		}
+		assert.NoError(t, err)
+		got := make([]string, 0, len(rendered))
+		for _, obj := range rendered {
+			got = append(got, obj.GetObjectKind().GroupVersionKind().Kind+"="+client.ObjectKeyFromObject(obj).String())
+		}
+		assert.Equal(t, o.want, got)
+
+		var buf bytes.Buffer
+		assert.NoError(t, Write(&buf, rendered))
+		output := buf.String()
+		assert.Equal(t, max(len(rendered)-1, 0), strings.Count(output, "\n---\n"))
+		assert.NotContains(t, output, "resourceVersion")
+		assert.NotContains(t, output, "status:")
+	}
+
+	// objects without controller are not rendered
+	f(opts{
+		manifests: `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: auth
+stringData:
+  password: secret
+`,
+		want: []string{},
+	})
+
+	// vmalert with rules
+	f(opts{
+		manifests: `
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMAlert
+metadata:
+  name: example
+spec:
+  datasource:
+    url: http://vmsingle-example:8428
+  notifier:
+    url: http://alertmanager:9093
+  ruleSelector: {}
+---
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMRule
+metadata:
+  name: rules
+  namespace: monitoring
+spec:
+  groups:
+  - name: group
+    rules:
+    - alert: Down
+      expr: up == 0
+`,
+		want: []string{
+			"ConfigMap=default/vm-example-rulefiles-0",
+			"Deployment=default/vmalert-example",
+			"Secret=default/tls-assets-vmalert-example",
+			"Secret=default/vmalert-example",
+			"Service=default/vmalert-example",
+			"ServiceAccount=default/vmalert-example",
+			"VMServiceScrape=default/vmalert-example",
+		},
+	})
+
+	// vmsingle
+	f(opts{
+		manifests: `
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMSingle
+metadata:
+  name: example
+  namespace: monitoring
+spec:
+  retentionPeriod: "1"
+`,
+		want: []string{
+			"Deployment=monitoring/vmsingle-example",
+			"Service=monitoring/vmsingle-example",
+			"ServiceAccount=monitoring/vmsingle-example",
+			"VMServiceScrape=monitoring/vmsingle-example",
+		},
+	})
+
+	// parsing error
+	f(opts{
+		manifests: `
+apiVersion: operator.victoriametrics.com/v1beta1
+kind: VMSingle
+metadata:
+  name: example
+spec:
+  retentionPeriod:
+    value: 1
+`,
+		wantErr: true,
+	})
+}
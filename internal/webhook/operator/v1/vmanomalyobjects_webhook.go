@@ -0,0 +1,125 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmv1 "github.com/VictoriaMetrics/operator/api/operator/v1"
+)
+
+// SetupVMAnomalyModelWebhookWithManager registers the webhook for VMAnomalyModel in the manager.
+func SetupVMAnomalyModelWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &vmv1.VMAnomalyModel{}).
+		WithValidator(&VMAnomalyModelCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1-vmanomalymodel,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmanomalymodels,verbs=create;update,versions=v1,name=vvmanomalymodel-v1.kb.io,admissionReviewVersions=v1
+type VMAnomalyModelCustomValidator struct{}
+
+var _ admission.Validator[*vmv1.VMAnomalyModel] = &VMAnomalyModelCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalyModelCustomValidator) ValidateCreate(_ context.Context, obj *vmv1.VMAnomalyModel) (admission.Warnings, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalyModelCustomValidator) ValidateUpdate(_ context.Context, _, newObj *vmv1.VMAnomalyModel) (admission.Warnings, error) {
+	if err := newObj.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalyModelCustomValidator) ValidateDelete(_ context.Context, _ *vmv1.VMAnomalyModel) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// SetupVMAnomalySchedulerWebhookWithManager registers the webhook for VMAnomalyScheduler in the manager.
+func SetupVMAnomalySchedulerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &vmv1.VMAnomalyScheduler{}).
+		WithValidator(&VMAnomalySchedulerCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1-vmanomalyscheduler,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmanomalyschedulers,verbs=create;update,versions=v1,name=vvmanomalyscheduler-v1.kb.io,admissionReviewVersions=v1
+type VMAnomalySchedulerCustomValidator struct{}
+
+var _ admission.Validator[*vmv1.VMAnomalyScheduler] = &VMAnomalySchedulerCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalySchedulerCustomValidator) ValidateCreate(_ context.Context, obj *vmv1.VMAnomalyScheduler) (admission.Warnings, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalySchedulerCustomValidator) ValidateUpdate(_ context.Context, _, newObj *vmv1.VMAnomalyScheduler) (admission.Warnings, error) {
+	if err := newObj.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalySchedulerCustomValidator) ValidateDelete(_ context.Context, _ *vmv1.VMAnomalyScheduler) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// SetupVMAnomalyQueryWebhookWithManager registers the webhook for VMAnomalyQuery in the manager.
+func SetupVMAnomalyQueryWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &vmv1.VMAnomalyQuery{}).
+		WithValidator(&VMAnomalyQueryCustomValidator{}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1-vmanomalyquery,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmanomalyqueries,verbs=create;update,versions=v1,name=vvmanomalyquery-v1.kb.io,admissionReviewVersions=v1
+type VMAnomalyQueryCustomValidator struct{}
+
+var _ admission.Validator[*vmv1.VMAnomalyQuery] = &VMAnomalyQueryCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalyQueryCustomValidator) ValidateCreate(_ context.Context, obj *vmv1.VMAnomalyQuery) (admission.Warnings, error) {
+	if err := obj.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalyQueryCustomValidator) ValidateUpdate(_ context.Context, _, newObj *vmv1.VMAnomalyQuery) (admission.Warnings, error) {
+	if err := newObj.Validate(); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type
+func (*VMAnomalyQueryCustomValidator) ValidateDelete(_ context.Context, _ *vmv1.VMAnomalyQuery) (admission.Warnings, error) {
+	return nil, nil
+}
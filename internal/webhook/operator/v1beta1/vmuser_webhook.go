@@ -18,42 +18,127 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	vmv1beta1 "github.com/VictoriaMetrics/operator/api/operator/v1beta1"
+	"github.com/VictoriaMetrics/operator/internal/config"
+	"github.com/VictoriaMetrics/operator/internal/controller/operator/factory/k8stools"
 )
 
 // SetupVMUserWebhookWithManager will setup the manager to manage the webhooks
 func SetupVMUserWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &vmv1beta1.VMUser{}).
-		WithValidator(&VMUserCustomValidator{}).
+		WithValidator(&VMUserCustomValidator{client: mgr.GetClient()}).
 		Complete()
 }
 
 // +kubebuilder:webhook:path=/validate-operator-victoriametrics-com-v1beta1-vmuser,mutating=false,failurePolicy=fail,sideEffects=None,groups=operator.victoriametrics.com,resources=vmusers,verbs=create;update,versions=v1beta1,name=vvmuser-v1beta1.kb.io,admissionReviewVersions=v1
-type VMUserCustomValidator struct{}
+type VMUserCustomValidator struct {
+	client client.Client
+}
 
 var _ admission.Validator[*vmv1beta1.VMUser] = &VMUserCustomValidator{}
 
 // ValidateCreate implements admission.Validator so a webhook will be registered for the type
-func (*VMUserCustomValidator) ValidateCreate(_ context.Context, obj *vmv1beta1.VMUser) (admission.Warnings, error) {
+func (v *VMUserCustomValidator) ValidateCreate(ctx context.Context, obj *vmv1beta1.VMUser) (admission.Warnings, error) {
 	if err := obj.Validate(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return nil, v.validateRateLimit(ctx, obj)
 }
 
 // ValidateUpdate implements admission.Validator so a webhook will be registered for the type
-func (*VMUserCustomValidator) ValidateUpdate(_ context.Context, _, newObj *vmv1beta1.VMUser) (admission.Warnings, error) {
+func (v *VMUserCustomValidator) ValidateUpdate(ctx context.Context, _, newObj *vmv1beta1.VMUser) (admission.Warnings, error) {
 	if err := newObj.Validate(); err != nil {
 		return nil, err
 	}
-	return nil, nil
+	return nil, v.validateRateLimit(ctx, newObj)
 }
 
 // ValidateDelete implements admission.Validator so a webhook will be registered for the type
 func (*VMUserCustomValidator) ValidateDelete(_ context.Context, _ *vmv1beta1.VMUser) (admission.Warnings, error) {
 	return nil, nil
 }
+
+// validateRateLimit rejects per-path rate limits if any of VMAuth objects, which select given user, doesn't support them.
+// User limits are applied as max_concurrent_requests for such VMAuth objects
+func (v *VMUserCustomValidator) validateRateLimit(ctx context.Context, user *vmv1beta1.VMUser) error {
+	if user.Spec.RateLimit == nil || len(user.Spec.RateLimit.Paths) == 0 || v.client == nil || vmv1beta1.MustSkipCRValidation(user) {
+		return nil
+	}
+	cfg := config.MustGetBaseConfig()
+	var vmauths []*vmv1beta1.VMAuth
+	if err := k8stools.ListObjectsByNamespace(ctx, v.client, cfg.GetWatchNamespaces(), func(dst *vmv1beta1.VMAuthList) {
+		for i := range dst.Items {
+			vmauth := &dst.Items[i]
+			if vmauth.IsUnmanaged() || !mayVMAuthSelectNamespace(vmauth, user.Namespace) {
+				continue
+			}
+			if vmauth.Spec.Image.Tag == "" {
+				vmauth.Spec.Image.Tag = cfg.VMAuth.Version
+			}
+			if vmauth.SupportsUserRateLimits() {
+				continue
+			}
+			vmauths = append(vmauths, vmauth.DeepCopy())
+		}
+	}); err != nil {
+		return fmt.Errorf("cannot list VMAuth objects: %w", err)
+	}
+	var ns *corev1.Namespace
+	for _, vmauth := range vmauths {
+		if ns == nil && vmauth.Spec.UserNamespaceSelector != nil {
+			ns = &corev1.Namespace{}
+			if err := v.client.Get(ctx, types.NamespacedName{Name: user.Namespace}, ns); err != nil {
+				return fmt.Errorf("cannot get namespace=%q: %w", user.Namespace, err)
+			}
+		}
+		ok, err := isVMAuthSelectsUser(vmauth, user, ns)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return fmt.Errorf("spec.rate_limit.paths is not supported by VMAuth=%s/%s with version=%q", vmauth.Namespace, vmauth.Name, vmauth.Spec.Image.Tag)
+		}
+	}
+	return nil
+}
+
+// mayVMAuthSelectNamespace checks if VMAuth could select users from the given namespace
+func mayVMAuthSelectNamespace(vmauth *vmv1beta1.VMAuth, namespace string) bool {
+	if vmauth.Namespace == namespace || vmauth.Spec.UserNamespaceSelector != nil {
+		return true
+	}
+	return vmauth.Spec.UserSelector == nil && vmauth.Spec.SelectAllByDefault
+}
+
+func isVMAuthSelectsUser(vmauth *vmv1beta1.VMAuth, user *vmv1beta1.VMUser, ns *corev1.Namespace) (bool, error) {
+	if vmauth.Spec.UserSelector == nil && vmauth.Spec.UserNamespaceSelector == nil {
+		return vmauth.Spec.SelectAllByDefault, nil
+	}
+	if vmauth.Spec.UserSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(vmauth.Spec.UserSelector)
+		if err != nil {
+			return false, fmt.Errorf("cannot parse userSelector of VMAuth=%s/%s: %w", vmauth.Namespace, vmauth.Name, err)
+		}
+		if !selector.Matches(labels.Set(user.Labels)) {
+			return false, nil
+		}
+	}
+	if vmauth.Spec.UserNamespaceSelector == nil {
+		return vmauth.Namespace == user.Namespace, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(vmauth.Spec.UserNamespaceSelector)
+	if err != nil {
+		return false, fmt.Errorf("cannot parse userNamespaceSelector of VMAuth=%s/%s: %w", vmauth.Namespace, vmauth.Name, err)
+	}
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}